@@ -153,6 +153,11 @@ func (b *hashBuilder) writeMethods(w io.Writer, baseBits int) {
 					continue
 				}
 				str, v := b.ftype(name, recvName+"."+fieldName.Name, field.Type)
+				if hasDirective(field, "facing_only") {
+					// Only the facing of the Attachment is part of the block state: The orientation of the block
+					// on the ground is stored elsewhere, such as in its NBT.
+					str, v = "uint64("+recvName+"."+fieldName.Name+".FaceUint8())", 3
+				}
 				if v == 0 {
 					// Assume this field is not used in the hash.
 					continue
@@ -178,6 +183,19 @@ func (b *hashBuilder) writeMethods(w io.Writer, baseBits int) {
 	log.Println("Assuming int size of 8 bits at most for all int fields: Make sure this is valid for all blocks.")
 }
 
+// hasDirective checks if the doc comment of the field passed has a //blockhash: directive with the name passed.
+func hasDirective(field *ast.Field, name string) bool {
+	if field.Doc == nil {
+		return false
+	}
+	for _, c := range field.Doc.List {
+		if c.Text == "//blockhash:"+name {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *hashBuilder) ftype(structName, s string, expr ast.Expr) (string, int) {
 	var name string
 	switch t := expr.(type) {
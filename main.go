@@ -1,13 +1,11 @@
 package main
 
 import (
-	"fmt"
+	"errors"
 	"github.com/df-mc/dragonfly/server"
 	"github.com/df-mc/dragonfly/server/player/chat"
-	"github.com/pelletier/go-toml"
 	"github.com/sirupsen/logrus"
-	"io/ioutil"
-	"os"
+	"io/fs"
 )
 
 func main() {
@@ -17,7 +15,7 @@ func main() {
 
 	chat.Global.Subscribe(chat.StdoutSubscriber{})
 
-	config, err := readConfig()
+	config, err := readConfig(log)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -30,30 +28,20 @@ func main() {
 
 	for {
 		if _, err := srv.Accept(); err != nil {
+			// Accept returns as soon as the server starts closing: Wait for it to be closed completely before
+			// returning, so that all data is saved.
+			_ = srv.Close()
 			return
 		}
 	}
 }
 
 // readConfig reads the configuration from the config.toml file, or creates the file if it does not yet exist.
-func readConfig() (server.Config, error) {
-	c := server.DefaultConfig()
-	if _, err := os.Stat("config.toml"); os.IsNotExist(err) {
-		data, err := toml.Marshal(c)
-		if err != nil {
-			return c, fmt.Errorf("failed encoding default config: %v", err)
-		}
-		if err := ioutil.WriteFile("config.toml", data, 0644); err != nil {
-			return c, fmt.Errorf("failed creating config: %v", err)
-		}
-		return c, nil
-	}
-	data, err := ioutil.ReadFile("config.toml")
-	if err != nil {
-		return c, fmt.Errorf("error reading config: %v", err)
-	}
-	if err := toml.Unmarshal(data, &c); err != nil {
-		return c, fmt.Errorf("error decoding config: %v", err)
+func readConfig(log *logrus.Logger) (server.Config, error) {
+	c, err := server.ReadConfig("config.toml", log)
+	if errors.Is(err, fs.ErrNotExist) {
+		c = server.DefaultConfig()
+		return c, c.Write("config.toml")
 	}
-	return c, nil
+	return c, err
 }
@@ -28,6 +28,15 @@ func (a Attachment) Uint8() uint8 {
 	return uint8(a.facing) << 1
 }
 
+// FaceUint8 returns the Attachment as a uint8, ignoring the orientation of standing Attachments. It is used for
+// blocks of which the orientation on the ground is not part of their block state.
+func (a Attachment) FaceUint8() uint8 {
+	if !a.hanging {
+		return 0
+	}
+	return 1 + uint8(a.facing)
+}
+
 // RotateLeft rotates the Attachment the left way around by 90 degrees.
 func (a Attachment) RotateLeft() Attachment {
 	return Attachment{hanging: a.hanging, facing: a.facing.RotateLeft(), o: a.o.RotateLeft()}
@@ -1,5 +1,7 @@
 package block
 
+import "image/color"
+
 // Bedrock is a block that is indestructible in survival.
 type Bedrock struct {
 	solid
@@ -21,3 +23,8 @@ func (b Bedrock) EncodeBlock() (name string, properties map[string]interface{})
 	//noinspection SpellCheckingInspection
 	return "minecraft:bedrock", map[string]interface{}{"infiniburn_bit": b.InfiniteBurning}
 }
+
+// MapColour ...
+func (Bedrock) MapColour() color.RGBA {
+	return stoneMapColour
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"math/rand"
 )
 
 // Activatable represents a block that may be activated by a viewer of the world. When activated, the block
@@ -78,6 +80,22 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// Harvestable represents a block that is harvested by interacting with it rather than by breaking it, such as
+// a sweet berry bush. Harvesting a block drops items, but leaves the block in place in a different state.
+type Harvestable interface {
+	// Harvest returns the items dropped by harvesting the block and the block left behind after harvesting.
+	// If the block cannot be harvested in its current state, Harvest returns false.
+	Harvest() (drops []item.Stack, after world.Block, ok bool)
+}
+
+// Suffocator represents a block that may override whether entities with their eyes inside of it suffocate.
+// Blocks that do not implement Suffocator suffocate entities whose eyes are inside one of their bounding
+// boxes.
+type Suffocator interface {
+	// Suffocates returns true if the block suffocates entities with their eyes inside of it.
+	Suffocates() bool
+}
+
 // Frictional represents a block that may have a custom friction value, friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -125,10 +143,20 @@ func replaceableWith(w *world.World, pos cube.Pos, with world.Block) bool {
 		return false
 	}
 	b := w.Block(pos)
+	return ReplaceableBy(b, with) && b != with
+}
+
+// ReplaceableBy checks if the block passed may be replaced by placing the block with on it. Blocks must have
+// the TagReplaceable tag to be replaceable. Blocks implementing Replaceable may further restrict the blocks
+// they are replaceable by.
+func ReplaceableBy(b, with world.Block) bool {
+	if !HasTag(b, TagReplaceable) {
+		return false
+	}
 	if replaceable, ok := b.(Replaceable); ok {
-		return replaceable.ReplaceableBy(with) && b != with
+		return replaceable.ReplaceableBy(with)
 	}
-	return false
+	return true
 }
 
 // firstReplaceable finds the first replaceable block position eligible to have a block placed on it after
@@ -285,6 +313,30 @@ type effectHolder interface {
 	AddEffect(effect.Effect)
 }
 
+// harvest harvests the Harvestable block at the position passed, replacing it with the block left behind and
+// dropping its items at the position. False is returned if the block could not be harvested.
+func harvest(pos cube.Pos, w *world.World, h Harvestable) bool {
+	drops, after, ok := h.Harvest()
+	if !ok {
+		return false
+	}
+	w.PlaceBlock(pos, after)
+	for _, drop := range drops {
+		itemEntity := entity.NewItem(drop, pos.Vec3Centre())
+		itemEntity.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
+		w.AddEntity(itemEntity)
+	}
+	return true
+}
+
+// velocityEntity represents an entity that has a velocity which may be changed.
+type velocityEntity interface {
+	// Velocity returns the current velocity of the entity.
+	Velocity() mgl64.Vec3
+	// SetVelocity sets the velocity of the entity.
+	SetVelocity(v mgl64.Vec3)
+}
+
 // supportsVegetation checks if the vegetation can exist on the block.
 func supportsVegetation(vegetation, block world.Block) bool {
 	soil, ok := block.(Soil)
@@ -1,5 +1,7 @@
 package block
 
+import "image/color"
+
 // BlueIce is a solid block similar to packed ice.
 type BlueIce struct {
 	solid
@@ -24,3 +26,8 @@ func (BlueIce) EncodeItem() (name string, meta int16) {
 func (BlueIce) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:blue_ice", nil
 }
+
+// MapColour ...
+func (BlueIce) MapColour() color.RGBA {
+	return iceMapColour
+}
@@ -0,0 +1,32 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Bookshelf is a decorative block made of wood and books. It drops three books when broken without silk
+// touch.
+type Bookshelf struct {
+	solid
+	bass
+}
+
+// BreakInfo ...
+func (b Bookshelf) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, axeEffective, silkTouchDrop(item.NewStack(item.Book{}, 3), item.NewStack(b, 1)))
+}
+
+// FlammabilityInfo ...
+func (Bookshelf) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(30, 20, true)
+}
+
+// EncodeItem ...
+func (Bookshelf) EncodeItem() (name string, meta int16) {
+	return "minecraft:bookshelf", 0
+}
+
+// EncodeBlock ...
+func (Bookshelf) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:bookshelf", nil
+}
@@ -86,18 +86,30 @@ type BreakInfo struct {
 	Drops func(t tool.Tool, enchantments []item.Enchantment) []item.Stack
 	// XPDrops is the range of XP a block can drop when broken.
 	XPDrops XPDropRange
+	// BlastResistance is the resistance of the block to explosions. The higher the blast resistance, the more
+	// an explosion is weakened by the block and the less likely it is that the block is destroyed by it.
+	BlastResistance float64
 }
 
-// newBreakInfo creates a BreakInfo struct with the properties passed. The XPDrops field is 0 by default.
+// newBreakInfo creates a BreakInfo struct with the properties passed. The XPDrops field is 0 by default. The
+// BlastResistance is equal to the hardness by default.
 func newBreakInfo(hardness float64, harvestable func(tool.Tool) bool, effective func(tool.Tool) bool, drops func(tool.Tool, []item.Enchantment) []item.Stack) BreakInfo {
 	return BreakInfo{
-		Hardness:    hardness,
-		Harvestable: harvestable,
-		Effective:   effective,
-		Drops:       drops,
+		Hardness:        hardness,
+		Harvestable:     harvestable,
+		Effective:       effective,
+		Drops:           drops,
+		BlastResistance: hardness,
 	}
 }
 
+// withBlastResistance sets the BlastResistance of the BreakInfo to the resistance passed and returns the new
+// BreakInfo.
+func (b BreakInfo) withBlastResistance(res float64) BreakInfo {
+	b.BlastResistance = res
+	return b
+}
+
 // XPDropRange holds the min & max XP drop amounts of blocks.
 type XPDropRange [2]int
 
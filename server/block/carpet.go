@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 )
 
 // Carpet is a colourful block that can be obtained by killing/shearing sheep, or crafted using four string.
@@ -47,6 +48,11 @@ func (c Carpet) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:carpet", map[string]interface{}{"color": c.Colour.String()}
 }
 
+// MapColour ...
+func (c Carpet) MapColour() color.RGBA {
+	return c.Colour.RGBA()
+}
+
 // HasLiquidDrops ...
 func (Carpet) HasLiquidDrops() bool {
 	return true
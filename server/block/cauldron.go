@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Cauldron is a block that can hold water. Glass bottles may be filled using the water in a cauldron.
+type Cauldron struct {
+	solid
+	transparent
+
+	// Level is the level of the water in the cauldron, ranging from 0 (empty) to 6 (full). Filling a glass
+	// bottle takes two levels of water from the cauldron.
+	Level int
+}
+
+// FillBottle ...
+func (c Cauldron) FillBottle() (world.Block, item.Stack, bool) {
+	if c.Level < 2 {
+		return nil, item.Stack{}, false
+	}
+	c.Level -= 2
+	return c, item.NewStack(item.Potion{Type: potion.Water()}, 1), true
+}
+
+// EmptyBucket ...
+func (c Cauldron) EmptyBucket(liq world.Liquid) (world.Block, bool) {
+	if _, water := liq.(Water); !water || c.Level == 6 {
+		return nil, false
+	}
+	c.Level = 6
+	return c, true
+}
+
+// SideClosed ...
+func (Cauldron) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (c Cauldron) BreakInfo() BreakInfo {
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(Cauldron{}))
+}
+
+// EncodeItem ...
+func (Cauldron) EncodeItem() (name string, meta int16) {
+	return "minecraft:cauldron", 0
+}
+
+// EncodeBlock ...
+func (c Cauldron) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:cauldron", map[string]interface{}{"fill_level": int32(c.Level), "cauldron_liquid": "water"}
+}
+
+// allCauldrons ...
+func allCauldrons() (cauldrons []world.Block) {
+	for level := 0; level <= 6; level++ {
+		cauldrons = append(cauldrons, Cauldron{Level: level})
+	}
+	return
+}
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/instrument"
 	"github.com/df-mc/dragonfly/server/item"
+	"image/color"
 )
 
 // Clay is a block that can be found underwater.
@@ -29,3 +30,8 @@ func (c Clay) EncodeItem() (name string, meta int16) {
 func (c Clay) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:clay", nil
 }
+
+// MapColour ...
+func (Clay) MapColour() color.RGBA {
+	return clayMapColour
+}
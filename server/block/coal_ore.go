@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/item"
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"image/color"
+)
 
 // CoalOre is a common ore.
 type CoalOre struct {
@@ -28,3 +31,8 @@ func (c CoalOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + c.Type.Prefix() + "coal_ore", nil
 
 }
+
+// MapColour ...
+func (CoalOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
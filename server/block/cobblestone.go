@@ -1,5 +1,7 @@
 package block
 
+import "image/color"
+
 // Cobblestone is a common block, obtained from mining stone.
 type Cobblestone struct {
 	solid
@@ -12,7 +14,7 @@ type Cobblestone struct {
 
 // BreakInfo ...
 func (c Cobblestone) BreakInfo() BreakInfo {
-	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(c))
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(c)).withBlastResistance(6)
 }
 
 // EncodeItem ...
@@ -30,3 +32,8 @@ func (c Cobblestone) EncodeBlock() (string, map[string]interface{}) {
 	}
 	return "minecraft:cobblestone", nil
 }
+
+// MapColour ...
+func (Cobblestone) MapColour() color.RGBA {
+	return stoneMapColour
+}
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Concrete is a solid block which comes in the 16 regular dye colors, created by placing concrete powder
@@ -30,6 +31,11 @@ func (c Concrete) EncodeBlock() (name string, properties map[string]interface{})
 	return "minecraft:concrete", map[string]interface{}{"color": c.Colour.String()}
 }
 
+// MapColour ...
+func (c Concrete) MapColour() color.RGBA {
+	return c.Colour.RGBA()
+}
+
 // allConcrete returns concrete blocks with all possible colours.
 func allConcrete() []world.Block {
 	b := make([]world.Block, 0, 16)
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // ConcretePowder is a gravity affected block that comes in 16 different colours. When interacting with water,
@@ -49,6 +50,11 @@ func (c ConcretePowder) EncodeBlock() (name string, properties map[string]interf
 	return "minecraft:concretePowder", map[string]interface{}{"color": c.Colour.String()}
 }
 
+// MapColour ...
+func (c ConcretePowder) MapColour() color.RGBA {
+	return c.Colour.RGBA()
+}
+
 // allConcretePowder returns concrete powder with all possible colours.
 func allConcretePowder() []world.Block {
 	b := make([]world.Block, 0, 16)
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // CopperOre is a rare mineral block found underground.
@@ -30,3 +31,8 @@ func (c CopperOre) EncodeItem() (name string, meta int16) {
 func (c CopperOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + c.Type.Prefix() + "copper_ore", nil
 }
+
+// MapColour ...
+func (CopperOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
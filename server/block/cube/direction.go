@@ -29,6 +29,11 @@ func (d Direction) Opposite() Direction {
 	panic("invalid direction")
 }
 
+// Offset returns the offset of a block position in this direction of another block position.
+func (d Direction) Offset() Pos {
+	return d.Face().Offset()
+}
+
 // Face converts the direction to a block face.
 func (d Direction) Face() Face {
 	return Face(d + 2)
@@ -47,6 +47,26 @@ func (f Face) FromString(s string) (interface{}, error) {
 	return nil, fmt.Errorf("unexpected facing '%v', expecting one of 'down', 'up', 'north', 'south', 'west' or 'east'", s)
 }
 
+// Offset returns the offset of a block position on this face of another block position. Adding it to a block
+// position produces the same result as Pos.Side.
+func (f Face) Offset() Pos {
+	switch f {
+	case FaceDown:
+		return Pos{0, -1, 0}
+	case FaceUp:
+		return Pos{0, 1, 0}
+	case FaceNorth:
+		return Pos{0, 0, -1}
+	case FaceSouth:
+		return Pos{0, 0, 1}
+	case FaceWest:
+		return Pos{-1, 0, 0}
+	case FaceEast:
+		return Pos{1, 0, 0}
+	}
+	return Pos{}
+}
+
 // Opposite returns the opposite face. FaceDown will return up, north will return south and west will return east,
 // and vice versa.
 func (f Face) Opposite() Face {
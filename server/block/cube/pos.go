@@ -63,21 +63,17 @@ func (p Pos) Vec3Centre() mgl64.Vec3 {
 
 // Side returns the position on the side of this block position, at a specific face.
 func (p Pos) Side(face Face) Pos {
-	switch face {
-	case FaceUp:
-		p[1]++
-	case FaceDown:
-		p[1]--
-	case FaceNorth:
-		p[2]--
-	case FaceSouth:
-		p[2]++
-	case FaceWest:
-		p[0]--
-	case FaceEast:
-		p[0]++
+	return p.Add(face.Offset())
+}
+
+// Sides returns the positions on all six sides of the block position, in the order of Faces. Unlike
+// Neighbours, Sides also returns positions that are out of bounds.
+func (p Pos) Sides() []Pos {
+	sides := make([]Pos, 0, 6)
+	for _, f := range Faces() {
+		sides = append(sides, p.Side(f))
 	}
-	return p
+	return sides
 }
 
 // Face returns the face that the other Pos was on compared to the current Pos. The other Pos
@@ -126,6 +122,25 @@ func (p Pos) Neighbours(f func(neighbour Pos)) {
 	f(p)
 }
 
+// PosBetween calls the function passed for every block position in the cuboid between the two corners
+// passed, including the corners themselves. The corners may be passed in any order.
+func PosBetween(a, b Pos, f func(pos Pos)) {
+	min, max := Pos{}, Pos{}
+	for i := range a {
+		min[i], max[i] = a[i], b[i]
+		if min[i] > max[i] {
+			min[i], max[i] = max[i], min[i]
+		}
+	}
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			for z := min[2]; z <= max[2]; z++ {
+				f(Pos{x, y, z})
+			}
+		}
+	}
+}
+
 // PosFromVec3 returns a block position by a Vec3, rounding the values down adequately.
 func PosFromVec3(vec3 mgl64.Vec3) Pos {
 	return Pos{int(math.Floor(vec3[0])), int(math.Floor(vec3[1])), int(math.Floor(vec3[2]))}
@@ -0,0 +1,28 @@
+package cube
+
+import (
+	"testing"
+)
+
+func TestPosSides(t *testing.T) {
+	p := Pos{1, 64, -1}
+	for i, side := range p.Sides() {
+		f := Faces()[i]
+		if side != p.Add(f.Offset()) || p.Face(side) != f || side.Side(f.Opposite()) != p {
+			t.Errorf("side %v of %v: unexpected position %v", f, p, side)
+		}
+	}
+}
+
+func TestPosBetween(t *testing.T) {
+	var n int
+	PosBetween(Pos{1, 1, 1}, Pos{-1, 0, 0}, func(pos Pos) {
+		if pos[0] < -1 || pos[0] > 1 || pos[1] < 0 || pos[1] > 1 || pos[2] < 0 || pos[2] > 1 {
+			t.Errorf("position %v out of range", pos)
+		}
+		n++
+	})
+	if n != 12 {
+		t.Fatalf("expected 12 positions, got %v", n)
+	}
+}
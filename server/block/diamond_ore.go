@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // DiamondOre is a rare ore that generates underground.
@@ -32,3 +33,8 @@ func (d DiamondOre) EncodeItem() (name string, meta int16) {
 func (d DiamondOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + d.Type.Prefix() + "diamond_ore", nil
 }
+
+// MapColour ...
+func (DiamondOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
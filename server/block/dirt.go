@@ -2,6 +2,7 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Dirt is a block found abundantly in most biomes under a layer of grass blocks at the top of the normal
@@ -17,7 +18,7 @@ type Dirt struct {
 // SoilFor ...
 func (d Dirt) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, DeadBush:
+	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, DeadBush, SweetBerryBush, Sapling:
 		return true
 	}
 	return false
@@ -56,3 +57,8 @@ func (d Dirt) EncodeBlock() (string, map[string]interface{}) {
 	}
 	return "minecraft:dirt", map[string]interface{}{"dirt_type": "normal"}
 }
+
+// MapColour ...
+func (Dirt) MapColour() color.RGBA {
+	return dirtMapColour
+}
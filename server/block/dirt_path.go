@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // DirtPath is a decorative block that can be created by using a shovel on a dirt or grass block.
@@ -39,3 +40,8 @@ func (DirtPath) EncodeItem() (name string, meta int16) {
 func (DirtPath) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:grass_path", nil
 }
+
+// MapColour ...
+func (DirtPath) MapColour() color.RGBA {
+	return dirtMapColour
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 )
 
@@ -88,6 +89,11 @@ func (d DoubleFlower) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:double_plant", map[string]interface{}{"double_flower_type": d.Type.String(), "upper_block_bit": d.UpperPart}
 }
 
+// MapColour ...
+func (DoubleFlower) MapColour() color.RGBA {
+	return plantMapColour
+}
+
 // allDoubleFlowers ...
 func allDoubleFlowers() (b []world.Block) {
 	for _, d := range DoubleFlowerTypes() {
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 )
 
@@ -89,6 +90,11 @@ func (d DoubleTallGrass) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:double_plant", map[string]interface{}{"double_flower_type": d.Type.String(), "upper_block_bit": d.UpperPart}
 }
 
+// MapColour ...
+func (DoubleTallGrass) MapColour() color.RGBA {
+	return plantMapColour
+}
+
 // allDoubleTallGrass ...
 func allDoubleTallGrass() (b []world.Block) {
 	for _, g := range GrassTypes() {
@@ -19,7 +19,7 @@ func (e EmeraldBlock) Instrument() instrument.Instrument {
 func (e EmeraldBlock) BreakInfo() BreakInfo {
 	return newBreakInfo(5, func(t tool.Tool) bool {
 		return t.ToolType() == tool.TypePickaxe && t.HarvestLevel() >= tool.TierIron.HarvestLevel
-	}, pickaxeEffective, oneOf(e))
+	}, pickaxeEffective, oneOf(e)).withBlastResistance(6)
 }
 
 // PowersBeacon ...
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // EmeraldOre is an ore generating exclusively under mountain biomes.
@@ -32,3 +33,8 @@ func (e EmeraldOre) EncodeItem() (name string, meta int16) {
 func (e EmeraldOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + e.Type.Prefix() + "emerald_ore", nil
 }
+
+// MapColour ...
+func (EmeraldOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
@@ -1,5 +1,7 @@
 package block
 
+import "image/color"
+
 // EndStone is a block found in The End.
 type EndStone struct {
 	solid
@@ -8,7 +10,7 @@ type EndStone struct {
 
 // BreakInfo ...
 func (e EndStone) BreakInfo() BreakInfo {
-	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(e))
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(e)).withBlastResistance(9)
 }
 
 // EncodeItem ...
@@ -20,3 +22,8 @@ func (EndStone) EncodeItem() (name string, meta int16) {
 func (EndStone) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:end_stone", nil
 }
+
+// MapColour ...
+func (EndStone) MapColour() color.RGBA {
+	return sandMapColour
+}
@@ -0,0 +1,210 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/entity/physics/trace"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+)
+
+// ExplosionConfig is the configuration of an explosion. The zero value of an ExplosionConfig is an explosion
+// of the size of exploding TNT, which destroys blocks and hurts entities, but does not spawn fire.
+type ExplosionConfig struct {
+	// Size is the size of the explosion. It determines how far the explosion reaches and how much damage it
+	// deals. If left 0, a size of 4 is used, which is the size of an explosion of TNT.
+	Size float64
+	// ItemDropChance is the chance, ranging from 0 to 1, that a block destroyed by the explosion drops as an
+	// item. If left 0, a chance of 1/Size is used, like in vanilla. A negative chance prevents any blocks
+	// from dropping.
+	ItemDropChance float64
+	// SpawnFire specifies if the explosion sets fire to some of the positions of the blocks it destroyed.
+	SpawnFire bool
+	// DisableBlockDamage prevents the explosion from destroying blocks if set to true.
+	DisableBlockDamage bool
+	// DisableEntityDamage prevents the explosion from hurting and knocking back entities if set to true.
+	DisableEntityDamage bool
+}
+
+// ExplodableEntity represents an entity that is hurt and knocked back by explosions.
+type ExplodableEntity interface {
+	world.Entity
+	// Explode is called when the entity is hit by an explosion at the position passed. The impact, ranging
+	// from 0 to 1, is the strength of the explosion at the entity, taking into account both the distance of
+	// the entity to the explosion and how much of the entity is exposed to it.
+	Explode(explosionPos mgl64.Vec3, impact float64, c ExplosionConfig)
+}
+
+// Explode creates an explosion at the position passed in the world passed. Rays are cast from the position
+// in all directions, destroying blocks until their strength, which is reduced by the blast resistance of the
+// blocks they pass through, runs out. ExplodableEntity implementations within twice the size of the
+// explosion are hurt and knocked back, less so the further away they are and the less they are exposed to
+// the explosion.
+// World.Handler().HandleExplosion is called with the entities and blocks affected before any of them are
+// changed, which may change them or cancel the explosion altogether.
+func (c ExplosionConfig) Explode(w *world.World, explosionPos mgl64.Vec3) {
+	if c.Size == 0 {
+		c.Size = 4
+	}
+	itemDropChance := c.ItemDropChance
+	if itemDropChance == 0 {
+		itemDropChance = 1 / c.Size
+	}
+	spawnFire := c.SpawnFire
+
+	r := c.Size * 2
+	var entities []world.Entity
+	if !c.DisableEntityDamage {
+		bb := physics.NewAABB(explosionPos, explosionPos).Grow(r)
+		for _, e := range w.EntitiesWithin(bb) {
+			if _, ok := e.(ExplodableEntity); ok && e.Position().Sub(explosionPos).Len() <= r {
+				entities = append(entities, e)
+			}
+		}
+	}
+	var blocks []cube.Pos
+	if !c.DisableBlockDamage {
+		blocks = c.affectedBlocks(w, explosionPos)
+	}
+
+	ctx := event.C()
+	w.Handler().HandleExplosion(ctx, explosionPos, &entities, &blocks, &itemDropChance, &spawnFire)
+	ctx.Continue(func() {
+		w.PlaySound(explosionPos, sound.Explosion{})
+		w.AddParticle(explosionPos, particle.HugeExplosion{})
+
+		for _, e := range entities {
+			explodable, ok := e.(ExplodableEntity)
+			if !ok {
+				continue
+			}
+			dist := e.Position().Sub(explosionPos).Len() / r
+			if dist > 1 {
+				continue
+			}
+			explodable.Explode(explosionPos, (1-dist)*exposure(w, explosionPos, e), c)
+		}
+
+		for _, pos := range blocks {
+			b := w.Block(pos)
+			if container, ok := b.(Container); ok {
+				// The contents of containers are always dropped, regardless of the item drop chance. The
+				// inventory is cleared, so that the contents are not dropped again with the block.
+				for _, it := range container.Inventory().Contents() {
+					dropItem(w, it, pos)
+				}
+				container.Inventory().Clear()
+			}
+			if rand.Float64() >= itemDropChance {
+				continue
+			}
+			if breakable, ok := b.(Breakable); ok {
+				for _, drop := range breakable.BreakInfo().Drops(tool.None{}, nil) {
+					dropItem(w, drop, pos)
+				}
+			}
+		}
+		// All blocks are broken at once, so that every chunk changed is sent to viewers only once.
+		w.BreakBlocks(blocks)
+
+		if spawnFire {
+			for _, pos := range blocks {
+				if rand.Intn(3) == 0 {
+					Fire{}.Start(w, pos)
+				}
+			}
+		}
+	})
+}
+
+// affectedBlocks returns the positions of all blocks destroyed by an explosion of the ExplosionConfig at the
+// position passed. Like in vanilla, rays are cast from the position towards every position on the surface of
+// a 16x16x16 cube around it.
+func (c ExplosionConfig) affectedBlocks(w *world.World, explosionPos mgl64.Vec3) []cube.Pos {
+	affected := make(map[cube.Pos]struct{})
+	var positions []cube.Pos
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			for z := 0; z < 16; z++ {
+				if x != 0 && x != 15 && y != 0 && y != 15 && z != 0 && z != 15 {
+					// Only rays towards the surface of the cube are cast.
+					continue
+				}
+				dir := mgl64.Vec3{float64(x)/15*2 - 1, float64(y)/15*2 - 1, float64(z)/15*2 - 1}.Normalize().Mul(0.3)
+				strength := c.Size * (0.7 + rand.Float64()*0.6)
+				for pos := explosionPos; strength > 0; pos = pos.Add(dir) {
+					blockPos := cube.PosFromVec3(pos)
+					if blockPos.OutOfBounds() {
+						break
+					}
+					res, destructible := blastResistance(w, blockPos)
+					if strength -= (res + 0.3) * 0.3; strength > 0 && destructible {
+						if _, ok := affected[blockPos]; !ok {
+							affected[blockPos] = struct{}{}
+							positions = append(positions, blockPos)
+						}
+					}
+					strength -= 0.225
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// blastResistance returns the blast resistance of the block at the position passed and whether the block may
+// be destroyed by an explosion at all. Blocks that cannot be broken, such as bedrock, stop explosions
+// entirely.
+func blastResistance(w *world.World, pos cube.Pos) (float64, bool) {
+	switch b := w.Block(pos).(type) {
+	case Air:
+		return 0, false
+	case Breakable:
+		return b.BreakInfo().BlastResistance, true
+	case world.Liquid:
+		return 100, false
+	}
+	return math.MaxFloat64, false
+}
+
+// exposure returns the fraction of the entity passed that is exposed to an explosion at the position passed,
+// ranging from 0 to 1. It is calculated by casting rays from points spread over the bounding box of the entity
+// to the explosion, counting the rays that are not blocked by any block.
+func exposure(w *world.World, explosionPos mgl64.Vec3, e world.Entity) float64 {
+	bb := e.AABB().Translate(e.Position())
+	min, max := bb.Min(), bb.Max()
+	step := mgl64.Vec3{1 / (bb.Width()*2 + 1), 1 / (bb.Height()*2 + 1), 1 / (bb.Length()*2 + 1)}
+
+	var exposed, total float64
+	for x := 0.0; x <= 1; x += step[0] {
+		for y := 0.0; y <= 1; y += step[1] {
+			for z := 0.0; z <= 1; z += step[2] {
+				point := mgl64.Vec3{
+					min[0] + (max[0]-min[0])*x,
+					min[1] + (max[1]-min[1])*y,
+					min[2] + (max[2]-min[2])*z,
+				}
+				total++
+				if point.ApproxEqual(explosionPos) {
+					exposed++
+					continue
+				}
+				var blocked bool
+				trace.TraverseBlocks(explosionPos, point, func(pos cube.Pos) bool {
+					_, blocked = trace.BlockIntercept(pos, w, w.Block(pos), explosionPos, point)
+					return !blocked
+				})
+				if !blocked {
+					exposed++
+				}
+			}
+		}
+	}
+	return exposed / total
+}
@@ -0,0 +1,118 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+// explosionHandler is a world.Handler that protects a single position from explosions, or cancels them
+// altogether.
+type explosionHandler struct {
+	world.NopHandler
+	protected cube.Pos
+	cancel    bool
+	called    int
+}
+
+func (h *explosionHandler) HandleExplosion(ctx *event.Context, _ mgl64.Vec3, _ *[]world.Entity, blocks *[]cube.Pos, _ *float64, _ *bool) {
+	h.called++
+	if h.cancel {
+		ctx.Cancel()
+		return
+	}
+	positions := (*blocks)[:0]
+	for _, pos := range *blocks {
+		if pos != h.protected {
+			positions = append(positions, pos)
+		}
+	}
+	*blocks = positions
+}
+
+// fillStone fills a cube of stone with a radius of 8 around the position passed.
+func fillStone(w *world.World, centre cube.Pos) {
+	for x := -8; x <= 8; x++ {
+		for y := -8; y <= 8; y++ {
+			for z := -8; z <= 8; z++ {
+				w.SetBlock(centre.Add(cube.Pos{x, y, z}), Stone{})
+			}
+		}
+	}
+}
+
+func TestExplosion(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	h := &explosionHandler{protected: cube.Pos{1, 50, 0}}
+	w.Handle(h)
+
+	centre := cube.Pos{0, 50, 0}
+	fillStone(w, centre)
+	w.SetBlock(centre.Add(cube.Pos{0, 1, 0}), Obsidian{})
+	w.SetBlock(centre, Air{})
+
+	ExplosionConfig{ItemDropChance: -1}.Explode(w, centre.Vec3Centre())
+	if h.called != 1 {
+		t.Fatalf("HandleExplosion called %v times, want 1", h.called)
+	}
+	if b := w.Block(centre.Add(cube.Pos{-1, 0, 0})); b != (Air{}) {
+		t.Errorf("stone next to the explosion is %#v, want air", b)
+	}
+	if b := w.Block(centre.Add(cube.Pos{0, 1, 0})); b != (Obsidian{}) {
+		t.Errorf("obsidian next to the explosion is %#v, want obsidian", b)
+	}
+	if b := w.Block(h.protected); b != (Stone{}) {
+		t.Errorf("block removed from the explosion by the handler is %#v, want stone", b)
+	}
+	if b := w.Block(centre.Add(cube.Pos{0, -7, 0})); b != (Stone{}) {
+		t.Errorf("stone far away from the explosion is %#v, want stone", b)
+	}
+}
+
+func TestExplosionCancelled(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	w.Handle(&explosionHandler{cancel: true})
+
+	centre := cube.Pos{0, 50, 0}
+	fillStone(w, centre)
+	w.SetBlock(centre, Air{})
+
+	ExplosionConfig{}.Explode(w, centre.Vec3Centre())
+	centre.Neighbours(func(pos cube.Pos) {
+		if b := w.Block(pos); b != (Stone{}) {
+			t.Errorf("block at %v after a cancelled explosion is %#v, want stone", pos, b)
+		}
+	})
+}
+
+func TestExplosionContainerContents(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	centre := cube.Pos{0, 50, 0}
+	pos := centre.Add(cube.Pos{1, 0, 0})
+	chest := NewChest()
+	_, _ = chest.Inventory().AddItem(item.NewStack(item.Apple{}, 12))
+	w.SetBlock(pos, chest)
+
+	// Blocks never drop with a drop chance below 0, but the contents of containers always do.
+	ExplosionConfig{ItemDropChance: -1}.Explode(w, centre.Vec3Centre())
+	if b := w.Block(pos); b != (Air{}) {
+		t.Fatalf("chest next to the explosion is %#v, want air", b)
+	}
+	var drops []item.Stack
+	for _, e := range w.Entities() {
+		if i, ok := e.(*entity.Item); ok {
+			drops = append(drops, i.Item())
+		}
+	}
+	if len(drops) != 1 || drops[0].Count() != 12 {
+		t.Errorf("expected the 12 apples in the chest to be dropped, got %v", drops)
+	}
+}
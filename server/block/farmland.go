@@ -3,7 +3,9 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 	"math/rand"
 )
 
@@ -52,8 +54,16 @@ func (f Farmland) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
 	}
 }
 
-// hydrated checks for water within 4 blocks in each direction from the farmland.
+// hydrated checks for water within 4 blocks in each direction from the farmland. The result is cached by the
+// world, so that the area is only scanned again after a liquid nearby changes.
 func (f Farmland) hydrated(pos cube.Pos, w *world.World) bool {
+	return w.CachedLiquidCheck(pos, 4, func() bool {
+		return f.scanHydration(pos, w)
+	})
+}
+
+// scanHydration scans the area within 4 blocks in each direction from the farmland for water.
+func (f Farmland) scanHydration(pos cube.Pos, w *world.World) bool {
 	posX, posY, posZ := pos.X(), pos.Y(), pos.Z()
 	for y := 0; y <= 1; y++ {
 		for x := -4; x <= 4; x++ {
@@ -73,7 +83,11 @@ func (f Farmland) hydrated(pos cube.Pos, w *world.World) bool {
 func (f Farmland) EntityLand(pos cube.Pos, w *world.World, e world.Entity) {
 	if living, ok := e.(entity.Living); ok {
 		if fall, ok := living.(FallDistanceEntity); ok && rand.Float64() < fall.FallDistance()-0.5 {
-			w.PlaceBlock(pos, Dirt{})
+			ctx := event.C()
+			w.Handler().HandleCropTrample(ctx, pos, e)
+			ctx.Continue(func() {
+				w.PlaceBlock(pos, Dirt{})
+			})
 		}
 	}
 }
@@ -88,6 +102,11 @@ func (f Farmland) EncodeBlock() (name string, properties map[string]interface{})
 	return "minecraft:farmland", map[string]interface{}{"moisturized_amount": int32(f.Hydration)}
 }
 
+// MapColour ...
+func (Farmland) MapColour() color.RGBA {
+	return dirtMapColour
+}
+
 // EncodeItem ...
 func (f Farmland) EncodeItem() (name string, meta int16) {
 	return "minecraft:farmland", 0
@@ -27,8 +27,8 @@ type Fire struct {
 
 // flammableBlock returns true if a block is flammable.
 func flammableBlock(block world.Block) bool {
-	flammable, ok := block.(Flammable)
-	return ok && flammable.FlammabilityInfo().Encouragement > 0
+	info, ok := flammabilityInfo(block)
+	return ok && info.Encouragement > 0
 }
 
 // neighboursFlammable returns true if one a block adjacent to the passed position is flammable.
@@ -63,7 +63,7 @@ func infinitelyBurning(pos cube.Pos, w *world.World) bool {
 
 // burn attempts to burn a block.
 func (f Fire) burn(pos cube.Pos, w *world.World, r *rand.Rand, chanceBound int) {
-	if flammable, ok := w.Block(pos).(Flammable); ok && r.Intn(chanceBound) < flammable.FlammabilityInfo().Flammability {
+	if info, ok := flammabilityInfo(w.Block(pos)); ok && r.Intn(chanceBound) < info.Flammability {
 		//TODO: Check if not raining
 		if r.Intn(f.Age+10) < 5 {
 			age := min(15, f.Age+r.Intn(5)/4)
@@ -139,8 +139,8 @@ func (f Fire) tick(pos cube.Pos, w *world.World, r *rand.Rand) {
 
 				encouragement := 0
 				blockPos.Neighbours(func(neighbour cube.Pos) {
-					if flammable, ok := w.Block(neighbour).(Flammable); ok {
-						encouragement = max(encouragement, flammable.FlammabilityInfo().Encouragement)
+					if info, ok := flammabilityInfo(w.Block(neighbour)); ok {
+						encouragement = max(encouragement, info.Encouragement)
 					}
 				})
 				if encouragement <= 0 {
@@ -165,7 +165,7 @@ func (f Fire) tick(pos cube.Pos, w *world.World, r *rand.Rand) {
 // EntityInside ...
 func (f Fire) EntityInside(pos cube.Pos, w *world.World, e world.Entity) {
 	if flammable, ok := e.(entity.Flammable); ok {
-		if l, ok := e.(entity.Living); ok && !l.AttackImmune() {
+		if l, ok := e.(entity.Living); ok {
 			l.Hurt(1, damage.SourceFire{})
 		}
 		if flammable.OnFireDuration() < time.Second*8 {
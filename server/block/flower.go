@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 	"time"
 )
@@ -113,6 +114,11 @@ func (f Flower) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:red_flower", map[string]interface{}{"flower_type": f.Type.String()}
 }
 
+// MapColour ...
+func (Flower) MapColour() color.RGBA {
+	return plantMapColour
+}
+
 // allFlowers ...
 func allFlowers() (b []world.Block) {
 	for _, f := range FlowerTypes() {
@@ -12,6 +12,11 @@ func (g Glass) BreakInfo() BreakInfo {
 	return newBreakInfo(0.3, alwaysHarvestable, nothingEffective, silkTouchOnlyDrop(g))
 }
 
+// Suffocates ...
+func (Glass) Suffocates() bool {
+	return false
+}
+
 // EncodeItem ...
 func (Glass) EncodeItem() (name string, meta int16) {
 	return "minecraft:glass", 0
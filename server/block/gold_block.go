@@ -19,7 +19,7 @@ func (g GoldBlock) Instrument() instrument.Instrument {
 func (g GoldBlock) BreakInfo() BreakInfo {
 	return newBreakInfo(5, func(t tool.Tool) bool {
 		return t.ToolType() == tool.TypePickaxe && t.HarvestLevel() >= tool.TierIron.HarvestLevel
-	}, pickaxeEffective, oneOf(g))
+	}, pickaxeEffective, oneOf(g)).withBlastResistance(6)
 }
 
 // PowersBeacon ...
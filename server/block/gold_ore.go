@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // GoldOre is a rare mineral block found underground.
@@ -30,3 +31,8 @@ func (g GoldOre) EncodeItem() (name string, meta int16) {
 func (g GoldOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + g.Type.Prefix() + "gold_ore", nil
 }
+
+// MapColour ...
+func (GoldOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
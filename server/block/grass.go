@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 	"math/rand"
 )
 
@@ -14,7 +15,7 @@ type Grass struct {
 // SoilFor ...
 func (g Grass) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts:
+	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, SweetBerryBush, Sapling:
 		return true
 	}
 	return false
@@ -69,6 +70,11 @@ func (Grass) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:grass", nil
 }
 
+// MapColour ...
+func (Grass) MapColour() color.RGBA {
+	return grassMapColour
+}
+
 // Till ...
 func (g Grass) Till() (world.Block, bool) {
 	return Farmland{}, true
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 	"math/rand"
 )
 
@@ -39,3 +40,8 @@ func (Gravel) EncodeItem() (name string, meta int16) {
 func (Gravel) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:gravel", nil
 }
+
+// MapColour ...
+func (Gravel) MapColour() color.RGBA {
+	return stoneMapColour
+}
@@ -15,11 +15,13 @@ const (
 	hashBeetrootSeeds
 	hashBlueIce
 	hashBoneBlock
+	hashBookshelf
 	hashBricks
 	hashCake
 	hashCalcite
 	hashCarpet
 	hashCarrot
+	hashCauldron
 	hashChest
 	hashChiseledQuartz
 	hashClay
@@ -66,6 +68,7 @@ const (
 	hashIronBars
 	hashIronBlock
 	hashIronOre
+	hashItemFrame
 	hashKelp
 	hashLadder
 	hashLantern
@@ -73,6 +76,7 @@ const (
 	hashLapisOre
 	hashLava
 	hashLeaves
+	hashLectern
 	hashLight
 	hashLitPumpkin
 	hashLog
@@ -102,12 +106,14 @@ const (
 	hashRawGoldBlock
 	hashRawIronBlock
 	hashSand
+	hashSapling
 	hashSandstone
 	hashSandstoneStairs
 	hashSeaLantern
 	hashSeaPickle
 	hashShroomlight
 	hashSign
+	hashSkull
 	hashSoulSand
 	hashSoulSoil
 	hashSponge
@@ -116,6 +122,7 @@ const (
 	hashStainedGlassPane
 	hashStainedTerracotta
 	hashStone
+	hashSweetBerryBush
 	hashTallGrass
 	hashTerracotta
 	hashTorch
@@ -144,11 +151,11 @@ func (AncientDebris) Hash() uint64 {
 }
 
 func (a Andesite) Hash() uint64 {
-	return hashAndesite | uint64(boolByte(a.Polished))<<7
+	return hashAndesite | uint64(boolByte(a.Polished))<<8
 }
 
 func (b Barrel) Hash() uint64 {
-	return hashBarrel | uint64(b.Facing)<<7 | uint64(boolByte(b.Open))<<10
+	return hashBarrel | uint64(b.Facing)<<8 | uint64(boolByte(b.Open))<<11
 }
 
 func (Barrier) Hash() uint64 {
@@ -156,7 +163,7 @@ func (Barrier) Hash() uint64 {
 }
 
 func (b Basalt) Hash() uint64 {
-	return hashBasalt | uint64(boolByte(b.Polished))<<7 | uint64(b.Axis)<<8
+	return hashBasalt | uint64(boolByte(b.Polished))<<8 | uint64(b.Axis)<<9
 }
 
 func (Beacon) Hash() uint64 {
@@ -164,11 +171,11 @@ func (Beacon) Hash() uint64 {
 }
 
 func (b Bedrock) Hash() uint64 {
-	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<7
+	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<8
 }
 
 func (b BeetrootSeeds) Hash() uint64 {
-	return hashBeetrootSeeds | uint64(b.Growth)<<7
+	return hashBeetrootSeeds | uint64(b.Growth)<<8
 }
 
 func (BlueIce) Hash() uint64 {
@@ -176,7 +183,11 @@ func (BlueIce) Hash() uint64 {
 }
 
 func (b BoneBlock) Hash() uint64 {
-	return hashBoneBlock | uint64(b.Axis)<<7
+	return hashBoneBlock | uint64(b.Axis)<<8
+}
+
+func (Bookshelf) Hash() uint64 {
+	return hashBookshelf
 }
 
 func (Bricks) Hash() uint64 {
@@ -184,7 +195,7 @@ func (Bricks) Hash() uint64 {
 }
 
 func (c Cake) Hash() uint64 {
-	return hashCake | uint64(c.Bites)<<7
+	return hashCake | uint64(c.Bites)<<8
 }
 
 func (c Calcite) Hash() uint64 {
@@ -192,15 +203,19 @@ func (c Calcite) Hash() uint64 {
 }
 
 func (c Carpet) Hash() uint64 {
-	return hashCarpet | uint64(c.Colour.Uint8())<<7
+	return hashCarpet | uint64(c.Colour.Uint8())<<8
 }
 
 func (c Carrot) Hash() uint64 {
-	return hashCarrot | uint64(c.Growth)<<7
+	return hashCarrot | uint64(c.Growth)<<8
+}
+
+func (c Cauldron) Hash() uint64 {
+	return hashCauldron | uint64(c.Level)<<8
 }
 
 func (c Chest) Hash() uint64 {
-	return hashChest | uint64(c.Facing)<<7
+	return hashChest | uint64(c.Facing)<<8
 }
 
 func (ChiseledQuartz) Hash() uint64 {
@@ -216,35 +231,35 @@ func (CoalBlock) Hash() uint64 {
 }
 
 func (c CoalOre) Hash() uint64 {
-	return hashCoalOre | uint64(c.Type.Uint8())<<7
+	return hashCoalOre | uint64(c.Type.Uint8())<<8
 }
 
 func (c Cobblestone) Hash() uint64 {
-	return hashCobblestone | uint64(boolByte(c.Mossy))<<7
+	return hashCobblestone | uint64(boolByte(c.Mossy))<<8
 }
 
 func (c CocoaBean) Hash() uint64 {
-	return hashCocoaBean | uint64(c.Facing)<<7 | uint64(c.Age)<<9
+	return hashCocoaBean | uint64(c.Facing)<<8 | uint64(c.Age)<<10
 }
 
 func (c Concrete) Hash() uint64 {
-	return hashConcrete | uint64(c.Colour.Uint8())<<7
+	return hashConcrete | uint64(c.Colour.Uint8())<<8
 }
 
 func (c ConcretePowder) Hash() uint64 {
-	return hashConcretePowder | uint64(c.Colour.Uint8())<<7
+	return hashConcretePowder | uint64(c.Colour.Uint8())<<8
 }
 
 func (c CopperOre) Hash() uint64 {
-	return hashCopperOre | uint64(c.Type.Uint8())<<7
+	return hashCopperOre | uint64(c.Type.Uint8())<<8
 }
 
 func (c Coral) Hash() uint64 {
-	return hashCoral | uint64(c.Type.Uint8())<<7 | uint64(boolByte(c.Dead))<<10
+	return hashCoral | uint64(c.Type.Uint8())<<8 | uint64(boolByte(c.Dead))<<11
 }
 
 func (c CoralBlock) Hash() uint64 {
-	return hashCoralBlock | uint64(c.Type.Uint8())<<7 | uint64(boolByte(c.Dead))<<10
+	return hashCoralBlock | uint64(c.Type.Uint8())<<8 | uint64(boolByte(c.Dead))<<11
 }
 
 func (d DeadBush) Hash() uint64 {
@@ -256,15 +271,15 @@ func (DiamondBlock) Hash() uint64 {
 }
 
 func (d DiamondOre) Hash() uint64 {
-	return hashDiamondOre | uint64(d.Type.Uint8())<<7
+	return hashDiamondOre | uint64(d.Type.Uint8())<<8
 }
 
 func (d Diorite) Hash() uint64 {
-	return hashDiorite | uint64(boolByte(d.Polished))<<7
+	return hashDiorite | uint64(boolByte(d.Polished))<<8
 }
 
 func (d Dirt) Hash() uint64 {
-	return hashDirt | uint64(boolByte(d.Coarse))<<7
+	return hashDirt | uint64(boolByte(d.Coarse))<<8
 }
 
 func (DirtPath) Hash() uint64 {
@@ -272,11 +287,11 @@ func (DirtPath) Hash() uint64 {
 }
 
 func (d DoubleFlower) Hash() uint64 {
-	return hashDoubleFlower | uint64(boolByte(d.UpperPart))<<7 | uint64(d.Type.Uint8())<<8
+	return hashDoubleFlower | uint64(boolByte(d.UpperPart))<<8 | uint64(d.Type.Uint8())<<9
 }
 
 func (d DoubleTallGrass) Hash() uint64 {
-	return hashDoubleTallGrass | uint64(boolByte(d.UpperPart))<<7 | uint64(d.Type.Uint8())<<8
+	return hashDoubleTallGrass | uint64(boolByte(d.UpperPart))<<8 | uint64(d.Type.Uint8())<<9
 }
 
 func (DragonEgg) Hash() uint64 {
@@ -296,11 +311,11 @@ func (EmeraldBlock) Hash() uint64 {
 }
 
 func (e EmeraldOre) Hash() uint64 {
-	return hashEmeraldOre | uint64(e.Type.Uint8())<<7
+	return hashEmeraldOre | uint64(e.Type.Uint8())<<8
 }
 
 func (s EndBrickStairs) Hash() uint64 {
-	return hashEndBrickStairs | uint64(boolByte(s.UpsideDown))<<7 | uint64(s.Facing)<<8
+	return hashEndBrickStairs | uint64(boolByte(s.UpsideDown))<<8 | uint64(s.Facing)<<9
 }
 
 func (EndBricks) Hash() uint64 {
@@ -312,15 +327,15 @@ func (EndStone) Hash() uint64 {
 }
 
 func (f Farmland) Hash() uint64 {
-	return hashFarmland | uint64(f.Hydration)<<7
+	return hashFarmland | uint64(f.Hydration)<<8
 }
 
 func (f Fire) Hash() uint64 {
-	return hashFire | uint64(f.Type.Uint8())<<7 | uint64(f.Age)<<8
+	return hashFire | uint64(f.Type.Uint8())<<8 | uint64(f.Age)<<9
 }
 
 func (f Flower) Hash() uint64 {
-	return hashFlower | uint64(f.Type.Uint8())<<7
+	return hashFlower | uint64(f.Type.Uint8())<<8
 }
 
 func (GildedBlackstone) Hash() uint64 {
@@ -336,7 +351,7 @@ func (GlassPane) Hash() uint64 {
 }
 
 func (t GlazedTerracotta) Hash() uint64 {
-	return hashGlazedTerracotta | uint64(t.Colour.Uint8())<<7 | uint64(t.Facing)<<11
+	return hashGlazedTerracotta | uint64(t.Colour.Uint8())<<8 | uint64(t.Facing)<<12
 }
 
 func (Glowstone) Hash() uint64 {
@@ -348,11 +363,11 @@ func (GoldBlock) Hash() uint64 {
 }
 
 func (g GoldOre) Hash() uint64 {
-	return hashGoldOre | uint64(g.Type.Uint8())<<7
+	return hashGoldOre | uint64(g.Type.Uint8())<<8
 }
 
 func (g Granite) Hash() uint64 {
-	return hashGranite | uint64(boolByte(g.Polished))<<7
+	return hashGranite | uint64(boolByte(g.Polished))<<8
 }
 
 func (Grass) Hash() uint64 {
@@ -380,19 +395,23 @@ func (IronBlock) Hash() uint64 {
 }
 
 func (i IronOre) Hash() uint64 {
-	return hashIronOre | uint64(i.Type.Uint8())<<7
+	return hashIronOre | uint64(i.Type.Uint8())<<8
+}
+
+func (i ItemFrame) Hash() uint64 {
+	return hashItemFrame | uint64(i.Facing)<<8 | uint64(boolByte(i.Glowing))<<11
 }
 
 func (k Kelp) Hash() uint64 {
-	return hashKelp | uint64(k.Age)<<7
+	return hashKelp | uint64(k.Age)<<8
 }
 
 func (l Ladder) Hash() uint64 {
-	return hashLadder | uint64(l.Facing)<<7
+	return hashLadder | uint64(l.Facing)<<8
 }
 
 func (l Lantern) Hash() uint64 {
-	return hashLantern | uint64(boolByte(l.Hanging))<<7 | uint64(l.Type.Uint8())<<8
+	return hashLantern | uint64(boolByte(l.Hanging))<<8 | uint64(l.Type.Uint8())<<9
 }
 
 func (LapisBlock) Hash() uint64 {
@@ -400,27 +419,31 @@ func (LapisBlock) Hash() uint64 {
 }
 
 func (l LapisOre) Hash() uint64 {
-	return hashLapisOre | uint64(l.Type.Uint8())<<7
+	return hashLapisOre | uint64(l.Type.Uint8())<<8
 }
 
 func (l Lava) Hash() uint64 {
-	return hashLava | uint64(boolByte(l.Still))<<7 | uint64(l.Depth)<<8 | uint64(boolByte(l.Falling))<<16
+	return hashLava | uint64(boolByte(l.Still))<<8 | uint64(l.Depth)<<9 | uint64(boolByte(l.Falling))<<17
 }
 
 func (l Leaves) Hash() uint64 {
-	return hashLeaves | uint64(l.Wood.Uint8())<<7 | uint64(boolByte(l.Persistent))<<10 | uint64(boolByte(l.ShouldUpdate))<<11
+	return hashLeaves | uint64(l.Wood.Uint8())<<8 | uint64(boolByte(l.Persistent))<<11 | uint64(boolByte(l.ShouldUpdate))<<12
+}
+
+func (l Lectern) Hash() uint64 {
+	return hashLectern | uint64(l.Facing)<<8
 }
 
 func (l Light) Hash() uint64 {
-	return hashLight | uint64(l.Level)<<7
+	return hashLight | uint64(l.Level)<<8
 }
 
 func (l LitPumpkin) Hash() uint64 {
-	return hashLitPumpkin | uint64(l.Facing)<<7
+	return hashLitPumpkin | uint64(l.Facing)<<8
 }
 
 func (l Log) Hash() uint64 {
-	return hashLog | uint64(l.Wood.Uint8())<<7 | uint64(boolByte(l.Stripped))<<10 | uint64(l.Axis)<<11
+	return hashLog | uint64(l.Wood.Uint8())<<8 | uint64(boolByte(l.Stripped))<<11 | uint64(l.Axis)<<12
 }
 
 func (Melon) Hash() uint64 {
@@ -428,7 +451,7 @@ func (Melon) Hash() uint64 {
 }
 
 func (m MelonSeeds) Hash() uint64 {
-	return hashMelonSeeds | uint64(m.Growth)<<7 | uint64(m.Direction)<<15
+	return hashMelonSeeds | uint64(m.Growth)<<8 | uint64(m.Direction)<<16
 }
 
 func (m MossCarpet) Hash() uint64 {
@@ -452,7 +475,7 @@ func (n NetherSprouts) Hash() uint64 {
 }
 
 func (n NetherWart) Hash() uint64 {
-	return hashNetherWart | uint64(n.Age)<<7
+	return hashNetherWart | uint64(n.Age)<<8
 }
 
 func (NetheriteBlock) Hash() uint64 {
@@ -468,7 +491,7 @@ func (n NoteBlock) Hash() uint64 {
 }
 
 func (o Obsidian) Hash() uint64 {
-	return hashObsidian | uint64(boolByte(o.Crying))<<7
+	return hashObsidian | uint64(boolByte(o.Crying))<<8
 }
 
 func (PackedIce) Hash() uint64 {
@@ -476,7 +499,7 @@ func (PackedIce) Hash() uint64 {
 }
 
 func (p Planks) Hash() uint64 {
-	return hashPlanks | uint64(p.Wood.Uint8())<<7
+	return hashPlanks | uint64(p.Wood.Uint8())<<8
 }
 
 func (Podzol) Hash() uint64 {
@@ -484,23 +507,23 @@ func (Podzol) Hash() uint64 {
 }
 
 func (p Potato) Hash() uint64 {
-	return hashPotato | uint64(p.Growth)<<7
+	return hashPotato | uint64(p.Growth)<<8
 }
 
 func (p Prismarine) Hash() uint64 {
-	return hashPrismarine | uint64(p.Type.Uint8())<<7
+	return hashPrismarine | uint64(p.Type.Uint8())<<8
 }
 
 func (p Pumpkin) Hash() uint64 {
-	return hashPumpkin | uint64(boolByte(p.Carved))<<7 | uint64(p.Facing)<<8
+	return hashPumpkin | uint64(boolByte(p.Carved))<<8 | uint64(p.Facing)<<9
 }
 
 func (p PumpkinSeeds) Hash() uint64 {
-	return hashPumpkinSeeds | uint64(p.Growth)<<7 | uint64(p.Direction)<<15
+	return hashPumpkinSeeds | uint64(p.Growth)<<8 | uint64(p.Direction)<<16
 }
 
 func (q Quartz) Hash() uint64 {
-	return hashQuartz | uint64(boolByte(q.Smooth))<<7
+	return hashQuartz | uint64(boolByte(q.Smooth))<<8
 }
 
 func (QuartzBricks) Hash() uint64 {
@@ -508,7 +531,7 @@ func (QuartzBricks) Hash() uint64 {
 }
 
 func (q QuartzPillar) Hash() uint64 {
-	return hashQuartzPillar | uint64(q.Axis)<<7
+	return hashQuartzPillar | uint64(q.Axis)<<8
 }
 
 func (RawCopperBlock) Hash() uint64 {
@@ -524,15 +547,19 @@ func (RawIronBlock) Hash() uint64 {
 }
 
 func (s Sand) Hash() uint64 {
-	return hashSand | uint64(boolByte(s.Red))<<7
+	return hashSand | uint64(boolByte(s.Red))<<8
+}
+
+func (s Sapling) Hash() uint64 {
+	return hashSapling | uint64(s.Wood.Uint8())<<8 | uint64(boolByte(s.Grown))<<11
 }
 
 func (s Sandstone) Hash() uint64 {
-	return hashSandstone | uint64(s.Type.Uint8())<<7 | uint64(boolByte(s.Red))<<9
+	return hashSandstone | uint64(s.Type.Uint8())<<8 | uint64(boolByte(s.Red))<<10
 }
 
 func (s SandstoneStairs) Hash() uint64 {
-	return hashSandstoneStairs | uint64(boolByte(s.Smooth))<<7 | uint64(boolByte(s.Red))<<8 | uint64(boolByte(s.UpsideDown))<<9 | uint64(s.Facing)<<10
+	return hashSandstoneStairs | uint64(boolByte(s.Smooth))<<8 | uint64(boolByte(s.Red))<<9 | uint64(boolByte(s.UpsideDown))<<10 | uint64(s.Facing)<<11
 }
 
 func (SeaLantern) Hash() uint64 {
@@ -540,7 +567,7 @@ func (SeaLantern) Hash() uint64 {
 }
 
 func (s SeaPickle) Hash() uint64 {
-	return hashSeaPickle | uint64(s.AdditionalCount)<<7 | uint64(boolByte(s.Dead))<<15
+	return hashSeaPickle | uint64(s.AdditionalCount)<<8 | uint64(boolByte(s.Dead))<<16
 }
 
 func (Shroomlight) Hash() uint64 {
@@ -548,7 +575,11 @@ func (Shroomlight) Hash() uint64 {
 }
 
 func (s Sign) Hash() uint64 {
-	return hashSign | uint64(s.Wood.Uint8())<<7 | uint64(s.Attach.Uint8())<<10
+	return hashSign | uint64(s.Wood.Uint8())<<8 | uint64(s.Attach.Uint8())<<11
+}
+
+func (s Skull) Hash() uint64 {
+	return hashSkull | uint64(s.Attach.FaceUint8())<<8
 }
 
 func (SoulSand) Hash() uint64 {
@@ -560,7 +591,7 @@ func (SoulSoil) Hash() uint64 {
 }
 
 func (s Sponge) Hash() uint64 {
-	return hashSponge | uint64(boolByte(s.Wet))<<7
+	return hashSponge | uint64(boolByte(s.Wet))<<8
 }
 
 func (s SporeBlossom) Hash() uint64 {
@@ -568,23 +599,27 @@ func (s SporeBlossom) Hash() uint64 {
 }
 
 func (g StainedGlass) Hash() uint64 {
-	return hashStainedGlass | uint64(g.Colour.Uint8())<<7
+	return hashStainedGlass | uint64(g.Colour.Uint8())<<8
 }
 
 func (p StainedGlassPane) Hash() uint64 {
-	return hashStainedGlassPane | uint64(p.Colour.Uint8())<<7
+	return hashStainedGlassPane | uint64(p.Colour.Uint8())<<8
 }
 
 func (t StainedTerracotta) Hash() uint64 {
-	return hashStainedTerracotta | uint64(t.Colour.Uint8())<<7
+	return hashStainedTerracotta | uint64(t.Colour.Uint8())<<8
 }
 
 func (s Stone) Hash() uint64 {
-	return hashStone | uint64(boolByte(s.Smooth))<<7
+	return hashStone | uint64(boolByte(s.Smooth))<<8
+}
+
+func (b SweetBerryBush) Hash() uint64 {
+	return hashSweetBerryBush | uint64(b.Age)<<8
 }
 
 func (g TallGrass) Hash() uint64 {
-	return hashTallGrass | uint64(g.Type.Uint8())<<7
+	return hashTallGrass | uint64(g.Type.Uint8())<<8
 }
 
 func (Terracotta) Hash() uint64 {
@@ -592,7 +627,7 @@ func (Terracotta) Hash() uint64 {
 }
 
 func (t Torch) Hash() uint64 {
-	return hashTorch | uint64(t.Facing)<<7 | uint64(t.Type.Uint8())<<10
+	return hashTorch | uint64(t.Facing)<<8 | uint64(t.Type.Uint8())<<11
 }
 
 func (t Tuff) Hash() uint64 {
@@ -600,37 +635,37 @@ func (t Tuff) Hash() uint64 {
 }
 
 func (w Water) Hash() uint64 {
-	return hashWater | uint64(boolByte(w.Still))<<7 | uint64(w.Depth)<<8 | uint64(boolByte(w.Falling))<<16
+	return hashWater | uint64(boolByte(w.Still))<<8 | uint64(w.Depth)<<9 | uint64(boolByte(w.Falling))<<17
 }
 
 func (s WheatSeeds) Hash() uint64 {
-	return hashWheatSeeds | uint64(s.Growth)<<7
+	return hashWheatSeeds | uint64(s.Growth)<<8
 }
 
 func (d WoodDoor) Hash() uint64 {
-	return hashWoodDoor | uint64(d.Wood.Uint8())<<7 | uint64(d.Facing)<<10 | uint64(boolByte(d.Open))<<12 | uint64(boolByte(d.Top))<<13 | uint64(boolByte(d.Right))<<14
+	return hashWoodDoor | uint64(d.Wood.Uint8())<<8 | uint64(d.Facing)<<11 | uint64(boolByte(d.Open))<<13 | uint64(boolByte(d.Top))<<14 | uint64(boolByte(d.Right))<<15
 }
 
 func (w WoodFence) Hash() uint64 {
-	return hashWoodFence | uint64(w.Wood.Uint8())<<7
+	return hashWoodFence | uint64(w.Wood.Uint8())<<8
 }
 
 func (f WoodFenceGate) Hash() uint64 {
-	return hashWoodFenceGate | uint64(f.Wood.Uint8())<<7 | uint64(f.Facing)<<10 | uint64(boolByte(f.Open))<<12 | uint64(boolByte(f.Lowered))<<13
+	return hashWoodFenceGate | uint64(f.Wood.Uint8())<<8 | uint64(f.Facing)<<11 | uint64(boolByte(f.Open))<<13 | uint64(boolByte(f.Lowered))<<14
 }
 
 func (s WoodSlab) Hash() uint64 {
-	return hashWoodSlab | uint64(s.Wood.Uint8())<<7 | uint64(boolByte(s.Top))<<10 | uint64(boolByte(s.Double))<<11
+	return hashWoodSlab | uint64(s.Wood.Uint8())<<8 | uint64(boolByte(s.Top))<<11 | uint64(boolByte(s.Double))<<12
 }
 
 func (s WoodStairs) Hash() uint64 {
-	return hashWoodStairs | uint64(s.Wood.Uint8())<<7 | uint64(boolByte(s.UpsideDown))<<10 | uint64(s.Facing)<<11
+	return hashWoodStairs | uint64(s.Wood.Uint8())<<8 | uint64(boolByte(s.UpsideDown))<<11 | uint64(s.Facing)<<12
 }
 
 func (t WoodTrapdoor) Hash() uint64 {
-	return hashWoodTrapdoor | uint64(t.Wood.Uint8())<<7 | uint64(t.Facing)<<10 | uint64(boolByte(t.Open))<<12 | uint64(boolByte(t.Top))<<13
+	return hashWoodTrapdoor | uint64(t.Wood.Uint8())<<8 | uint64(t.Facing)<<11 | uint64(boolByte(t.Open))<<13 | uint64(boolByte(t.Top))<<14
 }
 
 func (w Wool) Hash() uint64 {
-	return hashWool | uint64(w.Colour.Uint8())<<7
+	return hashWool | uint64(w.Colour.Uint8())<<8
 }
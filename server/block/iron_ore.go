@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // IronOre is a mineral block found underground.
@@ -30,3 +31,8 @@ func (i IronOre) EncodeItem() (name string, meta int16) {
 func (i IronOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + i.Type.Prefix() + "iron_ore", nil
 }
+
+// MapColour ...
+func (IronOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
@@ -0,0 +1,177 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// ItemFrame is a block that displays an item placed in it. Glowing item frames render the item they hold
+// fully lit, regardless of the light level around them.
+type ItemFrame struct {
+	transparent
+	empty
+
+	// Facing is the face of the block that the item frame is attached to, pointing away from that block.
+	Facing cube.Face
+	// Item is the item displayed in the item frame. It is empty if the item frame holds no item.
+	Item item.Stack
+	// Rotation is the rotation of the item in the item frame, in steps of 45 degrees. It is a value from 0 to
+	// 7.
+	Rotation int
+	// DropChance is the chance from 0 to 1 that the item in the item frame is dropped when it is punched out
+	// or when the item frame is broken.
+	DropChance float64
+	// Glowing specifies if the item frame is a glow item frame, which renders its item fully lit.
+	Glowing bool
+}
+
+// NewItemFrame returns a new ItemFrame that always drops its item.
+func NewItemFrame(glowing bool) ItemFrame {
+	return ItemFrame{DropChance: 1, Glowing: glowing}
+}
+
+// SideClosed ...
+func (ItemFrame) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (i ItemFrame) BreakInfo() BreakInfo {
+	return newBreakInfo(0.25, alwaysHarvestable, nothingEffective, func(tool.Tool, []item.Enchantment) []item.Stack {
+		return i.drops()
+	})
+}
+
+// drops returns the items dropped when the ItemFrame is broken: The item frame itself and, depending on its
+// DropChance, the item it holds.
+func (i ItemFrame) drops() []item.Stack {
+	drops := []item.Stack{item.NewStack(ItemFrame{Glowing: i.Glowing}, 1)}
+	if !i.Item.Empty() && rand.Float64() < i.DropChance {
+		drops = append(drops, i.Item)
+	}
+	return drops
+}
+
+// UseOnBlock ...
+func (i ItemFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, i)
+	if !used {
+		return false
+	}
+	if !w.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, w) {
+		return false
+	}
+	i.Facing, i.Item, i.Rotation, i.DropChance = face, item.Stack{}, 0, 1
+
+	place(w, pos, i, user, ctx)
+	return placed(ctx)
+}
+
+// Activate places the item held by the user in the item frame if it holds no item yet. If it does, the item
+// is rotated by 45 degrees.
+func (i ItemFrame) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) {
+	if !i.Item.Empty() {
+		i.Rotation = (i.Rotation + 1) % 8
+		w.SetBlock(pos, i)
+		return
+	}
+	held, left := u.HeldItems()
+	if held.Empty() {
+		return
+	}
+	i.Item, i.Rotation = held.Grow(1-held.Count()), 0
+	u.SetHeldItems(held.Grow(-1), left)
+	w.SetBlock(pos, i)
+}
+
+// Punch removes the item from the item frame, dropping it depending on the DropChance of the item frame.
+func (i ItemFrame) Punch(pos cube.Pos, _ cube.Face, w *world.World, _ item.User) {
+	if i.Item.Empty() {
+		return
+	}
+	if rand.Float64() < i.DropChance {
+		dropItem(w, i.Item, pos)
+	}
+	i.Item, i.Rotation = item.Stack{}, 0
+	w.SetBlock(pos, i)
+}
+
+// NeighbourUpdateTick ...
+func (i ItemFrame) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !w.Block(pos.Side(i.Facing.Opposite())).Model().FaceSolid(pos.Side(i.Facing.Opposite()), i.Facing, w) {
+		w.BreakBlockWithoutParticles(pos)
+		for _, drop := range i.drops() {
+			dropItem(w, drop, pos)
+		}
+	}
+}
+
+// dropItem drops the item.Stack passed as an item entity at the centre of the position passed.
+func dropItem(w *world.World, s item.Stack, pos cube.Pos) {
+	itemEntity := entity.NewItem(s, pos.Vec3Centre())
+	itemEntity.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
+	w.AddEntity(itemEntity)
+}
+
+// EncodeItem ...
+func (i ItemFrame) EncodeItem() (name string, meta int16) {
+	if i.Glowing {
+		return "minecraft:glow_frame", 0
+	}
+	return "minecraft:frame", 0
+}
+
+// EncodeBlock ...
+func (i ItemFrame) EncodeBlock() (name string, properties map[string]interface{}) {
+	name = "minecraft:frame"
+	if i.Glowing {
+		name = "minecraft:glow_frame"
+	}
+	return name, map[string]interface{}{
+		"facing_direction":     int32(i.Facing),
+		"item_frame_map_bit":   uint8(0),
+		"item_frame_photo_bit": uint8(0),
+	}
+}
+
+// DecodeNBT ...
+func (i ItemFrame) DecodeNBT(data map[string]interface{}) interface{} {
+	i.Item = nbtconv.MapItem(data, "Item")
+	i.Rotation = int(nbtconv.MapFloat32(data, "ItemRotation")/45) % 8
+	i.DropChance = float64(nbtconv.MapFloat32(data, "ItemDropChance"))
+	if _, ok := data["ItemDropChance"]; !ok {
+		i.DropChance = 1
+	}
+	return i
+}
+
+// EncodeNBT ...
+func (i ItemFrame) EncodeNBT() map[string]interface{} {
+	m := map[string]interface{}{
+		"id":             "ItemFrame",
+		"ItemRotation":   float32(i.Rotation * 45),
+		"ItemDropChance": float32(i.DropChance),
+	}
+	if i.Glowing {
+		m["id"] = "GlowItemFrame"
+	}
+	if !i.Item.Empty() {
+		m["Item"] = nbtconv.WriteItem(i.Item, true)
+	}
+	return m
+}
+
+// allItemFrames ...
+func allItemFrames() (frames []world.Block) {
+	for _, f := range cube.Faces() {
+		frames = append(frames, ItemFrame{Facing: f})
+		frames = append(frames, ItemFrame{Facing: f, Glowing: true})
+	}
+	return
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 )
 
@@ -52,6 +53,11 @@ func (k Kelp) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:kelp", map[string]interface{}{"kelp_age": int32(k.Age)}
 }
 
+// MapColour ...
+func (Kelp) MapColour() color.RGBA {
+	return waterMapColour
+}
+
 // CanDisplace will return true if the liquid is Water, since kelp can waterlog.
 func (Kelp) CanDisplace(b world.Liquid) bool {
 	_, water := b.(Water)
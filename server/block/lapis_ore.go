@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 	"math/rand"
 )
 
@@ -33,3 +34,8 @@ func (l LapisOre) EncodeItem() (name string, meta int16) {
 func (l LapisOre) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:" + l.Type.Prefix() + "lapis_ore", nil
 }
+
+// MapColour ...
+func (LapisOre) MapColour() color.RGBA {
+	return stoneMapColour
+}
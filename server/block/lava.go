@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"image/color"
 	"math/rand"
 	"time"
 )
@@ -31,7 +32,7 @@ type Lava struct {
 // neighboursLavaFlammable returns true if one a block adjacent to the passed position is flammable.
 func neighboursLavaFlammable(pos cube.Pos, w *world.World) bool {
 	for i := cube.Face(0); i < 6; i++ {
-		if flammable, ok := w.Block(pos.Side(i)).(Flammable); ok && flammable.FlammabilityInfo().LavaFlammable {
+		if info, ok := flammabilityInfo(w.Block(pos.Side(i))); ok && info.LavaFlammable {
 			return true
 		}
 	}
@@ -44,7 +45,7 @@ func (l Lava) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
 		fallEntity.ResetFallDistance()
 	}
 	if flammable, ok := e.(entity.Flammable); ok {
-		if l, ok := e.(entity.Living); ok && !l.AttackImmune() {
+		if l, ok := e.(entity.Living); ok {
 			l.Hurt(4, damage.SourceLava{})
 		}
 		flammable.SetOnFire(15 * time.Second)
@@ -67,7 +68,7 @@ func (l Lava) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 		for j := 0; j < 3; j++ {
 			pos = pos.Add(cube.Pos{r.Intn(3) - 1, 0, r.Intn(3) - 1})
 			if _, ok := w.Block(pos.Side(cube.FaceUp)).(Air); ok {
-				if flammable, ok := w.Block(pos).(Flammable); ok && flammable.FlammabilityInfo().LavaFlammable && flammable.FlammabilityInfo().Encouragement > 0 {
+				if info, ok := flammabilityInfo(w.Block(pos)); ok && info.LavaFlammable && info.Encouragement > 0 {
 					w.PlaceBlock(pos, Fire{})
 				}
 			}
@@ -209,6 +210,11 @@ func (l Lava) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:flowing_lava", map[string]interface{}{"liquid_depth": int32(v)}
 }
 
+// MapColour ...
+func (Lava) MapColour() color.RGBA {
+	return lavaMapColour
+}
+
 // allLava returns a list of all lava states.
 func allLava() (b []world.Block) {
 	f := func(still, falling bool) {
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 )
 
@@ -96,7 +97,14 @@ func (l Leaves) BreakInfo() BreakInfo {
 		if (l.Wood == OakWood() || l.Wood == DarkOakWood()) && rand.Float64() < 0.005 {
 			drops = append(drops, item.NewStack(item.Apple{}, 1))
 		}
-		// TODO: Saplings and sticks can drop along with apples
+		saplingChance := 0.05
+		if l.Wood == JungleWood() {
+			saplingChance = 0.025
+		}
+		if rand.Float64() < saplingChance {
+			drops = append(drops, item.NewStack(Sapling{Wood: l.Wood}, 1))
+		}
+		// TODO: Sticks can drop along with apples and saplings
 		return drops
 	})
 }
@@ -139,6 +147,11 @@ func (l Leaves) EncodeBlock() (name string, properties map[string]interface{}) {
 	panic("invalid wood type")
 }
 
+// MapColour ...
+func (Leaves) MapColour() color.RGBA {
+	return plantMapColour
+}
+
 // allLogs returns a list of all possible leaves states.
 func allLeaves() (leaves []world.Block) {
 	f := func(persistent, update bool) {
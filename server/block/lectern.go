@@ -0,0 +1,157 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lectern is a librarian's job site block that holds a WritableBook or WrittenBook. Any player may open the
+// book on a lectern to read it, but it cannot be edited while on the lectern. The page that the book is open
+// on is shared by all players.
+type Lectern struct {
+	transparent
+	bass
+
+	// Facing is the direction that the lectern is facing.
+	Facing cube.Direction
+	// Book is the book currently held by the lectern. It is empty if the lectern holds no book.
+	Book item.Stack
+	// Page is the page that the book on the lectern is open on.
+	Page int
+}
+
+// Model ...
+func (Lectern) Model() world.BlockModel {
+	return model.Lectern{}
+}
+
+// BreakInfo ...
+func (l Lectern) BreakInfo() BreakInfo {
+	drops := []item.Stack{item.NewStack(Lectern{}, 1)}
+	if !l.Book.Empty() {
+		drops = append(drops, l.Book)
+	}
+	return newBreakInfo(2.5, alwaysHarvestable, axeEffective, simpleDrops(drops...))
+}
+
+// FlammabilityInfo ...
+func (Lectern) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(0, 0, true)
+}
+
+// UseOnBlock ...
+func (l Lectern) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, l)
+	if !used {
+		return
+	}
+	l.Facing = user.Facing().Opposite()
+
+	place(w, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// Activate places the book held by the user on the lectern if it does not hold a book yet. If it does, the
+// client of the user opens the book by itself.
+func (l Lectern) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User) {
+	if !l.Book.Empty() {
+		return
+	}
+	held, left := u.HeldItems()
+	if bookPages(held) < 0 {
+		return
+	}
+	l.Book, l.Page = held.Grow(1-held.Count()), 0
+	u.SetHeldItems(held.Grow(-1), left)
+	w.SetBlock(pos, l)
+	w.PlaySound(pos.Vec3Centre(), sound.LecternBookPlace{})
+}
+
+// TurnPage turns the book on the lectern to the page passed. An error is returned if the lectern holds no
+// book or if the book does not have the page.
+func (l Lectern) TurnPage(page int) (Lectern, error) {
+	if l.Book.Empty() {
+		return l, fmt.Errorf("lectern holds no book")
+	}
+	// Every page in the book UI is shown as two pages next to each other, so the page may exceed the last
+	// page of the book by one.
+	if page < 0 || page > bookPages(l.Book) {
+		return l, fmt.Errorf("page %v out of range for book with %v pages", page, bookPages(l.Book))
+	}
+	l.Page = page
+	return l, nil
+}
+
+// RemoveBook removes the book from the lectern and returns it. The item.Stack returned is empty if the
+// lectern held no book.
+func (l Lectern) RemoveBook() (Lectern, item.Stack) {
+	book := l.Book
+	l.Book, l.Page = item.Stack{}, 0
+	return l, book
+}
+
+// bookPages returns the amount of pages of the book in the item.Stack passed. If the stack does not hold a
+// WritableBook or WrittenBook, -1 is returned.
+func bookPages(s item.Stack) int {
+	switch b := s.Item().(type) {
+	case item.WritableBook:
+		return len(b.Pages)
+	case item.WrittenBook:
+		return len(b.Pages)
+	}
+	return -1
+}
+
+// EncodeItem ...
+func (Lectern) EncodeItem() (name string, meta int16) {
+	return "minecraft:lectern", 0
+}
+
+// EncodeBlock ...
+func (l Lectern) EncodeBlock() (name string, properties map[string]interface{}) {
+	direction := 2
+	switch l.Facing {
+	case cube.South:
+		direction = 0
+	case cube.West:
+		direction = 1
+	case cube.East:
+		direction = 3
+	}
+	return "minecraft:lectern", map[string]interface{}{"direction": int32(direction), "powered_bit": uint8(0)}
+}
+
+// DecodeNBT ...
+func (l Lectern) DecodeNBT(data map[string]interface{}) interface{} {
+	l.Book = nbtconv.MapItem(data, "book")
+	l.Page = int(nbtconv.MapInt32(data, "page"))
+	return l
+}
+
+// EncodeNBT ...
+func (l Lectern) EncodeNBT() map[string]interface{} {
+	m := map[string]interface{}{
+		"id":      "Lectern",
+		"hasBook": boolByte(!l.Book.Empty()),
+		"page":    int32(l.Page),
+	}
+	if !l.Book.Empty() {
+		m["book"] = nbtconv.WriteItem(l.Book, true)
+		m["totalPages"] = int32(bookPages(l.Book))
+	}
+	return m
+}
+
+// allLecterns ...
+func allLecterns() (lecterns []world.Block) {
+	for i := cube.Direction(0); i <= 3; i++ {
+		lecterns = append(lecterns, Lectern{Facing: i})
+	}
+	return
+}
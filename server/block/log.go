@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 )
 
 // Log is a naturally occurring block found in trees, primarily used to create planks. It comes in six
@@ -95,6 +96,11 @@ func (l Log) EncodeBlock() (name string, properties map[string]interface{}) {
 	panic("invalid wood type")
 }
 
+// MapColour ...
+func (Log) MapColour() color.RGBA {
+	return woodMapColour
+}
+
 // allLogs returns a list of all possible log states.
 func allLogs() (logs []world.Block) {
 	for _, w := range WoodTypes() {
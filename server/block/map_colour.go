@@ -0,0 +1,22 @@
+package block
+
+import "image/color"
+
+// Colours of blocks on maps, such as those rendered using world.World.RenderTopDown. Blocks made of the same
+// material share the same colour, based on the colours that blocks have on vanilla maps.
+var (
+	grassMapColour  = color.RGBA{R: 0x7f, G: 0xb2, B: 0x38, A: 0xff}
+	sandMapColour   = color.RGBA{R: 0xf7, G: 0xe9, B: 0xa3, A: 0xff}
+	clayMapColour   = color.RGBA{R: 0xa4, G: 0xa8, B: 0xb8, A: 0xff}
+	lavaMapColour   = color.RGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	iceMapColour    = color.RGBA{R: 0xa0, G: 0xa0, B: 0xff, A: 0xff}
+	plantMapColour  = color.RGBA{R: 0x00, G: 0x7c, B: 0x00, A: 0xff}
+	dirtMapColour   = color.RGBA{R: 0x97, G: 0x6d, B: 0x4d, A: 0xff}
+	stoneMapColour  = color.RGBA{R: 0x70, G: 0x70, B: 0x70, A: 0xff}
+	waterMapColour  = color.RGBA{R: 0x40, G: 0x40, B: 0xff, A: 0xff}
+	woodMapColour   = color.RGBA{R: 0x8f, G: 0x77, B: 0x48, A: 0xff}
+	podzolMapColour = color.RGBA{R: 0x81, G: 0x56, B: 0x31, A: 0xff}
+	soulMapColour   = color.RGBA{R: 0x66, G: 0x4c, B: 0x33, A: 0xff}
+	blackMapColour  = color.RGBA{R: 0x19, G: 0x19, B: 0x19, A: 0xff}
+	netherMapColour = color.RGBA{R: 0x70, G: 0x02, B: 0x00, A: 0xff}
+)
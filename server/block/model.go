@@ -54,6 +54,11 @@ func (leaves) Model() world.BlockModel {
 	return model.Leaves{}
 }
 
+// Suffocates always returns false: Entities do not suffocate inside of leaves.
+func (leaves) Suffocates() bool {
+	return false
+}
+
 // thin represents a thin, partial block such as a glass pane or an iron bar, that connects to nearby solid faces.
 type thin struct{}
 
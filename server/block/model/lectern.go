@@ -0,0 +1,21 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lectern is the model of a lectern, which has a collision box slightly lower than a full block.
+type Lectern struct{}
+
+// AABB ...
+func (Lectern) AABB(cube.Pos, *world.World) []physics.AABB {
+	return []physics.AABB{physics.NewAABB(mgl64.Vec3{}, mgl64.Vec3{1, 0.9, 1})}
+}
+
+// FaceSolid ...
+func (Lectern) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return face == cube.FaceDown
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Skull is a model used by skulls.
+type Skull struct {
+	// Direction is the direction the skull is facing. It is only used if Hanging is true.
+	Direction cube.Direction
+	// Hanging specifies if the skull is attached to a wall rather than standing on the ground.
+	Hanging bool
+}
+
+// AABB ...
+func (s Skull) AABB(cube.Pos, *world.World) []physics.AABB {
+	if !s.Hanging {
+		return []physics.AABB{physics.NewAABB(mgl64.Vec3{0.25, 0, 0.25}, mgl64.Vec3{0.75, 0.5, 0.75})}
+	}
+	box := physics.NewAABB(mgl64.Vec3{0.25, 0.25, 0.25}, mgl64.Vec3{0.75, 0.75, 0.75})
+	return []physics.AABB{box.ExtendTowards(s.Direction.Opposite().Face(), 0.25).ExtendTowards(s.Direction.Face(), -0.25)}
+}
+
+// FaceSolid ...
+func (Skull) FaceSolid(cube.Pos, cube.Face, *world.World) bool {
+	return false
+}
@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
+)
 
 // Netherrack is a block found in The Nether.
 type Netherrack struct {
@@ -28,3 +31,8 @@ func (Netherrack) EncodeItem() (name string, meta int16) {
 func (Netherrack) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:netherrack", nil
 }
+
+// MapColour ...
+func (Netherrack) MapColour() color.RGBA {
+	return netherMapColour
+}
@@ -2,6 +2,7 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/item/tool"
+	"image/color"
 )
 
 // Obsidian is a dark purple block known for its high blast resistance and strength, most commonly found when
@@ -37,9 +38,14 @@ func (o Obsidian) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:obsidian", nil
 }
 
+// MapColour ...
+func (Obsidian) MapColour() color.RGBA {
+	return blackMapColour
+}
+
 // BreakInfo ...
 func (o Obsidian) BreakInfo() BreakInfo {
 	return newBreakInfo(50, func(t tool.Tool) bool {
 		return t.ToolType() == tool.TypePickaxe && t.HarvestLevel() >= tool.TierDiamond.HarvestLevel
-	}, pickaxeEffective, oneOf(o))
+	}, pickaxeEffective, oneOf(o)).withBlastResistance(1200)
 }
@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/block/instrument"
+import (
+	"github.com/df-mc/dragonfly/server/block/instrument"
+	"image/color"
+)
 
 // PackedIce is an opaque solid block variant of ice. Unlike regular ice, it does not melt near bright light sources.
 type PackedIce struct {
@@ -31,3 +34,8 @@ func (PackedIce) EncodeItem() (name string, meta int16) {
 func (PackedIce) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:packed_ice", nil
 }
+
+// MapColour ...
+func (PackedIce) MapColour() color.RGBA {
+	return iceMapColour
+}
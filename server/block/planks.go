@@ -2,6 +2,7 @@ package block
 
 import (
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Planks are common blocks used in crafting recipes. They are made by crafting logs into planks.
@@ -24,7 +25,7 @@ func (p Planks) FlammabilityInfo() FlammabilityInfo {
 
 // BreakInfo ...
 func (p Planks) BreakInfo() BreakInfo {
-	return newBreakInfo(2, alwaysHarvestable, axeEffective, oneOf(p))
+	return newBreakInfo(2, alwaysHarvestable, axeEffective, oneOf(p)).withBlastResistance(3)
 }
 
 // EncodeItem ...
@@ -48,6 +49,11 @@ func (p Planks) EncodeBlock() (name string, properties map[string]interface{}) {
 	}
 }
 
+// MapColour ...
+func (Planks) MapColour() color.RGBA {
+	return woodMapColour
+}
+
 // allPlanks returns all planks types.
 func allPlanks() (planks []world.Block) {
 	for _, w := range WoodTypes() {
@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
+)
 
 // Podzol is a dirt-type block that naturally blankets the surface of the giant tree taiga and bamboo jungles, along
 // with their respective variants.
@@ -11,7 +14,7 @@ type Podzol struct {
 // SoilFor ...
 func (p Podzol) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, DeadBush:
+	case TallGrass, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, DeadBush, SweetBerryBush, Sapling:
 		return true
 	}
 	return false
@@ -36,3 +39,8 @@ func (Podzol) EncodeItem() (name string, meta int16) {
 func (Podzol) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:podzol", nil
 }
+
+// MapColour ...
+func (Podzol) MapColour() color.RGBA {
+	return podzolMapColour
+}
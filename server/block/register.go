@@ -9,6 +9,19 @@ import (
 
 //go:generate go run ../../cmd/blockhash -o hash.go .
 
+// RegisterCustom registers a custom block added by a resource pack, such as 'example:ruby_block', together
+// with all of its states. Once registered, the block may be placed and broken in worlds, is saved using its
+// identifier and is sent to players joining the server. RegisterCustom must be called before the server is
+// created and panics if the identifier of the block collides with a vanilla block or another custom block.
+// See world.RegisterCustomBlock for more details.
+func RegisterCustom(b world.CustomBlock) {
+	world.RegisterCustomBlock(b)
+	// Registering a custom block reassigns the runtime IDs that the tags of blocks are stored by.
+	tagMu.Lock()
+	tagSets = map[uint32]map[string]struct{}{}
+	tagMu.Unlock()
+}
+
 // init registers all blocks implemented by Dragonfly.
 func init() {
 	world.RegisterBlock(Air{})
@@ -30,6 +43,7 @@ func init() {
 	world.RegisterBlock(Bedrock{InfiniteBurning: true})
 	world.RegisterBlock(Obsidian{})
 	world.RegisterBlock(Obsidian{Crying: true})
+	world.RegisterBlock(Bookshelf{})
 	world.RegisterBlock(DiamondBlock{})
 	world.RegisterBlock(Glass{})
 	world.RegisterBlock(Glowstone{})
@@ -99,6 +113,7 @@ func init() {
 	registerAll(allBeetroot())
 	registerAll(allBoneBlock())
 	registerAll(allCake())
+	registerAll(allCauldrons())
 	registerAll(allCarpet())
 	registerAll(allCarrots())
 	registerAll(allChests())
@@ -124,10 +139,13 @@ func init() {
 	registerAll(allWoodSlabs())
 	registerAll(allLogs())
 	registerAll(allLeaves())
+	registerAll(allSaplings())
 	registerAll(allTorches())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
 	registerAll(allLitPumpkins())
+	registerAll(allLecterns())
+	registerAll(allItemFrames())
 	registerAll(allMelonStems())
 	registerAll(allFarmland())
 	registerAll(allLava())
@@ -135,6 +153,7 @@ func init() {
 	registerAll(allKelp())
 	registerAll(allPotato())
 	registerAll(allWheat())
+	registerAll(allSweetBerryBushes())
 	registerAll(allQuartz())
 	registerAll(allNetherWart())
 	registerAll(allTallGrass())
@@ -148,6 +167,7 @@ func init() {
 	registerAll(allLadders())
 	registerAll(allSandstoneStairs())
 	registerAll(allSeaPickles())
+	registerAll(allSkulls())
 }
 
 func init() {
@@ -197,6 +217,10 @@ func init() {
 	world.RegisterItem(Barrel{})
 	world.RegisterItem(Pumpkin{})
 	world.RegisterItem(LitPumpkin{})
+	world.RegisterItem(Lectern{})
+	world.RegisterItem(ItemFrame{})
+	world.RegisterItem(ItemFrame{Glowing: true})
+	world.RegisterItem(Bookshelf{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(EndStone{})
 	world.RegisterItem(Netherrack{})
@@ -210,6 +234,7 @@ func init() {
 	world.RegisterItem(BeetrootSeeds{})
 	world.RegisterItem(Potato{})
 	world.RegisterItem(Carrot{})
+	world.RegisterItem(SweetBerryBush{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
@@ -227,6 +252,7 @@ func init() {
 	world.RegisterItem(GildedBlackstone{})
 	world.RegisterItem(Shroomlight{})
 	world.RegisterItem(Cake{})
+	world.RegisterItem(Cauldron{})
 	world.RegisterItem(NetherWart{})
 	world.RegisterItem(InvisibleBedrock{})
 	world.RegisterItem(NoteBlock{Pitch: 24})
@@ -280,6 +306,7 @@ func init() {
 		world.RegisterItem(Log{Wood: w, Stripped: true})
 		if w != WarpedWood() && w != CrimsonWood() {
 			world.RegisterItem(Leaves{Wood: w, Persistent: true})
+			world.RegisterItem(Sapling{Wood: w})
 		}
 		world.RegisterItem(Planks{Wood: w})
 		world.RegisterItem(WoodStairs{Wood: w})
@@ -317,6 +344,9 @@ func init() {
 	for _, p := range PrismarineTypes() {
 		world.RegisterItem(Prismarine{Type: p})
 	}
+	for _, s := range SkullTypes() {
+		world.RegisterItem(Skull{Type: s})
+	}
 }
 
 //noinspection GoCommentLeadingSpace
@@ -0,0 +1,17 @@
+package block
+
+import "github.com/df-mc/dragonfly/server/world"
+
+// RuntimeID returns the network runtime ID of the block passed, which may be used by plugins to write packets
+// holding blocks, such as UpdateBlock packets that show fake blocks to a player. The bool returned is false if
+// the block was not registered. Runtime IDs are reassigned when custom blocks are registered, but stay the same
+// once the server is created.
+func RuntimeID(b world.Block) (uint32, bool) {
+	return world.BlockRuntimeID(b)
+}
+
+// ByRuntimeID returns the block with the network runtime ID passed. The bool returned is false if no block
+// has the runtime ID, in which case air is returned.
+func ByRuntimeID(rid uint32) (world.Block, bool) {
+	return world.BlockByRuntimeID(rid)
+}
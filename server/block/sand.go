@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Sand is a block affected by gravity. It can come in a red variant.
@@ -46,3 +47,8 @@ func (s Sand) EncodeBlock() (string, map[string]interface{}) {
 	}
 	return "minecraft:sand", map[string]interface{}{"sand_type": "normal"}
 }
+
+// MapColour ...
+func (Sand) MapColour() color.RGBA {
+	return sandMapColour
+}
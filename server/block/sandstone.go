@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
+)
 
 // Sandstone is a solid block commonly found in deserts and beaches underneath sand.
 type Sandstone struct {
@@ -36,6 +39,11 @@ func (s Sandstone) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:sandstone", map[string]interface{}{"sand_stone_type": s.Type.String()}
 }
 
+// MapColour ...
+func (Sandstone) MapColour() color.RGBA {
+	return sandMapColour
+}
+
 // allSandstones returns a list of all sandstone block variants.
 func allSandstones() (c []world.Block) {
 	f := func(red bool) {
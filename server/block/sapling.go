@@ -0,0 +1,143 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
+	"math/rand"
+)
+
+// Sapling is a non-solid plant that grows into a tree of its wood type over time, or when bone meal is used on
+// it.
+type Sapling struct {
+	empty
+	transparent
+
+	// Wood is the type of wood of the sapling, and so of the tree that it grows into. Saplings do not exist for
+	// crimson and warped wood.
+	Wood WoodType
+	// Grown specifies if the sapling passed its first growth stage. A sapling that is grown turns into a tree
+	// the next time it grows.
+	Grown bool
+}
+
+// BoneMeal ...
+func (s Sapling) BoneMeal(pos cube.Pos, w *world.World) bool {
+	if rand.Float64() < 0.45 {
+		s.grow(pos, w)
+	}
+	return true
+}
+
+// RandomTick ...
+func (s Sapling) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if w.Light(pos.Side(cube.FaceUp)) >= 9 && r.Intn(7) == 0 {
+		s.grow(pos, w)
+	}
+}
+
+// grow advances the sapling to its next growth stage, turning it into a tree if it was already grown.
+func (s Sapling) grow(pos cube.Pos, w *world.World) {
+	if !s.Grown {
+		s.Grown = true
+		w.SetBlock(pos, s)
+		return
+	}
+	growTree(pos, w, s.Wood, 4+rand.Intn(3))
+}
+
+// growTree grows a simple tree of the WoodType passed with a trunk of the height passed, starting at pos. No
+// tree is grown if there is not enough space for the trunk.
+func growTree(pos cube.Pos, w *world.World, wood WoodType, height int) {
+	for y := 1; y <= height+1; y++ {
+		if !replaceableWith(w, pos.Add(cube.Pos{0, y}), Log{}) {
+			return
+		}
+	}
+	leaves := Leaves{Wood: wood}
+	for y := height - 3; y <= height; y++ {
+		// The two bottom layers of leaves have a radius of 2, the top layers a radius of 1.
+		radius := 2
+		if y >= height-1 {
+			radius = 1
+		}
+		for x := -radius; x <= radius; x++ {
+			for z := -radius; z <= radius; z++ {
+				if (x == radius || x == -radius) && (z == radius || z == -radius) && (y == height || rand.Intn(2) == 0) {
+					// Leave out the corners of the top layer and some corners of the other layers.
+					continue
+				}
+				leavesPos := pos.Add(cube.Pos{x, y, z})
+				if replaceableWith(w, leavesPos, leaves) {
+					w.SetBlock(leavesPos, leaves)
+				}
+			}
+		}
+	}
+	for y := 0; y < height; y++ {
+		w.SetBlock(pos.Add(cube.Pos{0, y}), Log{Wood: wood, Axis: cube.Y})
+	}
+	if _, ok := w.Block(pos.Side(cube.FaceDown)).(Grass); ok {
+		w.SetBlock(pos.Side(cube.FaceDown), Dirt{})
+	}
+}
+
+// NeighbourUpdateTick ...
+func (s Sapling) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		w.BreakBlock(pos)
+	}
+}
+
+// UseOnBlock ...
+func (s Sapling) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(w, pos, Sapling{Wood: s.Wood}, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (Sapling) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (s Sapling) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Sapling{Wood: s.Wood}))
+}
+
+// EncodeItem ...
+func (s Sapling) EncodeItem() (name string, meta int16) {
+	return "minecraft:sapling", int16(s.Wood.Uint8())
+}
+
+// EncodeBlock ...
+func (s Sapling) EncodeBlock() (string, map[string]interface{}) {
+	return "minecraft:sapling", map[string]interface{}{"sapling_type": s.Wood.String(), "age_bit": s.Grown}
+}
+
+// MapColour ...
+func (Sapling) MapColour() color.RGBA {
+	return plantMapColour
+}
+
+// allSaplings ...
+func allSaplings() (saplings []world.Block) {
+	for _, w := range WoodTypes() {
+		if w == CrimsonWood() || w == WarpedWood() {
+			continue
+		}
+		saplings = append(saplings, Sapling{Wood: w})
+		saplings = append(saplings, Sapling{Wood: w, Grown: true})
+	}
+	return
+}
@@ -0,0 +1,34 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestSaplingGrowth(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+
+	pos := cube.Pos{0, 11, 0}
+	w.SetBlock(pos.Side(cube.FaceDown), Grass{})
+	w.SetBlock(pos, Sapling{Wood: BirchWood()})
+
+	Sapling{Wood: BirchWood()}.grow(pos, w)
+	if b := w.Block(pos); b != (Sapling{Wood: BirchWood(), Grown: true}) {
+		t.Fatalf("expected grown sapling after first growth stage, got %#v", b)
+	}
+	Sapling{Wood: BirchWood(), Grown: true}.grow(pos, w)
+	for y := 0; y < 4; y++ {
+		if b := w.Block(pos.Add(cube.Pos{0, y})); b != (Log{Wood: BirchWood(), Axis: cube.Y}) {
+			t.Fatalf("expected birch log at height %v of tree, got %#v", y, b)
+		}
+	}
+	if b := w.Block(pos.Add(cube.Pos{1, 3, 0})); b != (Leaves{Wood: BirchWood()}) {
+		t.Errorf("expected birch leaves next to trunk, got %#v", b)
+	}
+	if _, ok := w.Block(pos.Side(cube.FaceDown)).(Dirt); !ok {
+		t.Errorf("expected grass below tree to turn into dirt")
+	}
+}
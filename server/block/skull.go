@@ -0,0 +1,176 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Skull is a decorative block that may be placed on the ground or against walls, or worn as a helmet. Skulls come in
+// several variants, such as skeleton skulls, zombie heads and player heads.
+type Skull struct {
+	transparent
+
+	// Type is the type of the skull. It is stored in the NBT of the skull rather than its block state.
+	Type SkullType
+	// Attach is the attachment of the Skull. It is either of the type WallAttachment or StandingAttachment.
+	//blockhash:facing_only
+	Attach Attachment
+	// Skin is the skin displayed on the Skull if its Type is PlayerHead. Skin may be nil, in which case the default
+	// player head is displayed.
+	Skin *skin.Skin
+}
+
+// PlayerHeadOf returns a Skull of the PlayerHead type that holds the skin passed.
+func PlayerHeadOf(s skin.Skin) Skull {
+	return Skull{Type: PlayerHead(), Skin: &s}
+}
+
+// Helmet ...
+func (Skull) Helmet() bool {
+	return true
+}
+
+// DefencePoints ...
+func (Skull) DefencePoints() float64 {
+	return 0
+}
+
+// KnockBackResistance ...
+func (Skull) KnockBackResistance() float64 {
+	return 0
+}
+
+// UseOnBlock ...
+func (s Skull) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, face, used = firstReplaceable(w, pos, face, s)
+	if !used || face == cube.FaceDown {
+		return false
+	}
+
+	if face == cube.FaceUp {
+		yaw, _ := user.Rotation()
+		s.Attach = StandingAttachment(cube.OrientationFromYaw(yaw))
+	} else {
+		s.Attach = WallAttachment(face.Direction())
+	}
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (s Skull) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if s.Attach.hanging {
+		if _, ok := w.Block(pos.Side(s.Attach.facing.Opposite().Face())).(Air); ok {
+			w.BreakBlock(pos)
+		}
+		return
+	}
+	if _, ok := w.Block(pos.Side(cube.FaceDown)).(Air); ok {
+		w.BreakBlock(pos)
+	}
+}
+
+// Model ...
+func (s Skull) Model() world.BlockModel {
+	return model.Skull{Direction: s.Attach.facing, Hanging: s.Attach.hanging}
+}
+
+// CanDisplace ...
+func (Skull) CanDisplace(l world.Liquid) bool {
+	_, water := l.(Water)
+	return water
+}
+
+// SideClosed ...
+func (Skull) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (s Skull) BreakInfo() BreakInfo {
+	return newBreakInfo(1, alwaysHarvestable, nothingEffective, oneOf(Skull{Type: s.Type, Skin: s.Skin}))
+}
+
+// EncodeItem ...
+func (s Skull) EncodeItem() (name string, meta int16) {
+	return "minecraft:skull", int16(s.Type.Uint8())
+}
+
+// DecodeNBT ...
+func (s Skull) DecodeNBT(data map[string]interface{}) interface{} {
+	s.Type = SkullType{skull(nbtconv.MapByte(data, "SkullType"))}
+	if !s.Attach.hanging {
+		s.Attach.o = cube.OrientationFromYaw(float64(nbtconv.MapFloat32(data, "Rotation")))
+	}
+	if m, ok := data["Skin"].(map[string]interface{}); ok && s.Type == PlayerHead() {
+		s.Skin = decodeSkullSkin(m)
+	}
+	return s
+}
+
+// EncodeNBT ...
+func (s Skull) EncodeNBT() map[string]interface{} {
+	m := map[string]interface{}{
+		"id":          "Skull",
+		"SkullType":   s.Type.Uint8(),
+		"Rotation":    float32(s.Attach.o.Yaw()),
+		"MouthMoving": uint8(0),
+	}
+	if s.Skin != nil && s.Type == PlayerHead() {
+		m["Skin"] = encodeSkullSkin(*s.Skin)
+	}
+	return m
+}
+
+// EncodeBlock ...
+func (s Skull) EncodeBlock() (string, map[string]interface{}) {
+	if s.Attach.hanging {
+		return "minecraft:skull", map[string]interface{}{"facing_direction": int32(s.Attach.facing + 2), "no_drop_bit": uint8(0)}
+	}
+	return "minecraft:skull", map[string]interface{}{"facing_direction": int32(1), "no_drop_bit": uint8(0)}
+}
+
+// encodeSkullSkin encodes a skin.Skin to a map that may be stored in the NBT of a Skull. The field names match those
+// that the client uses for skins sent over the network. Byte arrays are used for the texture and geometry, as both may
+// exceed the maximum length of an NBT string.
+func encodeSkullSkin(s skin.Skin) map[string]interface{} {
+	return map[string]interface{}{
+		"SkinImageWidth":    int32(s.Bounds().Max.X),
+		"SkinImageHeight":   int32(s.Bounds().Max.Y),
+		"SkinData":          s.Pix,
+		"SkinResourcePatch": s.ModelConfig.Encode(),
+		"SkinGeometryData":  s.Model,
+		"PersonaSkin":       boolByte(s.Persona),
+		"PlayFabID":         s.PlayFabID,
+	}
+}
+
+// decodeSkullSkin decodes a skin.Skin from a map previously produced by encodeSkullSkin. Nil is returned if the skin
+// data found in the map does not match its dimensions.
+func decodeSkullSkin(m map[string]interface{}) *skin.Skin {
+	w, h := int(nbtconv.MapInt32(m, "SkinImageWidth")), int(nbtconv.MapInt32(m, "SkinImageHeight"))
+	pix := nbtconv.MapBytes(m, "SkinData")
+	if w <= 0 || h <= 0 || len(pix) != w*h*4 {
+		return nil
+	}
+	s := skin.New(w, h)
+	copy(s.Pix, pix)
+	s.ModelConfig, _ = skin.DecodeModelConfig(nbtconv.MapBytes(m, "SkinResourcePatch"))
+	s.Model = nbtconv.MapBytes(m, "SkinGeometryData")
+	s.Persona = nbtconv.MapByte(m, "PersonaSkin") == 1
+	s.PlayFabID = nbtconv.MapString(m, "PlayFabID")
+	return &s
+}
+
+// allSkulls ...
+func allSkulls() (skulls []world.Block) {
+	for _, d := range cube.Directions() {
+		skulls = append(skulls, Skull{Attach: WallAttachment(d)})
+	}
+	return append(skulls, Skull{Attach: StandingAttachment(0)})
+}
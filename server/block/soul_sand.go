@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/instrument"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // SoulSand is a block found naturally only in the Nether. SoulSand slows movement of mobs & players.
@@ -37,3 +38,8 @@ func (SoulSand) EncodeItem() (name string, meta int16) {
 func (SoulSand) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:soul_sand", nil
 }
+
+// MapColour ...
+func (SoulSand) MapColour() color.RGBA {
+	return soulMapColour
+}
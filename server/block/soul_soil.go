@@ -1,6 +1,9 @@
 package block
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
+)
 
 // SoulSoil is a block naturally found only in the soul sand valley.
 type SoulSoil struct {
@@ -27,3 +30,8 @@ func (SoulSoil) EncodeItem() (name string, meta int16) {
 func (SoulSoil) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:soul_soil", nil
 }
+
+// MapColour ...
+func (SoulSoil) MapColour() color.RGBA {
+	return soulMapColour
+}
@@ -20,6 +20,11 @@ func (g StainedGlass) BreakInfo() BreakInfo {
 	return newBreakInfo(0.3, alwaysHarvestable, nothingEffective, silkTouchOnlyDrop(g))
 }
 
+// Suffocates ...
+func (StainedGlass) Suffocates() bool {
+	return false
+}
+
 // EncodeItem ...
 func (g StainedGlass) EncodeItem() (name string, meta int16) {
 	return "minecraft:stained_glass", int16(g.Colour.Uint8())
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // StainedTerracotta is a block formed from clay, with a hardness and blast resistance comparable to stone. In contrast
@@ -36,6 +37,11 @@ func (t StainedTerracotta) EncodeBlock() (name string, properties map[string]int
 	return "minecraft:stained_hardened_clay", map[string]interface{}{"color": t.Colour.String()}
 }
 
+// MapColour ...
+func (s StainedTerracotta) MapColour() color.RGBA {
+	return s.Colour.RGBA()
+}
+
 // allStainedTerracotta returns stained terracotta blocks with all possible colours.
 func allStainedTerracotta() []world.Block {
 	b := make([]world.Block, 0, 16)
@@ -1,5 +1,7 @@
 package block
 
+import "image/color"
+
 type (
 	// Stone is a block found underground in the world or on mountains.
 	Stone struct {
@@ -27,7 +29,7 @@ type (
 	}
 )
 
-var stoneBreakInfo = newBreakInfo(1.5, pickaxeHarvestable, pickaxeEffective, silkTouchOneOf(Cobblestone{}, Stone{}))
+var stoneBreakInfo = newBreakInfo(1.5, pickaxeHarvestable, pickaxeEffective, silkTouchOneOf(Cobblestone{}, Stone{})).withBlastResistance(6)
 
 // BreakInfo ...
 func (s Stone) BreakInfo() BreakInfo {
@@ -76,6 +78,11 @@ func (s Stone) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:stone", map[string]interface{}{"stone_type": "stone"}
 }
 
+// MapColour ...
+func (Stone) MapColour() color.RGBA {
+	return stoneMapColour
+}
+
 // EncodeItem ...
 func (a Andesite) EncodeItem() (name string, meta int16) {
 	if a.Polished {
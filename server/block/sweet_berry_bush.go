@@ -0,0 +1,172 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// SweetBerryBush is a bush that grows sweet berries. Once it carries berries, they may be picked by using the
+// bush, after which it grows new berries. Sweet berries are also the item used to plant the bush and may be
+// eaten.
+type SweetBerryBush struct {
+	transparent
+	empty
+
+	// Age is the stage of the bush's growth. 0 is a sapling, 2 carries some berries and 3 is fully grown.
+	Age int
+}
+
+// Activate picks the berries of the bush if it carries any.
+func (b SweetBerryBush) Activate(pos cube.Pos, _ cube.Face, w *world.World, _ item.User) {
+	if harvest(pos, w, b) {
+		w.PlaySound(pos.Vec3Centre(), sound.SweetBerryBushPick{})
+	}
+}
+
+// Harvest ...
+func (b SweetBerryBush) Harvest() ([]item.Stack, world.Block, bool) {
+	if b.Age < 2 {
+		return nil, b, false
+	}
+	count := rand.Intn(2) + b.Age - 1
+	return []item.Stack{item.NewStack(SweetBerryBush{}, count)}, SweetBerryBush{Age: 1}, true
+}
+
+// EntityInside damages living entities moving horizontally through the bush once it is no longer a sapling and
+// slows them down. Entities standing still in the bush are not damaged. Players controlled by a client have no
+// velocity on the server: Their client slows them down by itself.
+func (b SweetBerryBush) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
+	l, ok := e.(entity.Living)
+	if !ok {
+		return
+	}
+	moving := false
+	if v, ok := e.(velocityEntity); ok {
+		if vel := v.Velocity(); vel != (mgl64.Vec3{}) {
+			v.SetVelocity(mgl64.Vec3{vel[0] * 0.8, vel[1] * 0.75, vel[2] * 0.8})
+			moving = vel[0] != 0 || vel[2] != 0
+		}
+	}
+	if m, ok := e.(horizontalMover); ok {
+		moving = m.MovedHorizontally()
+	}
+	if b.Age > 0 && moving {
+		l.Hurt(1, damage.SourceBlock{Block: b})
+	}
+}
+
+// horizontalMover represents an entity that keeps track of whether it moved horizontally, such as a player
+// controlled by a client, which has no velocity on the server.
+type horizontalMover interface {
+	// MovedHorizontally checks if the entity moved horizontally during the last tick.
+	MovedHorizontally() bool
+}
+
+// BoneMeal ...
+func (b SweetBerryBush) BoneMeal(pos cube.Pos, w *world.World) bool {
+	if b.Age == 3 {
+		return false
+	}
+	b.Age++
+	w.PlaceBlock(pos, b)
+	return true
+}
+
+// RandomTick ...
+func (b SweetBerryBush) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if b.Age < 3 && r.Intn(5) == 0 && w.Light(pos.Side(cube.FaceUp)) >= 9 {
+		b.Age++
+		w.PlaceBlock(pos, b)
+	}
+}
+
+// NeighbourUpdateTick ...
+func (b SweetBerryBush) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !b.supportedBy(w.Block(pos.Side(cube.FaceDown))) {
+		w.BreakBlock(pos)
+	}
+}
+
+// UseOnBlock ...
+func (b SweetBerryBush) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, b)
+	if !used {
+		return false
+	}
+	if !b.supportedBy(w.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(w, pos, SweetBerryBush{}, user, ctx)
+	return placed(ctx)
+}
+
+// supportedBy checks if the bush may be planted on the block passed.
+func (b SweetBerryBush) supportedBy(below world.Block) bool {
+	if _, ok := below.(Farmland); ok {
+		return true
+	}
+	return supportsVegetation(b, below)
+}
+
+// AlwaysConsumable ...
+func (SweetBerryBush) AlwaysConsumable() bool {
+	return false
+}
+
+// ConsumeDuration ...
+func (SweetBerryBush) ConsumeDuration() time.Duration {
+	return item.DefaultConsumeDuration
+}
+
+// Consume ...
+func (SweetBerryBush) Consume(_ *world.World, consumer item.Consumer) item.Stack {
+	consumer.Saturate(2, 1.2)
+	return item.Stack{}
+}
+
+// HasLiquidDrops ...
+func (SweetBerryBush) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (SweetBerryBush) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(60, 100, true)
+}
+
+// BreakInfo ...
+func (b SweetBerryBush) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, func(tool.Tool, []item.Enchantment) []item.Stack {
+		if drops, _, ok := b.Harvest(); ok {
+			return drops
+		}
+		return nil
+	})
+}
+
+// EncodeItem ...
+func (SweetBerryBush) EncodeItem() (name string, meta int16) {
+	return "minecraft:sweet_berries", 0
+}
+
+// EncodeBlock ...
+func (b SweetBerryBush) EncodeBlock() (name string, properties map[string]interface{}) {
+	return "minecraft:sweet_berry_bush", map[string]interface{}{"growth": int32(b.Age)}
+}
+
+// allSweetBerryBushes ...
+func allSweetBerryBushes() (bushes []world.Block) {
+	for age := 0; age <= 3; age++ {
+		bushes = append(bushes, SweetBerryBush{Age: age})
+	}
+	return
+}
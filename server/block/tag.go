@@ -0,0 +1,200 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/world"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Tags of vanilla material groups that blocks may belong to. Plugins may use any other string as a tag as
+// well.
+const (
+	// TagWood is the tag of blocks made out of wood, such as logs, planks and wooden fences.
+	TagWood = "wood"
+	// TagStone is the tag of stone-like blocks, such as stone, cobblestone and granite.
+	TagStone = "stone"
+	// TagOre is the tag of ore blocks that drop minerals when mined.
+	TagOre = "ore"
+	// TagCrops is the tag of crops that are grown on farmland.
+	TagCrops = "crops"
+	// TagClimbable is the tag of blocks that entities may climb, such as ladders.
+	TagClimbable = "climbable"
+	// TagReplaceable is the tag of blocks that may be replaced by placing another block on them, such as
+	// tall grass. Blocks that implement Replaceable may further restrict the blocks they are replaceable by.
+	TagReplaceable = "replaceable"
+	// TagFlammable is the tag of blocks that fire may spread to. Blocks that do not implement Flammable use
+	// the flammability of planks.
+	TagFlammable = "flammable"
+	// TagMineablePickaxe is the tag of blocks that are mined effectively with a pickaxe.
+	TagMineablePickaxe = "mineable/pickaxe"
+	// TagMineableAxe is the tag of blocks that are mined effectively with an axe.
+	TagMineableAxe = "mineable/axe"
+	// TagMineableShovel is the tag of blocks that are mined effectively with a shovel.
+	TagMineableShovel = "mineable/shovel"
+	// TagMineableHoe is the tag of blocks that are mined effectively with a hoe.
+	TagMineableHoe = "mineable/hoe"
+	// TagMineableShears is the tag of blocks that are mined effectively with shears.
+	TagMineableShears = "mineable/shears"
+)
+
+// Tagged represents a block that declares its own tags. Custom blocks may implement Tagged to be part of
+// vanilla material groups, such as TagFlammable, or of groups defined by plugins.
+type Tagged interface {
+	// Tags returns the tags of the block. These are added to the tags derived from the behaviour of the
+	// block.
+	Tags() []string
+}
+
+var (
+	tagMu sync.RWMutex
+	// tags holds the tags registered using RegisterTags, indexed by the type of the block.
+	tags = map[reflect.Type][]string{}
+	// tagSets holds the tags of registered blocks once they are first looked up, indexed by the runtime ID of
+	// the block. Tags that follow from the behaviour of a block may depend on its properties, so these are
+	// stored per block state rather than per block type.
+	tagSets = map[uint32]map[string]struct{}{}
+)
+
+// RegisterTags registers tags for all blocks of the same type as the block passed, regardless of their
+// properties. It may be called multiple times for the same block type to add more tags.
+func RegisterTags(b world.Block, t ...string) {
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	typ := reflect.TypeOf(b)
+	tags[typ] = append(tags[typ], t...)
+	tagSets = map[uint32]map[string]struct{}{}
+}
+
+// Tags returns all tags of the block passed, sorted alphabetically. Tags are gathered from the tags
+// registered using RegisterTags, the Tags method of blocks implementing Tagged and the behaviour of the
+// block, such as the tools it is mined effectively with.
+func Tags(b world.Block) []string {
+	set := tagSet(b)
+	s := make([]string, 0, len(set))
+	for t := range set {
+		s = append(s, t)
+	}
+	sort.Strings(s)
+	return s
+}
+
+// HasTag checks if the block passed has the tag passed.
+func HasTag(b world.Block, tag string) bool {
+	_, ok := tagSet(b)[tag]
+	return ok
+}
+
+// tagSet returns the set of all tags of the block passed. The set is computed once for every registered block
+// state and stored in tagSets, so that it may be looked up quickly by blocks that check the tags of their
+// neighbours every tick, such as fire. The set returned must not be modified.
+func tagSet(b world.Block) map[string]struct{} {
+	rid, registered := RuntimeID(b)
+	if registered {
+		tagMu.RLock()
+		set, ok := tagSets[rid]
+		tagMu.RUnlock()
+		if ok {
+			return set
+		}
+	}
+
+	set := map[string]struct{}{}
+	if tagged, ok := b.(Tagged); ok {
+		for _, t := range tagged.Tags() {
+			set[t] = struct{}{}
+		}
+	}
+	for _, t := range behaviourTags(b) {
+		set[t] = struct{}{}
+	}
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	for _, t := range tags[reflect.TypeOf(b)] {
+		set[t] = struct{}{}
+	}
+	if registered {
+		tagSets[rid] = set
+	}
+	return set
+}
+
+// behaviourTags returns the tags of a block that follow from the interfaces it implements.
+func behaviourTags(b world.Block) []string {
+	var t []string
+	if _, ok := b.(Replaceable); ok {
+		t = append(t, TagReplaceable)
+	}
+	if flammable, ok := b.(Flammable); ok && flammable.FlammabilityInfo().Encouragement > 0 {
+		t = append(t, TagFlammable)
+	}
+	if _, ok := b.(Crop); ok {
+		t = append(t, TagCrops)
+	}
+	if breakable, ok := b.(Breakable); ok {
+		if effective := breakable.BreakInfo().Effective; effective != nil {
+			for tag, typ := range mineableTags {
+				if effective(probeTool{typ: typ}) {
+					t = append(t, tag)
+				}
+			}
+		}
+	}
+	return t
+}
+
+// mineableTags maps the mineable tags to the tool types they stand for.
+var mineableTags = map[string]tool.Type{
+	TagMineablePickaxe: tool.TypePickaxe,
+	TagMineableAxe:     tool.TypeAxe,
+	TagMineableShovel:  tool.TypeShovel,
+	TagMineableHoe:     tool.TypeHoe,
+	TagMineableShears:  tool.TypeShears,
+}
+
+// probeTool is a tool.Tool of a specific type used to check which tools a block is mined effectively with.
+type probeTool struct {
+	typ tool.Type
+}
+
+// ToolType ...
+func (p probeTool) ToolType() tool.Type {
+	return p.typ
+}
+
+// HarvestLevel ...
+func (p probeTool) HarvestLevel() int {
+	return tool.TierNetherite.HarvestLevel
+}
+
+// BaseMiningEfficiency ...
+func (p probeTool) BaseMiningEfficiency(world.Block) float64 {
+	return tool.TierNetherite.BaseMiningEfficiency
+}
+
+// flammabilityInfo returns the FlammabilityInfo of a block. Blocks tagged TagFlammable that do not implement
+// Flammable burn like planks. The bool returned is false if the block is not flammable.
+func flammabilityInfo(b world.Block) (FlammabilityInfo, bool) {
+	if !HasTag(b, TagFlammable) {
+		return FlammabilityInfo{}, false
+	}
+	if flammable, ok := b.(Flammable); ok {
+		return flammable.FlammabilityInfo(), true
+	}
+	return newFlammabilityInfo(5, 20, true), true
+}
+
+// init registers the tags of vanilla blocks that do not follow from their behaviour.
+func init() {
+	for _, b := range []world.Block{Log{}, Planks{}, WoodSlab{}, WoodStairs{}, WoodFence{}, WoodFenceGate{}, WoodDoor{}, WoodTrapdoor{}} {
+		RegisterTags(b, TagWood)
+	}
+	for _, b := range []world.Block{Stone{}, Cobblestone{}, Granite{}, Diorite{}, Andesite{}} {
+		RegisterTags(b, TagStone)
+	}
+	for _, b := range []world.Block{CoalOre{}, CopperOre{}, DiamondOre{}, EmeraldOre{}, GoldOre{}, IronOre{}, LapisOre{}, NetherGoldOre{}, NetherQuartzOre{}} {
+		RegisterTags(b, TagOre)
+	}
+	RegisterTags(Ladder{}, TagClimbable)
+}
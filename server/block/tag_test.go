@@ -0,0 +1,37 @@
+package block
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	if !HasTag(Planks{}, TagWood) || !HasTag(Planks{}, TagFlammable) || !HasTag(Planks{}, TagMineableAxe) {
+		t.Fatalf("planks should be wood, flammable and mineable with an axe, got %v", Tags(Planks{}))
+	}
+	if HasTag(Stone{}, TagFlammable) || !HasTag(Stone{}, TagMineablePickaxe) {
+		t.Fatalf("stone should not be flammable and be mineable with a pickaxe, got %v", Tags(Stone{}))
+	}
+	if !ReplaceableBy(Air{}, Stone{}) || ReplaceableBy(Stone{}, Dirt{}) {
+		t.Fatalf("air should be replaceable and stone should not")
+	}
+
+	if HasTag(tagBlock{}, "custom") || ReplaceableBy(tagBlock{}, Stone{}) {
+		t.Fatalf("block should have no tags before registering them, got %v", Tags(tagBlock{}))
+	}
+	RegisterTags(tagBlock{}, TagReplaceable, "custom")
+	if !HasTag(tagBlock{}, "custom") || !ReplaceableBy(tagBlock{}, Stone{}) {
+		t.Fatalf("registered tags should be returned, got %v", Tags(tagBlock{}))
+	}
+	if HasTag(Planks{Wood: CrimsonWood()}, TagFlammable) {
+		t.Fatalf("crimson planks should not be flammable, got %v", Tags(Planks{Wood: CrimsonWood()}))
+	}
+}
+
+// tagBlock is a block type only used to test registering tags, so that vanilla blocks keep their tags.
+type tagBlock struct {
+	solid
+}
+
+func (tagBlock) EncodeBlock() (string, map[string]interface{}) { return "test:tag_block", nil }
+func (tagBlock) Hash() uint64                                  { return math.MaxUint64 }
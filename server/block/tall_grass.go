@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/tool"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand"
 )
 
@@ -90,6 +91,11 @@ func (g TallGrass) EncodeBlock() (name string, properties map[string]interface{}
 	panic("should never happen")
 }
 
+// MapColour ...
+func (TallGrass) MapColour() color.RGBA {
+	return plantMapColour
+}
+
 // allTallGrass ...
 func allTallGrass() (grasses []world.Block) {
 	for _, g := range GrassTypes() {
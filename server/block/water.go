@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
+	"image/color"
 	"math/rand"
 	"time"
 )
@@ -153,6 +154,11 @@ func (w Water) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:flowing_water", map[string]interface{}{"liquid_depth": int32(v)}
 }
 
+// MapColour ...
+func (Water) MapColour() color.RGBA {
+	return waterMapColour
+}
+
 // allWater returns a list of all water states.
 func allWater() (b []world.Block) {
 	f := func(still, falling bool) {
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/instrument"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
 )
 
 // Wool is a colourful block that can be obtained by killing/shearing sheep, or crafted using four string.
@@ -39,6 +40,11 @@ func (w Wool) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:wool", map[string]interface{}{"color": w.Colour.String()}
 }
 
+// MapColour ...
+func (w Wool) MapColour() color.RGBA {
+	return w.Colour.RGBA()
+}
+
 // allWool returns wool blocks with all possible colours.
 func allWool() []world.Block {
 	b := make([]world.Block, 0, 16)
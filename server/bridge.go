@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/bridge"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// bridgeEvent is the payload of events published over a bridge.Bridge by the server.
+type bridgeEvent struct {
+	// Origin is the unique ID of the server that published the event. Servers ignore events that they
+	// published themselves.
+	Origin  string `json:"origin"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message,omitempty"`
+	Count   int    `json:"count,omitempty"`
+}
+
+// Bridge attaches a bridge.Bridge to the server, so that events may be shared with other servers attached
+// to the same bridge. Only events of the topics passed are published and received. Supported topics are
+// bridge.TopicChat, bridge.TopicJoin, bridge.TopicQuit and bridge.TopicPlayerCount.
+// Chat messages received from other servers are written to chat.Global using the chat format of the server,
+// and joins and quits are shown using the join and quit messages of the server.
+// Bridge may only be called once. The bridge.Bridge passed is not closed when the server is closed.
+func (server *Server) Bridge(b bridge.Bridge, topics ...string) {
+	server.bridgeMu.Lock()
+	defer server.bridgeMu.Unlock()
+	if server.bridge != nil {
+		panic("server: bridge already attached")
+	}
+	server.bridge, server.bridgeTopics = b, map[string]bool{}
+	for _, topic := range topics {
+		server.bridgeTopics[topic] = true
+	}
+
+	for _, topic := range topics {
+		topic := topic
+		b.Subscribe(topic, func(payload []byte) {
+			var e bridgeEvent
+			if err := json.Unmarshal(payload, &e); err != nil {
+				server.log.Errorf("error decoding bridge event: %v", err)
+				return
+			}
+			if e.Origin != server.origin {
+				server.handleBridgeEvent(topic, e)
+			}
+		})
+	}
+	server.OnPlayerJoin(func(p *player.Player) {
+		server.publish(bridge.TopicJoin, bridgeEvent{Name: p.Name()})
+		server.publish(bridge.TopicPlayerCount, bridgeEvent{Count: server.PlayerCount()})
+	})
+	server.OnPlayerQuit(func(p *player.Player) {
+		server.publish(bridge.TopicQuit, bridgeEvent{Name: p.Name()})
+		// The player is not yet removed from the server when quit hooks are called.
+		server.publish(bridge.TopicPlayerCount, bridgeEvent{Count: server.PlayerCount() - 1})
+	})
+}
+
+// NetworkPlayerCount returns the total amount of players online on the server and all other servers that
+// published their player count over the bridge attached using Server.Bridge. If no bridge is attached, or if
+// bridge.TopicPlayerCount is not bridged, NetworkPlayerCount returns the same as PlayerCount.
+func (server *Server) NetworkPlayerCount() int {
+	count := server.PlayerCount()
+
+	server.bridgeMu.RLock()
+	defer server.bridgeMu.RUnlock()
+	for _, n := range server.remoteCounts {
+		count += n
+	}
+	return count
+}
+
+// chat writes a chat message sent by a player to chat.Global and publishes it over the bridge, if attached.
+func (server *Server) chat(p *player.Player, message string) {
+	_, _ = fmt.Fprintln(chat.Global, player.FormatChat(p.ChatFormat(), p.Name(), message))
+	server.publish(bridge.TopicChat, bridgeEvent{Name: p.Name(), Message: message})
+}
+
+// publish publishes an event under a topic over the bridge attached to the server. Nothing happens if no
+// bridge is attached or if the topic was not passed to Server.Bridge.
+func (server *Server) publish(topic string, e bridgeEvent) {
+	server.bridgeMu.RLock()
+	b, ok := server.bridge, server.bridgeTopics[topic]
+	server.bridgeMu.RUnlock()
+	if !ok {
+		return
+	}
+	e.Origin = server.origin
+	payload, _ := json.Marshal(e)
+	if err := b.PublishEvent(topic, payload); err != nil {
+		server.log.Errorf("error publishing bridge event: %v", err)
+	}
+}
+
+// handleBridgeEvent handles an event of a topic received from another server over the bridge.
+func (server *Server) handleBridgeEvent(topic string, e bridgeEvent) {
+	switch topic {
+	case bridge.TopicChat:
+		_, _ = fmt.Fprintln(chat.Global, player.FormatChat(server.chatFormat.Load(), e.Name, e.Message))
+	case bridge.TopicJoin:
+		if j := server.joinMessage.Load(); j != "" {
+			_, _ = fmt.Fprintln(chat.Global, text.Colourf("<yellow>%v</yellow>", fmt.Sprintf(j, e.Name)))
+		}
+	case bridge.TopicQuit:
+		if j := server.quitMessage.Load(); j != "" {
+			_, _ = fmt.Fprintln(chat.Global, text.Colourf("<yellow>%v</yellow>", fmt.Sprintf(j, e.Name)))
+		}
+	case bridge.TopicPlayerCount:
+		server.bridgeMu.Lock()
+		if e.Count == 0 {
+			delete(server.remoteCounts, e.Origin)
+		} else {
+			server.remoteCounts[e.Origin] = e.Count
+		}
+		server.bridgeMu.Unlock()
+	}
+}
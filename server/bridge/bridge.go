@@ -0,0 +1,33 @@
+// Package bridge implements a way for multiple servers to exchange events, such as chat messages and joins,
+// so that networks running several servers may share a global chat and join notifications. A Bridge may be
+// attached to a server using Server.Bridge.
+package bridge
+
+// Bridge is a connection to other servers over which events may be published and received. Events are
+// published under a topic and delivered to all handlers subscribed to that topic on the other servers.
+// Implementations must be safe for concurrent use.
+type Bridge interface {
+	// PublishEvent publishes the payload passed under a topic. The payload is delivered to all handlers
+	// subscribed to the topic. Implementations may also deliver it to the handlers of the Bridge it was
+	// published with.
+	PublishEvent(topic string, payload []byte) error
+	// Subscribe subscribes a Handler to a topic, so that it is called for every payload published under
+	// that topic. Handlers may be called from any goroutine.
+	Subscribe(topic string, h Handler)
+	// Close closes the Bridge. No more events are published or received after calling Close.
+	Close() error
+}
+
+// Handler handles a payload received over a Bridge.
+type Handler func(payload []byte)
+
+const (
+	// TopicChat is the topic of chat messages sent by players.
+	TopicChat = "chat"
+	// TopicJoin is the topic of players joining a server.
+	TopicJoin = "join"
+	// TopicQuit is the topic of players leaving a server.
+	TopicQuit = "quit"
+	// TopicPlayerCount is the topic of changes to the player count of a server.
+	TopicPlayerCount = "player_count"
+)
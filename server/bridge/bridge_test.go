@@ -0,0 +1,74 @@
+package bridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocal(t *testing.T) {
+	l := NewLocal()
+	var got []string
+	l.Subscribe(TopicChat, func(payload []byte) {
+		got = append(got, string(payload))
+	})
+	_ = l.PublishEvent(TopicChat, []byte("hello"))
+	_ = l.PublishEvent(TopicJoin, []byte("ignored"))
+
+	if len(got) != 1 || got[0] != "hello" {
+		t.Fatalf("unexpected payloads %v", got)
+	}
+}
+
+func TestTCP(t *testing.T) {
+	hub, err := ListenTCP("127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer hub.Close()
+
+	a, err := DialTCP(hub.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer a.Close()
+	b, err := DialTCP(hub.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer b.Close()
+
+	received, echoed := make(chan string, 1), make(chan string, 1)
+	b.Subscribe(TopicChat, func(payload []byte) { send(received, string(payload)) })
+	a.Subscribe(TopicChat, func(payload []byte) { send(echoed, string(payload)) })
+
+	// The hub may not have registered both connections yet, so keep publishing until the event arrives.
+	deadline := time.After(time.Second * 5)
+	for {
+		if err := a.PublishEvent(TopicChat, []byte("hello")); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+		select {
+		case msg := <-received:
+			if msg != "hello" {
+				t.Fatalf("expected hello, got %v", msg)
+			}
+			select {
+			case msg := <-echoed:
+				t.Fatalf("publisher received its own event %v", msg)
+			default:
+			}
+			return
+		case <-time.After(time.Millisecond * 50):
+		case <-deadline:
+			t.Fatalf("event was not received")
+		}
+	}
+}
+
+// send sends v to c without blocking if c is full.
+func send(c chan string, v string) {
+	select {
+	case c <- v:
+	default:
+	}
+}
@@ -0,0 +1,52 @@
+package bridge
+
+import "sync"
+
+// Local is a Bridge that delivers events to servers running in the same process. A single Local should be
+// attached to all servers that should share events. Events published are also delivered to the handlers of
+// the server that published them.
+// The zero value of Local is ready for use.
+type Local struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+}
+
+// NewLocal returns a new Local bridge.
+func NewLocal() *Local {
+	return &Local{}
+}
+
+// PublishEvent calls all handlers subscribed to the topic passed with the payload.
+func (l *Local) PublishEvent(topic string, payload []byte) error {
+	l.mu.RLock()
+	handlers := l.handlers[topic]
+	l.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(payload)
+	}
+	return nil
+}
+
+// Subscribe ...
+func (l *Local) Subscribe(topic string, h Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	if l.handlers == nil {
+		l.handlers = map[string][]Handler{}
+	}
+	// Handlers are copied on write so that PublishEvent may call them without holding the lock.
+	l.handlers[topic] = append(append([]Handler(nil), l.handlers[topic]...), h)
+}
+
+// Close removes all handlers from the Local bridge.
+func (l *Local) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handlers, l.closed = nil, true
+	return nil
+}
@@ -0,0 +1,158 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// frame is a single event sent over a TCP connection. Frames are encoded as JSON, one frame per line.
+type frame struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+}
+
+// Hub relays events between servers connected to it using DialTCP. Events received from one connection are
+// sent to all other connections. A Hub may be run in one of the server processes or in a separate process.
+type Hub struct {
+	l     net.Listener
+	mu    sync.Mutex
+	conns map[net.Conn]*json.Encoder
+}
+
+// ListenTCP starts listening for servers on the address passed and returns a Hub relaying events between
+// them. The Hub must be closed using Hub.Close when it is no longer used.
+func ListenTCP(address string) (*Hub, error) {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("listen bridge hub: %w", err)
+	}
+	h := &Hub{l: l, conns: map[net.Conn]*json.Encoder{}}
+	go h.accept()
+	return h, nil
+}
+
+// Addr returns the address the Hub is listening on.
+func (h *Hub) Addr() net.Addr {
+	return h.l.Addr()
+}
+
+// Close stops the Hub from accepting servers and closes all connections to servers.
+func (h *Hub) Close() error {
+	err := h.l.Close()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.conns {
+		_ = conn.Close()
+	}
+	return err
+}
+
+// accept accepts connections until the listener of the Hub is closed.
+func (h *Hub) accept() {
+	for {
+		conn, err := h.l.Accept()
+		if err != nil {
+			return
+		}
+		h.mu.Lock()
+		h.conns[conn] = json.NewEncoder(conn)
+		h.mu.Unlock()
+		go h.relay(conn)
+	}
+}
+
+// relay reads frames from the connection passed and sends them to all other connections until the
+// connection is closed.
+func (h *Hub) relay(conn net.Conn) {
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, conn)
+		h.mu.Unlock()
+		_ = conn.Close()
+	}()
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			return
+		}
+		h.mu.Lock()
+		for other, enc := range h.conns {
+			if other != conn {
+				_ = enc.Encode(f)
+			}
+		}
+		h.mu.Unlock()
+	}
+}
+
+// TCP is a Bridge connected to a Hub over TCP. Events are encoded as JSON. Events published are not
+// delivered to the handlers of the TCP bridge that published them.
+type TCP struct {
+	conn net.Conn
+
+	encMu sync.Mutex
+	enc   *json.Encoder
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// DialTCP connects to a Hub listening on the address passed and returns a TCP bridge.
+func DialTCP(address string) (*TCP, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("dial bridge hub: %w", err)
+	}
+	t := &TCP{conn: conn, enc: json.NewEncoder(conn), handlers: map[string][]Handler{}}
+	go t.read()
+	return t, nil
+}
+
+// PublishEvent sends the payload under the topic passed to the Hub.
+func (t *TCP) PublishEvent(topic string, payload []byte) error {
+	t.encMu.Lock()
+	defer t.encMu.Unlock()
+	if err := t.enc.Encode(frame{Topic: topic, Payload: payload}); err != nil {
+		return fmt.Errorf("publish bridge event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe ...
+func (t *TCP) Subscribe(topic string, h Handler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers[topic] = append(append([]Handler(nil), t.handlers[topic]...), h)
+}
+
+// Close closes the connection to the Hub.
+func (t *TCP) Close() error {
+	if err := t.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+	return nil
+}
+
+// read reads frames from the Hub and calls the handlers subscribed to their topics until the connection is
+// closed.
+func (t *TCP) read() {
+	dec := json.NewDecoder(bufio.NewReader(t.conn))
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			return
+		}
+		t.mu.RLock()
+		handlers := t.handlers[f.Topic]
+		t.mu.RUnlock()
+
+		for _, h := range handlers {
+			h(f.Payload)
+		}
+	}
+}
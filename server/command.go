@@ -0,0 +1,227 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RegisterCommand registers a command so that it may be executed by players on the server. The command is
+// sent to all players currently online, so that it shows up in their command list right away. Players that
+// join afterwards receive it automatically.
+// Any command already registered with the same name or aliases is overwritten.
+func (server *Server) RegisterCommand(c cmd.Command) {
+	cmd.Register(c)
+	for _, p := range server.Players() {
+		p.SendCommands()
+	}
+}
+
+// registerBuiltinCommands registers the commands that are built into the server, such as /stop and /list.
+func (server *Server) registerBuiltinCommands() {
+	cmd.Register(cmd.New("stop", "Stops the server.", nil, stopCommand{srv: server}))
+	cmd.Register(cmd.New("list", "Lists the players currently online.", nil, listCommand{srv: server}))
+	cmd.Register(cmd.New("save-all", "Saves all worlds of the server.", nil, saveAllCommand{srv: server}))
+	cmd.Register(cmd.New("backup", "Writes a backup of the world to a .mcworld file or folder.", nil, backupCommand{srv: server}))
+	tickingArea := permission{node: "dragonfly.command.tickingarea"}
+	cmd.Register(cmd.New("tickingarea", "Adds, removes or lists ticking areas.", nil, tickingAreaAdd{permission: tickingArea}, tickingAreaRemove{permission: tickingArea}, tickingAreaList{permission: tickingArea}))
+	effect := permission{node: "dragonfly.command.effect"}
+	cmd.Register(cmd.New("effect", "Adds or removes status effects.", nil, effectGive{permission: effect}, effectClear{permission: effect}))
+	cmd.Register(cmd.New("enchant", "Adds an enchantment to the item held by a player.", nil, enchantCommand{permission: permission{node: "dragonfly.command.enchant"}}))
+	t := permission{node: "dragonfly.command.time"}
+	cmd.Register(cmd.New("time", "Changes or queries the time of the world.", nil, timeSet{permission: t}, timeSetKeyword{permission: t}, timeAdd{permission: t}, timeQuery{permission: t}))
+	cmd.Register(cmd.New("difficulty", "Sets the difficulty of the world.", nil, difficultyCommand{permission: permission{node: "dragonfly.command.difficulty"}}))
+	// TODO: /weather once worlds have weather.
+}
+
+// localOnly may be embedded in a command to only allow it to be run by players connected from the machine
+// that the server runs on, or by sources that are not connected over the network at all, such as the console.
+type localOnly struct{}
+
+// Allow ...
+func (localOnly) Allow(src cmd.Source) bool {
+	a, ok := src.(interface{ Addr() net.Addr })
+	if !ok {
+		return true
+	}
+	addr, ok := a.Addr().(*net.UDPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+// permission may be embedded in a command to only allow it to be run by sources that have the permission with
+// its node, such as players for which player.Player.HasPermission returns true. Sources that are allowed to run
+// localOnly commands may always run it.
+type permission struct {
+	node string
+}
+
+// Allow ...
+func (p permission) Allow(src cmd.Source) bool {
+	if (localOnly{}).Allow(src) {
+		return true
+	}
+	h, ok := src.(interface{ HasPermission(node string) bool })
+	return ok && h.HasPermission(p.node)
+}
+
+// stopCommand implements the /stop command, which closes the server. It may only be run locally, so that
+// players on the server cannot shut it down.
+type stopCommand struct {
+	localOnly
+	srv *Server
+}
+
+// Run ...
+func (s stopCommand) Run(_ cmd.Source, o *cmd.Output) {
+	o.Print("Stopping the server...")
+	go func() {
+		if err := s.srv.Close(); err != nil {
+			s.srv.log.Errorf("error closing server: %v", err)
+		}
+	}()
+}
+
+// saveAllCommand implements the /save-all command, which saves all worlds of the server.
+type saveAllCommand struct {
+	localOnly
+	srv *Server
+}
+
+// Run ...
+func (s saveAllCommand) Run(_ cmd.Source, o *cmd.Output) {
+	o.Print("Saving the server...")
+	go func() {
+		for _, w := range s.srv.Worlds() {
+			w.Save()
+		}
+	}()
+}
+
+// backupCommand implements the /backup command, which writes a backup of the world of the source to the
+// destination passed. If no destination is passed, the backup is written to a .mcworld file in the backups
+// folder, named after the world and the current time.
+type backupCommand struct {
+	localOnly
+	srv         *Server
+	Destination string `optional:""`
+}
+
+// Run ...
+func (b backupCommand) Run(src cmd.Source, o *cmd.Output) {
+	w := src.World()
+	dst := b.Destination
+	if dst == "" {
+		dst = filepath.Join("backups", fmt.Sprintf("%v_%v.mcworld", w.Name(), time.Now().Format("2006-01-02_15-04-05")))
+	}
+	o.Printf("Backing up the world to %v...", dst)
+	go func() {
+		stats, err := w.Backup(dst)
+		if err != nil {
+			b.srv.log.Errorf("error backing up world '%v': %v", w.Name(), err)
+			return
+		}
+		b.srv.log.Infof("Backed up world '%v' to %v: %v chunks, %v bytes in %v.", w.Name(), dst, stats.Chunks, stats.Bytes, stats.Duration.Round(time.Millisecond))
+	}()
+}
+
+// listCommand implements the /list command, which lists all players currently online.
+type listCommand struct {
+	srv *Server
+}
+
+// Run ...
+func (l listCommand) Run(_ cmd.Source, o *cmd.Output) {
+	players := l.srv.Players()
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name()
+	}
+	if max := l.srv.MaxPlayerCount(); max >= 0 {
+		o.Printf("There are %v/%v players online:", len(players), max)
+	} else {
+		o.Printf("There are %v players online:", len(players))
+	}
+	o.Print(strings.Join(names, ", "))
+}
+
+// tickingAreaAdd implements the /tickingarea add subcommand, which adds a ticking area to the world of the
+// source.
+type tickingAreaAdd struct {
+	permission
+	Sub  add
+	From mgl64.Vec3
+	To   mgl64.Vec3
+	Name string
+}
+
+// Run ...
+func (t tickingAreaAdd) Run(src cmd.Source, o *cmd.Output) {
+	min := world.ChunkPosFromBlockPos(cube.PosFromVec3(t.From))
+	max := world.ChunkPosFromBlockPos(cube.PosFromVec3(t.To))
+	if err := src.World().AddTickingArea(t.Name, min, max); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Added ticking area %v from chunk %v to chunk %v.", t.Name, min, max)
+}
+
+// tickingAreaRemove implements the /tickingarea remove subcommand, which removes a ticking area from the
+// world of the source.
+type tickingAreaRemove struct {
+	permission
+	Sub  remove
+	Name string
+}
+
+// Run ...
+func (t tickingAreaRemove) Run(src cmd.Source, o *cmd.Output) {
+	if !src.World().RemoveTickingArea(t.Name) {
+		o.Errorf("No ticking area with the name %v exists.", t.Name)
+		return
+	}
+	o.Printf("Removed ticking area %v.", t.Name)
+}
+
+// tickingAreaList implements the /tickingarea list subcommand, which lists the ticking areas in the world of
+// the source.
+type tickingAreaList struct {
+	permission
+	Sub list
+}
+
+// Run ...
+func (tickingAreaList) Run(src cmd.Source, o *cmd.Output) {
+	areas := src.World().TickingAreas()
+	o.Printf("There are %v ticking areas:", len(areas))
+	for _, a := range areas {
+		o.Printf("- %v: chunk %v to chunk %v (%v chunks)", a.Name, a.Min, a.Max, a.Chunks())
+	}
+}
+
+// add, remove and list are the subcommands of the /tickingarea command.
+type (
+	add    string
+	remove string
+	list   string
+)
+
+// SubName ...
+func (add) SubName() string {
+	return "add"
+}
+
+// SubName ...
+func (remove) SubName() string {
+	return "remove"
+}
+
+// SubName ...
+func (list) SubName() string {
+	return "list"
+}
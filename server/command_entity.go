@@ -0,0 +1,248 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// effectHolder is a cmd.Target that may have effects added to it, such as a player.
+type effectHolder interface {
+	cmd.Target
+	AddEffect(e effect.Effect)
+	RemoveEffect(e effect.Type)
+	Effects() []effect.Effect
+}
+
+// effectGive implements the /effect give subcommand, which adds an effect to the targets passed.
+type effectGive struct {
+	permission
+	Sub           give
+	Targets       []cmd.Target
+	Effect        effectName
+	Seconds       int  `optional:""`
+	Amplifier     int  `optional:""`
+	HideParticles bool `optional:""`
+}
+
+// Run ...
+func (e effectGive) Run(_ cmd.Source, o *cmd.Output) {
+	t := effectTypes[string(e.Effect)]
+	if e.Seconds < 0 || e.Amplifier < 0 || e.Amplifier > 255 {
+		o.Errorf("Seconds must be at least 0 and amplifier must be between 0 and 255.")
+		return
+	}
+	if e.Seconds == 0 {
+		e.Seconds = 30
+	}
+
+	eff := effect.NewInstant(t, e.Amplifier+1)
+	if lasting, ok := t.(effect.LastingType); ok {
+		eff = effect.New(lasting, e.Amplifier+1, time.Duration(e.Seconds)*time.Second)
+	}
+	if e.HideParticles {
+		eff = eff.WithoutParticles()
+	}
+	for _, target := range e.Targets {
+		h, ok := target.(effectHolder)
+		if !ok {
+			o.Errorf("%v cannot have effects.", target.Name())
+			continue
+		}
+		h.AddEffect(eff)
+		o.Printf("Gave %v %v for %v seconds to %v.", e.Effect, e.Amplifier+1, e.Seconds, target.Name())
+	}
+}
+
+// effectClear implements the /effect clear subcommand, which removes either one or all effects from the
+// targets passed.
+type effectClear struct {
+	permission
+	Sub     clear
+	Targets []cmd.Target
+	Effect  effectName `optional:""`
+}
+
+// Run ...
+func (e effectClear) Run(_ cmd.Source, o *cmd.Output) {
+	for _, target := range e.Targets {
+		h, ok := target.(effectHolder)
+		if !ok {
+			o.Errorf("%v cannot have effects.", target.Name())
+			continue
+		}
+		if e.Effect != "" {
+			h.RemoveEffect(effectTypes[string(e.Effect)])
+			o.Printf("Took %v from %v.", e.Effect, target.Name())
+			continue
+		}
+		for _, eff := range h.Effects() {
+			h.RemoveEffect(eff.Type())
+		}
+		o.Printf("Took all effects from %v.", target.Name())
+	}
+}
+
+// effectTypes holds all effect types that may be passed to the /effect command, indexed by their name.
+var effectTypes = map[string]effect.Type{
+	"speed":           effect.Speed{},
+	"slowness":        effect.Slowness{},
+	"haste":           effect.Haste{},
+	"mining_fatigue":  effect.MiningFatigue{},
+	"strength":        effect.Strength{},
+	"instant_health":  effect.InstantHealth{},
+	"instant_damage":  effect.InstantDamage{},
+	"jump_boost":      effect.JumpBoost{},
+	"nausea":          effect.Nausea{},
+	"regeneration":    effect.Regeneration{},
+	"resistance":      effect.Resistance{},
+	"fire_resistance": effect.FireResistance{},
+	"water_breathing": effect.WaterBreathing{},
+	"invisibility":    effect.Invisibility{},
+	"blindness":       effect.Blindness{},
+	"night_vision":    effect.NightVision{},
+	"hunger":          effect.Hunger{},
+	"weakness":        effect.Weakness{},
+	"poison":          effect.Poison{},
+	"wither":          effect.Wither{},
+	"health_boost":    effect.HealthBoost{},
+	"absorption":      effect.Absorption{},
+	"saturation":      effect.Saturation{},
+	"levitation":      effect.Levitation{},
+	"fatal_poison":    effect.FatalPoison{},
+	"conduit_power":   effect.ConduitPower{},
+	"slow_falling":    effect.SlowFalling{},
+}
+
+// effectName is an enum parameter holding the name of one of the effects in effectTypes.
+type effectName string
+
+// Type ...
+func (effectName) Type() string {
+	return "Effect"
+}
+
+// Options ...
+func (effectName) Options(cmd.Source) []string {
+	names := make([]string, 0, len(effectTypes))
+	for name := range effectTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetOption ...
+func (effectName) SetOption(option string, v reflect.Value) {
+	v.SetString(option)
+}
+
+// enchantable is a cmd.Target that holds items which may be enchanted, such as a player.
+type enchantable interface {
+	cmd.Target
+	HeldItems() (mainHand, offHand item.Stack)
+	SetHeldItems(mainHand, offHand item.Stack)
+}
+
+// enchantCommand implements the /enchant command, which adds an enchantment to the item held in the main hand
+// of the targets passed.
+type enchantCommand struct {
+	permission
+	Targets     []cmd.Target
+	Enchantment enchantmentName
+	Level       int `optional:""`
+}
+
+// Run ...
+func (e enchantCommand) Run(_ cmd.Source, o *cmd.Output) {
+	ench := enchantmentByName(string(e.Enchantment))
+	if e.Level == 0 {
+		e.Level = 1
+	}
+	if e.Level < 1 || e.Level > ench.MaxLevel() {
+		o.Errorf("Level %v is not supported by %v: It must be between 1 and %v.", e.Level, ench.Name(), ench.MaxLevel())
+		return
+	}
+	for _, target := range e.Targets {
+		h, ok := target.(enchantable)
+		if !ok {
+			o.Errorf("%v cannot hold items.", target.Name())
+			continue
+		}
+		main, off := h.HeldItems()
+		if main.Empty() {
+			o.Errorf("%v is not holding an item.", target.Name())
+			continue
+		}
+		if !ench.CompatibleWith(main) {
+			o.Errorf("%v cannot be applied to the item held by %v.", ench.Name(), target.Name())
+			continue
+		}
+		h.SetHeldItems(main.WithEnchantment(ench.WithLevel(e.Level)), off)
+		o.Printf("Applied %v %v to the item held by %v.", ench.Name(), e.Level, target.Name())
+	}
+}
+
+// maxEnchantmentID is the highest ID that an enchantment may be registered with in vanilla.
+const maxEnchantmentID = 36
+
+// enchantmentByName returns the registered enchantment with the name passed, as returned by enchantmentKey.
+// Nil is returned if no such enchantment was registered.
+func enchantmentByName(name string) item.Enchantment {
+	for id := 0; id <= maxEnchantmentID; id++ {
+		if e, ok := item.EnchantmentByID(id); ok && enchantmentKey(e) == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// enchantmentKey returns the name of an enchantment as it is passed to the /enchant command, such as
+// 'fire_aspect' for Fire Aspect.
+func enchantmentKey(e item.Enchantment) string {
+	return strings.ReplaceAll(strings.ToLower(e.Name()), " ", "_")
+}
+
+// enchantmentName is an enum parameter holding the name of a registered enchantment.
+type enchantmentName string
+
+// Type ...
+func (enchantmentName) Type() string {
+	return "Enchant"
+}
+
+// Options ...
+func (enchantmentName) Options(cmd.Source) []string {
+	var names []string
+	for id := 0; id <= maxEnchantmentID; id++ {
+		if e, ok := item.EnchantmentByID(id); ok {
+			names = append(names, enchantmentKey(e))
+		}
+	}
+	return names
+}
+
+// SetOption ...
+func (enchantmentName) SetOption(option string, v reflect.Value) {
+	v.SetString(option)
+}
+
+// give and clear are the subcommands of the /effect command.
+type (
+	give  string
+	clear string
+)
+
+// SubName ...
+func (give) SubName() string {
+	return "give"
+}
+
+// SubName ...
+func (clear) SubName() string {
+	return "clear"
+}
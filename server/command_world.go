@@ -0,0 +1,138 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"reflect"
+)
+
+// timeSet implements the /time set subcommand with a number of ticks, which changes the time of the world
+// of the source.
+type timeSet struct {
+	permission
+	Sub  set
+	Time int
+}
+
+// Run ...
+func (t timeSet) Run(src cmd.Source, o *cmd.Output) {
+	src.World().SetTime(t.Time)
+	o.Printf("Set the time to %v.", t.Time)
+}
+
+// timeSetKeyword implements the /time set subcommand with a keyword such as 'day' or 'night', which changes
+// the time of the world of the source.
+type timeSetKeyword struct {
+	permission
+	Sub  set
+	Time timeKeyword
+}
+
+// Run ...
+func (t timeSetKeyword) Run(src cmd.Source, o *cmd.Output) {
+	time := timeKeywords[string(t.Time)]
+	src.World().SetTime(time)
+	o.Printf("Set the time to %v.", time)
+}
+
+// timeAdd implements the /time add subcommand, which adds a number of ticks to the time of the world of the
+// source.
+type timeAdd struct {
+	permission
+	Sub  add
+	Time int
+}
+
+// Run ...
+func (t timeAdd) Run(src cmd.Source, o *cmd.Output) {
+	w := src.World()
+	w.SetTime(w.Time() + t.Time)
+	o.Printf("Set the time to %v.", w.Time())
+}
+
+// timeQuery implements the /time query subcommand, which shows the time of the world of the source.
+type timeQuery struct {
+	permission
+	Sub query
+}
+
+// Run ...
+func (timeQuery) Run(src cmd.Source, o *cmd.Output) {
+	o.Printf("The time is %v.", src.World().Time())
+}
+
+// timeKeywords holds the times that keywords passed to /time set correspond to.
+var timeKeywords = map[string]int{
+	"day":      world.TimeDay,
+	"noon":     world.TimeNoon,
+	"sunset":   world.TimeSunset,
+	"night":    world.TimeNight,
+	"midnight": world.TimeMidnight,
+	"sunrise":  world.TimeSunrise,
+}
+
+// timeKeyword is an enum parameter holding one of the keywords in timeKeywords.
+type timeKeyword string
+
+// Type ...
+func (timeKeyword) Type() string {
+	return "TimeSpec"
+}
+
+// Options ...
+func (timeKeyword) Options(cmd.Source) []string {
+	return []string{"day", "noon", "sunset", "night", "midnight", "sunrise"}
+}
+
+// SetOption ...
+func (timeKeyword) SetOption(option string, v reflect.Value) {
+	v.SetString(option)
+}
+
+// difficultyCommand implements the /difficulty command, which changes the difficulty of the world of the
+// source.
+type difficultyCommand struct {
+	permission
+	Difficulty difficultyName
+}
+
+// Run ...
+func (d difficultyCommand) Run(src cmd.Source, o *cmd.Output) {
+	diff, _ := world.DifficultyByName(string(d.Difficulty))
+	src.World().SetDifficulty(diff)
+	o.Printf("Set the difficulty to %v.", d.Difficulty)
+}
+
+// difficultyName is an enum parameter holding the name of a world.Difficulty.
+type difficultyName string
+
+// Type ...
+func (difficultyName) Type() string {
+	return "Difficulty"
+}
+
+// Options ...
+func (difficultyName) Options(cmd.Source) []string {
+	return []string{"peaceful", "easy", "normal", "hard"}
+}
+
+// SetOption ...
+func (difficultyName) SetOption(option string, v reflect.Value) {
+	v.SetString(option)
+}
+
+// set and query are subcommands of the /time command.
+type (
+	set   string
+	query string
+)
+
+// SubName ...
+func (set) SubName() string {
+	return "set"
+}
+
+// SubName ...
+func (query) SubName() string {
+	return "query"
+}
@@ -1,21 +1,71 @@
 package server
 
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/pelletier/go-toml"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Config is the configuration of a Dragonfly server. It holds settings that affect different aspects of the
 // server, such as its name and maximum players.
 type Config struct {
 	// Network holds settings related to network aspects of the server.
 	Network struct {
 		// Address is the address on which the server should listen. Players may connect to this address in
-		// order to join.
+		// order to join. If left empty, the server does not listen on RakNet by itself and only accepts
+		// connections from Listeners added using Server.Listen.
 		Address string
+		// Addresses holds additional addresses on which the server should listen, next to Address. A
+		// listener is created for every address, so that the server may, for example, listen on both an IPv4
+		// address and an IPv6 address such as '[::]:19133'.
+		Addresses []string
+		// LANVisible specifies if the server should be advertised to players on the local network. If true,
+		// the server answers the discovery pings that clients broadcast on the LAN, so that it shows up in
+		// the friends tab of the server list. If the server listens on port 19132, the discovery port, the
+		// RakNet listener answers these pings itself, without the sub-name and game mode of the server.
+		LANVisible bool
+		// MaxConnectionsPerIP is the maximum amount of simultaneous connections accepted from a single IP
+		// address. Connections exceeding it are disconnected before joining. Set to 0 to disable the limit,
+		// for example when all players join through a proxy.
+		MaxConnectionsPerIP int
+		// JoinsPerMinute is the maximum amount of connections accepted from a single IP address within one
+		// minute. Set to 0 to disable the limit.
+		JoinsPerMinute int
+		// JoinWorkers is the maximum amount of connections that are spawned at the same time. Spawning a
+		// connection is expensive, so limiting this keeps a flood of connections from exhausting the memory of
+		// the server. Set to 0 to disable the limit.
+		JoinWorkers int
+		// JoinQueueSize is the maximum amount of connections that wait to be spawned once JoinWorkers
+		// connections are already spawning. Connections beyond it are disconnected with a message that the
+		// server is busy.
+		JoinQueueSize int
 	}
 	Server struct {
 		// Name is the name of the server as it shows up in the server list.
 		Name string
+		// SubName is the second line of the MOTD, shown below the name of the server in the friends and LAN
+		// tab of the server list. It is only advertised if LANVisible is true and the server does not listen
+		// on port 19132: The RakNet listener always advertises a fixed sub-name.
+		SubName string
 		// ShutdownMessage is the message shown to players when the server shuts down. If empty, players will
 		// be directed to the menu screen right away.
 		ShutdownMessage string
+		// ShutdownTimeout is the maximum amount of seconds that the server waits for players to be disconnected
+		// when it shuts down. Connections still open after this time are closed forcibly. If set to 0, the
+		// server waits for players indefinitely.
+		ShutdownTimeout int
 		// AuthEnabled controls whether or not players must be connected to Xbox Live in order to join the server.
+		// If disabled, the UUID and XUID of players are derived from their name.
 		AuthEnabled bool
 		// JoinMessage is the message that appears when a player joins the server. Leave this empty to disable it.
 		// %v is the placeholder for the username of the player
@@ -23,24 +73,100 @@ type Config struct {
 		// QuitMessage is the message that appears when a player leaves the server. Leave this empty to disable it.
 		// %v is the placeholder for the username of the player
 		QuitMessage string
+		// ChatFormat is the format of chat messages sent by players. %name% is the placeholder for the name of
+		// the player and %message% is the placeholder for the message sent.
+		ChatFormat string
+		// Console specifies if commands may be run from the standard input of the process, as if run by a
+		// player with all permissions. The console is only started if the standard input is a terminal, so
+		// that programs embedding the server without a terminal are not affected.
+		Console bool
 	}
 	World struct {
 		// Name is the name of the world that the server holds. A world with this name will be loaded and
 		// the name will be displayed at the top of the player list in the in-game pause menu.
 		Name string
-		// Folder is the folder that the data of the world resides in.
+		// Folder is the folder that the data of the world resides in. If the folder holds a Java Edition world
+		// in the Anvil format, the world is converted when the server starts.
 		Folder string
 		// SimulationDistance is the maximum distance in chunks that a chunk must be to a player in order for
-		// it to receive random ticks. This field may be set to 0 to disable random block updates altogether.
+		// it to receive random ticks and for its entities to be ticked. Scheduled block updates in chunks
+		// further away are delayed until a player comes close. A SimulationDistance of 0 is not unlimited:
+		// It disables random ticks altogether and only ticks the entities and block updates in the chunks
+		// that players are in.
 		SimulationDistance int
+		// RandomTickSpeed is the amount of blocks in every sub chunk within the simulation distance that are
+		// randomly ticked every tick. Random ticks make crops and saplings grow and grass spread. Setting it to
+		// 0 disables random ticks, while a higher value makes these happen faster.
+		RandomTickSpeed int
+		// DefaultGameMode is the game mode that players are given when they join the server for the first time.
+		// It may be one of 'survival', 'creative', 'adventure' or 'spectator'. If left empty, the default game
+		// mode stored in the world's level.dat is used. If set, it overrides the game mode of the world and
+		// is saved to its level.dat.
+		DefaultGameMode string
+		// Difficulty is the difficulty of the world. It may be one of 'peaceful', 'easy', 'normal' or 'hard'.
+		// The difficulty controls how hunger behaves: In peaceful, food regenerates, while starvation stops
+		// at five hearts in easy, at one heart in normal and deals damage until death in hard. If left empty, the
+		// difficulty stored in the world's level.dat is used.
+		Difficulty string
+		// KeepInventory specifies if players keep their inventory when they die. If false, the inventory is
+		// dropped on death unless the keep inventory game rule is enabled in the world's level.dat.
+		KeepInventory bool
+		// MaxTickingAreas is the maximum amount of ticking areas that may be added to the world. Chunks in
+		// ticking areas are kept loaded and ticked, even if no players are near.
+		MaxTickingAreas int
+		// MaxTickingAreaChunks is the maximum amount of chunks that a single ticking area may contain.
+		MaxTickingAreaChunks int
+		// SaveInterval is the interval in minutes at which the world is saved automatically, so that changes
+		// are not lost if the server crashes. Set to 0 to only save the world when the server is closed.
+		SaveInterval int
+		// ChunkUnloadDelay is the time in seconds after which chunks that are no longer viewed by any player are
+		// saved and unloaded. Chunks viewed again in the meantime stay loaded.
+		ChunkUnloadDelay int
+		// MaxLoadedChunks is the maximum amount of chunks loaded in the world at the same time. If exceeded, the
+		// chunks that were viewed least recently are unloaded right away, as long as they are no longer viewed.
+		// Set to 0 to not limit the amount of chunks.
+		MaxLoadedChunks int
+		// PauseTickingOnSave specifies if the world stops ticking while its chunks are copied to be saved, so
+		// that the state saved is consistent across all chunks. Large worlds may briefly stutter for players
+		// when this is enabled.
+		PauseTickingOnSave bool
+		// Generator is the generator used to create chunks that do not yet exist in the world. It may be either
+		// 'flat' or 'void'. If left empty, 'flat' is used.
+		Generator string
+		// TickRate is the amount of times per second that the world is ticked. It may be lowered for worlds in
+		// which little happens, such as lobbies, to reduce the resources used. It is clamped between 1 and 20.
+		TickRate int
+		// WeatherCycle specifies if the weather changes naturally over time, making it rain and thunder every
+		// now and then. If false, the weather only changes when changed through commands or plugins.
+		WeatherCycle bool
+		// PauseWhenEmpty specifies if the world stops ticking entirely while no players are in it, even if it
+		// has ticking areas. Worlds without players and ticking areas are always paused.
+		PauseWhenEmpty bool
+		// CatchUpBlockUpdates specifies if scheduled block updates, such as flowing liquids, catch up on the
+		// time that the world was paused once a player enters it. If false, they are frozen while the world
+		// is paused.
+		CatchUpBlockUpdates bool
+		// ReadOnly specifies if the files of the world should never be changed. If true, chunks, the level.dat
+		// and player data are no longer saved. Changes made to the world while the server is running still work
+		// as usual, but are discarded once the server is closed. The world must already exist if set.
+		ReadOnly bool
+		// FlatLayers are the layers of blocks that flat worlds are generated with, from the bottom up, such as
+		// 'minecraft:bedrock,2*minecraft:dirt,minecraft:grass'. A layer may be prefixed with 'n*' to repeat it n
+		// times. If left empty, the default layers of flat worlds are used.
+		FlatLayers string
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server at the same time. If set
-		// to 0, the amount of maximum players will grow every time a player joins.
+		// to 0, the amount of players is unlimited.
 		MaxCount int
+		// FullMessage is the message shown to players that are disconnected because the server is full.
+		FullMessage string
 		// MaximumChunkRadius is the maximum chunk radius that players may set in their settings. If they try
 		// to set it above this number, it will be capped and set to the max.
 		MaximumChunkRadius int
+		// MinimumChunkRadius is the minimum chunk radius that players may set in their settings. If they try
+		// to set it below this number, it will be raised to the minimum.
+		MinimumChunkRadius int
 		// SaveData controls whether or not a player's data will be saved and loaded. If true, the server
 		// will use the default LevelDB data provider and if false, an empty provider will be used. To use your
 		// own provider, turn this value to false as you will still be able to pass your own provider.
@@ -48,6 +174,25 @@ type Config struct {
 		// Folder controls where the player data will be stored by the default LevelDB
 		// player provider if it is enabled.
 		Folder string
+		// LogCorrections specifies if every correction sent to a player is logged at debug level along with
+		// its cause. Corrections are sent when a player predicts an action, such as placing a block, that the
+		// server rejects. Logging them helps finding actions that players and the server disagree on.
+		LogCorrections bool
+		// MovementResponse is the response to movement of players that fails the basic movement checks, such
+		// as moving more than 8 blocks in a single tick or walking while a form is opened. It may be one of
+		// 'correct', which moves the player back to its last valid position, 'log', which only logs the movement
+		// at debug level, or 'none', which disables the checks. If left empty, 'correct' is used.
+		MovementResponse string
+		// SpawnPosition is the position that players without saved data spawn at, as x, y and z coordinates,
+		// for example [0.5, 64, 0.5]. If empty, these players spawn at the spawn of the world.
+		SpawnPosition []float64
+		// SpawnWorld is the name of the world, loaded using Server.LoadWorld, that players without saved
+		// data spawn in. If empty, these players spawn in the world of the server.
+		SpawnWorld string
+		// OperatorsFile is the JSON file that the operator levels of players are stored in, indexed by their
+		// XUID. Operator levels are loaded when players join and saved when they leave or when changed using
+		// Server.SetOperator. If empty, operator levels are not saved.
+		OperatorsFile string
 	}
 
 	Resources struct {
@@ -60,17 +205,257 @@ type Config struct {
 func DefaultConfig() Config {
 	c := Config{}
 	c.Network.Address = ":19132"
+	c.Network.MaxConnectionsPerIP = 3
+	c.Network.JoinsPerMinute = 10
+	c.Network.JoinWorkers = 16
+	c.Network.JoinQueueSize = 128
 	c.Server.Name = "Dragonfly Server"
+	c.Server.SubName = "Dragonfly"
 	c.Server.ShutdownMessage = "Server closed."
+	c.Server.ShutdownTimeout = 10
 	c.Server.AuthEnabled = true
 	c.Server.JoinMessage = "%v has joined the game"
 	c.Server.QuitMessage = "%v has left the game"
+	c.Server.ChatFormat = "<%name%> %message%"
+	c.Server.Console = true
 	c.World.Name = "World"
 	c.World.Folder = "world"
 	c.World.SimulationDistance = 8
+	c.World.RandomTickSpeed = 3
+	c.World.MaxTickingAreas = 10
+	c.World.MaxTickingAreaChunks = 100
+	c.World.SaveInterval = 5
+	c.World.ChunkUnloadDelay = 60
+	c.World.TickRate = 20
+	c.World.WeatherCycle = true
+	c.World.Generator = "flat"
+	c.World.FlatLayers = "minecraft:bedrock,2*minecraft:dirt,minecraft:grass"
+	c.Players.FullMessage = "Server is full."
 	c.Players.MaximumChunkRadius = 32
+	c.Players.MinimumChunkRadius = 4
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
+	c.Players.MovementResponse = "correct"
+	c.Players.OperatorsFile = "ops.json"
 	c.Resources.Folder = "resources"
 	return c
 }
+
+// Validate checks if the values in the Config are valid. An error describing the first invalid value found
+// is returned, prefixed with the key of the value in the config file, such as 'Network.Address: missing port'.
+func (c Config) Validate() error {
+	if addr := c.Network.Address; addr != "" {
+		if err := validateAddress(addr); err != nil {
+			return fmt.Errorf("Network.Address: %w", err)
+		}
+	}
+	seen := map[string]bool{c.Network.Address: true}
+	for _, addr := range c.Network.Addresses {
+		if err := validateAddress(addr); err != nil {
+			return fmt.Errorf("Network.Addresses: %w", err)
+		}
+		if seen[addr] {
+			return fmt.Errorf("Network.Addresses: duplicate address %q", addr)
+		}
+		seen[addr] = true
+	}
+	for key, n := range map[string]int{
+		"Network.MaxConnectionsPerIP": c.Network.MaxConnectionsPerIP,
+		"Network.JoinsPerMinute":      c.Network.JoinsPerMinute,
+		"Network.JoinWorkers":         c.Network.JoinWorkers,
+		"Network.JoinQueueSize":       c.Network.JoinQueueSize,
+		"Server.ShutdownTimeout":      c.Server.ShutdownTimeout,
+		"World.SimulationDistance":    c.World.SimulationDistance,
+		"World.RandomTickSpeed":       c.World.RandomTickSpeed,
+		"World.MaxTickingAreas":       c.World.MaxTickingAreas,
+		"World.MaxTickingAreaChunks":  c.World.MaxTickingAreaChunks,
+		"World.SaveInterval":          c.World.SaveInterval,
+		"World.ChunkUnloadDelay":      c.World.ChunkUnloadDelay,
+		"World.MaxLoadedChunks":       c.World.MaxLoadedChunks,
+		"World.TickRate":              c.World.TickRate,
+		"Players.MaxCount":            c.Players.MaxCount,
+		"Players.MinimumChunkRadius":  c.Players.MinimumChunkRadius,
+	} {
+		if n < 0 {
+			return fmt.Errorf("%v: must not be negative, got %v", key, n)
+		}
+	}
+	if c.World.Folder == "" {
+		return fmt.Errorf("World.Folder: missing folder")
+	}
+	if name := c.World.DefaultGameMode; name != "" {
+		if _, ok := world.GameModeByName(name); !ok {
+			return fmt.Errorf("World.DefaultGameMode: unknown game mode %q", name)
+		}
+	}
+	if name := c.World.Difficulty; name != "" {
+		if _, ok := world.DifficultyByName(name); !ok {
+			return fmt.Errorf("World.Difficulty: unknown difficulty %q", name)
+		}
+	}
+	switch strings.ToLower(c.World.Generator) {
+	case "", "flat":
+		if _, err := parseFlatLayers(c.World.FlatLayers); err != nil {
+			return fmt.Errorf("World.FlatLayers: %w", err)
+		}
+	case "void":
+	default:
+		return fmt.Errorf("World.Generator: unknown world generator %q", c.World.Generator)
+	}
+	if c.Players.MaximumChunkRadius < 1 {
+		return fmt.Errorf("Players.MaximumChunkRadius: must be at least 1, got %v", c.Players.MaximumChunkRadius)
+	}
+	if c.Players.MinimumChunkRadius > c.Players.MaximumChunkRadius {
+		return fmt.Errorf("Players.MinimumChunkRadius: %v is higher than Players.MaximumChunkRadius %v", c.Players.MinimumChunkRadius, c.Players.MaximumChunkRadius)
+	}
+	if c.Players.SaveData && c.Players.Folder == "" {
+		return fmt.Errorf("Players.Folder: missing folder while Players.SaveData is enabled")
+	}
+	if name := c.Players.MovementResponse; name != "" {
+		if _, ok := session.MovementResponseByName(name); !ok {
+			return fmt.Errorf("Players.MovementResponse: unknown movement response %q", name)
+		}
+	}
+	if n := len(c.Players.SpawnPosition); n != 0 && n != 3 {
+		return fmt.Errorf("Players.SpawnPosition: expected 3 coordinates, got %v", n)
+	}
+	if c.Resources.Folder == "" {
+		return fmt.Errorf("Resources.Folder: missing folder")
+	}
+	return nil
+}
+
+// validateAddress checks if the address passed is a valid address to listen on, including a port.
+func validateAddress(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		if strings.Contains(err.Error(), "missing port") {
+			return fmt.Errorf("missing port in %q", addr)
+		}
+		return err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the Config in which every unset field is filled with its value from
+// DefaultConfig. Only fields of which the zero value has no meaning of its own are filled, so that, for
+// example, an empty Network.Address or a Players.MaxCount of 0 are left as is.
+func (c Config) withDefaults() Config {
+	def := DefaultConfig()
+	fillString(&c.Server.Name, def.Server.Name)
+	fillString(&c.Server.ChatFormat, def.Server.ChatFormat)
+	fillString(&c.World.Name, def.World.Name)
+	fillString(&c.World.Folder, def.World.Folder)
+	fillString(&c.World.Generator, def.World.Generator)
+	fillString(&c.World.FlatLayers, def.World.FlatLayers)
+	fillString(&c.Players.Folder, def.Players.Folder)
+	fillString(&c.Players.MovementResponse, def.Players.MovementResponse)
+	fillString(&c.Resources.Folder, def.Resources.Folder)
+	fillInt(&c.World.TickRate, def.World.TickRate)
+	fillInt(&c.Players.MaximumChunkRadius, def.Players.MaximumChunkRadius)
+	return c
+}
+
+// fillString sets the string pointed to by s to def if it is empty.
+func fillString(s *string, def string) {
+	if *s == "" {
+		*s = def
+	}
+}
+
+// fillInt sets the int pointed to by n to def if it is 0.
+func fillInt(n *int, def int) {
+	if *n == 0 {
+		*n = def
+	}
+}
+
+// ReadConfig reads a Config from the file at the path passed. The file is decoded as JSON if its extension is
+// .json and as TOML otherwise. Fields missing in the file are left at their values in DefaultConfig. Keys in
+// the file that do not match any field of the Config are logged as a warning to the Logger passed, or at info
+// level if the Logger has no Warnln method. The Logger may be nil to not log them.
+// If the file does not exist, an error for which errors.Is(err, fs.ErrNotExist) holds is returned.
+func ReadConfig(path string, log internal.Logger) (Config, error) {
+	c := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, fmt.Errorf("read config: %w", err)
+	}
+	var m map[string]interface{}
+	if isJSON(path) {
+		if err := json.Unmarshal(data, &c); err != nil {
+			return c, fmt.Errorf("read config: decode json: %w", err)
+		}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return c, fmt.Errorf("read config: decode json: %w", err)
+		}
+	} else {
+		tree, err := toml.LoadBytes(data)
+		if err != nil {
+			return c, fmt.Errorf("read config: decode toml: %w", err)
+		}
+		if err := tree.Unmarshal(&c); err != nil {
+			return c, fmt.Errorf("read config: decode toml: %w", err)
+		}
+		m = tree.ToMap()
+	}
+	if unknown := unknownKeys(m, reflect.TypeOf(c), ""); len(unknown) != 0 && log != nil {
+		sort.Strings(unknown)
+		msg := fmt.Sprintf("Ignoring unknown keys in config %v: %v", path, strings.Join(unknown, ", "))
+		if w, ok := log.(interface{ Warnln(v ...interface{}) }); ok {
+			w.Warnln(msg)
+		} else {
+			log.Infof("%v", msg)
+		}
+	}
+	return c, nil
+}
+
+// Write writes the Config to the file at the path passed, creating the file if it does not yet exist. The
+// Config is encoded as JSON if the extension of the file is .json and as TOML otherwise.
+func (c Config) Write(path string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if isJSON(path) {
+		data, err = json.MarshalIndent(c, "", "\t")
+	} else {
+		data, err = toml.Marshal(c)
+	}
+	if err != nil {
+		return fmt.Errorf("write config: encode: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// isJSON checks if the file at the path passed is a JSON file, judging by its extension.
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// unknownKeys returns the keys in the map passed that do not match any field of the struct type t, prefixed
+// with the prefix passed. Keys of nested structs are checked recursively. Keys are matched case-insensitively,
+// like they are when decoding.
+func unknownKeys(m map[string]interface{}, t reflect.Type, prefix string) []string {
+	var unknown []string
+	for k, v := range m {
+		field, ok := t.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, k)
+		})
+		if !ok {
+			unknown = append(unknown, prefix+k)
+			continue
+		}
+		if nested, ok := v.(map[string]interface{}); ok && field.Type.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownKeys(nested, field.Type, prefix+field.Name+".")...)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("default config is invalid: %v", err)
+	}
+	for _, test := range []struct {
+		change func(c *Config)
+		err    string
+	}{
+		{func(c *Config) { c.Network.Address = "0.0.0.0" }, "Network.Address: missing port"},
+		{func(c *Config) { c.Network.Address = ":port" }, "Network.Address: invalid port"},
+		{func(c *Config) { c.Network.Addresses = []string{"[::]"} }, "Network.Addresses: missing port"},
+		{func(c *Config) { c.Network.Addresses = []string{":19132"} }, "Network.Addresses: duplicate address"},
+		{func(c *Config) { c.World.Folder = "" }, "World.Folder: missing folder"},
+		{func(c *Config) { c.World.SimulationDistance = -1 }, "World.SimulationDistance: must not be negative"},
+		{func(c *Config) { c.Players.MaximumChunkRadius = 0 }, "Players.MaximumChunkRadius: must be at least 1"},
+		{func(c *Config) { c.Players.MovementResponse = "kick" }, "Players.MovementResponse: unknown movement response"},
+	} {
+		c := DefaultConfig()
+		test.change(&c)
+		if err := c.Validate(); err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("Validate() = %v, want error starting with %q", err, test.err)
+		}
+	}
+}
+
+func TestConfigWithDefaults(t *testing.T) {
+	c := Config{}
+	c.Network.Address = ":19133"
+	c = c.withDefaults()
+	if c.World.Folder != "world" || c.Players.MaximumChunkRadius != 32 {
+		t.Errorf("unset fields were not filled: folder %q, chunk radius %v", c.World.Folder, c.Players.MaximumChunkRadius)
+	}
+	if c.Network.Address != ":19133" || c.Players.MaxCount != 0 {
+		t.Errorf("set fields were changed: address %q, max count %v", c.Network.Address, c.Players.MaxCount)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("config with defaults filled is invalid: %v", err)
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadConfig(filepath.Join(dir, "missing.toml"), nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist reading missing config, got %v", err)
+	}
+	for _, name := range []string{"config.toml", "config.json"} {
+		path := filepath.Join(dir, name)
+		c := DefaultConfig()
+		c.Server.Name = "Test Server"
+		if err := c.Write(path); err != nil {
+			t.Fatalf("write %v: %v", name, err)
+		}
+		read, err := ReadConfig(path, nil)
+		if err != nil {
+			t.Fatalf("read %v: %v", name, err)
+		}
+		if read.Server.Name != "Test Server" || read.World.Folder != "world" {
+			t.Errorf("read %v: values were not read back: name %q, folder %q", name, read.Server.Name, read.World.Folder)
+		}
+	}
+
+	path := filepath.Join(dir, "unknown.toml")
+	if err := os.WriteFile(path, []byte("[Server]\nName = \"A\"\nNmae = \"B\"\n[Bogus]\nKey = 1\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	buf := bytes.NewBuffer(nil)
+	log := logrus.New()
+	log.Out = buf
+	c, err := ReadConfig(path, log)
+	if err != nil {
+		t.Fatalf("read config with unknown keys: %v", err)
+	}
+	if c.Server.Name != "A" {
+		t.Errorf("expected name %q, got %q", "A", c.Server.Name)
+	}
+	if out := buf.String(); !strings.Contains(out, "Bogus, Server.Nmae") {
+		t.Errorf("expected warning listing unknown keys, got %q", out)
+	}
+}
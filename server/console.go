@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bufio"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"io"
+	"os"
+	"strings"
+)
+
+// Console is a cmd.Source that runs commands on behalf of the operator of the server, typically by reading
+// them from the terminal that the server runs in. The Console has all permissions and is not connected over
+// the network, so it may run any command, including /stop. The output of commands is written to the logger
+// of the Server.
+type Console struct {
+	srv *Server
+}
+
+// Console returns the Console of the Server. Commands may be run with it using Console.ExecuteCommand,
+// regardless of whether the console reading from the standard input was enabled.
+func (server *Server) Console() Console {
+	return Console{srv: server}
+}
+
+// EnableConsole enables the console of the Server, which reads commands from the standard input of the
+// process and runs them using the Console. If the Server is already running, the console is started right
+// away. Otherwise, it is started once the Server is. If the standard input is not a terminal, for example
+// because the Server is embedded in a program running as a service, the console is not started at all.
+// EnableConsole is equivalent to enabling Server.Console in the Config.
+func (server *Server) EnableConsole() {
+	server.consoleEnabled.Store(true)
+	server.lifeMu.Lock()
+	defer server.lifeMu.Unlock()
+	if server.running() {
+		server.startConsole()
+	}
+}
+
+// startConsole starts reading commands from the standard input on a new goroutine, unless the console was
+// already started or the standard input is not a terminal.
+func (server *Server) startConsole() {
+	if !isTerminal(os.Stdin) {
+		server.log.Debugf("Standard input is not a terminal: Console disabled.")
+		return
+	}
+	if !server.consoleStarted.CAS(false, true) {
+		return
+	}
+	go server.readConsole(os.Stdin)
+}
+
+// readConsole reads commands from the io.Reader passed line by line and executes them using the Console of
+// the Server. It returns once the reader is closed, for example when Ctrl-D is pressed, without closing the
+// Server, or once the Server starts closing. A read that is already in progress at that point cannot be
+// interrupted, so the goroutine only returns after the next line is read.
+func (server *Server) readConsole(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		select {
+		case <-server.closing:
+			return
+		default:
+		}
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			server.Console().ExecuteCommand(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		server.log.Errorf("error reading console input: %v", err)
+		return
+	}
+	server.log.Infof("Console input closed: Commands can no longer be run from the console.")
+}
+
+// ExecuteCommand executes the command line passed as the Console. A leading slash is optional. If the command
+// could not be found, or if the usage was incorrect, an error is written to the logger.
+func (c Console) ExecuteCommand(commandLine string) {
+	commandLine = strings.TrimPrefix(commandLine, "/")
+	args := strings.Split(commandLine, " ")
+
+	command, ok := cmd.ByAlias(args[0])
+	if !ok {
+		output := &cmd.Output{}
+		output.Errorf("Unknown command '%v'", args[0])
+		c.SendCommandOutput(output)
+		return
+	}
+	command.Execute(strings.TrimPrefix(strings.TrimPrefix(commandLine, args[0]), " "), c)
+}
+
+// Name returns 'Console'.
+func (Console) Name() string {
+	return "Console"
+}
+
+// Position returns the spawn position of the default world of the Server.
+func (c Console) Position() mgl64.Vec3 {
+	return c.World().Spawn().Vec3Middle()
+}
+
+// World returns the default world of the Server.
+func (c Console) World() *world.World {
+	return c.srv.World()
+}
+
+// HasPermission always returns true: The Console has all permissions.
+func (Console) HasPermission(string) bool {
+	return true
+}
+
+// SendCommandOutput writes the messages and errors of the output passed to the logger of the Server.
+func (c Console) SendCommandOutput(output *cmd.Output) {
+	for _, m := range output.Messages() {
+		c.srv.log.Infof("%v", m)
+	}
+	for _, err := range output.Errors() {
+		c.srv.log.Errorf("%v", err)
+	}
+}
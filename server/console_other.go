@@ -0,0 +1,11 @@
+//go:build !aix && !linux && !solaris && !zos && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package server
+
+import "os"
+
+// isTerminal always returns false: Terminals cannot be detected on this platform, so the console is never
+// started from the standard input.
+func isTerminal(*os.File) bool {
+	return false
+}
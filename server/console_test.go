@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// consoleTestCommand is a command that passes the source that ran it to a channel.
+type consoleTestCommand struct {
+	sources chan cmd.Source
+	Message string
+}
+
+// Run ...
+func (c consoleTestCommand) Run(src cmd.Source, o *cmd.Output) {
+	o.Printf("received %v", c.Message)
+	c.sources <- src
+}
+
+func TestConsole(t *testing.T) {
+	conf := DefaultConfig()
+	conf.World.Folder = filepath.Join(t.TempDir(), "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(t.TempDir(), "resources")
+
+	buf := bytes.NewBuffer(nil)
+	log := logrus.New()
+	log.SetOutput(buf)
+	srv := New(&conf, log)
+	defer srv.Close()
+
+	sources := make(chan cmd.Source, 1)
+	cmd.Register(cmd.New("consoletest", "", nil, consoleTestCommand{sources: sources}))
+
+	// The reader is closed after the last line, after which readConsole must return.
+	srv.readConsole(strings.NewReader("\n/consoletest hello\nunknowncommand\n"))
+	select {
+	case src := <-sources:
+		if _, ok := src.(Console); !ok {
+			t.Fatalf("expected command to be run by the console, got %T", src)
+		}
+	default:
+		t.Fatalf("expected command to be run")
+	}
+	if !strings.Contains(buf.String(), "received hello") {
+		t.Errorf("expected output of the command to be logged, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "Unknown command 'unknowncommand'") {
+		t.Errorf("expected unknown command to be logged, got %q", buf.String())
+	}
+	if srv.State() == StateClosed {
+		t.Errorf("expected server not to be closed when the console input is closed")
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	// The null device is a character device, but not a terminal, so the console must not read from it.
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("open %v: %v", os.DevNull, err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Errorf("expected %v not to be a terminal", os.DevNull)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build aix || linux || solaris || zos
+
+package server
+
+import (
+	"golang.org/x/sys/unix"
+	"os"
+)
+
+// isTerminal checks if the file passed is a terminal by requesting its terminal attributes. Other character
+// devices, such as /dev/null, are not terminals.
+func isTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
@@ -0,0 +1,13 @@
+package server
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+// isTerminal checks if the file passed is a console by requesting its console mode. Other character devices,
+// such as NUL, are not consoles.
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(f.Fd()), &mode) == nil
+}
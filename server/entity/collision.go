@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+)
+
+const (
+	// maxEntityPushes is the maximum amount of entities that a single entity is pushed by in one tick, so that
+	// an entity in a crowd is not launched away.
+	maxEntityPushes = 4
+	// maxPushOutIterations is the maximum amount of times that an entity is moved to push it out of blocks in
+	// one tick. An entity stuck in several blocks may need to be moved more than once.
+	maxPushOutIterations = 4
+	// minPenetration is the minimum depth that an entity must be inside a block before it is pushed out of it,
+	// so that entities merely touching blocks are not moved.
+	minPenetration = 0.01
+)
+
+// EntityPush returns the velocity with which the Living entity passed is pushed away from the living entities
+// that it collides with in a tick, based on the push strength set using World.SetEntityPush. A zero Vec3 is
+// returned if the entity does not collide with other living entities or if pushing is disabled in its world.
+// Entities with a game mode without collision, such as spectators, neither push nor are pushed.
+func EntityPush(e Living) mgl64.Vec3 {
+	w := e.World()
+	strength := w.EntityPush()
+	if strength <= 0 || !hasCollision(e) {
+		return mgl64.Vec3{}
+	}
+	pos := e.Position()
+
+	var push mgl64.Vec3
+	n := 0
+	for _, other := range w.CollidingEntities(e.AABB().Translate(pos), e) {
+		if _, ok := other.(Living); !ok || !hasCollision(other) {
+			continue
+		}
+		diff := pos.Sub(other.Position())
+		diff[1] = 0
+		if diff.Len() < epsilon {
+			// The entities are at the same horizontal position, so they are pushed apart in a random direction.
+			angle := rand.Float64() * math.Pi * 2
+			diff = mgl64.Vec3{math.Cos(angle), 0, math.Sin(angle)}
+		}
+		push = push.Add(diff.Normalize().Mul(strength))
+		if n++; n == maxEntityPushes {
+			break
+		}
+	}
+	return push
+}
+
+// hasCollision checks if the entity passed collides with blocks and other entities. Entities with a game mode
+// only collide if their game mode has collision.
+func hasCollision(e world.Entity) bool {
+	if g, ok := e.(interface {
+		GameMode() world.GameMode
+	}); ok {
+		return g.GameMode().HasCollision()
+	}
+	return true
+}
+
+// PushOutOfBlocks returns the position that the entity passed, at the position passed, must be moved to so
+// that its bounding box no longer intersects with solid blocks. The entity is moved along the axis on which
+// it is the least deep inside a block. False is returned if the entity is not stuck in any blocks or if
+// pushing entities out of blocks is disabled in its world using World.SetPushOutOfBlocks. Like EntityPush,
+// entities with a game mode without collision are never pushed out of blocks.
+func PushOutOfBlocks(e world.Entity, pos mgl64.Vec3) (mgl64.Vec3, bool) {
+	if !e.World().PushOutOfBlocks() || !hasCollision(e) {
+		return pos, false
+	}
+	box := e.AABB().Translate(pos)
+	blocks := blockAABBsAround(e, box)
+
+	moved := false
+	for i := 0; i < maxPushOutIterations; i++ {
+		offset, ok := penetration(box, blocks)
+		if !ok {
+			break
+		}
+		box, pos, moved = box.Translate(offset), pos.Add(offset), true
+	}
+	return pos, moved
+}
+
+// penetration finds the first block box that the box passed is inside of, and returns the smallest offset
+// that moves the box out of it. False is returned if the box is not inside any of the blocks.
+func penetration(box physics.AABB, blocks []physics.AABB) (mgl64.Vec3, bool) {
+	min, max := box.Min(), box.Max()
+	for _, b := range blocks {
+		if !box.IntersectsWith(b) {
+			continue
+		}
+		bMin, bMax := b.Min(), b.Max()
+		var offset mgl64.Vec3
+		depth := math.MaxFloat64
+		for axis := 0; axis < 3; axis++ {
+			// The box may be moved in the positive direction, past the maximum of the block, or in the
+			// negative direction, past its minimum.
+			if d := bMax[axis] - min[axis]; d < depth {
+				depth, offset = d, mgl64.Vec3{}
+				offset[axis] = d
+			}
+			if d := max[axis] - bMin[axis]; d < depth {
+				depth, offset = d, mgl64.Vec3{}
+				offset[axis] = -d
+			}
+		}
+		if depth < minPenetration {
+			continue
+		}
+		return offset, true
+	}
+	return mgl64.Vec3{}, false
+}
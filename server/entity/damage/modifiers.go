@@ -0,0 +1,59 @@
+package damage
+
+import "math"
+
+// Modifiers holds the breakdown of damage dealt to an entity into the stages that reduce it. Starting from
+// Base, the stages are applied in a fixed order by Apply: Armour, Resistance, Enchantment and FeatherFalling
+// and finally Absorption. Each stage may be changed individually, for example to double the Base damage
+// while leaving the reduction by armour intact.
+type Modifiers struct {
+	// Base is the raw damage dealt, before any reduction. It includes additions to the damage, such as the
+	// Sharpness enchantment on the item used to attack.
+	Base float64
+	// Armour is the fraction of the damage reduced by the armour points of the entity, ranging from 0 to 1.
+	// Every armour point reduces the damage by 4%, up to a maximum of 80% for 20 armour points.
+	Armour float64
+	// Resistance is the fraction of the damage left after Armour that is reduced by the resistance effect,
+	// ranging from 0 to 1.
+	Resistance float64
+	// Enchantment is the flat amount of damage left after Resistance that is reduced by protection
+	// enchantments on the armour of the entity.
+	Enchantment float64
+	// FeatherFalling is the fraction of the damage left after Enchantment that is reduced by the feather
+	// falling enchantment, ranging from 0 to 1. It is only non-zero for fall damage.
+	FeatherFalling float64
+	// Absorption is the absorption health of the entity that absorbs the damage left after all other stages.
+	// It is 0 if the source of the damage cannot be absorbed.
+	Absorption float64
+}
+
+// Result holds the damage left after each stage of Modifiers was applied.
+type Result struct {
+	// AfterArmour is the damage left after reduction by armour.
+	AfterArmour float64
+	// AfterResistance is the damage left after reduction by the resistance effect.
+	AfterResistance float64
+	// AfterEnchantment is the damage left after reduction by enchantments, including feather falling.
+	AfterEnchantment float64
+	// Absorbed is the damage absorbed by the absorption health of the entity.
+	Absorbed float64
+	// Final is the damage dealt to the health of the entity.
+	Final float64
+}
+
+// Apply applies all stages of the Modifiers in order and returns the damage left after each of them. The
+// damage left after a stage is never lower than 0.
+func (m Modifiers) Apply() Result {
+	var r Result
+	r.AfterArmour = math.Max(m.Base*(1-fraction(m.Armour)), 0)
+	r.AfterResistance = r.AfterArmour * (1 - fraction(m.Resistance))
+	r.AfterEnchantment = math.Max(r.AfterResistance-m.Enchantment, 0) * (1 - fraction(m.FeatherFalling))
+	r.Absorbed = math.Min(r.AfterEnchantment, math.Max(m.Absorption, 0))
+	r.Final = r.AfterEnchantment - r.Absorbed
+	return r
+}
+
+// fraction clamps the fraction passed between 0 and 1.
+func fraction(f float64) float64 {
+	return math.Max(0, math.Min(f, 1))
+}
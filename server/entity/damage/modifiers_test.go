@@ -0,0 +1,68 @@
+package damage
+
+import (
+	"math"
+	"testing"
+)
+
+func TestModifiersApply(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Modifiers
+		want Result
+	}{
+		{
+			name: "no armour",
+			m:    Modifiers{Base: 7},
+			want: Result{AfterArmour: 7, AfterResistance: 7, AfterEnchantment: 7, Final: 7},
+		},
+		{
+			name: "full diamond armour",
+			m:    Modifiers{Base: 7, Armour: 0.8},
+			want: Result{AfterArmour: 1.4, AfterResistance: 1.4, AfterEnchantment: 1.4, Final: 1.4},
+		},
+		{
+			name: "full diamond armour with protection IV",
+			m:    Modifiers{Base: 7, Armour: 0.8, Enchantment: 0.8},
+			want: Result{AfterArmour: 1.4, AfterResistance: 1.4, AfterEnchantment: 0.6, Final: 0.6},
+		},
+		{
+			name: "iron armour and resistance II",
+			m:    Modifiers{Base: 10, Armour: 0.6, Resistance: 0.4},
+			want: Result{AfterArmour: 4, AfterResistance: 2.4, AfterEnchantment: 2.4, Final: 2.4},
+		},
+		{
+			name: "fall damage with feather falling IV",
+			m:    Modifiers{Base: 10, FeatherFalling: 0.48},
+			want: Result{AfterArmour: 10, AfterResistance: 10, AfterEnchantment: 5.2, Final: 5.2},
+		},
+		{
+			name: "absorption partially absorbed",
+			m:    Modifiers{Base: 6, Absorption: 4},
+			want: Result{AfterArmour: 6, AfterResistance: 6, AfterEnchantment: 6, Absorbed: 4, Final: 2},
+		},
+		{
+			name: "absorption fully absorbed",
+			m:    Modifiers{Base: 3, Absorption: 4},
+			want: Result{AfterArmour: 3, AfterResistance: 3, AfterEnchantment: 3, Absorbed: 3},
+		},
+		{
+			name: "enchantment reduction exceeds damage",
+			m:    Modifiers{Base: 1, Armour: 0.8, Enchantment: 0.8},
+			want: Result{AfterArmour: 0.2, AfterResistance: 0.2},
+		},
+	}
+	for _, test := range tests {
+		got := test.m.Apply()
+		if !approx(got.AfterArmour, test.want.AfterArmour) || !approx(got.AfterResistance, test.want.AfterResistance) ||
+			!approx(got.AfterEnchantment, test.want.AfterEnchantment) || !approx(got.Absorbed, test.want.Absorbed) ||
+			!approx(got.Final, test.want.Final) {
+			t.Errorf("%v: Apply() = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+// approx checks if two floats are equal, allowing for small rounding errors.
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
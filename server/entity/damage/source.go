@@ -45,12 +45,39 @@ type SourceFireTick struct{}
 // SourceLava is used for damage caused by being in lava.
 type SourceLava struct{}
 
+// SourceSuffocation is used for damage caused by the eyes of an entity being inside of a solid block, for
+// example after being pushed into a wall or having sand fall on top of it.
+type SourceSuffocation struct{}
+
 // SourceFall is a source that is used if the player fell.
 type SourceFall struct{}
 
 // SourceLightning is used for damage caused by being struck by lightning.
 type SourceLightning struct{}
 
+// SourceExplosion is used for damage caused by an explosion, such as one created using
+// block.ExplosionConfig.
+type SourceExplosion struct{}
+
+// SourceWorldBorder is used for damage caused by being outside the border of a world, as set using
+// world.World.SetBorder.
+type SourceWorldBorder struct{}
+
+// SourceBlock is used for damage caused by touching a block, for example when walking through a sweet berry
+// bush.
+type SourceBlock struct {
+	// Block is the block that dealt the damage.
+	Block world.Block
+}
+
+// SourceDrowning is used for damage caused by an entity running out of air while its head is submerged in
+// water.
+type SourceDrowning struct{}
+
+// SourceCommand is used for damage dealt by a command, such as /kill. Like SourceVoid, it hurts players in any
+// game mode.
+type SourceCommand struct{}
+
 // SourceCustom is a cause used for dealing any kind of custom damage. Armour reduces damage of this source,
 // but otherwise no enchantments have an additional effect.
 type SourceCustom struct{}
@@ -65,6 +92,16 @@ func (SourceLightning) ReducedByArmour() bool {
 	return true
 }
 
+// ReducedByArmour ...
+func (SourceExplosion) ReducedByArmour() bool {
+	return true
+}
+
+// ReducedByArmour ...
+func (SourceWorldBorder) ReducedByArmour() bool {
+	return false
+}
+
 // ReducedByArmour ...
 func (SourceEntityAttack) ReducedByArmour() bool {
 	return true
@@ -80,6 +117,11 @@ func (SourceInstantDamageEffect) ReducedByArmour() bool {
 	return false
 }
 
+// ReducedByArmour ...
+func (SourceBlock) ReducedByArmour() bool {
+	return true
+}
+
 // ReducedByArmour ...
 func (SourceCustom) ReducedByArmour() bool {
 	return false
@@ -90,6 +132,16 @@ func (SourceVoid) ReducedByArmour() bool {
 	return false
 }
 
+// ReducedByArmour ...
+func (SourceDrowning) ReducedByArmour() bool {
+	return false
+}
+
+// ReducedByArmour ...
+func (SourceCommand) ReducedByArmour() bool {
+	return false
+}
+
 // ReducedByArmour ...
 func (SourcePoisonEffect) ReducedByArmour() bool {
 	return false
@@ -114,3 +166,8 @@ func (SourceFireTick) ReducedByArmour() bool {
 func (SourceLava) ReducedByArmour() bool {
 	return true
 }
+
+// ReducedByArmour ...
+func (SourceSuffocation) ReducedByArmour() bool {
+	return false
+}
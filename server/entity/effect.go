@@ -75,6 +75,15 @@ func (m *EffectManager) Remove(e effect.Type, entity Living) {
 	}
 }
 
+// Effect returns the effect of the type passed currently present in the EffectManager. If no such effect is
+// present, false is returned.
+func (m *EffectManager) Effect(t effect.Type) (effect.Effect, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.effects[reflect.TypeOf(t)]
+	return e, ok
+}
+
 // Effects returns a list of all effects currently present in the effect manager. This will never include
 // effects that have expired.
 func (m *EffectManager) Effects() []effect.Effect {
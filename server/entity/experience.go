@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"math"
+	"sync"
+)
+
+// ExperienceManager handles the experience of an entity. The experience is stored as a total amount of
+// experience points, from which the level and the progress towards the next level are derived using the
+// vanilla level curve.
+type ExperienceManager struct {
+	mu         sync.RWMutex
+	experience int
+}
+
+// NewExperienceManager returns a new experience manager with no experience.
+func NewExperienceManager() *ExperienceManager {
+	return &ExperienceManager{}
+}
+
+// Experience returns the total amount of experience points of the entity.
+func (m *ExperienceManager) Experience() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.experience
+}
+
+// Level returns the experience level of the entity.
+func (m *ExperienceManager) Level() int {
+	l, _ := ProgressFromExperience(m.Experience())
+	return l
+}
+
+// Progress returns the progress of the entity towards the next level, a value between 0 and 1.
+func (m *ExperienceManager) Progress() float64 {
+	_, progress := ProgressFromExperience(m.Experience())
+	return progress
+}
+
+// Add adds an amount of experience points to the entity. If the amount is negative and the resulting
+// experience would be lower than 0, the experience is set to 0. Add returns the level before and after
+// adding the experience.
+func (m *ExperienceManager) Add(amount int) (before, after int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	before, _ = ProgressFromExperience(m.experience)
+
+	if amount < 0 && m.experience < -amount {
+		m.experience = 0
+	} else if amount > 0 && m.experience > math.MaxInt32-amount {
+		m.experience = math.MaxInt32
+	} else {
+		m.experience += amount
+	}
+	after, _ = ProgressFromExperience(m.experience)
+	return before, after
+}
+
+// SetLevel sets the experience level of the entity, keeping the progress towards the next level. Negative
+// levels are treated as 0.
+func (m *ExperienceManager) SetLevel(level int) {
+	if level < 0 {
+		level = 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, progress := ProgressFromExperience(m.experience)
+	m.experience = ExperienceForLevel(level) + int(progress*float64(experienceToNextLevel(level)))
+}
+
+// SetProgress sets the experience level and the progress towards the next level of the entity. The progress
+// passed is clamped between 0 and 1.
+func (m *ExperienceManager) SetProgress(level int, progress float64) {
+	if level < 0 {
+		level = 0
+	}
+	progress = math.Max(0, math.Min(progress, 1))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.experience = ExperienceForLevel(level) + int(progress*float64(experienceToNextLevel(level)))
+}
+
+// Reset removes all experience of the entity.
+func (m *ExperienceManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.experience = 0
+}
+
+// ExperienceForLevel returns the total amount of experience points needed to reach the level passed from
+// level 0.
+func ExperienceForLevel(level int) int {
+	switch {
+	case level <= 16:
+		return level*level + 6*level
+	case level <= 31:
+		return (5*level*level - 81*level + 720) / 2
+	default:
+		return (9*level*level - 325*level + 4440) / 2
+	}
+}
+
+// ProgressFromExperience converts a total amount of experience points to a level and the progress towards
+// the next level, a value between 0 and 1.
+func ProgressFromExperience(experience int) (level int, progress float64) {
+	if experience <= 0 {
+		return 0, 0
+	}
+	for ExperienceForLevel(level+1) <= experience {
+		level++
+	}
+	return level, float64(experience-ExperienceForLevel(level)) / float64(experienceToNextLevel(level))
+}
+
+// experienceToNextLevel returns the amount of experience points needed to go from the level passed to the
+// next level.
+func experienceToNextLevel(level int) int {
+	switch {
+	case level <= 15:
+		return 2*level + 7
+	case level <= 30:
+		return 5*level - 38
+	default:
+		return 9*level - 158
+	}
+}
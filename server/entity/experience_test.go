@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestExperienceForLevel(t *testing.T) {
+	for level, experience := range map[int]int{0: 0, 1: 7, 16: 352, 17: 394, 30: 1395, 31: 1507, 32: 1628, 40: 2920} {
+		if got := ExperienceForLevel(level); got != experience {
+			t.Errorf("level %v: expected %v experience, got %v", level, experience, got)
+		}
+		if l, progress := ProgressFromExperience(experience); l != level || progress != 0 {
+			t.Errorf("%v experience: expected level %v, got level %v with progress %v", experience, level, l, progress)
+		}
+		if level > 0 && ExperienceForLevel(level)-ExperienceForLevel(level-1) != experienceToNextLevel(level-1) {
+			t.Errorf("level %v: level curve is not continuous", level)
+		}
+	}
+}
+
+func TestExperienceManager(t *testing.T) {
+	m := NewExperienceManager()
+	if before, after := m.Add(10); before != 0 || after != 1 {
+		t.Fatalf("expected level 0 -> 1, got %v -> %v", before, after)
+	}
+	if progress := m.Progress(); progress != 3.0/9 {
+		t.Fatalf("expected progress 3/9, got %v", progress)
+	}
+	m.Add(-100)
+	if m.Experience() != 0 {
+		t.Fatalf("expected experience to be clamped at 0, got %v", m.Experience())
+	}
+	m.SetProgress(20, 0.5)
+	m.SetLevel(25)
+	if m.Level() != 25 || m.Progress() < 0.49 || m.Progress() > 0.51 {
+		t.Fatalf("expected level 25 with progress 0.5, got level %v with progress %v", m.Level(), m.Progress())
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultImmunityDuration is the default duration that an entity is immune to damage after being hurt, equal
+// to 10 ticks.
+const DefaultImmunityDuration = time.Second / 2
+
+// ImmunityManager handles the damage immunity of an entity. After being hurt, an entity is immune to damage
+// for a short duration. Damage dealt during this window is only applied if it is higher than the damage
+// that started the window, in which case only the difference is applied.
+type ImmunityManager struct {
+	mu    sync.Mutex
+	until time.Time
+	last  float64
+}
+
+// NewImmunityManager returns a new ImmunityManager for an entity that is not currently immune.
+func NewImmunityManager() *ImmunityManager {
+	return &ImmunityManager{}
+}
+
+// Damage returns the damage that should be dealt to the entity if it is hurt with the damage passed. If the
+// entity is not immune, the damage passed is returned and the entity turns immune for the duration passed.
+// If the entity is immune, only the difference between the damage passed and the damage last dealt is
+// returned. Damage returns false if no damage should be dealt at all.
+func (m *ImmunityManager) Damage(dmg float64, d time.Duration) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(m.until) {
+		if dmg <= m.last {
+			return 0, false
+		}
+		dmg, m.last = dmg-m.last, dmg
+		return dmg, true
+	}
+	m.until, m.last = now.Add(d), dmg
+	return dmg, true
+}
+
+// Remaining returns the remaining duration that the entity is immune to damage. If the entity is not
+// immune, 0 is returned.
+func (m *ImmunityManager) Remaining() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r := time.Until(m.until); r > 0 {
+		return r
+	}
+	return 0
+}
+
+// Immune checks if the entity is currently immune to damage.
+func (m *ImmunityManager) Immune() bool {
+	return m.Remaining() > 0
+}
+
+// Reset resets the immunity of the entity, so that it is no longer immune to damage.
+func (m *ImmunityManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.until, m.last = time.Time{}, 0
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestImmunityManagerDamage(t *testing.T) {
+	m := NewImmunityManager()
+	if dmg, ok := m.Damage(4, time.Minute); !ok || dmg != 4 {
+		t.Fatalf("expected full damage 4 when not immune, got %v (%v)", dmg, ok)
+	}
+	if !m.Immune() {
+		t.Fatal("expected entity to be immune after being damaged")
+	}
+	if _, ok := m.Damage(3, time.Minute); ok {
+		t.Error("expected lower damage to be ignored while immune")
+	}
+	if _, ok := m.Damage(4, time.Minute); ok {
+		t.Error("expected equal damage to be ignored while immune")
+	}
+	if dmg, ok := m.Damage(7, time.Minute); !ok || dmg != 3 {
+		t.Errorf("expected difference 3 for higher damage while immune, got %v (%v)", dmg, ok)
+	}
+	if dmg, ok := m.Damage(8, time.Minute); !ok || dmg != 1 {
+		t.Errorf("expected difference 1 with the last damage, got %v (%v)", dmg, ok)
+	}
+
+	m.Reset()
+	if m.Immune() || m.Remaining() != 0 {
+		t.Error("expected entity not to be immune after reset")
+	}
+	if dmg, ok := m.Damage(2, 0); !ok || dmg != 2 || m.Immune() {
+		t.Errorf("expected full damage 2 and no immunity with zero duration, got %v (%v)", dmg, ok)
+	}
+}
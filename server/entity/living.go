@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/healing"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"time"
 )
 
 // Living represents an entity that is alive and that has health. It is able to take damage and will die upon
@@ -17,9 +18,12 @@ type Living interface {
 	MaxHealth() float64
 	// SetMaxHealth changes the maximum health of the entity to the value passed.
 	SetMaxHealth(v float64)
-	// AttackImmune checks if the entity is currently immune to entity attacks. Entities typically turn
-	// immune for half a second after being attacked.
+	// AttackImmune checks if the entity is currently immune to damage. Entities typically turn immune for
+	// half a second after being hurt. Damage dealt to an immune entity is only applied if it is higher than
+	// the damage that made it immune.
 	AttackImmune() bool
+	// AttackImmunity returns the remaining duration that the entity is immune to damage.
+	AttackImmunity() time.Duration
 	// Hurt hurts the entity for a given amount of damage. The source passed represents the cause of the
 	// damage, for example damage.SourceEntityAttack if the entity is attacked by another entity.
 	// If the final damage exceeds the health that the player currently has, the entity is killed.
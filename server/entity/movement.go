@@ -26,8 +26,16 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, yaw
 	w := e.World()
 	viewers := w.Viewers(pos)
 
+	start := pos
+	if pushed, ok := PushOutOfBlocks(e, pos); ok {
+		pos = pushed
+	}
+
 	vel = c.applyHorizontalForces(w, pos, c.applyVerticalForces(vel))
 	dPos, vel := c.checkCollision(e, pos, vel)
+	// Include the distance that the entity was pushed out of blocks in the movement sent to viewers.
+	dPos = dPos.Add(pos.Sub(start))
+	pos = start
 
 	c.sendMovement(e, viewers, pos, dPos, vel, yaw, pitch)
 
@@ -1,6 +1,9 @@
 package entity
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
 
 // init registers all entities that can be saved in a world.World, so that they can be loaded when found in the world
 // save.
@@ -8,4 +11,8 @@ func init() {
 	world.RegisterEntity(&Text{})
 	world.RegisterEntity(&FallingBlock{})
 	world.RegisterEntity(&Item{})
+
+	world.RegisterLightning(func(pos mgl64.Vec3) world.Entity {
+		return NewLightning(pos)
+	})
 }
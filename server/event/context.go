@@ -5,6 +5,7 @@ package event
 type Context struct {
 	cancel bool
 	after  []func(bool)
+	vals   map[interface{}]interface{}
 }
 
 // C returns a new event context.
@@ -17,6 +18,11 @@ func (ctx *Context) Cancel() {
 	ctx.cancel = true
 }
 
+// Cancelled checks if the context was cancelled using a call to Cancel.
+func (ctx *Context) Cancelled() bool {
+	return ctx.cancel
+}
+
 // After calls the function passed after the action of the event has been completed, either by a call to
 // (*Context).Continue() or (*Context).Stop().
 // After can be executed multiple times to attach more functions to be called after the event is executed.
@@ -50,3 +56,26 @@ func (ctx *Context) Stop(f func()) {
 		}
 	}
 }
+
+// key is the key under which a value of type T is stored in a Context.
+type key[T any] struct{}
+
+// Set attaches a value of type T to the Context passed. Handlers called later for the same event may read the
+// value using Val. If a value of the same type was already attached, it is overwritten.
+func Set[T any](ctx *Context, v T) {
+	if ctx.vals == nil {
+		ctx.vals = map[interface{}]interface{}{}
+	}
+	ctx.vals[key[T]{}] = v
+}
+
+// Val returns the value of type T attached to the Context passed using Set. If no value of type T was
+// attached, the zero value of T is returned and the bool returned is false.
+func Val[T any](ctx *Context) (T, bool) {
+	v, ok := ctx.vals[key[T]{}]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return v.(T), true
+}
@@ -0,0 +1,67 @@
+package event_test
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"reflect"
+	"testing"
+)
+
+func TestContextHandlerOrder(t *testing.T) {
+	var order []string
+	handlers := []func(ctx *event.Context){
+		func(ctx *event.Context) {
+			order = append(order, "first")
+			event.Set(ctx, "first")
+		},
+		func(ctx *event.Context) {
+			order = append(order, "second")
+			if v, ok := event.Val[string](ctx); !ok || v != "first" {
+				t.Errorf("expected value %q attached by first handler, got %q (%v)", "first", v, ok)
+			}
+			ctx.Cancel()
+			ctx.After(func(cancelled bool) {
+				order = append(order, "after")
+				if !cancelled {
+					t.Error("expected after function to be called with cancelled=true")
+				}
+			})
+		},
+		func(ctx *event.Context) {
+			order = append(order, "third")
+			if !ctx.Cancelled() {
+				t.Error("expected context to be cancelled by second handler")
+			}
+		},
+	}
+
+	ctx := event.C()
+	for _, h := range handlers {
+		h(ctx)
+	}
+	ctx.Continue(func() {
+		order = append(order, "built-in")
+	})
+	ctx.Stop(func() {
+		order = append(order, "stop")
+	})
+
+	expected := []string{"first", "second", "third", "stop", "after"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestContextVal(t *testing.T) {
+	ctx := event.C()
+	if _, ok := event.Val[int](ctx); ok {
+		t.Error("expected no int value in new context")
+	}
+	event.Set(ctx, 5)
+	event.Set(ctx, "value")
+	if v, ok := event.Val[int](ctx); !ok || v != 5 {
+		t.Errorf("expected int value 5, got %v (%v)", v, ok)
+	}
+	if v, ok := event.Val[string](ctx); !ok || v != "value" {
+		t.Errorf("expected string value %q, got %q (%v)", "value", v, ok)
+	}
+}
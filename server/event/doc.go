@@ -0,0 +1,20 @@
+// Package event implements the Context passed to every handler of an event, such as the handlers of
+// player.Handler and world.Handler.
+//
+// Every event is handled in the same way: A new Context is created using event.C() and passed to the
+// handlers of the event, in the order that they were attached. Handlers may call Context.Cancel() to cancel
+// the event, which prevents the built-in behaviour of the event from being executed. Handlers called after
+// the event was cancelled are still called and may check if the event was cancelled using
+// Context.Cancelled().
+//
+// Once all handlers have been called, the built-in behaviour of the event is executed through a call to
+// Context.Continue() if the event was not cancelled, or the behaviour of a cancelled event through a call to
+// Context.Stop(). Functions attached using Context.After() are called right after, with a bool that
+// indicates if the event was cancelled.
+// Some events, such as the death of a player, cannot be cancelled. Calling Cancel() on the Context of those
+// events has no effect on the event itself.
+//
+// Values may be attached to a Context using event.Set() so that handlers called later can read them using
+// event.Val(). Values are identified by their type, so that each type of value may be attached to a Context
+// once.
+package event
@@ -0,0 +1,89 @@
+package event
+
+import (
+	"sort"
+	"sync"
+)
+
+// Token identifies a handler attached to a Handlers list. It may be passed to Handlers.Detach to remove the
+// handler again.
+type Token uint64
+
+// Handlers holds a list of handlers of type H ordered by priority. Handlers with a lower priority are called
+// before handlers with a higher priority, so that handlers with a higher priority have the final say over
+// the cancellation of an event. Handlers with the same priority are called in the order they were attached.
+// A zero Handlers is ready for use. Handlers is safe for concurrent use.
+type Handlers[H any] struct {
+	mu   sync.RWMutex
+	next Token
+	// list holds the handlers currently attached. It is never modified, but instead replaced with a new
+	// slice when handlers are attached or detached, so that it may be returned by List without copying.
+	list []handler[H]
+	h    []H
+}
+
+// handler is a handler attached to a Handlers list with a priority.
+type handler[H any] struct {
+	h        H
+	priority int
+	token    Token
+}
+
+// Attach attaches a handler to the list with the priority passed. A Token is returned that may be passed to
+// Detach to remove the handler from the list.
+func (l *Handlers[H]) Attach(h H, priority int) Token {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.next++
+	list := make([]handler[H], len(l.list), len(l.list)+1)
+	copy(list, l.list)
+	list = append(list, handler[H]{h: h, priority: priority, token: l.next})
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].priority < list[j].priority
+	})
+	l.set(list)
+	return l.next
+}
+
+// Detach detaches the handler identified by the Token passed from the list. Detach returns false if no
+// handler with the Token was attached.
+func (l *Handlers[H]) Detach(t Token) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, v := range l.list {
+		if v.token == t {
+			list := make([]handler[H], 0, len(l.list)-1)
+			list = append(list, l.list[:i]...)
+			l.set(append(list, l.list[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
+
+// Clear detaches all handlers from the list.
+func (l *Handlers[H]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.set(nil)
+}
+
+// List returns all handlers attached to the list, ordered by priority. The slice returned must not be
+// modified.
+func (l *Handlers[H]) List() []H {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.h
+}
+
+// set sets the handlers of the list to the slice passed. The mutex of the list must be held when set is
+// called.
+func (l *Handlers[H]) set(list []handler[H]) {
+	h := make([]H, len(list))
+	for i, v := range list {
+		h[i] = v.h
+	}
+	l.list, l.h = list, h
+}
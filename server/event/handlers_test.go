@@ -0,0 +1,32 @@
+package event_test
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"reflect"
+	"testing"
+)
+
+func TestHandlersOrder(t *testing.T) {
+	var l event.Handlers[string]
+	l.Attach("b", 0)
+	l.Attach("a", -1)
+	tok := l.Attach("c", 0)
+	l.Attach("d", 5)
+
+	if expected := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(l.List(), expected) {
+		t.Errorf("expected handlers %v, got %v", expected, l.List())
+	}
+	if !l.Detach(tok) {
+		t.Error("expected handler to be detached")
+	}
+	if l.Detach(tok) {
+		t.Error("expected handler detached twice to return false")
+	}
+	if expected := []string{"a", "b", "d"}; !reflect.DeepEqual(l.List(), expected) {
+		t.Errorf("expected handlers %v, got %v", expected, l.List())
+	}
+	l.Clear()
+	if len(l.List()) != 0 {
+		t.Errorf("expected no handlers after Clear, got %v", l.List())
+	}
+}
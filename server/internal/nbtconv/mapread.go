@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"reflect"
 )
 
 // MapSlice reads an interface slice from a map at the key passed.
@@ -43,6 +44,27 @@ func MapByte(m map[string]interface{}, key string) byte {
 	return b
 }
 
+// MapFloat32 reads a float32 from a map at the key passed.
+func MapFloat32(m map[string]interface{}, key string) float32 {
+	b, _ := m[key].(float32)
+	return b
+}
+
+// MapBytes reads a byte slice from a map at the key passed. Byte arrays decoded from NBT are fixed size Go arrays
+// rather than slices, so MapBytes accepts both.
+func MapBytes(m map[string]interface{}, key string) []byte {
+	if b, ok := m[key].([]byte); ok {
+		return b
+	}
+	v := reflect.ValueOf(m[key])
+	if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b
+}
+
 // MapVec3 converts x, y and z values in an NBT map to an mgl64.Vec3.
 func MapVec3(x map[string]interface{}, k string) mgl64.Vec3 {
 	if i, ok := x[k].([]interface{}); ok {
@@ -13,6 +13,8 @@ func WriteItem(s item.Stack, disk bool) map[string]interface{} {
 	m := make(map[string]interface{})
 	if disk {
 		writeItemStack(m, s)
+	} else {
+		writeItemNBT(m, s)
 	}
 	writeDamage(m, s, disk)
 	writeDisplay(m, s)
@@ -47,6 +49,20 @@ func writeItemStack(m map[string]interface{}, s item.Stack) {
 	}
 }
 
+// writeItemNBT writes the NBT of an item that is not a block to a map ready for NBT encoding, so that the client
+// is able to display data such as the pages of a book. The NBT of blocks is only used for their block entity
+// and is not sent.
+func writeItemNBT(m map[string]interface{}, s item.Stack) {
+	if _, ok := s.Item().(world.Block); ok {
+		return
+	}
+	if nbt, ok := s.Item().(world.NBTer); ok {
+		for k, v := range nbt.EncodeNBT() {
+			m[k] = v
+		}
+	}
+}
+
 // writeBlock writes the name, properties and version of a block to a map ready for NBT encoding.
 func writeBlock(m map[string]interface{}, b world.Block) {
 	m["name"], m["states"] = b.EncodeBlock()
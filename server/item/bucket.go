@@ -32,7 +32,13 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 		return b.fillFrom(pos, w, ctx)
 	}
 	liq := b.Content.WithDepth(8, false)
-	if bl := w.Block(pos); canDisplace(bl, liq) || replaceableWith(bl, liq) {
+	if e, ok := w.Block(pos).(bucketEmptier); ok {
+		res, ok := e.EmptyBucket(liq)
+		if !ok {
+			return false
+		}
+		w.PlaceBlock(pos, res)
+	} else if bl := w.Block(pos); canDisplace(bl, liq) || replaceableWith(bl, liq) {
 		w.SetLiquid(pos, liq)
 	} else if bl := w.Block(pos.Side(face)); canDisplace(bl, liq) || replaceableWith(bl, liq) {
 		w.SetLiquid(pos.Side(face), liq)
@@ -75,6 +81,14 @@ func (b Bucket) EncodeItem() (name string, meta int16) {
 	return "minecraft:bucket", 0
 }
 
+// bucketEmptier is implemented by blocks that a filled Bucket may be emptied into, such as cauldrons.
+type bucketEmptier interface {
+	// EmptyBucket empties a Bucket holding the liquid passed into the block. It returns the block that should
+	// be placed in the world after emptying the bucket. If the bool returned is false, the bucket cannot be
+	// emptied into the block.
+	EmptyBucket(liq world.Liquid) (world.Block, bool)
+}
+
 type replaceable interface {
 	ReplaceableBy(b world.Block) bool
 }
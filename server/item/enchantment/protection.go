@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/damage"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/armour"
+	"math"
 )
 
 // BlastProtection is an armour enchantment that decreases explosion damage.
@@ -37,6 +38,17 @@ func (e BlastProtection) CompatibleWith(s item.Stack) bool {
 	return ok && !fireProt && !projectileProt && !prot
 }
 
+// Subtrahend returns the amount of damage from explosions that should be subtracted with blast protection.
+func (e BlastProtection) Subtrahend(level int) float64 {
+	return float64(level) / 10
+}
+
+// KnockBackMultiplier returns the multiplier of the knock back from explosions that is left with blast
+// protection.
+func (e BlastProtection) KnockBackMultiplier(level int) float64 {
+	return math.Max(1-float64(level)*0.15, 0)
+}
+
 // FireProtection is an armour enchantment that decreases fire damage.
 type FireProtection struct {
 	enchantment
@@ -106,7 +118,7 @@ type Protection struct {
 
 // Affects ...
 func (e Protection) Affects(src damage.Source) bool {
-	return src == damage.SourceEntityAttack{} || src == damage.SourceFall{} || src == damage.SourceFire{} || src == damage.SourceFireTick{} || src == damage.SourceLava{}
+	return src == damage.SourceEntityAttack{} || src == damage.SourceFall{} || src == damage.SourceFire{} || src == damage.SourceFireTick{} || src == damage.SourceLava{} || src == damage.SourceExplosion{}
 }
 
 // Subtrahend returns the amount of damage that should be subtracted with protection.
@@ -0,0 +1,42 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// HoneyBottle is a drinkable item that restores hunger and cures poison.
+type HoneyBottle struct{}
+
+// MaxCount ...
+func (HoneyBottle) MaxCount() int {
+	return 16
+}
+
+// AlwaysConsumable ...
+func (HoneyBottle) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (HoneyBottle) ConsumeDuration() time.Duration {
+	return DefaultDrinkDuration
+}
+
+// Drinkable ...
+func (HoneyBottle) Drinkable() bool {
+	return true
+}
+
+// Consume ...
+func (HoneyBottle) Consume(_ *world.World, c Consumer) Stack {
+	c.RemoveEffect(effect.Poison{})
+	c.Saturate(6, 1.2)
+	return NewStack(GlassBottle{}, 1)
+}
+
+// EncodeItem ...
+func (HoneyBottle) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_bottle", 0
+}
@@ -204,6 +204,29 @@ func (inv *Inventory) RemoveItem(it item.Stack) error {
 	return fmt.Errorf("could not remove all items from the inventory")
 }
 
+// MoveTo moves all items in the inventory to the inventory passed, as if they were added using AddItem. Each
+// item is removed from the inventory before it is added to the other inventory, so that an item is never
+// present in both inventories at once. The items that could not be added because the other inventory was
+// full are returned.
+func (inv *Inventory) MoveTo(to *Inventory) (leftover []item.Stack) {
+	for slot := 0; slot < inv.Size(); slot++ {
+		inv.mu.Lock()
+		it := inv.slots[slot]
+		if it.Empty() {
+			inv.mu.Unlock()
+			continue
+		}
+		f := inv.setItem(slot, item.Stack{})
+		inv.mu.Unlock()
+		f()
+
+		if n, err := to.AddItem(it); err != nil {
+			leftover = append(leftover, it.Grow(-n))
+		}
+	}
+	return leftover
+}
+
 // Contents returns a list of all contents of the inventory. This method excludes air items, so the method
 // only ever returns item stacks which actually represent an item.
 func (inv *Inventory) Contents() []item.Stack {
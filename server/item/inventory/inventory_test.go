@@ -0,0 +1,24 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+func TestMoveTo(t *testing.T) {
+	from, to := New(3, nil), New(1, nil)
+	_ = from.SetItem(0, item.NewStack(item.Stick{}, 40))
+	_ = from.SetItem(2, item.NewStack(item.Stick{}, 40))
+
+	leftover := from.MoveTo(to)
+	if !from.Empty() {
+		t.Fatalf("expected source inventory to be empty, got %v", from)
+	}
+	if it, _ := to.Item(0); it.Count() != 64 {
+		t.Fatalf("expected 64 sticks in target inventory, got %v", it.Count())
+	}
+	if len(leftover) != 1 || leftover[0].Count() != 16 {
+		t.Fatalf("expected 16 sticks left over, got %v", leftover)
+	}
+}
@@ -56,10 +56,20 @@ type Consumable interface {
 	// this duration, the item will be consumed and have its Consume method called.
 	ConsumeDuration() time.Duration
 	// Consume consumes one item of the Stack that the Consumable is in. The Stack returned is added back to
-	// the inventory after consuming the item. For potions, for example, an empty bottle is returned.
+	// the inventory after consuming the item. For potions, for example, an empty bottle is returned, and for
+	// soups an empty bowl. If nothing remains after consuming the item, an empty Stack is returned.
 	Consume(w *world.World, c Consumer) Stack
 }
 
+// Drinkable represents a Consumable that is drunk rather than eaten, such as potions and honey bottles.
+// Drinking an item plays a drinking sound instead of showing eating particles, and does not make the
+// Consumer burp once finished.
+type Drinkable interface {
+	Consumable
+	// Drinkable returns true if the Consumable is drunk rather than eaten.
+	Drinkable() bool
+}
+
 // Consumer represents a User that is able to consume Consumable items.
 type Consumer interface {
 	User
@@ -71,12 +81,17 @@ type Consumer interface {
 	// AddEffect will overwrite any effects present if the level of the effect is higher than the existing one, or
 	// if the effects' levels are equal and the new effect has a longer duration.
 	AddEffect(e effect.Effect)
+	// RemoveEffect removes any effect that might currently be active on the Consumer.
+	RemoveEffect(e effect.Type)
 }
 
 // DefaultConsumeDuration is the default duration that consuming an item takes. Dried kelp takes half this
 // time to be consumed.
 const DefaultConsumeDuration = (time.Second * 161) / 100
 
+// DefaultDrinkDuration is the default duration that drinking an item, such as a potion, takes.
+const DefaultDrinkDuration = (time.Second * 6) / 5
+
 // UseContext is passed to every item Use methods. It may be used to subtract items or to deal damage to them
 // after the action is complete.
 type UseContext struct {
@@ -6,7 +6,8 @@ import (
 	"time"
 )
 
-// Potion is an item that grants effects on consumption.
+// Potion is an item that grants effects on consumption. A Potion with the potion.Water() type is a water
+// bottle, which is obtained by filling a GlassBottle and grants no effects.
 type Potion struct {
 	// Type is the type of potion.
 	Type potion.Potion
@@ -24,7 +25,12 @@ func (p Potion) AlwaysConsumable() bool {
 
 // ConsumeDuration ...
 func (p Potion) ConsumeDuration() time.Duration {
-	return DefaultConsumeDuration
+	return DefaultDrinkDuration
+}
+
+// Drinkable ...
+func (p Potion) Drinkable() bool {
+	return true
 }
 
 // Consume ...
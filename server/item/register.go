@@ -56,6 +56,7 @@ func init() {
 	world.RegisterItem(Leather{})
 
 	world.RegisterItem(GlassBottle{})
+	world.RegisterItem(HoneyBottle{})
 	for _, p := range potion.All() {
 		world.RegisterItem(Potion{Type: p})
 	}
@@ -82,6 +83,8 @@ func init() {
 	world.RegisterItem(BlazeRod{})
 	world.RegisterItem(Bone{})
 	world.RegisterItem(Book{})
+	world.RegisterItem(WritableBook{})
+	world.RegisterItem(WrittenBook{})
 	world.RegisterItem(Bowl{})
 	world.RegisterItem(Charcoal{})
 	world.RegisterItem(DragonBreath{})
@@ -0,0 +1,173 @@
+package item
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// MaxBookPages is the maximum amount of pages that a WritableBook or WrittenBook may have.
+	MaxBookPages = 50
+	// MaxBookPageLength is the maximum length in characters of a single page of a WritableBook or WrittenBook.
+	MaxBookPageLength = 256
+	// MaxBookTitleLength is the maximum length in characters of the title of a WrittenBook.
+	MaxBookTitleLength = 16
+)
+
+// WritableBook is an item used to write WrittenBooks. Players may write text on its pages and sign it, after
+// which it turns into a WrittenBook that can no longer be edited.
+type WritableBook struct {
+	// Pages holds the text of the pages of the book.
+	Pages []string
+}
+
+// MaxCount ...
+func (WritableBook) MaxCount() int {
+	return 1
+}
+
+// Page returns the text of the page passed. If the book does not have the page, Page returns false.
+func (w WritableBook) Page(page int) (string, bool) {
+	if page < 0 || page >= len(w.Pages) {
+		return "", false
+	}
+	return w.Pages[page], true
+}
+
+// SetPage sets the text of the page passed, adding empty pages up to the page if the book does not have it
+// yet. An error is returned if the page or text exceeds MaxBookPages or MaxBookPageLength.
+func (w WritableBook) SetPage(page int, text string) (WritableBook, error) {
+	if err := validatePage(page, text); err != nil {
+		return w, err
+	}
+	pages := w.copyPages(page + 1)
+	pages[page] = text
+	w.Pages = pages
+	return w, nil
+}
+
+// InsertPage inserts a page with the text passed before the page passed, moving all pages after it back by
+// one. An error is returned if the book would have more than MaxBookPages pages or if the text exceeds
+// MaxBookPageLength.
+func (w WritableBook) InsertPage(page int, text string) (WritableBook, error) {
+	if err := validatePage(page, text); err != nil {
+		return w, err
+	}
+	if len(w.Pages) >= MaxBookPages {
+		return w, fmt.Errorf("book already has the maximum of %v pages", MaxBookPages)
+	}
+	if page >= len(w.Pages) {
+		return w.SetPage(page, text)
+	}
+	pages := w.copyPages(len(w.Pages) + 1)
+	copy(pages[page+1:], pages[page:])
+	pages[page] = text
+	w.Pages = pages
+	return w, nil
+}
+
+// DeletePage deletes the page passed, moving all pages after it forward by one. Nothing happens if the book
+// does not have the page.
+func (w WritableBook) DeletePage(page int) WritableBook {
+	if page < 0 || page >= len(w.Pages) {
+		return w
+	}
+	pages := w.copyPages(len(w.Pages))
+	w.Pages = append(pages[:page], pages[page+1:]...)
+	return w
+}
+
+// SwapPages swaps the two pages passed. Nothing happens if the book does not have one of the pages.
+func (w WritableBook) SwapPages(a, b int) WritableBook {
+	if a < 0 || b < 0 || a >= len(w.Pages) || b >= len(w.Pages) {
+		return w
+	}
+	pages := w.copyPages(len(w.Pages))
+	pages[a], pages[b] = pages[b], pages[a]
+	w.Pages = pages
+	return w
+}
+
+// Sign signs the book with the title and author passed, turning it into a WrittenBook. An error is returned
+// if the title is empty or exceeds MaxBookTitleLength.
+func (w WritableBook) Sign(title, author string) (WrittenBook, error) {
+	if title == "" || utf8.RuneCountInString(title) > MaxBookTitleLength {
+		return WrittenBook{}, fmt.Errorf("book title must be between 1 and %v characters long", MaxBookTitleLength)
+	}
+	if !utf8.ValidString(title) {
+		return WrittenBook{}, fmt.Errorf("book title must be valid UTF8")
+	}
+	return WrittenBook{Title: title, Author: author, Pages: w.copyPages(len(w.Pages))}, nil
+}
+
+// copyPages returns a copy of the pages of the book with at least n pages, so that the pages of other copies
+// of the book are not changed when modifying them.
+func (w WritableBook) copyPages(n int) []string {
+	if n < len(w.Pages) {
+		n = len(w.Pages)
+	}
+	pages := make([]string, n)
+	copy(pages, w.Pages)
+	return pages
+}
+
+// DecodeNBT ...
+func (w WritableBook) DecodeNBT(data map[string]interface{}) interface{} {
+	w.Pages = readPages(data)
+	return w
+}
+
+// EncodeNBT ...
+func (w WritableBook) EncodeNBT() map[string]interface{} {
+	if len(w.Pages) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"pages": writePages(w.Pages)}
+}
+
+// EncodeItem ...
+func (WritableBook) EncodeItem() (name string, meta int16) {
+	return "minecraft:writable_book", 0
+}
+
+// validatePage checks if the page number and text passed are within the limits of a book.
+func validatePage(page int, text string) error {
+	if page < 0 || page >= MaxBookPages {
+		return fmt.Errorf("page %v out of range 0-%v", page, MaxBookPages-1)
+	}
+	if utf8.RuneCountInString(text) > MaxBookPageLength {
+		return fmt.Errorf("page text longer than %v characters", MaxBookPageLength)
+	}
+	if !utf8.ValidString(text) {
+		return fmt.Errorf("page text must be valid UTF8")
+	}
+	return nil
+}
+
+// readPages reads the pages of a book from the NBT data passed. Pages over MaxBookPages and text over
+// MaxBookPageLength are dropped, so that oversized books from world data cannot be sent to clients.
+func readPages(data map[string]interface{}) []string {
+	list, _ := data["pages"].([]interface{})
+	pages := make([]string, 0, len(list))
+	for _, v := range list {
+		if len(pages) == MaxBookPages {
+			break
+		}
+		m, _ := v.(map[string]interface{})
+		text, _ := m["text"].(string)
+		if utf8.RuneCountInString(text) > MaxBookPageLength {
+			text = string([]rune(text)[:MaxBookPageLength])
+		}
+		pages = append(pages, text)
+	}
+	return pages
+}
+
+// writePages writes the pages passed to a slice that can be stored in the NBT of a book.
+func writePages(pages []string) []interface{} {
+	list := make([]interface{}, len(pages))
+	for i, text := range pages {
+		list[i] = map[string]interface{}{"text": text, "photoname": ""}
+	}
+	return list
+}
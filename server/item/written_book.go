@@ -0,0 +1,47 @@
+package item
+
+// WrittenBook is a WritableBook that was signed by its author. It may be read by any player, but it can no
+// longer be edited.
+type WrittenBook struct {
+	// Title is the title of the book.
+	Title string
+	// Author is the name of the author of the book.
+	Author string
+	// Pages holds the text of the pages of the book.
+	Pages []string
+}
+
+// MaxCount ...
+func (WrittenBook) MaxCount() int {
+	return 16
+}
+
+// Page returns the text of the page passed. If the book does not have the page, Page returns false.
+func (w WrittenBook) Page(page int) (string, bool) {
+	if page < 0 || page >= len(w.Pages) {
+		return "", false
+	}
+	return w.Pages[page], true
+}
+
+// DecodeNBT ...
+func (w WrittenBook) DecodeNBT(data map[string]interface{}) interface{} {
+	w.Title, _ = data["title"].(string)
+	w.Author, _ = data["author"].(string)
+	w.Pages = readPages(data)
+	return w
+}
+
+// EncodeNBT ...
+func (w WrittenBook) EncodeNBT() map[string]interface{} {
+	return map[string]interface{}{
+		"title":  w.Title,
+		"author": w.Author,
+		"pages":  writePages(w.Pages),
+	}
+}
+
+// EncodeItem ...
+func (WrittenBook) EncodeItem() (name string, meta int16) {
+	return "minecraft:written_book", 0
+}
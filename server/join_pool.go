@@ -0,0 +1,76 @@
+package server
+
+import "sync"
+
+// joinPool bounds the amount of connections that are spawning simultaneously. Spawning a connection, which
+// includes starting the game and decoding the skin of the player, is expensive, so that a flood of connections
+// could otherwise exhaust the memory of the server. Connections that are waiting for their turn to spawn are
+// queued, and connections exceeding the queue are rejected.
+type joinPool struct {
+	workers chan struct{}
+
+	mu sync.Mutex
+	// pending is the amount of connections that are spawning or queued to spawn. maxPending is the maximum
+	// value of pending, which is the amount of workers plus the size of the queue.
+	pending, maxPending int
+}
+
+// newJoinPool returns a joinPool that lets the amount of connections passed spawn at the same time and queues
+// up to queueSize connections beyond that. If workers is 0 or lower, the amount of connections spawning is not
+// limited.
+func newJoinPool(workers, queueSize int) *joinPool {
+	if workers <= 0 {
+		return &joinPool{}
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	return &joinPool{workers: make(chan struct{}, workers), maxPending: workers + queueSize}
+}
+
+// admit adds a connection to the queue of the joinPool. False is returned if the queue is full, in which case
+// the connection must be rejected. If true is returned, wait must be called for the connection.
+func (pool *joinPool) admit() bool {
+	if pool.workers == nil {
+		return true
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.pending >= pool.maxPending {
+		return false
+	}
+	pool.pending++
+	return true
+}
+
+// wait blocks until the connection admitted using admit may spawn. False is returned if the closing channel
+// passed was closed first, in which case the connection is removed from the queue. If true is returned, done
+// must be called once the connection finished spawning.
+func (pool *joinPool) wait(closing <-chan struct{}) bool {
+	if pool.workers == nil {
+		return true
+	}
+	select {
+	case pool.workers <- struct{}{}:
+		return true
+	case <-closing:
+		pool.remove()
+		return false
+	}
+}
+
+// done frees the worker taken by a connection using wait, so that the next connection in the queue may spawn.
+func (pool *joinPool) done() {
+	if pool.workers == nil {
+		return
+	}
+	<-pool.workers
+	pool.remove()
+}
+
+// remove removes a connection from the pending connections.
+func (pool *joinPool) remove() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.pending--
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJoinPool(t *testing.T) {
+	pool := newJoinPool(1, 1)
+	closing := make(chan struct{})
+
+	if !pool.admit() || !pool.admit() {
+		t.Fatalf("expected one spawning and one queued connection to be admitted")
+	}
+	if pool.admit() {
+		t.Fatalf("expected connection beyond the queue to be rejected")
+	}
+	if !pool.wait(closing) {
+		t.Fatalf("expected first connection to spawn straight away")
+	}
+
+	spawned := make(chan bool, 1)
+	go func() {
+		spawned <- pool.wait(closing)
+	}()
+	select {
+	case <-spawned:
+		t.Fatalf("expected queued connection to wait for a free worker")
+	case <-time.After(time.Millisecond * 50):
+	}
+	pool.done()
+	if !<-spawned {
+		t.Fatalf("expected queued connection to spawn once the worker was freed")
+	}
+	if !pool.admit() {
+		t.Errorf("expected connection to be admitted once the queue has room")
+	}
+	close(closing)
+	if pool.wait(closing) {
+		t.Errorf("expected waiting to fail once the server is closing")
+	}
+	if !pool.admit() {
+		t.Errorf("expected connection that stopped waiting to be removed from the queue")
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+	"net"
+	"strconv"
+)
+
+// lanDiscoveryPort is the port on which clients broadcast pings to discover servers on the local network.
+const lanDiscoveryPort = 19132
+
+const (
+	idUnconnectedPing                = 0x01
+	idUnconnectedPingOpenConnections = 0x02
+	idUnconnectedPong                = 0x1c
+)
+
+// unconnectedMessageSequence is the magic sequence of bytes present in every unconnected RakNet message.
+var unconnectedMessageSequence = [16]byte{0x00, 0xff, 0xff, 0x00, 0xfe, 0xfe, 0xfe, 0xfe, 0xfd, 0xfd, 0xfd, 0xfd, 0x12, 0x34, 0x56, 0x78}
+
+// lanAdvertiser answers the unconnected pings that clients broadcast on the local network, so that the
+// server shows up in the friends tab of the server list even if it is not listening on the discovery port.
+// Unlike the pongs of the RakNet listener, whose sub-name and game mode are fixed by gophertunnel, the pongs of
+// the lanAdvertiser hold the sub-name and default game mode of the server.
+type lanAdvertiser struct {
+	s    *Server
+	conn net.PacketConn
+	id   int64
+	port int
+}
+
+// advertiseLAN starts advertising the server on the local network. The port passed is the port that the
+// server is listening on, which clients will connect to after discovering the server.
+func (server *Server) advertiseLAN(port int) {
+	if port == lanDiscoveryPort {
+		// The server is already listening on the discovery port, so the RakNet listener answers pings by
+		// itself. Its pongs cannot be changed to hold the sub-name and game mode of the server.
+		return
+	}
+	conn, err := net.ListenPacket("udp", ":"+strconv.Itoa(lanDiscoveryPort))
+	if err != nil {
+		server.log.Errorf("Error advertising server on LAN: %v", err)
+		return
+	}
+	server.lan = &lanAdvertiser{s: server, conn: conn, id: rand.Int63(), port: port}
+	go server.lan.listen()
+}
+
+// listen reads unconnected pings from the discovery port and answers each of them with a pong holding the
+// status of the server.
+func (l *lanAdvertiser) listen() {
+	b := make([]byte, 1500)
+	for {
+		n, addr, err := l.conn.ReadFrom(b)
+		if err != nil {
+			return
+		}
+		if n < 33 || (b[0] != idUnconnectedPing && b[0] != idUnconnectedPingOpenConnections) {
+			continue
+		}
+		if !bytes.Equal(b[9:25], unconnectedMessageSequence[:]) {
+			continue
+		}
+		data := l.s.pongData(l.id, l.port)
+
+		buf := bytes.NewBuffer(make([]byte, 0, 35+len(data)))
+		buf.WriteByte(idUnconnectedPong)
+		// The timestamp of the ping is echoed back to the client.
+		buf.Write(b[1:9])
+		_ = binary.Write(buf, binary.BigEndian, l.id)
+		buf.Write(unconnectedMessageSequence[:])
+		_ = binary.Write(buf, binary.BigEndian, uint16(len(data)))
+		buf.Write(data)
+		_, _ = l.conn.WriteTo(buf.Bytes(), addr)
+	}
+}
+
+// Close stops advertising the server on the local network.
+func (l *lanAdvertiser) Close() error {
+	return l.conn.Close()
+}
+
+// pongData returns the data sent in response to a ping from a client in the server list. The id passed is
+// the unique ID of the server and the port passed is the port that clients should connect to.
+func (server *Server) pongData(id int64, port int) []byte {
+	mode, e := server.world.DefaultGameMode(), server.serverListEntry()
+	return []byte(fmt.Sprintf("MCPE;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;%v;",
+		e.Name, e.Protocol, e.Version, e.PlayerCount, e.MaxPlayers, id,
+		e.SubName, gameModeName(mode), gameModeID(mode), port, port,
+	))
+}
+
+// subName returns the sub-name of the server, shown below the name in the server list. If no sub-name is
+// set, the name of the world is used.
+func (server *Server) subName() string {
+	if n := server.sub.Load(); n != "" {
+		return n
+	}
+	return server.world.Name()
+}
+
+// gameModeName returns the name of a world.GameMode as displayed in the server list.
+func gameModeName(mode world.GameMode) string {
+	switch mode.(type) {
+	case world.GameModeCreative:
+		return "Creative"
+	case world.GameModeAdventure:
+		return "Adventure"
+	case world.GameModeSpectator:
+		return "Spectator"
+	default:
+		return "Survival"
+	}
+}
+
+// gameModeID returns the numeric ID of a world.GameMode as displayed in the server list.
+func gameModeID(mode world.GameMode) int {
+	switch mode.(type) {
+	case world.GameModeCreative:
+		return 1
+	case world.GameModeAdventure:
+		return 2
+	case world.GameModeSpectator:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/sandertv/gophertunnel/minecraft"
 	"io"
+	"net"
 )
 
 // Listener is a source for connections that may be listened on by a Server using Server.Listen. Proxies can use this to
@@ -14,6 +15,8 @@ type Listener interface {
 	Accept() (session.Conn, error)
 	// Disconnect disconnects a connection from the Listener with a reason.
 	Disconnect(conn session.Conn, reason string) error
+	// Addr returns the address that the Listener accepts connections on.
+	Addr() net.Addr
 	io.Closer
 }
 
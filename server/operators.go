@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// operators holds the operator levels of players, indexed by their XUID, and saves them to a JSON file.
+type operators struct {
+	// path is the path of the file that the levels are saved to. If empty, levels are not saved.
+	path string
+
+	mu     sync.Mutex
+	levels map[string]int
+}
+
+// loadOperators loads the operator levels from the JSON file at the path passed. If the file does not exist,
+// no players are an operator. If the path is empty, the levels are not loaded or saved at all.
+func loadOperators(path string) (*operators, error) {
+	o := &operators{path: path, levels: map[string]int{}}
+	if path == "" {
+		return o, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read operators file: %w", err)
+	}
+	if err := json.Unmarshal(data, &o.levels); err != nil {
+		return nil, fmt.Errorf("decode operators file: %w", err)
+	}
+	return o, nil
+}
+
+// level returns the operator level of the player with the XUID passed, or 0 if the player is not an operator.
+func (o *operators) level(xuid string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.levels[xuid]
+}
+
+// set sets the operator level of the player with the XUID passed and saves the levels if it changed. A level
+// of 0 removes the player from the operators.
+func (o *operators) set(xuid string, level int) error {
+	if xuid == "" {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.levels[xuid] == level {
+		return nil
+	}
+	if level <= 0 {
+		delete(o.levels, xuid)
+	} else {
+		o.levels[xuid] = level
+	}
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(o.levels, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encode operators file: %w", err)
+	}
+	if err := os.WriteFile(o.path, data, 0644); err != nil {
+		return fmt.Errorf("write operators file: %w", err)
+	}
+	return nil
+}
+
+// SetOperator sets the operator level of the player with the XUID passed, ranging from 0 for players that are
+// not an operator to player.MaxOperatorLevel, and saves it to the operators file. The level of the player is
+// updated right away if it is online, and is otherwise applied when it joins.
+func (server *Server) SetOperator(xuid string, level int) error {
+	if level < 0 {
+		level = 0
+	} else if level > player.MaxOperatorLevel {
+		level = player.MaxOperatorLevel
+	}
+	if p, ok := server.PlayerByXUID(xuid); ok {
+		p.SetOperator(level)
+	}
+	return server.ops.set(xuid, level)
+}
+
+// OperatorLevel returns the operator level of the player with the XUID passed. If the player is online, its
+// current level is returned. Otherwise, the level saved in the operators file is returned.
+func (server *Server) OperatorLevel(xuid string) int {
+	if p, ok := server.PlayerByXUID(xuid); ok {
+		return p.OperatorLevel()
+	}
+	return server.ops.level(xuid)
+}
+
+// PermissionChecker sets the player.PermissionChecker used to check the permissions of all players on the
+// server, including those that are already online. Commands built into the server, such as /time, check for a
+// permission with the node 'dragonfly.command.<name>'. By default, player.DefaultPermissionChecker is used,
+// which grants all permissions to operators. Passing nil restores the default.
+func (server *Server) PermissionChecker(c player.PermissionChecker) {
+	server.permMu.Lock()
+	server.perms = c
+	server.permMu.Unlock()
+	for _, p := range server.Players() {
+		p.SetPermissionChecker(c)
+		p.SendCommands()
+	}
+}
+
+// permissionChecker returns the player.PermissionChecker set using PermissionChecker, or nil if none was set.
+func (server *Server) permissionChecker() player.PermissionChecker {
+	server.permMu.RLock()
+	defer server.permMu.RUnlock()
+	return server.perms
+}
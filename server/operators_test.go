@@ -0,0 +1,58 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOperators(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ops.json")
+	o, err := loadOperators(path)
+	if err != nil {
+		t.Fatalf("load missing operators file: %v", err)
+	}
+	if l := o.level("123"); l != 0 {
+		t.Fatalf("expected level 0 without operators file, got %v", l)
+	}
+	if err := o.set("123", 3); err != nil {
+		t.Fatalf("set operator level: %v", err)
+	}
+	if err := o.set("456", 1); err != nil {
+		t.Fatalf("set operator level: %v", err)
+	}
+	if err := o.set("456", 0); err != nil {
+		t.Fatalf("remove operator: %v", err)
+	}
+
+	o, err = loadOperators(path)
+	if err != nil {
+		t.Fatalf("load operators file: %v", err)
+	}
+	if l := o.level("123"); l != 3 {
+		t.Errorf("expected level 3 after reloading, got %v", l)
+	}
+	if _, ok := o.levels["456"]; ok {
+		t.Errorf("expected removed operator not to be saved")
+	}
+}
+
+func TestOperatorsAfterFailedInit(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	// A file in place of the resources folder makes loading the resources fail before the operators are.
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+	if err := os.WriteFile(conf.Resources.Folder, nil, 0644); err != nil {
+		t.Fatalf("create file: %v", err)
+	}
+
+	srv := New(&conf, nil)
+	if l := srv.OperatorLevel("123"); l != 0 {
+		t.Errorf("expected level 0 for server that failed to be created, got %v", l)
+	}
+	if err := srv.Start(); err == nil {
+		t.Errorf("expected error starting server that failed to be created")
+	}
+}
@@ -0,0 +1,77 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+const (
+	// borderWallDistance is the maximum distance in blocks from a side of the world border at which the wall
+	// of particles marking it is shown to a player. Sides beyond the render distance of the player are never
+	// shown.
+	borderWallDistance = 32
+	// borderWallWidth and borderWallHeight are the distances in blocks from the player, along the side of the
+	// world border and vertically, over which the wall of particles is shown.
+	borderWallWidth, borderWallHeight = 8, 4
+	// borderWallSpacing is the distance in blocks between the particles that make up the wall.
+	borderWallSpacing = 2
+)
+
+// borderParticle is the particle used to show the wall along the world border.
+var borderParticle = particle.Effect{Name: "minecraft:blue_flame_particle"}
+
+// tickBorder enforces the border of the world passed on the player. Players outside a border that deals damage
+// are hurt every second, while players outside other borders, for example because it shrunk, are pushed back
+// inside it. Every second, the part of the border near the player is shown to it using particles.
+func (p *Player) tickBorder(w *world.World, current int64) {
+	border := w.Border()
+	if !border.Enabled() {
+		return
+	}
+	pos := p.Position()
+	if !border.Contains(pos) {
+		if border.Damage <= 0 {
+			pos = border.Clamp(pos)
+			p.teleport(pos)
+		} else if current%20 == 0 {
+			p.Hurt(border.Damage, damage.SourceWorldBorder{})
+		}
+	}
+	if current%20 == 0 {
+		p.showBorder(border, pos)
+	}
+}
+
+// showBorder shows a wall of particles along the sides of the world.Border passed that are close to the
+// position passed, so that the player can see where the border is.
+func (p *Player) showBorder(border world.Border, pos mgl64.Vec3) {
+	maxDist := math.Min(float64(p.ChunkRadius()*16), borderWallDistance)
+	x, z, r := float64(border.Center[0]), float64(border.Center[1]), border.Radius
+
+	// Every side is described by the axis that it is perpendicular to and its coordinate on that axis.
+	for _, side := range [...]struct {
+		axis  int
+		coord float64
+	}{{0, x - r}, {0, x + r}, {2, z - r}, {2, z + r}} {
+		if math.Abs(pos[side.axis]-side.coord) > maxDist {
+			continue
+		}
+		// The centre holds only the X and Z coordinates, so the axis along the side is halved to index it.
+		along := 2 - side.axis
+		min, max := float64(border.Center[along/2])-r, float64(border.Center[along/2])+r
+		for i := -borderWallWidth; i <= borderWallWidth; i += borderWallSpacing {
+			a := math.Round(pos[along]) + float64(i)
+			if a < min || a > max {
+				continue
+			}
+			for y := -borderWallHeight; y <= borderWallHeight; y += borderWallSpacing {
+				var particlePos mgl64.Vec3
+				particlePos[side.axis], particlePos[along], particlePos[1] = side.coord, a, math.Round(pos[1])+float64(y)
+				p.session().ViewParticle(particlePos, borderParticle)
+			}
+		}
+	}
+}
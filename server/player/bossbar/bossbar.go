@@ -3,44 +3,121 @@ package bossbar
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
-// BossBar represents a boss bar that may be sent to a player. It is shown as a purple bar with text above
-// it. The health shown by the bar may be changed.
+// BossBar represents a boss bar that may be sent to a player. It is shown as a coloured bar with text above
+// it. The text, health and colour of the bar may be changed after it is sent, in which case the change is
+// shown to all players that the bar was sent to.
+// A single BossBar may be sent to multiple players. Methods on BossBar may be called from multiple goroutines
+// concurrently.
 type BossBar struct {
-	text   string
-	health float64
+	mu      sync.Mutex
+	text    string
+	health  float64
+	colour  Colour
+	viewers map[Viewer]struct{}
+}
+
+// Viewer is a viewer of a BossBar, such as a player that the BossBar was sent to. Viewers are notified when
+// the BossBar changes, so that they may update the BossBar shown.
+type Viewer interface {
+	// ViewBossBarUpdate is called when the text, health or colour of a BossBar viewed changes.
+	ViewBossBarUpdate(bar *BossBar)
 }
 
 // New creates a new boss bar with the text passed. The text is formatted according to the rules of
 // fmt.Sprintln.
-// By default, the boss bar will have a full health bar. To change this, use BossBar.WithHealthPercentage().
-func New(text ...interface{}) BossBar {
-	return BossBar{text: format(text), health: 1}
+// By default, the boss bar will have a full, purple health bar. To change this, use
+// BossBar.SetHealthPercentage() and BossBar.SetColour().
+func New(text ...interface{}) *BossBar {
+	return &BossBar{text: format(text), health: 1, colour: Purple(), viewers: map[Viewer]struct{}{}}
 }
 
-// Text returns the text of the boss bar: The text passed when creating the bar using New().
-func (bar BossBar) Text() string {
+// Text returns the text of the boss bar: The text passed when creating the bar using New() or the text last
+// set using SetText().
+func (bar *BossBar) Text() string {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
 	return bar.text
 }
 
-// WithHealthPercentage sets the health percentage of the boss bar. The value passed must be between 0 and 1.
-// If a value out of that range is passed, WithHealthPercentage panics.
-// The new BossBar with the changed health percentage is returned.
-func (bar BossBar) WithHealthPercentage(v float64) BossBar {
+// SetText changes the text of the boss bar. The text is formatted according to the rules of fmt.Sprintln.
+func (bar *BossBar) SetText(text ...interface{}) {
+	bar.mu.Lock()
+	bar.text = format(text)
+	bar.mu.Unlock()
+	bar.update()
+}
+
+// SetHealthPercentage sets the health percentage of the boss bar. The value passed must be between 0 and 1.
+// If a value out of that range is passed, SetHealthPercentage panics.
+func (bar *BossBar) SetHealthPercentage(v float64) {
 	if v < 0 || v > 1 {
 		panic("boss bar: value out of range: health percentage must be between 0.0 and 1.0")
 	}
+	bar.mu.Lock()
 	bar.health = v
-	return bar
+	bar.mu.Unlock()
+	bar.update()
 }
 
 // HealthPercentage returns the health percentage of the boss bar. The number returned is a value between 0
 // and 1, with 0 being an empty boss bar and 1 being a full one.
-func (bar BossBar) HealthPercentage() float64 {
+func (bar *BossBar) HealthPercentage() float64 {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
 	return bar.health
 }
 
+// SetColour changes the colour of the boss bar.
+func (bar *BossBar) SetColour(c Colour) {
+	bar.mu.Lock()
+	bar.colour = c
+	bar.mu.Unlock()
+	bar.update()
+}
+
+// Colour returns the colour of the boss bar.
+func (bar *BossBar) Colour() Colour {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	return bar.colour
+}
+
+// AddViewer adds a Viewer to the BossBar, so that it is notified when the BossBar changes. AddViewer is
+// called automatically when the BossBar is sent to a player.
+func (bar *BossBar) AddViewer(v Viewer) {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	if bar.viewers == nil {
+		bar.viewers = map[Viewer]struct{}{}
+	}
+	bar.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a Viewer from the BossBar, so that it is no longer notified of changes.
+func (bar *BossBar) RemoveViewer(v Viewer) {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	delete(bar.viewers, v)
+}
+
+// update notifies all viewers of the BossBar that it changed. The mutex of the BossBar must not be held when
+// update is called.
+func (bar *BossBar) update() {
+	bar.mu.Lock()
+	viewers := make([]Viewer, 0, len(bar.viewers))
+	for v := range bar.viewers {
+		viewers = append(viewers, v)
+	}
+	bar.mu.Unlock()
+
+	for _, v := range viewers {
+		v.ViewBossBarUpdate(bar)
+	}
+}
+
 // format is a utility function to format a list of values to have spaces between them, but no newline at the
 // end, which is typically used for sending messages, popups and tips.
 func format(a []interface{}) string {
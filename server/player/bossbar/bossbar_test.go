@@ -0,0 +1,26 @@
+package bossbar
+
+import "testing"
+
+type countViewer struct{ n int }
+
+func (c *countViewer) ViewBossBarUpdate(*BossBar) { c.n++ }
+
+func TestBossBarViewers(t *testing.T) {
+	bar := New("test")
+	a, b := &countViewer{}, &countViewer{}
+	bar.AddViewer(a)
+	bar.AddViewer(b)
+
+	bar.SetText("changed")
+	bar.SetHealthPercentage(0.5)
+	bar.RemoveViewer(b)
+	bar.SetColour(Red())
+
+	if a.n != 3 || b.n != 2 {
+		t.Fatalf("expected 3 and 2 updates, got %v and %v", a.n, b.n)
+	}
+	if bar.Text() != "changed" || bar.HealthPercentage() != 0.5 || bar.Colour() != Red() {
+		t.Fatalf("unexpected boss bar state %v, %v, %v", bar.Text(), bar.HealthPercentage(), bar.Colour())
+	}
+}
@@ -0,0 +1,48 @@
+package bossbar
+
+// Colour represents the colour of a boss bar.
+type Colour struct {
+	colour
+}
+
+// Pink returns the pink boss bar colour.
+func Pink() Colour {
+	return Colour{colour(0)}
+}
+
+// Blue returns the blue boss bar colour.
+func Blue() Colour {
+	return Colour{colour(1)}
+}
+
+// Red returns the red boss bar colour.
+func Red() Colour {
+	return Colour{colour(2)}
+}
+
+// Green returns the green boss bar colour.
+func Green() Colour {
+	return Colour{colour(3)}
+}
+
+// Yellow returns the yellow boss bar colour.
+func Yellow() Colour {
+	return Colour{colour(4)}
+}
+
+// Purple returns the purple boss bar colour. It is the colour of boss bars by default.
+func Purple() Colour {
+	return Colour{colour(5)}
+}
+
+// White returns the white boss bar colour.
+func White() Colour {
+	return Colour{colour(6)}
+}
+
+type colour uint8
+
+// Uint8 returns the colour as a uint8.
+func (c colour) Uint8() uint8 {
+	return uint8(c)
+}
@@ -12,6 +12,9 @@ import (
 type Data struct {
 	// UUID is the player's unique identifier for their account
 	UUID uuid.UUID
+	// XUID is the XBOX Live user ID of the player, or the fallback returned by OfflineXUID if the player was
+	// not authenticated. The data of a player is keyed by its XUID.
+	XUID string
 	// Username is the last username the player joined with.
 	Username string
 	// Position is the last position the player was located at.
@@ -33,10 +36,9 @@ type Data struct {
 	ExhaustionLevel, SaturationLevel float64
 	// XPLevel is the current xp level the player has, XPTotal is the total amount of xp the
 	// player has collected during their lifetime, which is used to display score upon player death.
-	// These are currently not implemented in DF.
+	// XPTotal is currently not implemented in DF.
 	XPLevel, XPTotal int
 	// XPPercentage is the player's current progress towards the next level.
-	// This is currently not implemented in DF.
 	XPPercentage float64
 	// XPSeed is the random seed used to determine the next enchantment in enchantment tables.
 	// This is currently not implemented in DF.
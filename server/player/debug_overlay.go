@@ -0,0 +1,172 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// debugOverlayInterval is the interval in ticks at which the elements of a DebugOverlay are shown again,
+	// so that they remain visible after their particles disappear.
+	debugOverlayInterval = 20
+	// maxDebugOverlayParticles is the maximum amount of particles that a DebugOverlay shows to a player in a
+	// single tick. Elements beyond this amount are shown in the ticks after.
+	maxDebugOverlayParticles = 64
+	// debugOverlayEdgeSpacing is the distance in blocks between the particles that make up the edges of a box
+	// shown by a DebugOverlay.
+	debugOverlayEdgeSpacing = 0.5
+)
+
+var (
+	// debugPositionParticle is the particle used to show positions in a DebugOverlay.
+	debugPositionParticle = particle.Effect{Name: "minecraft:villager_happy"}
+	// debugBoxParticle is the particle used to show the edges of boxes in a DebugOverlay.
+	debugBoxParticle = particle.Effect{Name: "minecraft:basic_flame_particle"}
+)
+
+// DebugOverlay visualises server-side data, such as positions with a low light level or the areas of protected
+// regions, to a single Player using particles. The data is grouped in named layers, which may be shown and
+// hidden independently. The particles are refreshed every second while a layer is shown, and the amount of
+// particles shown per tick is limited so that large layers do not overload the client.
+// DebugOverlay is intended for debugging and is only visible to the Player that it belongs to. Its layers are
+// removed when the Player disconnects. Methods on DebugOverlay may be called from multiple goroutines.
+type DebugOverlay struct {
+	mu     sync.Mutex
+	layers map[string][]debugElement
+	// pending holds the elements that are still to be shown in the current refresh of the overlay.
+	pending []debugElement
+	ticks   int
+}
+
+// debugElement is a single particle shown by a DebugOverlay as part of a layer.
+type debugElement struct {
+	layer string
+	pos   mgl64.Vec3
+	p     particle.Effect
+}
+
+// newDebugOverlay returns a new DebugOverlay without any layers.
+func newDebugOverlay() *DebugOverlay {
+	return &DebugOverlay{layers: map[string][]debugElement{}}
+}
+
+// Show shows the block positions passed in the layer with the name passed, replacing any data that was
+// previously shown in the layer. A particle is shown in the centre of every position.
+func (o *DebugOverlay) Show(layer string, positions []cube.Pos) {
+	elements := make([]debugElement, 0, len(positions))
+	for _, pos := range positions {
+		elements = append(elements, debugElement{layer: layer, pos: pos.Vec3Centre(), p: debugPositionParticle})
+	}
+	o.set(layer, elements)
+}
+
+// ShowBoxes shows the edges of the boxes passed in the layer with the name passed, replacing any data that was
+// previously shown in the layer.
+func (o *DebugOverlay) ShowBoxes(layer string, boxes []physics.AABB) {
+	var elements []debugElement
+	for _, box := range boxes {
+		min, max := box.Min(), box.Max()
+		corners := [8]mgl64.Vec3{}
+		for i := range corners {
+			for axis := 0; axis < 3; axis++ {
+				if i&(1<<axis) == 0 {
+					corners[i][axis] = min[axis]
+				} else {
+					corners[i][axis] = max[axis]
+				}
+			}
+		}
+		for i := range corners {
+			for axis := 0; axis < 3; axis++ {
+				// Every edge runs from a corner with the minimum value on an axis to one with the maximum.
+				if i&(1<<axis) != 0 {
+					continue
+				}
+				from, to := corners[i], corners[i|1<<axis]
+				steps := int(math.Max(math.Ceil(to.Sub(from).Len()/debugOverlayEdgeSpacing), 1))
+				for step := 0; step <= steps; step++ {
+					pos := from.Add(to.Sub(from).Mul(float64(step) / float64(steps)))
+					elements = append(elements, debugElement{layer: layer, pos: pos, p: debugBoxParticle})
+				}
+			}
+		}
+	}
+	o.set(layer, elements)
+}
+
+// Hide stops showing the layer with the name passed. Nothing happens if no layer with the name is shown.
+func (o *DebugOverlay) Hide(layer string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.layers, layer)
+	o.removePending(layer)
+}
+
+// Layers returns the names of all layers currently shown, sorted alphabetically.
+func (o *DebugOverlay) Layers() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	names := make([]string, 0, len(o.layers))
+	for name := range o.layers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Clear stops showing all layers of the DebugOverlay.
+func (o *DebugOverlay) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.layers = map[string][]debugElement{}
+	o.pending = nil
+}
+
+// set sets the elements shown in a layer. The layer is shown immediately rather than at the next refresh.
+func (o *DebugOverlay) set(layer string, elements []debugElement) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.layers[layer] = elements
+	o.removePending(layer)
+	o.pending = append(o.pending, elements...)
+}
+
+// removePending removes all pending elements of the layer passed. It must be called while holding o.mu.
+func (o *DebugOverlay) removePending(layer string) {
+	pending := o.pending[:0]
+	for _, e := range o.pending {
+		if e.layer != layer {
+			pending = append(pending, e)
+		}
+	}
+	o.pending = pending
+}
+
+// tick shows the next particles of the DebugOverlay to the Player passed, refreshing the overlay every
+// debugOverlayInterval ticks if all elements of the previous refresh were shown.
+func (o *DebugOverlay) tick(p *Player) {
+	o.mu.Lock()
+	if o.ticks++; o.ticks >= debugOverlayInterval && len(o.pending) == 0 {
+		o.ticks = 0
+		for _, elements := range o.layers {
+			o.pending = append(o.pending, elements...)
+		}
+	}
+	n := len(o.pending)
+	if n > maxDebugOverlayParticles {
+		n = maxDebugOverlayParticles
+	}
+	shown := make([]debugElement, n)
+	copy(shown, o.pending)
+	o.pending = o.pending[n:]
+	o.mu.Unlock()
+
+	for _, e := range shown {
+		p.session().ViewParticle(e.pos, e.p)
+	}
+}
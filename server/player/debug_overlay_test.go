@@ -0,0 +1,30 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/go-gl/mathgl/mgl64"
+	"testing"
+)
+
+func TestDebugOverlayLayers(t *testing.T) {
+	o := newDebugOverlay()
+	o.Show("light", []cube.Pos{{0, 0, 0}, {1, 0, 0}})
+	// A 1x1x1 box has 12 edges of 1 block, each shown using 3 particles.
+	o.ShowBoxes("region", []physics.AABB{physics.NewAABB(mgl64.Vec3{}, mgl64.Vec3{1, 1, 1})})
+	if n := len(o.pending); n != 2+12*3 {
+		t.Fatalf("expected %v pending particles, got %v", 2+12*3, n)
+	}
+	if layers := o.Layers(); len(layers) != 2 || layers[0] != "light" || layers[1] != "region" {
+		t.Fatalf("expected layers light and region, got %v", layers)
+	}
+
+	o.Hide("region")
+	if n := len(o.pending); n != 2 {
+		t.Fatalf("expected 2 pending particles after hiding layer, got %v", n)
+	}
+	o.Clear()
+	if len(o.pending) != 0 || len(o.Layers()) != 0 {
+		t.Fatalf("expected no layers or particles after clearing overlay")
+	}
+}
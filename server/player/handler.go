@@ -9,8 +9,10 @@ import (
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 	"net"
 )
 
@@ -22,9 +24,21 @@ type Handler interface {
 	HandleMove(ctx *event.Context, newPos mgl64.Vec3, newYaw, newPitch float64)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
 	HandleTeleport(ctx *event.Context, pos mgl64.Vec3)
-	// HandleToggleSneak handles when the player starts or stops sneaking.
+	// HandleChangeWorld handles the player moving from one world to another using Player.ChangeWorld.
+	// ctx.Cancel() may be called to keep the player in the world it is in.
+	HandleChangeWorld(ctx *event.Context, before, after *world.World)
+	// HandleToggleSneak handles when the player starts or stops sneaking. ctx.Cancel() may be called to keep
+	// the player in the sneaking state it was in.
 	// After is true if the player is sneaking after toggling (changing their sneaking state).
 	HandleToggleSneak(ctx *event.Context, after bool)
+	// HandleToggleSprint handles when the player starts or stops sprinting. ctx.Cancel() may be called to keep
+	// the player in the sprinting state it was in.
+	// After is true if the player is sprinting after toggling (changing their sprinting state).
+	HandleToggleSprint(ctx *event.Context, after bool)
+	// HandleToggleSwim handles when the player starts or stops swimming. ctx.Cancel() may be called to keep
+	// the player in the swimming state it was in.
+	// After is true if the player is swimming after toggling (changing their swimming state).
+	HandleToggleSwim(ctx *event.Context, after bool)
 	// HandleChat handles a message sent in the chat by a player. ctx.Cancel() may be called to cancel the
 	// message being sent in chat.
 	// The message may be changed by assigning to *message.
@@ -38,13 +52,15 @@ type Handler interface {
 	HandleHeal(ctx *event.Context, health *float64, src healing.Source)
 	// HandleHurt handles the player being hurt by any damage source. ctx.Cancel() may be called to cancel the
 	// damage being dealt to the player.
-	// The damage dealt to the player may be changed by assigning to *damage.
-	HandleHurt(ctx *event.Context, damage *float64, src damage.Source)
-	// HandleDeath handles the player dying to a particular damage cause.
-	HandleDeath(src damage.Source)
+	// The damage dealt to the player is broken down into the stages of *dmg, which may each be changed. The
+	// damage finally dealt is calculated using dmg.Apply() after all handlers were called.
+	HandleHurt(ctx *event.Context, dmg *damage.Modifiers, src damage.Source)
+	// HandleDeath handles the player dying to a particular damage cause. The death of a player cannot be
+	// cancelled.
+	HandleDeath(ctx *event.Context, src damage.Source)
 	// HandleRespawn handles the respawning of the player in the world. The spawn position passed may be
-	// changed by assigning to *pos.
-	HandleRespawn(pos *mgl64.Vec3)
+	// changed by assigning to *pos. The respawning of a player cannot be cancelled.
+	HandleRespawn(ctx *event.Context, pos *mgl64.Vec3)
 	// HandleSkinChange handles the player changing their skin. ctx.Cancel() may be called to cancel the skin
 	// change.
 	HandleSkinChange(ctx *event.Context, skin skin.Skin)
@@ -86,6 +102,9 @@ type Handler interface {
 	HandleAttackEntity(ctx *event.Context, e world.Entity, force, height *float64)
 	// HandlePunchAir handles the player punching air.
 	HandlePunchAir(ctx *event.Context)
+	// HandleEmote handles the player performing an emote, either by itself or through a call to
+	// Player.Emote. ctx.Cancel() may be called to prevent the emote from being shown to other players.
+	HandleEmote(ctx *event.Context, emote uuid.UUID)
 	// HandleSignEdit handles the player editing a sign. It is called for every keystroke while editing a sign and
 	// has both the old text passed and the text after the edit. This typically only has a change of one character.
 	HandleSignEdit(ctx *event.Context, oldText, newText string)
@@ -107,9 +126,13 @@ type Handler interface {
 	// HandleCommandExecution handles the command execution of a player, who wrote a command in the chat.
 	// ctx.Cancel() may be called to cancel the command execution.
 	HandleCommandExecution(ctx *event.Context, command cmd.Command, args []string)
+	// HandleInputModeChange handles the player switching input modes, for example from touch to a controller.
+	// The client has already switched when the event is called, so it cannot be cancelled.
+	HandleInputModeChange(ctx *event.Context, before, after session.InputMode)
 	// HandleQuit handles the closing of a player. It is always called when the player is disconnected,
-	// regardless of the reason.
-	HandleQuit()
+	// regardless of the reason, and cannot be cancelled. The reason that the player was disconnected for is
+	// passed, such as the player leaving, timing out or being kicked.
+	HandleQuit(ctx *event.Context, reason session.DisconnectReason)
 }
 
 // NopHandler implements the Handler interface but does not execute any code when an event is called. The
@@ -129,9 +152,18 @@ func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64) {}
 // HandleTeleport ...
 func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3) {}
 
+// HandleChangeWorld ...
+func (NopHandler) HandleChangeWorld(*event.Context, *world.World, *world.World) {}
+
 // HandleToggleSneak ...
 func (NopHandler) HandleToggleSneak(*event.Context, bool) {}
 
+// HandleToggleSprint ...
+func (NopHandler) HandleToggleSprint(*event.Context, bool) {}
+
+// HandleToggleSwim ...
+func (NopHandler) HandleToggleSwim(*event.Context, bool) {}
+
 // HandleCommandExecution ...
 func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string) {}
 
@@ -180,8 +212,11 @@ func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *fl
 // HandlePunchAir ...
 func (NopHandler) HandlePunchAir(*event.Context) {}
 
+// HandleEmote ...
+func (NopHandler) HandleEmote(*event.Context, uuid.UUID) {}
+
 // HandleHurt ...
-func (NopHandler) HandleHurt(*event.Context, *float64, damage.Source) {}
+func (NopHandler) HandleHurt(*event.Context, *damage.Modifiers, damage.Source) {}
 
 // HandleHeal ...
 func (NopHandler) HandleHeal(*event.Context, *float64, healing.Source) {}
@@ -190,10 +225,238 @@ func (NopHandler) HandleHeal(*event.Context, *float64, healing.Source) {}
 func (NopHandler) HandleFoodLoss(*event.Context, int, int) {}
 
 // HandleDeath ...
-func (NopHandler) HandleDeath(damage.Source) {}
+func (NopHandler) HandleDeath(*event.Context, damage.Source) {}
 
 // HandleRespawn ...
-func (NopHandler) HandleRespawn(*mgl64.Vec3) {}
+func (NopHandler) HandleRespawn(*event.Context, *mgl64.Vec3) {}
+
+// HandleInputModeChange ...
+func (NopHandler) HandleInputModeChange(*event.Context, session.InputMode, session.InputMode) {}
 
 // HandleQuit ...
-func (NopHandler) HandleQuit() {}
+func (NopHandler) HandleQuit(*event.Context, session.DisconnectReason) {}
+
+// handlerList is a list of handlers ordered by priority. It implements Handler by calling the respective
+// method of every handler in the list with the same event.Context, so that a cancellation by one handler is
+// visible to the handlers called after it.
+type handlerList []Handler
+
+// Compile time check to make sure handlerList implements Handler.
+var _ Handler = handlerList(nil)
+
+// HandleMove ...
+func (l handlerList) HandleMove(ctx *event.Context, newPos mgl64.Vec3, newYaw, newPitch float64) {
+	for _, h := range l {
+		h.HandleMove(ctx, newPos, newYaw, newPitch)
+	}
+}
+
+// HandleTeleport ...
+func (l handlerList) HandleTeleport(ctx *event.Context, pos mgl64.Vec3) {
+	for _, h := range l {
+		h.HandleTeleport(ctx, pos)
+	}
+}
+
+// HandleChangeWorld ...
+func (l handlerList) HandleChangeWorld(ctx *event.Context, before, after *world.World) {
+	for _, h := range l {
+		h.HandleChangeWorld(ctx, before, after)
+	}
+}
+
+// HandleToggleSneak ...
+func (l handlerList) HandleToggleSneak(ctx *event.Context, after bool) {
+	for _, h := range l {
+		h.HandleToggleSneak(ctx, after)
+	}
+}
+
+// HandleToggleSprint ...
+func (l handlerList) HandleToggleSprint(ctx *event.Context, after bool) {
+	for _, h := range l {
+		h.HandleToggleSprint(ctx, after)
+	}
+}
+
+// HandleToggleSwim ...
+func (l handlerList) HandleToggleSwim(ctx *event.Context, after bool) {
+	for _, h := range l {
+		h.HandleToggleSwim(ctx, after)
+	}
+}
+
+// HandleChat ...
+func (l handlerList) HandleChat(ctx *event.Context, message *string) {
+	for _, h := range l {
+		h.HandleChat(ctx, message)
+	}
+}
+
+// HandleFoodLoss ...
+func (l handlerList) HandleFoodLoss(ctx *event.Context, from, to int) {
+	for _, h := range l {
+		h.HandleFoodLoss(ctx, from, to)
+	}
+}
+
+// HandleHeal ...
+func (l handlerList) HandleHeal(ctx *event.Context, health *float64, src healing.Source) {
+	for _, h := range l {
+		h.HandleHeal(ctx, health, src)
+	}
+}
+
+// HandleHurt ...
+func (l handlerList) HandleHurt(ctx *event.Context, dmg *damage.Modifiers, src damage.Source) {
+	for _, h := range l {
+		h.HandleHurt(ctx, dmg, src)
+	}
+}
+
+// HandleDeath ...
+func (l handlerList) HandleDeath(ctx *event.Context, src damage.Source) {
+	for _, h := range l {
+		h.HandleDeath(ctx, src)
+	}
+}
+
+// HandleRespawn ...
+func (l handlerList) HandleRespawn(ctx *event.Context, pos *mgl64.Vec3) {
+	for _, h := range l {
+		h.HandleRespawn(ctx, pos)
+	}
+}
+
+// HandleSkinChange ...
+func (l handlerList) HandleSkinChange(ctx *event.Context, skin skin.Skin) {
+	for _, h := range l {
+		h.HandleSkinChange(ctx, skin)
+	}
+}
+
+// HandleStartBreak ...
+func (l handlerList) HandleStartBreak(ctx *event.Context, pos cube.Pos) {
+	for _, h := range l {
+		h.HandleStartBreak(ctx, pos)
+	}
+}
+
+// HandleBlockBreak ...
+func (l handlerList) HandleBlockBreak(ctx *event.Context, pos cube.Pos) {
+	for _, h := range l {
+		h.HandleBlockBreak(ctx, pos)
+	}
+}
+
+// HandleBlockPlace ...
+func (l handlerList) HandleBlockPlace(ctx *event.Context, pos cube.Pos, b world.Block) {
+	for _, h := range l {
+		h.HandleBlockPlace(ctx, pos, b)
+	}
+}
+
+// HandleBlockPick ...
+func (l handlerList) HandleBlockPick(ctx *event.Context, pos cube.Pos, b world.Block) {
+	for _, h := range l {
+		h.HandleBlockPick(ctx, pos, b)
+	}
+}
+
+// HandleItemUse ...
+func (l handlerList) HandleItemUse(ctx *event.Context) {
+	for _, h := range l {
+		h.HandleItemUse(ctx)
+	}
+}
+
+// HandleItemUseOnBlock ...
+func (l handlerList) HandleItemUseOnBlock(ctx *event.Context, pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) {
+	for _, h := range l {
+		h.HandleItemUseOnBlock(ctx, pos, face, clickPos)
+	}
+}
+
+// HandleItemUseOnEntity ...
+func (l handlerList) HandleItemUseOnEntity(ctx *event.Context, e world.Entity) {
+	for _, h := range l {
+		h.HandleItemUseOnEntity(ctx, e)
+	}
+}
+
+// HandleAttackEntity ...
+func (l handlerList) HandleAttackEntity(ctx *event.Context, e world.Entity, force, height *float64) {
+	for _, h := range l {
+		h.HandleAttackEntity(ctx, e, force, height)
+	}
+}
+
+// HandlePunchAir ...
+func (l handlerList) HandlePunchAir(ctx *event.Context) {
+	for _, h := range l {
+		h.HandlePunchAir(ctx)
+	}
+}
+
+// HandleEmote ...
+func (l handlerList) HandleEmote(ctx *event.Context, emote uuid.UUID) {
+	for _, h := range l {
+		h.HandleEmote(ctx, emote)
+	}
+}
+
+// HandleSignEdit ...
+func (l handlerList) HandleSignEdit(ctx *event.Context, oldText, newText string) {
+	for _, h := range l {
+		h.HandleSignEdit(ctx, oldText, newText)
+	}
+}
+
+// HandleItemDamage ...
+func (l handlerList) HandleItemDamage(ctx *event.Context, i item.Stack, damage int) {
+	for _, h := range l {
+		h.HandleItemDamage(ctx, i, damage)
+	}
+}
+
+// HandleItemPickup ...
+func (l handlerList) HandleItemPickup(ctx *event.Context, i item.Stack) {
+	for _, h := range l {
+		h.HandleItemPickup(ctx, i)
+	}
+}
+
+// HandleItemDrop ...
+func (l handlerList) HandleItemDrop(ctx *event.Context, e *entity.Item) {
+	for _, h := range l {
+		h.HandleItemDrop(ctx, e)
+	}
+}
+
+// HandleTransfer ...
+func (l handlerList) HandleTransfer(ctx *event.Context, addr *net.UDPAddr) {
+	for _, h := range l {
+		h.HandleTransfer(ctx, addr)
+	}
+}
+
+// HandleCommandExecution ...
+func (l handlerList) HandleCommandExecution(ctx *event.Context, command cmd.Command, args []string) {
+	for _, h := range l {
+		h.HandleCommandExecution(ctx, command, args)
+	}
+}
+
+// HandleInputModeChange ...
+func (l handlerList) HandleInputModeChange(ctx *event.Context, before, after session.InputMode) {
+	for _, h := range l {
+		h.HandleInputModeChange(ctx, before, after)
+	}
+}
+
+// HandleQuit ...
+func (l handlerList) HandleQuit(ctx *event.Context, reason session.DisconnectReason) {
+	for _, h := range l {
+		h.HandleQuit(ctx, reason)
+	}
+}
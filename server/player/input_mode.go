@@ -0,0 +1,46 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/session"
+)
+
+// defaultTouchTolerance is the default interaction tolerance of players using a touch screen. Tapping a block
+// or entity is less precise than clicking it, so touch players are allowed to interact slightly further away.
+const defaultTouchTolerance = 1.0
+
+// InputMode returns the session.InputMode that the Player currently uses, such as session.InputModeTouch. It
+// is updated when the Player switches input modes. session.InputModeUnknown is returned for players without
+// a session.
+func (p *Player) InputMode() session.InputMode {
+	return session.InputMode(p.inputMode.Load())
+}
+
+// UpdateInputMode updates the session.InputMode of the Player. It is called by the session of the Player when
+// the client switches input modes, and calls Handler.HandleInputModeChange if the input mode changed.
+func (p *Player) UpdateInputMode(mode session.InputMode) {
+	before := session.InputMode(p.inputMode.Swap(uint32(mode)))
+	if before == mode {
+		return
+	}
+	p.handler().HandleInputModeChange(event.C(), before, mode)
+}
+
+// SetInteractionTolerance sets the extra distance in blocks that the Player may be away from blocks and
+// entities it interacts with while it uses the session.InputMode passed, on top of the normal reach of the
+// Player. By default, players using a touch screen have a tolerance of 1 block and other input modes have no
+// tolerance. Negative tolerances reduce the reach of the Player.
+func (p *Player) SetInteractionTolerance(mode session.InputMode, tolerance float64) {
+	if int(mode) < len(p.tolerances) {
+		p.tolerances[mode].Store(tolerance)
+	}
+}
+
+// InteractionTolerance returns the interaction tolerance of the Player for the session.InputMode passed, as
+// set using SetInteractionTolerance.
+func (p *Player) InteractionTolerance(mode session.InputMode) float64 {
+	if int(mode) < len(p.tolerances) {
+		return p.tolerances[mode].Load()
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"strings"
+)
+
+// Clicker is an entity that is able to have a Menu opened and click the items in it, such as a player.
+type Clicker interface {
+	// OpenMenu opens a Menu for the Clicker. Any menu or container the Clicker has opened is closed.
+	OpenMenu(m Menu)
+	// CloseMenu closes the Menu that the Clicker currently has opened, if any.
+	CloseMenu()
+}
+
+// Menu represents an inventory menu that may be opened for a player. It is shown to the player as a chest
+// holding items that cannot be taken out of it. Instead, clicking an item in the menu calls the function set
+// for its slot.
+// Menus are opened client-side only: The chest shown to the player does not exist in the world.
+type Menu struct {
+	title string
+	size  int
+
+	items   map[int]item.Stack
+	clicks  map[int]func(c Clicker)
+	onClose func(c Clicker)
+}
+
+// Sizes of menus that may be passed to New. A Small menu is shown as a single chest, whereas a Large menu is
+// shown as a double chest.
+const (
+	Small = 27
+	Large = 54
+)
+
+// New creates a new Menu with the size passed, which must be either Small or Large. The title is formatted
+// following the rules of fmt.Sprintln, but without the newline at the end, and is displayed at the top of
+// the menu. If any other size than Small or Large is passed, New panics.
+func New(size int, title ...interface{}) Menu {
+	if size != Small && size != Large {
+		panic(fmt.Sprintf("menu: invalid size %v: must be either menu.Small or menu.Large", size))
+	}
+	return Menu{title: format(title), size: size}
+}
+
+// Title returns the title of the Menu as passed to New.
+func (m Menu) Title() string {
+	return m.title
+}
+
+// Size returns the amount of slots in the Menu. It is either Small or Large.
+func (m Menu) Size() int {
+	return m.size
+}
+
+// WithItem sets the item.Stack displayed in a slot of the Menu. If f is not nil, it is called when a Clicker
+// clicks the item. WithItem panics if the slot is out of range of the size of the Menu.
+// The new Menu with the item set is returned.
+func (m Menu) WithItem(slot int, it item.Stack, f func(c Clicker)) Menu {
+	if slot < 0 || slot >= m.size {
+		panic(fmt.Sprintf("menu: slot %v out of range for menu with size %v", slot, m.size))
+	}
+	items, clicks := make(map[int]item.Stack, len(m.items)+1), make(map[int]func(c Clicker), len(m.clicks)+1)
+	for k, v := range m.items {
+		items[k] = v
+	}
+	for k, v := range m.clicks {
+		clicks[k] = v
+	}
+	items[slot] = it
+	if f != nil {
+		clicks[slot] = f
+	} else {
+		delete(clicks, slot)
+	}
+	m.items, m.clicks = items, clicks
+	return m
+}
+
+// WithCloseFunc sets a function that is called when the Menu is closed, either by the Clicker closing it
+// or by another menu or container being opened. The new Menu is returned.
+func (m Menu) WithCloseFunc(f func(c Clicker)) Menu {
+	m.onClose = f
+	return m
+}
+
+// Items returns all items in the Menu, indexed by their slot. Slots without an item are not present in the
+// map returned.
+func (m Menu) Items() map[int]item.Stack {
+	items := make(map[int]item.Stack, len(m.items))
+	for k, v := range m.items {
+		items[k] = v
+	}
+	return items
+}
+
+// Click clicks the item in the slot passed on behalf of the Clicker passed, calling the function set for the
+// slot using WithItem. If no function was set, Click does nothing.
+func (m Menu) Click(c Clicker, slot int) {
+	if f, ok := m.clicks[slot]; ok {
+		f(c)
+	}
+}
+
+// Close calls the function set using WithCloseFunc, if any, on behalf of the Clicker passed.
+func (m Menu) Close(c Clicker) {
+	if m.onClose != nil {
+		m.onClose(c)
+	}
+}
+
+// format is a utility function to format a list of values to have spaces between them, but no newline at the
+// end.
+func format(a []interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+}
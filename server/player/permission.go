@@ -0,0 +1,89 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/session"
+)
+
+// MaxOperatorLevel is the highest operator level that a Player may have. Players with operator level 0 are not
+// an operator.
+const MaxOperatorLevel = 4
+
+// PermissionChecker checks if a Player has a permission. Permissions are identified by a node, such as
+// 'dragonfly.command.time'. Commands and other systems that should only be used by some players call
+// Player.HasPermission, which consults the PermissionChecker of the Player. A PermissionChecker may be
+// implemented to back permissions with a database, for example.
+type PermissionChecker interface {
+	// HasPermission checks if the Player passed has the permission with the node passed.
+	HasPermission(p *Player, node string) bool
+}
+
+// OperatorChecker is the default PermissionChecker. It grants permissions to players based on their operator
+// level, as set using Player.SetOperator.
+type OperatorChecker struct {
+	// Levels holds the operator level that a Player must have at least to have the permission with a
+	// specific node.
+	Levels map[string]int
+	// DefaultLevel is the operator level that a Player must have at least to have permissions with a node
+	// not found in Levels.
+	DefaultLevel int
+}
+
+// DefaultPermissionChecker is the PermissionChecker used by players that do not have a PermissionChecker set
+// using Player.SetPermissionChecker. It grants all permissions to operators of any level.
+var DefaultPermissionChecker PermissionChecker = OperatorChecker{DefaultLevel: 1}
+
+// HasPermission ...
+func (c OperatorChecker) HasPermission(p *Player, node string) bool {
+	level, ok := c.Levels[node]
+	if !ok {
+		level = c.DefaultLevel
+	}
+	return p.OperatorLevel() >= level
+}
+
+// SetOperator sets the operator level of the Player. A level of 0 means the Player is not an operator,
+// while higher levels grant more permissions if the OperatorChecker is used. The level is clamped between 0 and
+// MaxOperatorLevel. Operators are shown the operator UI of the client, such as commands that require a
+// higher permission level.
+// SetOperator does not save the level: The server saves the operator level of players to its operators
+// file when they leave. Server.SetOperator may be used to change the level of players that are offline.
+func (p *Player) SetOperator(level int) {
+	if level < 0 {
+		level = 0
+	} else if level > MaxOperatorLevel {
+		level = MaxOperatorLevel
+	}
+	if int(p.operatorLevel.Swap(int32(level))) == level {
+		return
+	}
+	if s := p.session(); s != session.Nop {
+		s.SendGameMode(p.GameMode())
+		s.SendAvailableCommands()
+	}
+}
+
+// OperatorLevel returns the operator level of the Player as set using SetOperator. 0 is returned if the Player
+// is not an operator.
+func (p *Player) OperatorLevel() int {
+	return int(p.operatorLevel.Load())
+}
+
+// SetPermissionChecker sets the PermissionChecker consulted by HasPermission. Passing nil restores the
+// DefaultPermissionChecker.
+func (p *Player) SetPermissionChecker(c PermissionChecker) {
+	p.permMu.Lock()
+	defer p.permMu.Unlock()
+	p.perms = c
+}
+
+// HasPermission checks if the Player has the permission with the node passed, such as
+// 'dragonfly.command.time', using the PermissionChecker of the Player.
+func (p *Player) HasPermission(node string) bool {
+	p.permMu.RLock()
+	c := p.perms
+	p.permMu.RUnlock()
+	if c == nil {
+		c = DefaultPermissionChecker
+	}
+	return c.HasPermission(p, node)
+}
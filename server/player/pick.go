@@ -0,0 +1,53 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// blockEntityKey is the key of the value that holds the encoded block entity data of items picked using
+// PickBlock with data. The data is stored as encoded NBT, so that it is saved along with the item.
+const blockEntityKey = "dragonfly:block_entity"
+
+// withBlockEntityData returns the item.Stack passed with the block entity data of the block passed, if the
+// block has a block entity. The item is given the '(+DATA)' lore, like in vanilla.
+func withBlockEntityData(s item.Stack, b world.Block) (item.Stack, bool) {
+	n, ok := b.(world.NBTer)
+	if !ok {
+		return s, false
+	}
+	data, err := nbt.Marshal(n.EncodeNBT())
+	if err != nil {
+		return s, false
+	}
+	return s.WithValue(blockEntityKey, data).WithLore("(+DATA)"), true
+}
+
+// applyBlockEntityData applies the block entity data held by the item.Stack passed, as added by
+// withBlockEntityData, to the block passed. The block is returned unchanged if the item holds no data or if it
+// is not an item of the same block.
+func applyBlockEntityData(s item.Stack, b world.Block) world.Block {
+	v, ok := s.Value(blockEntityKey)
+	if !ok {
+		return b
+	}
+	data, _ := v.([]byte)
+	n, ok := b.(world.NBTer)
+	i, isItem := b.(world.Item)
+	if !ok || !isItem {
+		return b
+	}
+	name, _ := i.EncodeItem()
+	if heldName, _ := s.Item().EncodeItem(); heldName != name {
+		return b
+	}
+	var m map[string]interface{}
+	if err := nbt.Unmarshal(data, &m); err != nil {
+		return b
+	}
+	if decoded, ok := n.DecodeNBT(m).(world.Block); ok {
+		return decoded
+	}
+	return b
+}
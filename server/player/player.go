@@ -21,6 +21,7 @@ import (
 	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/menu"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/player/title"
@@ -30,11 +31,14 @@ import (
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"go.uber.org/atomic"
 	"golang.org/x/text/language"
+	"io"
 	"math"
 	"math/rand"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -48,9 +52,15 @@ type Player struct {
 	xuid                                string
 	locale                              language.Tag
 	pos, vel                            atomic.Value
-	nameTag                             atomic.String
+	nameTag, chatFormat                 atomic.String
 	yaw, pitch, absorptionHealth, scale atomic.Float64
 
+	chatMu   sync.RWMutex
+	chatFunc func(message string)
+
+	spawnMu  sync.RWMutex
+	spawnPos *mgl64.Vec3
+
 	gameModeMu sync.RWMutex
 	gameMode   world.GameMode
 
@@ -61,10 +71,12 @@ type Player struct {
 	// s holds the session of the player. This field should not be used directly, but instead,
 	// Player.session() should be called.
 	s *session.Session
+	// disconnectReason holds the session.DisconnectReason of the player once it is closed.
+	disconnectReason atomic.Value
 
-	hMutex sync.RWMutex
-	// h holds the current handler of the player. It may be changed at any time by calling the Start method.
-	h Handler
+	// handlers holds the handlers currently attached to the player. Handlers may be attached and detached at
+	// any time by calling the Attach and Detach methods.
+	handlers event.Handlers[Handler]
 
 	inv, offHand *inventory.Inventory
 	armour       *inventory.Armour
@@ -73,24 +85,49 @@ type Player struct {
 	sneaking, sprinting, swimming, flying,
 	invisible, immobile, onGround, usingItem atomic.Bool
 	usingSince atomic.Int64
+	// flightAllowed specifies if the player may fly regardless of its game mode. It is set using
+	// SetFlightAllowed.
+	flightAllowed atomic.Bool
+
+	// tickPos is the position of the player at the start of the last tick. movedHorizontally is true if the
+	// player moved horizontally between the starts of the last two ticks.
+	tickPos           atomic.Value
+	movedHorizontally atomic.Bool
 
 	fireTicks    atomic.Int64
 	fallDistance atomic.Float64
+	// airSupply is the amount of ticks that the player may stay under water before it starts drowning.
+	airSupply atomic.Int64
 
 	speed    atomic.Float64
 	health   *entity.HealthManager
 	effects  *entity.EffectManager
-	immunity atomic.Value
+	immunity *entity.ImmunityManager
 
 	mc *entity.MovementComputer
 
 	breaking          atomic.Bool
 	breakingPos       atomic.Value
 	lastBreakDuration time.Duration
+	// breakProgress is the progress of breaking the block at breakingPos, from 0 to 1, as of lastBreakUpdate.
+	breakProgress   float64
+	lastBreakUpdate time.Time
 
 	breakParticleCounter atomic.Uint32
 
-	hunger *hungerManager
+	hunger     *hungerManager
+	experience *entity.ExperienceManager
+
+	debug *DebugOverlay
+	music *world.MusicPlayer
+
+	inputMode atomic.Uint32
+	// tolerances holds the interaction tolerance of every session.InputMode, indexed by the input mode.
+	tolerances [session.InputModeMotionController + 1]atomic.Float64
+
+	operatorLevel atomic.Int32
+	permMu        sync.RWMutex
+	perms         PermissionChecker
 }
 
 // New returns a new initialised player. A random UUID is generated for the player, so that it may be
@@ -104,26 +141,36 @@ func New(name string, skin skin.Skin, pos mgl64.Vec3) *Player {
 				p.broadcastItems(slot, item)
 			}
 		}),
-		uuid:     uuid.New(),
-		offHand:  inventory.New(1, p.broadcastItems),
-		armour:   inventory.NewArmour(p.broadcastArmour),
-		hunger:   newHungerManager(),
-		health:   entity.NewHealthManager(),
-		effects:  entity.NewEffectManager(),
-		gameMode: world.GameModeAdventure{},
-		h:        NopHandler{},
-		name:     name,
-		skin:     skin,
-		speed:    *atomic.NewFloat64(0.1),
-		nameTag:  *atomic.NewString(name),
-		heldSlot: atomic.NewUint32(0),
-		locale:   language.BritishEnglish,
-		scale:    *atomic.NewFloat64(1),
+		uuid:       uuid.New(),
+		offHand:    inventory.New(1, p.broadcastItems),
+		armour:     inventory.NewArmour(p.broadcastArmour),
+		hunger:     newHungerManager(),
+		experience: entity.NewExperienceManager(),
+		health:     entity.NewHealthManager(),
+		immunity:   entity.NewImmunityManager(),
+		effects:    entity.NewEffectManager(),
+		gameMode:   world.GameModeAdventure{},
+		name:       name,
+		skin:       skin,
+		speed:      *atomic.NewFloat64(0.1),
+		nameTag:    *atomic.NewString(name),
+		chatFormat: *atomic.NewString(DefaultChatFormat),
+		heldSlot:   atomic.NewUint32(0),
+		locale:     language.BritishEnglish,
+		scale:      *atomic.NewFloat64(1),
+		debug:      newDebugOverlay(),
+		airSupply:  *atomic.NewInt64(maxAirSupply),
 	}
 	p.mc = &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true}
+	p.music = world.NewMusicPlayer(func(name string, volume, pitch float64) {
+		p.session().ViewMusic(name, volume, pitch)
+	}, func(name string) {
+		p.session().ViewSoundStop(name)
+	})
+	p.tolerances[session.InputModeTouch].Store(defaultTouchTolerance)
 	p.pos.Store(pos)
+	p.tickPos.Store(pos)
 	p.vel.Store(mgl64.Vec3{})
-	p.immunity.Store(time.Now())
 	p.breakingPos.Store(cube.Pos{})
 	return p
 }
@@ -138,10 +185,12 @@ func NewWithSession(name, xuid string, uuid uuid.UUID, skin skin.Skin, s *sessio
 	p.s, p.uuid, p.xuid, p.skin = s, uuid, xuid, skin
 	p.inv, p.offHand, p.armour, p.heldSlot = s.HandleInventories()
 	p.locale, _ = language.Parse(strings.Replace(s.ClientData().LanguageCode, "_", "-", 1))
+	p.inputMode.Store(uint32(s.JoinInputMode()))
 	chat.Global.Subscribe(p)
 	if data != nil {
 		p.load(*data)
 	}
+	p.sendExperience()
 	return p
 }
 
@@ -160,15 +209,32 @@ func (p *Player) UUID() uuid.UUID {
 }
 
 // XUID returns the XBOX Live user ID of the player. It will remain consistent with the XBOX Live account,
-// and will not change in the lifetime of an account.
-// The XUID is a number that can be parsed as an int64. No more information on what it represents is
-// available, and the UUID should be preferred.
-// The XUID returned is empty if the Player is not connected to a network session or if the Player is not
-// authenticated with XBOX Live.
+// and will not change in the lifetime of an account, even if the player changes its name. The XUID should
+// therefore be used to identify players, for example when storing data of a player.
+// The XUID of an authenticated player is a number that can be parsed as an int64. Players that are not
+// authenticated have an XUID returned by OfflineXUID instead, so that they may be identified the same way.
+// The XUID returned is empty if the Player is not connected to a network session.
 func (p *Player) XUID() string {
 	return p.xuid
 }
 
+// offlineXUIDPrefix is the prefix of XUIDs returned by OfflineXUID. It ensures that the XUIDs never collide
+// with those of XBOX Live accounts, which are always numeric.
+const offlineXUIDPrefix = "offline:"
+
+// OfflineXUID returns the XUID used for a player with the name passed that is not authenticated with XBOX
+// Live. The XUID is derived from the name, so that it is the same every time the player joins.
+func OfflineXUID(name string) string {
+	return offlineXUIDPrefix + uuid.NewMD5(uuid.NameSpaceOID, []byte("OfflinePlayer:"+name)).String()
+}
+
+// Authenticated checks if the player is authenticated with XBOX Live. Players that are not authenticated,
+// for example because authentication is disabled in the server config, may have joined using any name, and
+// have a UUID and XUID derived from that name.
+func (p *Player) Authenticated() bool {
+	return p.xuid != "" && !strings.HasPrefix(p.xuid, offlineXUIDPrefix)
+}
+
 // Addr returns the net.Addr of the Player. If the Player is not connected to a network session, nil is returned.
 func (p *Player) Addr() net.Addr {
 	if p.session() == session.Nop {
@@ -186,11 +252,15 @@ func (p *Player) Skin() skin.Skin {
 	return p.skin
 }
 
-// SetSkin changes the skin of the player. This skin will be visible to other players that the player
-// is shown to.
-func (p *Player) SetSkin(skin skin.Skin) {
+// SetSkin changes the skin of the player. The skin is changed immediately for the player itself and for all
+// other players, including players that join after the change. An error is returned if the skin does not pass
+// skin.Skin.Validate, as sending an invalid skin may crash clients.
+func (p *Player) SetSkin(skin skin.Skin) error {
+	if err := skin.Validate(); err != nil {
+		return err
+	}
 	if p.Dead() {
-		return
+		return nil
 	}
 
 	ctx := event.C()
@@ -200,6 +270,11 @@ func (p *Player) SetSkin(skin skin.Skin) {
 		p.skin = skin
 		p.skinMu.Unlock()
 
+		if s := p.session(); s != session.Nop {
+			// Players with a session are in the player list of all players, which must be updated too.
+			s.BroadcastSkin()
+			return
+		}
 		for _, v := range p.viewers() {
 			v.ViewSkin(p)
 		}
@@ -207,6 +282,13 @@ func (p *Player) SetSkin(skin skin.Skin) {
 	ctx.Stop(func() {
 		p.session().ViewSkin(p)
 	})
+	return nil
+}
+
+// Head returns a player head item carrying the skin that the player currently has. The name of the player is set as
+// the custom name of the item. Head may, for example, be used to drop the head of a player when it is killed.
+func (p *Player) Head() item.Stack {
+	return item.NewStack(block.PlayerHeadOf(p.Skin()), 1).WithCustomName(p.Name() + "'s Head")
 }
 
 // Locale returns the language and locale of the Player, as selected in the Player's settings.
@@ -216,15 +298,29 @@ func (p *Player) Locale() language.Tag {
 
 // Handle changes the current handler of the player. As a result, events called by the player will call
 // handlers of the Handler passed.
-// Handle sets the player's handler to NopHandler if nil is passed.
+// Handle detaches all handlers previously attached to the player, including those attached using Attach.
+// To add a Handler without removing the other handlers of the player, use Attach instead. If nil is passed,
+// all handlers are detached.
 func (p *Player) Handle(h Handler) {
-	p.hMutex.Lock()
-	defer p.hMutex.Unlock()
-
-	if h == nil {
-		h = NopHandler{}
+	p.handlers.Clear()
+	if h != nil {
+		p.handlers.Attach(h, 0)
 	}
-	p.h = h
+}
+
+// Attach attaches a Handler to the player with a specific priority, without removing any handlers already
+// attached. Handlers with a lower priority are called first, and handlers with the same priority are called
+// in the order they were attached. All handlers are called for an event, even if an earlier handler
+// cancelled it, so that handlers called later may check for cancellation using ctx.Cancelled().
+// Attach returns an event.Token that may be passed to Detach to detach the Handler again.
+func (p *Player) Attach(h Handler, priority int) event.Token {
+	return p.handlers.Attach(h, priority)
+}
+
+// Detach detaches the Handler attached using Attach with the event.Token passed. Detach returns false if
+// no Handler was attached with the token.
+func (p *Player) Detach(t event.Token) bool {
+	return p.handlers.Detach(t)
 }
 
 // Message sends a formatted message to the player. The message is formatted following the rules of
@@ -267,20 +363,22 @@ func (p *Player) FallDistance() float64 {
 // and the text it shows.
 // If non-empty, the subtitle is shown in a smaller font below the title. The same counts for the action text
 // of the title, which is shown in a font similar to that of a tip/popup.
+// Durations of the title that are 0 are replaced with the default durations of the client.
 func (p *Player) SendTitle(t title.Title) {
-	p.session().SetTitleDurations(t.FadeInDuration(), t.Duration(), t.FadeOutDuration())
-	p.session().SendTitle(t.Text())
-	if t.Subtitle() != "" {
-		p.session().SendSubtitle(t.Subtitle())
-	}
-	if t.ActionText() != "" {
-		p.session().SendActionBarMessage(t.ActionText())
-	}
+	p.session().ShowTitle(t)
+}
+
+// SendActionBar sends an action bar message to the player. The message is shown above the hotbar of the
+// player, similarly to a tip or popup, and is formatted following the rules of fmt.Sprintln without a
+// newline at the end.
+func (p *Player) SendActionBar(a ...interface{}) {
+	p.session().SendActionBarMessage(format(a))
 }
 
 // SendScoreboard sends a scoreboard to the player. The scoreboard will be present indefinitely until removed
 // by the caller.
-// SendScoreboard may be called at any time to change the scoreboard of the player.
+// SendScoreboard may be called at any time to change the scoreboard of the player. Changes made to the
+// scoreboard after it is sent are shown to the player automatically.
 func (p *Player) SendScoreboard(scoreboard *scoreboard.Scoreboard) {
 	p.session().SendScoreboard(scoreboard)
 }
@@ -291,11 +389,18 @@ func (p *Player) RemoveScoreboard() {
 	p.session().RemoveScoreboard()
 }
 
+// DebugOverlay returns the DebugOverlay of the player, which may be used to visualise server-side data, such
+// as positions or areas, to only this player.
+func (p *Player) DebugOverlay() *DebugOverlay {
+	return p.debug
+}
+
 // SendBossBar sends a boss bar to the player, so that it will be shown indefinitely at the top of the
 // player's screen.
-// The boss bar may be removed by calling Player.RemoveBossBar().
-func (p *Player) SendBossBar(bar bossbar.BossBar) {
-	p.session().SendBossBar(bar.Text(), bar.HealthPercentage())
+// The boss bar may be removed by calling Player.RemoveBossBar(). Changes made to the boss bar after it is
+// sent are shown to the player automatically. The same boss bar may be sent to multiple players.
+func (p *Player) SendBossBar(bar *bossbar.BossBar) {
+	p.session().SendBossBar(bar)
 }
 
 // RemoveBossBar removes any boss bar currently active on the player's screen. If no boss bar is currently
@@ -304,8 +409,75 @@ func (p *Player) RemoveBossBar() {
 	p.session().RemoveBossBar()
 }
 
-// Chat writes a message in the global chat (chat.Global). The message is prefixed with the name of the
-// player and is formatted following the rules of fmt.Sprintln.
+// AddPacketHandler adds a session.PacketHandler to the player that handles all packets received from and
+// sent to its client, before they are handled or sent by the server. Nothing happens if the player has no
+// network session.
+func (p *Player) AddPacketHandler(h session.PacketHandler) {
+	p.session().AddPacketHandler(h)
+}
+
+// RemovePacketHandler removes a session.PacketHandler added using Player.AddPacketHandler.
+func (p *Player) RemovePacketHandler(h session.PacketHandler) {
+	p.session().RemovePacketHandler(h)
+}
+
+// StartRecording starts recording all packets sent to and received from the client of the player to the
+// io.Writer passed, until Player.StopRecording is called or the player disconnects. The recording may be read
+// and replayed using session.NewReplayReader. Nothing happens if the player has no network session.
+func (p *Player) StartRecording(w io.Writer) {
+	p.session().StartRecording(w)
+}
+
+// StopRecording stops a recording started using Player.StartRecording and returns the first error
+// encountered while writing it, if any.
+func (p *Player) StopRecording() error {
+	return p.session().StopRecording()
+}
+
+// WritePacket writes a packet directly to the client of the player. It may be used to send packets that are
+// not otherwise implemented. An error is returned if the player has no network session.
+func (p *Player) WritePacket(pk packet.Packet) error {
+	s := p.session()
+	if s == session.Nop {
+		return fmt.Errorf("write packet: player is not connected")
+	}
+	s.WritePacket(pk)
+	return nil
+}
+
+// SetVisuals overrides the client-side visual settings, such as fog and render distance, of the world the
+// player is in. The override persists across world changes until Player.ResetVisuals is called.
+func (p *Player) SetVisuals(v world.Visuals) {
+	p.session().SetVisuals(&v)
+}
+
+// ResetVisuals removes the visual settings set using Player.SetVisuals, so that the Visuals of the world the
+// player is in are applied again.
+func (p *Player) ResetVisuals() {
+	p.session().SetVisuals(nil)
+}
+
+// ChunkRadius returns the chunk radius of the player: The radius in chunks around the player that chunks are
+// sent in. It is the radius set in the settings of the client, limited by the server and the Visuals of the
+// world. For players without a session, 0 is returned.
+func (p *Player) ChunkRadius() int {
+	return p.session().ChunkRadius()
+}
+
+// SetChunkRadius limits the chunk radius of the player to r, for example to send fewer chunks to players in
+// areas that are expensive to load. Chunks further away than the new radius are no longer sent, and a chunk
+// radius requested by the client is limited by r until SetChunkRadius is called with 0 to remove the limit.
+func (p *Player) SetChunkRadius(r int) {
+	p.session().SetChunkRadius(r)
+}
+
+// DefaultChatFormat is the format that chat messages of a player are written in by default. %name% is
+// replaced with the name of the player and %message% with the message sent.
+const DefaultChatFormat = "<%name%> %message%"
+
+// Chat writes a message in the global chat (chat.Global). The message is formatted following the rules of
+// fmt.Sprintln and is written to the chat in the chat format of the player. (See SetChatFormat.)
+// The message may be changed or cancelled in the Handler of the player through HandleChat.
 func (p *Player) Chat(msg ...interface{}) {
 	if p.Dead() {
 		return
@@ -315,10 +487,46 @@ func (p *Player) Chat(msg ...interface{}) {
 	p.handler().HandleChat(ctx, &message)
 
 	ctx.Continue(func() {
-		_, _ = fmt.Fprintf(chat.Global, "<%v> %v\n", p.name, message)
+		p.chatMu.RLock()
+		f := p.chatFunc
+		p.chatMu.RUnlock()
+		if f != nil {
+			f(message)
+			return
+		}
+		_, _ = fmt.Fprintln(chat.Global, FormatChat(p.chatFormat.Load(), p.name, message))
 	})
 }
 
+// FormatChat formats a chat message sent by a player with the name passed using a chat format. %name% in the
+// format is replaced with the name and %message% with the message.
+func FormatChat(format, name, message string) string {
+	return strings.NewReplacer("%name%", name, "%message%", message).Replace(format)
+}
+
+// SetChatFormat changes the format that chat messages sent by the player are written to the chat in. %name%
+// in the format is replaced with the name of the player and %message% with the message sent. By default,
+// DefaultChatFormat is used.
+func (p *Player) SetChatFormat(format string) {
+	p.chatFormat.Store(format)
+}
+
+// ChatFormat returns the format that chat messages sent by the player are written to the chat in, as set
+// using SetChatFormat.
+func (p *Player) ChatFormat() string {
+	return p.chatFormat.Load()
+}
+
+// SetChatFunc changes the function called with chat messages sent by the player once they passed the
+// handlers of the player. By default, messages are formatted using the chat format of the player and
+// written to chat.Global. If a function is set, it is responsible for writing the message to the chat.
+// Passing nil restores the default behaviour.
+func (p *Player) SetChatFunc(f func(message string)) {
+	p.chatMu.Lock()
+	defer p.chatMu.Unlock()
+	p.chatFunc = f
+}
+
 // ExecuteCommand executes a command passed as the player. If the command could not be found, or if the usage
 // was incorrect, an error message is sent to the player.
 func (p *Player) ExecuteCommand(commandLine string) {
@@ -351,20 +559,44 @@ func (p *Player) Disconnect(msg ...interface{}) {
 	p.close()
 }
 
-// Transfer transfers the player to a server at the address passed. If the address could not be resolved, an
-// error is returned. If it is returned, the player is closed and transferred to the server.
-func (p *Player) Transfer(address string) (err error) {
+// TransferTimeout is the time that a client has to disconnect after being transferred using Player.Transfer.
+// If it has not disconnected by then, the connection is closed.
+const TransferTimeout = time.Second * 5
+
+// Transfer transfers the player to a server at the address passed, which must be of the form host:port. An
+// error is returned if the address is invalid or could not be resolved, or if the player is no longer
+// connected. After the transfer is sent, the client disconnects from the server by itself. If it has not done
+// so after TransferTimeout, the connection of the player is closed.
+func (p *Player) Transfer(address string) error {
+	s := p.session()
+	if s == session.Nop {
+		return fmt.Errorf("transfer: player is not connected")
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("transfer: %w", err)
+	}
+	portNum, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNum == 0 {
+		return fmt.Errorf("transfer: invalid port %q", port)
+	}
 	addr, err := net.ResolveUDPAddr("udp", address)
 	if err != nil {
-		return err
+		return fmt.Errorf("transfer: %w", err)
 	}
 	ctx := event.C()
 	p.handler().HandleTransfer(ctx, addr)
 
 	ctx.Continue(func() {
-		p.session().Transfer(addr.IP, addr.Port)
+		s.Transfer(host, uint16(portNum))
+		time.AfterFunc(TransferTimeout, func() {
+			if p.session() == s {
+				// The client ignored the transfer and is still connected.
+				p.close()
+			}
+		})
 	})
-	return
+	return nil
 }
 
 // SendCommandOutput sends the output of a command to the player.
@@ -372,11 +604,33 @@ func (p *Player) SendCommandOutput(output *cmd.Output) {
 	p.session().SendCommandOutput(output)
 }
 
+// SendCommands sends all commands currently registered using cmd.Register to the player, so that they show
+// up in the command list and are auto-completed. Commands are sent automatically when the player joins, so
+// SendCommands only needs to be called if commands are registered after that.
+func (p *Player) SendCommands() {
+	p.session().SendAvailableCommands()
+}
+
+// OpenMenu opens a menu.Menu for the player. The menu is shown as a chest holding the items of the menu,
+// which cannot be taken out of it. Clicking an item calls the function set for it in the menu. Any menu or
+// container the player currently has opened is closed.
+func (p *Player) OpenMenu(m menu.Menu) {
+	p.session().SendMenu(m)
+}
+
+// CloseMenu closes the menu.Menu currently opened by the player using OpenMenu. If the player has no menu
+// opened, CloseMenu does nothing.
+func (p *Player) CloseMenu() {
+	p.session().CloseMenu()
+}
+
 // SendForm sends a form to the player for the client to fill out. Once the client fills it out, the Submit
 // method of the form will be called.
 // Note that the client may also close the form instead of filling it out, which will result in the form not
-// having its Submit method called at all. Forms should never depend on the player actually filling out the
-// form.
+// having its Submit method called at all. Instead, the Close method is called if the form implements the
+// form.Closer interface. Forms should never depend on the player actually filling out the form.
+// If the player still has another form opened, the form is queued and shown once the player has submitted or
+// closed the forms sent before it.
 func (p *Player) SendForm(f form.Form) {
 	p.session().SendForm(f)
 }
@@ -413,6 +667,36 @@ func (p *Player) NameTag() string {
 	return p.nameTag.Load()
 }
 
+// SetListName changes the name shown for the player in the player list of all players. Unlike SetNameTag, it
+// does not change the name tag displayed over the player in-game. Passing an empty name shows the name of the
+// player in the player list again.
+func (p *Player) SetListName(name string) {
+	p.session().SetListName(name)
+}
+
+// ListName returns the name shown for the player in the player list. It is the name of the player unless
+// changed using SetListName. If the player has no session, ListName returns an empty string.
+func (p *Player) ListName() string {
+	return p.session().ListName()
+}
+
+// HideFromList hides the player from the player list of all players, including its own. The player can still
+// be seen in the world.
+func (p *Player) HideFromList() {
+	p.session().SetListed(false)
+}
+
+// ShowInList shows the player in the player list of all players again after a call to HideFromList.
+func (p *Player) ShowInList() {
+	p.session().SetListed(true)
+}
+
+// Listed checks if the player is shown in the player list. Players are listed unless hidden using
+// HideFromList. Players without a session are never listed.
+func (p *Player) Listed() bool {
+	return p.session().Listed()
+}
+
 // SetSpeed sets the speed of the player. The value passed is the blocks/tick speed that the player will then
 // obtain.
 func (p *Player) SetSpeed(speed float64) {
@@ -469,6 +753,11 @@ func (p *Player) Heal(health float64, source healing.Source) {
 // updateFallState is called to update the entities falling state.
 func (p *Player) updateFallState(distanceThisTick float64) {
 	fallDistance := p.fallDistance.Load()
+	if p.slowFalling() || p.inWater() {
+		// Slow falling players and players in water never build up fall distance.
+		p.ResetFallDistance()
+		return
+	}
 	if p.OnGround() {
 		if fallDistance > 0 {
 			p.fall(fallDistance)
@@ -481,6 +770,26 @@ func (p *Player) updateFallState(distanceThisTick float64) {
 	}
 }
 
+// slowFalling checks if the player has the slow falling effect.
+func (p *Player) slowFalling() bool {
+	for _, e := range p.Effects() {
+		if _, ok := e.Type().(effect.SlowFalling); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// inWater checks if the player is in water.
+func (p *Player) inWater() bool {
+	l, ok := p.World().Liquid(cube.PosFromVec3(p.Position()))
+	if !ok {
+		return false
+	}
+	_, water := l.(block.Water)
+	return water
+}
+
 // fall is called when a falling entity hits the ground.
 func (p *Player) fall(fallDistance float64) {
 	w := p.World()
@@ -510,11 +819,16 @@ func (p *Player) fall(fallDistance float64) {
 
 // Hurt hurts the player for a given amount of damage. The source passed represents the cause of the damage,
 // for example damage.SourceEntityAttack if the player is attacked by another entity.
+// Players in a game mode that does not allow taking damage, such as creative, are only hurt by
+// damage.SourceVoid and damage.SourceCommand.
 // If the final damage exceeds the health that the player currently has, the player is killed and will have to
 // respawn.
 // If the damage passed is negative, Hurt will not do anything.
 func (p *Player) Hurt(dmg float64, source damage.Source) {
-	if p.Dead() || dmg < 0 || !p.GameMode().AllowsTakingDamage() {
+	if p.Dead() || dmg < 0 || (!p.GameMode().AllowsTakingDamage() && !bypassesGameMode(source)) {
+		return
+	}
+	if rule, ok := damageGameRule(source); ok && !gameRuleEnabled(p.World(), rule) {
 		return
 	}
 	for _, e := range p.Effects() {
@@ -523,24 +837,28 @@ func (p *Player) Hurt(dmg float64, source damage.Source) {
 		}
 	}
 
+	m := p.damageModifiers(dmg, source)
 	ctx := event.C()
-	p.handler().HandleHurt(ctx, &dmg, source)
+	p.handler().HandleHurt(ctx, &m, source)
 
 	ctx.Continue(func() {
+		var ok bool
+		if m.Base, ok = p.immunity.Damage(m.Base, p.World().ImmunityDuration()); !ok {
+			// The player is immune and the damage was not higher than the damage that made it immune.
+			return
+		}
 		if source.ReducedByArmour() {
 			p.Exhaust(0.1)
+			p.damageArmour(m.Base)
 		}
-		finalDamage := p.FinalDamageFrom(dmg, source)
+		res := m.Apply()
 
-		a := p.absorption()
-		if a > 0 && (effect.Absorption{}).Absorbs(source) {
-			if finalDamage > a {
-				finalDamage -= a
+		if res.Absorbed > 0 {
+			if a := p.absorption() - res.Absorbed; a > 0 {
+				p.SetAbsorption(a)
+			} else {
 				p.SetAbsorption(0)
 				p.effects.Remove(effect.Absorption{}, p)
-			} else {
-				p.SetAbsorption(a - finalDamage)
-				finalDamage = 0
 			}
 		}
 
@@ -566,68 +884,121 @@ func (p *Player) Hurt(dmg float64, source damage.Source) {
 			}
 		}
 
-		p.addHealth(-finalDamage)
+		p.addHealth(-res.Final)
 
 		for _, viewer := range p.viewers() {
 			viewer.ViewEntityAction(p, action.Hurt{})
 		}
-		p.immunity.Store(time.Now().Add(time.Second / 2))
 		if p.Dead() {
 			p.kill(source)
 		}
 	})
 }
 
+// bypassesGameMode checks if the damage.Source passed hurts players in game modes that do not allow taking
+// damage, such as creative and spectator mode.
+func bypassesGameMode(src damage.Source) bool {
+	switch src.(type) {
+	case damage.SourceVoid, damage.SourceCommand:
+		return true
+	}
+	return false
+}
+
+// damageGameRule returns the name of the game rule that specifies if players take damage from the
+// damage.Source passed. False is returned if no game rule applies to the source.
+func damageGameRule(src damage.Source) (string, bool) {
+	switch src := src.(type) {
+	case damage.SourceFall:
+		return world.GameRuleFallDamage, true
+	case damage.SourceDrowning:
+		return world.GameRuleDrowningDamage, true
+	case damage.SourceFire, damage.SourceFireTick, damage.SourceLava:
+		return world.GameRuleFireDamage, true
+	case damage.SourceEntityAttack:
+		if _, ok := src.Attacker.(*Player); ok {
+			return world.GameRulePVP, true
+		}
+	}
+	return "", false
+}
+
+// gameRuleEnabled checks if the bool game rule with the name passed is enabled in the world passed.
+func gameRuleEnabled(w *world.World, name string) bool {
+	v, _ := w.GameRule(name)
+	enabled, _ := v.(bool)
+	return enabled
+}
+
 // FinalDamageFrom resolves the final damage received by the player if it is attacked by the source passed
 // with the damage passed. FinalDamageFrom takes into account things such as the armour worn and the
-// enchantments on the individual pieces.
+// enchantments on the individual pieces, but not the absorption health of the player.
 // The damage returned will be at the least 0.
 func (p *Player) FinalDamageFrom(dmg float64, src damage.Source) float64 {
-	if src.ReducedByArmour() {
-		defencePoints, damageToArmour := 0.0, int(dmg/4)
-		if damageToArmour == 0 {
-			damageToArmour++
+	m := p.damageModifiers(dmg, src)
+	m.Absorption = 0
+	return m.Apply().Final
+}
+
+// damageModifiers returns the damage.Modifiers that apply to damage dealt to the player by the source passed,
+// based on the armour, effects and absorption health of the player.
+func (p *Player) damageModifiers(dmg float64, src damage.Source) damage.Modifiers {
+	m := damage.Modifiers{Base: dmg}
+	if entityAttack, ok := src.(damage.SourceEntityAttack); ok {
+		if carrier, ok := entityAttack.Attacker.(item.Carrier); ok {
+			held, _ := carrier.HeldItems()
+			if e, ok := held.Enchantment(enchantment.Sharpness{}); ok {
+				m.Base += (enchantment.Sharpness{}).Addend(e.Level())
+			}
 		}
-		for i, it := range p.armour.Items() {
+	}
+	if src.ReducedByArmour() {
+		defencePoints := 0.0
+		for _, it := range p.armour.Items() {
 			if a, ok := it.Item().(armour.Armour); ok {
 				defencePoints += a.DefencePoints()
-				if _, ok := it.Item().(item.Durable); ok {
-					_ = p.armour.Inv().SetItem(i, p.damageItem(it, damageToArmour))
-				}
 			}
 		}
 		// Armour in Bedrock edition reduces the damage taken by 4% for every armour point that the player
 		// has, with a maximum of 4*20=80%
-		dmg -= dmg * 0.04 * defencePoints
+		m.Armour = math.Min(0.04*defencePoints, 0.8)
 	}
 	for _, e := range p.Effects() {
 		if resistance, ok := e.Type().(effect.Resistance); ok {
-			dmg *= resistance.Multiplier(src, e.Level())
+			m.Resistance = 1 - resistance.Multiplier(src, e.Level())
 		}
 	}
-
-	if entityAttack, ok := src.(damage.SourceEntityAttack); ok {
-		if carrier, ok := entityAttack.Attacker.(item.Carrier); ok {
-			held, _ := carrier.HeldItems()
-			if e, ok := held.Enchantment(enchantment.Sharpness{}); ok {
-				dmg += (enchantment.Sharpness{}).Addend(e.Level())
-			}
-		}
-	}
-
 	for _, it := range p.armour.Items() {
 		if p, ok := it.Enchantment(enchantment.Protection{}); ok {
-			dmg -= (enchantment.Protection{}).Subtrahend(p.Level())
+			m.Enchantment += (enchantment.Protection{}).Subtrahend(p.Level())
+		}
+		if b, ok := it.Enchantment(enchantment.BlastProtection{}); ok && (src == damage.SourceExplosion{}) {
+			m.Enchantment += (enchantment.BlastProtection{}).Subtrahend(b.Level())
 		}
 	}
-
 	if f, ok := p.Armour().Boots().Enchantment(enchantment.FeatherFalling{}); ok && (src == damage.SourceFall{}) {
-		dmg *= (enchantment.FeatherFalling{}).Multiplier(f.Level())
+		m.FeatherFalling = 1 - (enchantment.FeatherFalling{}).Multiplier(f.Level())
+	}
+	if (effect.Absorption{}).Absorbs(src) {
+		m.Absorption = p.absorption()
+	}
+	return m
+}
+
+// damageArmour damages the durable armour pieces worn by the player after being hurt with the base damage
+// passed.
+func (p *Player) damageArmour(dmg float64) {
+	damageToArmour := int(dmg / 4)
+	if damageToArmour == 0 {
+		damageToArmour++
 	}
-	if dmg < 0 {
-		dmg = 0
+	for i, it := range p.armour.Items() {
+		if _, ok := it.Item().(armour.Armour); ok {
+			if _, ok := it.Item().(item.Durable); ok {
+				_ = p.armour.Inv().SetItem(i, p.damageItem(it, damageToArmour))
+			}
+		}
 	}
-	return dmg
 }
 
 // SetAbsorption sets the absorption health of a player. This extra health shows as golden hearts and do not
@@ -668,9 +1039,39 @@ func (p *Player) KnockBack(src mgl64.Vec3, force, height float64) {
 	p.SetVelocity(velocity.Mul(1 - resistance))
 }
 
-// AttackImmune checks if the player is currently immune to entity attacks, meaning it was recently attacked.
+// Explode hurts the player and knocks it away from the position of an explosion. The damage dealt and the
+// strength of the knock back depend on the impact passed, which is the strength of the explosion at the
+// player. Armour with blast protection reduces both.
+func (p *Player) Explode(explosionPos mgl64.Vec3, impact float64, c block.ExplosionConfig) {
+	p.Hurt(math.Floor((impact*impact+impact)*3.5*c.Size*2+1), damage.SourceExplosion{})
+	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	diff := p.Position().Sub(explosionPos)
+	if diff.Len() == 0 {
+		return
+	}
+	level := 0
+	for _, it := range p.armour.Items() {
+		if e, ok := it.Enchantment(enchantment.BlastProtection{}); ok && e.Level() > level {
+			level = e.Level()
+		}
+	}
+	knockBack := impact * (enchantment.BlastProtection{}).KnockBackMultiplier(level)
+	p.SetVelocity(p.Velocity().Add(diff.Normalize().Mul(knockBack)))
+}
+
+// AttackImmune checks if the player is currently immune to damage, meaning it was recently hurt. Damage
+// dealt to the player while it is immune is only applied if it is higher than the damage that made it immune,
+// in which case only the difference is dealt.
 func (p *Player) AttackImmune() bool {
-	return p.immunity.Load().(time.Time).After(time.Now())
+	return p.immunity.Immune()
+}
+
+// AttackImmunity returns the remaining duration that the player is immune to damage. The duration of the
+// immunity after being hurt may be changed for a world using World.SetImmunityDuration.
+func (p *Player) AttackImmunity() time.Duration {
+	return p.immunity.Remaining()
 }
 
 // Food returns the current food level of a player. The level returned is guaranteed to always be between 0
@@ -707,12 +1108,66 @@ func (p *Player) sendFood() {
 	p.session().SendFood(p.hunger.foodLevel, p.hunger.saturationLevel, p.hunger.exhaustionLevel)
 }
 
+// Experience returns the total amount of experience points of the player.
+func (p *Player) Experience() int {
+	return p.experience.Experience()
+}
+
+// AddExperience adds an amount of experience points to the player. The amount may be negative to remove
+// experience, but the experience of the player never drops below 0. A level up sound is played if the
+// experience level of the player increases.
+func (p *Player) AddExperience(amount int) {
+	before, after := p.experience.Add(amount)
+	if after > before {
+		p.PlaySound(sound.LevelUp{})
+	}
+	p.sendExperience()
+}
+
+// Level returns the experience level of the player.
+func (p *Player) Level() int {
+	return p.experience.Level()
+}
+
+// SetLevel sets the experience level of the player, keeping the progress towards the next level. Negative
+// levels are treated as 0.
+func (p *Player) SetLevel(level int) {
+	p.experience.SetLevel(level)
+	p.sendExperience()
+}
+
+// ExperienceProgress returns the progress of the player towards the next experience level, a value between
+// 0 and 1.
+func (p *Player) ExperienceProgress() float64 {
+	return p.experience.Progress()
+}
+
+// sendExperience sends the current experience level and progress to the client.
+func (p *Player) sendExperience() {
+	level, progress := entity.ProgressFromExperience(p.experience.Experience())
+	p.session().SendExperience(level, progress)
+}
+
 // AddEffect adds an entity.Effect to the Player. If the effect is instant, it is applied to the Player
 // immediately. If not, the effect is applied to the player every time the Tick method is called.
 // AddEffect will overwrite any effects present if the level of the effect is higher than the existing one, or
 // if the effects' levels are equal and the new effect has a longer duration.
 func (p *Player) AddEffect(e effect.Effect) {
-	p.session().SendEffect(p.effects.Add(e, p))
+	existing, ok := p.effects.Effect(e.Type())
+	added := p.effects.Add(e, p)
+	if _, lasting := e.Type().(effect.LastingType); !lasting {
+		// Instant effects are applied immediately and never shown to the client.
+		return
+	}
+	switch {
+	case !ok:
+		p.session().SendEffect(added)
+	case added.Level() == existing.Level() && added.Duration() == existing.Duration():
+		// The effect added was weaker than the existing one and was ignored.
+		return
+	default:
+		p.session().SendEffectModification(added)
+	}
 	p.updateState()
 }
 
@@ -775,16 +1230,20 @@ func (p *Player) kill(src damage.Source) {
 	}
 
 	p.addHealth(-p.MaxHealth())
-	p.StopSneaking()
-	p.StopSprinting()
-	p.inv.Clear()
-	p.armour.Clear()
-	p.offHand.Clear()
+	p.ReleaseItem()
+	p.resetMovementState()
 	for _, e := range p.Effects() {
 		p.RemoveEffect(e.Type())
 	}
 
-	p.handler().HandleDeath(src)
+	p.handler().HandleDeath(event.C(), src)
+	if gameRuleEnabled(p.World(), world.GameRuleShowDeathMessages) {
+		_, _ = fmt.Fprintln(chat.Global, DeathMessage(p.Name(), src))
+	}
+
+	if !p.World().KeepInventory() {
+		p.dropContents()
+	}
 
 	// Wait for a little bit before removing the entity. The client displays a death animation while the
 	// player is dying.
@@ -798,23 +1257,119 @@ func (p *Player) kill(src damage.Source) {
 			// We have an actual client connected to this player: We change its position server side so that in
 			// the future, the client won't respawn on the death location when disconnecting. The client should
 			// not see the movement itself yet, though.
-			p.pos.Store(p.World().Spawn().Vec3())
+			p.pos.Store(p.SpawnPosition())
 		}
 	})
 }
 
+// Kill kills the player immediately, regardless of its health, game mode and armour, as if it died from the
+// damage.Source passed. Kill does nothing if the player is already dead or if it is not in a world, for
+// example because it was disconnected.
+func (p *Player) Kill(src damage.Source) {
+	if p.Dead() || p.World() == nil {
+		return
+	}
+	p.kill(src)
+}
+
+// dropContents drops all items in the inventory, armour inventory and off-hand of the player at its
+// position and clears them.
+func (p *Player) dropContents() {
+	w, pos := p.World(), p.Position()
+	for _, it := range append(append(p.inv.Items(), p.armour.Items()...), p.offHand.Items()...) {
+		if it.Empty() {
+			continue
+		}
+		itemEntity := entity.NewItem(it, pos)
+		itemEntity.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
+		w.AddEntity(itemEntity)
+	}
+	p.inv.Clear()
+	p.armour.Clear()
+	p.offHand.Clear()
+}
+
+// DeathMessage returns the message broadcast when a player with the name passed dies from the damage.Source
+// passed. If the player was killed by an entity with a name, the name of that entity is included.
+func DeathMessage(name string, src damage.Source) string {
+	switch s := src.(type) {
+	case damage.SourceEntityAttack:
+		if n, ok := s.Attacker.(interface{ Name() string }); ok {
+			return fmt.Sprintf("%v was slain by %v", name, n.Name())
+		}
+		return fmt.Sprintf("%v was slain", name)
+	case damage.SourceStarvation:
+		return fmt.Sprintf("%v starved to death", name)
+	case damage.SourceInstantDamageEffect, damage.SourcePoisonEffect:
+		return fmt.Sprintf("%v was killed by magic", name)
+	case damage.SourceWitherEffect:
+		return fmt.Sprintf("%v withered away", name)
+	case damage.SourceVoid:
+		return fmt.Sprintf("%v fell out of the world", name)
+	case damage.SourceFire:
+		return fmt.Sprintf("%v went up in flames", name)
+	case damage.SourceFireTick:
+		return fmt.Sprintf("%v burned to death", name)
+	case damage.SourceLava:
+		return fmt.Sprintf("%v tried to swim in lava", name)
+	case damage.SourceSuffocation:
+		return fmt.Sprintf("%v suffocated in a wall", name)
+	case damage.SourceFall:
+		return fmt.Sprintf("%v hit the ground too hard", name)
+	case damage.SourceLightning:
+		return fmt.Sprintf("%v was struck by lightning", name)
+	case damage.SourceDrowning:
+		return fmt.Sprintf("%v drowned", name)
+	case damage.SourceWorldBorder:
+		return fmt.Sprintf("%v left the confines of this world", name)
+	case damage.SourceBlock:
+		if _, ok := s.Block.(block.SweetBerryBush); ok {
+			return fmt.Sprintf("%v was poked to death by a sweet berry bush", name)
+		}
+	}
+	return fmt.Sprintf("%v died", name)
+}
+
+// SpawnPosition returns the position that the player respawns at after dying. Unless changed using
+// Player.SetSpawnPosition, this is the spawn of the world that the player is in.
+func (p *Player) SpawnPosition() mgl64.Vec3 {
+	p.spawnMu.RLock()
+	defer p.spawnMu.RUnlock()
+	if p.spawnPos != nil {
+		return *p.spawnPos
+	}
+	return p.World().Spawn().Vec3Middle()
+}
+
+// SetSpawnPosition changes the position that the player respawns at after dying. The position may be reset to
+// the spawn of the world using Player.ResetSpawnPosition.
+func (p *Player) SetSpawnPosition(pos mgl64.Vec3) {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	p.spawnPos = &pos
+}
+
+// ResetSpawnPosition resets the position that the player respawns at after dying to the spawn of the world
+// that the player is in.
+func (p *Player) ResetSpawnPosition() {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	p.spawnPos = nil
+}
+
 // Respawn spawns the player after it dies, so that its health is replenished and it is spawned in the world
 // again. Nothing will happen if the player does not have a session connected to it.
 func (p *Player) Respawn() {
 	if !p.Dead() || p.World() == nil || p.session() == session.Nop {
 		return
 	}
-	pos := p.World().Spawn().Vec3Middle()
-	p.handler().HandleRespawn(&pos)
+	pos := p.SpawnPosition()
+	p.handler().HandleRespawn(event.C(), &pos)
 	p.addHealth(p.MaxHealth())
 	p.hunger.Reset()
 	p.sendFood()
 	p.Extinguish()
+	p.airSupply.Store(maxAirSupply)
 
 	p.World().AddEntity(p)
 	p.SetVisible()
@@ -826,17 +1381,29 @@ func (p *Player) Respawn() {
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
+// If the player is not allowed to start sprinting, the sprinting state of the player is corrected client-side.
 func (p *Player) StartSprinting() {
-	if !p.hunger.canSprint() && p.GameMode().AllowsTakingDamage() {
+	if p.sprinting.Load() {
 		return
 	}
-	if !p.sprinting.CAS(false, true) {
+	if !p.hunger.canSprint() && p.GameMode().AllowsTakingDamage() {
+		p.session().ViewEntityState(p)
 		return
 	}
-	p.StopSneaking()
-	p.SetSpeed(p.Speed() * 1.3)
+	ctx := event.C()
+	p.handler().HandleToggleSprint(ctx, true)
+	ctx.Continue(func() {
+		if !p.sprinting.CAS(false, true) {
+			return
+		}
+		p.StopSneaking()
+		p.SetSpeed(p.Speed() * 1.3)
 
-	p.updateState()
+		p.updateState()
+	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
 // Sprinting checks if the player is currently sprinting.
@@ -846,18 +1413,31 @@ func (p *Player) Sprinting() bool {
 
 // StopSprinting makes a player stop sprinting, setting back the speed of the player to its original value.
 func (p *Player) StopSprinting() {
-	if !p.sprinting.CAS(true, false) {
+	if !p.sprinting.Load() {
 		return
 	}
-	p.SetSpeed(p.Speed() / 1.3)
+	ctx := event.C()
+	p.handler().HandleToggleSprint(ctx, false)
+	ctx.Continue(func() {
+		if !p.sprinting.CAS(true, false) {
+			return
+		}
+		p.SetSpeed(p.Speed() / 1.3)
 
-	p.updateState()
+		p.updateState()
+	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
 // StartSneaking makes a player start sneaking. If the player is already sneaking, StartSneaking will not do
 // anything.
 // If the player is sprinting while StartSneaking is called, the sprinting is stopped.
 func (p *Player) StartSneaking() {
+	if p.sneaking.Load() {
+		return
+	}
 	ctx := event.C()
 	p.handler().HandleToggleSneak(ctx, true)
 	ctx.Continue(func() {
@@ -867,6 +1447,9 @@ func (p *Player) StartSneaking() {
 		p.StopSprinting()
 		p.updateState()
 	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
 // Sneaking checks if the player is currently sneaking.
@@ -877,6 +1460,9 @@ func (p *Player) Sneaking() bool {
 // StopSneaking makes a player stop sneaking if it currently is. If the player is not sneaking, StopSneaking
 // will not do anything.
 func (p *Player) StopSneaking() {
+	if !p.sneaking.Load() {
+		return
+	}
 	ctx := event.C()
 	p.handler().HandleToggleSneak(ctx, false)
 	ctx.Continue(func() {
@@ -885,16 +1471,29 @@ func (p *Player) StopSneaking() {
 		}
 		p.updateState()
 	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
 // StartSwimming makes the player start swimming if it is not currently doing so. If the player is sneaking
 // while StartSwimming is called, the sneaking is stopped.
 func (p *Player) StartSwimming() {
-	if !p.swimming.CAS(false, true) {
+	if p.swimming.Load() {
 		return
 	}
-	p.StopSneaking()
-	p.updateState()
+	ctx := event.C()
+	p.handler().HandleToggleSwim(ctx, true)
+	ctx.Continue(func() {
+		if !p.swimming.CAS(false, true) {
+			return
+		}
+		p.StopSneaking()
+		p.updateState()
+	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
 // Swimming checks if the player is currently swimming.
@@ -904,16 +1503,42 @@ func (p *Player) Swimming() bool {
 
 // StopSwimming makes the player stop swimming if it is currently doing so.
 func (p *Player) StopSwimming() {
-	if !p.swimming.CAS(true, false) {
+	if !p.swimming.Load() {
 		return
 	}
-	p.updateState()
+	ctx := event.C()
+	p.handler().HandleToggleSwim(ctx, false)
+	ctx.Continue(func() {
+		if !p.swimming.CAS(true, false) {
+			return
+		}
+		p.updateState()
+	})
+	ctx.Stop(func() {
+		p.session().ViewEntityState(p)
+	})
 }
 
-// StartFlying makes the player start flying if they aren't already. It requires the player to be in a gamemode which
-// allows flying.
+// resetMovementState makes the player stop sneaking, sprinting and swimming without calling the handler of
+// the player, so that the player is in its base state after dying or being moved elsewhere.
+func (p *Player) resetMovementState() {
+	changed := p.sneaking.CAS(true, false)
+	if p.sprinting.CAS(true, false) {
+		p.SetSpeed(p.Speed() / 1.3)
+		changed = true
+	}
+	if p.swimming.CAS(true, false) {
+		changed = true
+	}
+	if changed {
+		p.updateState()
+	}
+}
+
+// StartFlying makes the player start flying if they aren't already. It requires the player to be allowed to fly,
+// either by its game mode or by SetFlightAllowed.
 func (p *Player) StartFlying() {
-	if !p.GameMode().AllowsFlying() || !p.flying.CAS(false, true) {
+	if !p.FlightAllowed() || !p.flying.CAS(false, true) {
 		return
 	}
 	p.session().SendGameMode(p.GameMode())
@@ -932,6 +1557,25 @@ func (p *Player) StopFlying() {
 	p.session().SendGameMode(p.GameMode())
 }
 
+// SetFlightAllowed changes if the player is allowed to fly, regardless of its game mode. Players in a game
+// mode that allows flying, such as creative, may always fly. If flight is disallowed while the player is
+// flying, it stops flying.
+func (p *Player) SetFlightAllowed(allowed bool) {
+	if !p.flightAllowed.CAS(!allowed, allowed) {
+		return
+	}
+	if !p.FlightAllowed() {
+		p.flying.Store(false)
+	}
+	p.session().SendGameMode(p.GameMode())
+}
+
+// FlightAllowed checks if the player is allowed to fly, either because its game mode allows flying or because
+// flight was allowed using SetFlightAllowed.
+func (p *Player) FlightAllowed() bool {
+	return p.GameMode().AllowsFlying() || p.flightAllowed.Load()
+}
+
 // SetInvisible sets the player invisible, so that other players will not be able to see it.
 func (p *Player) SetInvisible() {
 	if !p.invisible.CAS(false, true) {
@@ -1049,7 +1693,7 @@ func (p *Player) SetGameMode(mode world.GameMode) {
 
 	p.session().SendGameMode(mode)
 
-	if !mode.AllowsFlying() {
+	if !p.FlightAllowed() {
 		p.StopFlying()
 	}
 	if !mode.Visible() {
@@ -1114,7 +1758,9 @@ func (p *Player) UseItem() {
 				}
 				p.SetHeldItems(p.subtractItem(i, 1), left)
 				p.addNewItem(&item.UseContext{NewItem: usable.Consume(w, p)})
-				w.PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Burp{})
+				if !drinkable(usable) {
+					w.PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Burp{})
+				}
 			}
 			p.usingSince.Store(time.Now().UnixNano())
 			p.updateState()
@@ -1122,6 +1768,12 @@ func (p *Player) UseItem() {
 	})
 }
 
+// drinkable checks if the item.Consumable passed is drunk rather than eaten.
+func drinkable(c item.Consumable) bool {
+	d, ok := c.(item.Drinkable)
+	return ok && d.Drinkable()
+}
+
 // ReleaseItem makes the Player release the item it is currently using. This is only applicable for items that
 // implement the item.Consumable interface.
 // If the Player is not currently using any item, ReleaseItem returns immediately.
@@ -1152,6 +1804,11 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 	i, left := p.HeldItems()
 
 	w := p.World()
+	if !w.Border().Contains(pos.Vec3Centre()) {
+		p.session().CorrectBlock(pos, "item used on block outside the world border")
+		p.session().CorrectBlock(pos.Side(face), "item used on block outside the world border")
+		return
+	}
 
 	ctx := event.C()
 	p.handler().HandleItemUseOnBlock(ctx, pos, face, clickPos)
@@ -1179,29 +1836,25 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 				p.SetHeldItems(p.subtractItem(p.damageItem(i, ctx.Damage), ctx.CountSub), left)
 				p.addNewItem(ctx)
 			}
-		} else if b, ok := i.Item().(world.Block); ok && p.GameMode().AllowsEditing() {
+		} else if b, ok := i.Item().(world.Block); ok {
 			// The item IS a block, meaning it is being placed.
 			replacedPos := pos
-			if replaceable, ok := w.Block(pos).(block.Replaceable); !ok || !replaceable.ReplaceableBy(b) {
+			if !block.ReplaceableBy(w.Block(pos), b) {
 				// The block clicked was either not replaceable, or not replaceable using the block passed.
 				replacedPos = pos.Side(face)
 			}
-			if replaceable, ok := w.Block(replacedPos).(block.Replaceable); ok && replaceable.ReplaceableBy(b) && !replacedPos.OutOfBounds() {
-				if p.placeBlock(replacedPos, b, false) && !p.GameMode().CreativeInventory() {
-					p.SetHeldItems(p.subtractItem(i, 1), left)
-				}
+			if !block.ReplaceableBy(w.Block(replacedPos), b) || replacedPos.OutOfBounds() {
+				p.session().CorrectBlock(replacedPos, "block placed at a position that is not replaceable")
+				return
+			}
+			if p.placeBlock(replacedPos, b, false) && !p.GameMode().CreativeInventory() {
+				p.SetHeldItems(p.subtractItem(i, 1), left)
 			}
 		}
 	})
 	ctx.Stop(func() {
-		w.SetBlock(pos, w.Block(pos))
-		w.SetBlock(pos.Side(face), w.Block(pos.Side(face)))
-		if liq, ok := w.Liquid(pos); ok {
-			w.SetLiquid(pos, liq)
-		}
-		if liq, ok := w.Liquid(pos.Side(face)); ok {
-			w.SetLiquid(pos.Side(face), liq)
-		}
+		p.session().CorrectBlock(pos, "item use on block cancelled")
+		p.session().CorrectBlock(pos.Side(face), "item use on block cancelled")
 	})
 }
 
@@ -1253,6 +1906,9 @@ func (p *Player) AttackEntity(e world.Entity) {
 		if living.AttackImmune() {
 			return
 		}
+		if _, ok := e.(*Player); ok && !gameRuleEnabled(p.World(), world.GameRulePVP) {
+			return
+		}
 		p.StopSprinting()
 
 		healthBefore := living.Health()
@@ -1299,8 +1955,9 @@ func (p *Player) AttackEntity(e world.Entity) {
 func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	p.AbortBreaking()
 	w := p.World()
-	if _, air := w.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) {
-		// The block was either out of range or air, so it can't be broken by the player.
+	if _, air := w.Block(pos).(block.Air); air || !p.canReach(pos.Vec3Centre()) || !w.Border().Contains(pos.Vec3Centre()) {
+		// The block was either out of range, outside the world border or air, so it can't be broken by the
+		// player.
 		return
 	}
 	if _, ok := w.Block(pos.Side(face)).(block.Fire); ok {
@@ -1339,9 +1996,26 @@ func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 			viewer.ViewBlockAction(pos, blockAction.StartCrack{BreakTime: breakTime})
 		}
 		p.lastBreakDuration = breakTime
+		p.breakProgress, p.lastBreakUpdate = 0, time.Now()
 	})
 }
 
+// breakTolerance is the fraction of the break time of a block that a client may finish breaking it early,
+// to account for latency between the start and the end of breaking the block.
+const breakTolerance = 0.2
+
+// updateBreakProgress adds the progress made breaking the block at breakingPos since the last update, using
+// the break time that applied since then.
+func (p *Player) updateBreakProgress() {
+	now := time.Now()
+	if p.lastBreakDuration <= 0 {
+		p.breakProgress = 1
+	} else {
+		p.breakProgress += float64(now.Sub(p.lastBreakUpdate)) / float64(p.lastBreakDuration)
+	}
+	p.lastBreakUpdate = now
+}
+
 // breakTime returns the time needed to break a block at the position passed, taking into account the item
 // held, if the player is on the ground/underwater and if the player has any effects.
 func (p *Player) breakTime(pos cube.Pos) time.Duration {
@@ -1369,18 +2043,29 @@ func (p *Player) breakTime(pos cube.Pos) time.Duration {
 	return breakTime
 }
 
+// brokenInTime checks if the player has been breaking the block at the position passed for long enough to
+// break it, allowing for breakTolerance. The progress made breaking the block is also returned.
+func (p *Player) brokenInTime(pos cube.Pos) (float64, bool) {
+	if !p.breaking.Load() || p.breakingPos.Load().(cube.Pos) != pos {
+		return 0, false
+	}
+	p.updateBreakProgress()
+	return p.breakProgress, p.breakProgress >= 1-breakTolerance
+}
+
 // FinishBreaking makes the player finish breaking the block it is currently breaking, or returns immediately
 // if the player isn't breaking anything.
-// FinishBreaking will stop the animation and break the block.
+// FinishBreaking will stop the animation and break the block. Like with BreakBlock, if the player does not
+// have a creative inventory and finishes breaking the block much sooner than the break time of the block
+// allows, the block is resent to the player instead.
 func (p *Player) FinishBreaking() {
 	pos := p.breakingPos.Load().(cube.Pos)
 	if !p.breaking.Load() {
-		w := p.World()
-		w.SetBlock(pos, w.Block(pos))
+		p.session().CorrectBlock(pos, "finished breaking block that was not being broken")
 		return
 	}
-	p.AbortBreaking()
 	p.BreakBlock(pos)
+	p.AbortBreaking()
 }
 
 // AbortBreaking makes the player stop breaking the block it is currently breaking, or returns immediately
@@ -1419,6 +2104,9 @@ func (p *Player) ContinueBreaking(face cube.Face) {
 	}
 	breakTime := p.breakTime(pos)
 	if breakTime != p.lastBreakDuration {
+		// The break time changed, for example because the player stopped standing on the ground. The progress
+		// made so far is kept, and the rest of the block is broken using the new break time.
+		p.updateBreakProgress()
 		for _, viewer := range p.viewers() {
 			viewer.ViewBlockAction(pos, blockAction.ContinueCrack{BreakTime: breakTime})
 		}
@@ -1442,20 +2130,27 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 // of the player. A bool is returned indicating if a block was placed successfully.
 func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreAABB bool) (success bool) {
 	w := p.World()
-	defer func() {
-		if !success {
-			w.SetBlock(pos, w.Block(pos))
-		}
-	}()
 	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+		p.session().CorrectBlock(pos, "block placed out of reach or without permission to edit")
+		return false
+	}
+	if !w.Border().Contains(pos.Vec3Centre()) {
+		p.session().CorrectBlock(pos, "block placed outside the world border")
 		return false
 	}
 	if !ignoreAABB {
 		if p.obstructedPos(pos, b) {
+			p.session().CorrectBlock(pos, "block placed inside an entity")
 			return false
 		}
 	}
 
+	// Blocks placed using an item picked with block entity data, such as a chest with items, get that data.
+	held, _ := p.HeldItems()
+	if !held.Empty() {
+		b = applyBlockEntityData(held, b)
+	}
+
 	ctx := event.C()
 	p.handler().HandleBlockPlace(ctx, pos, b)
 	ctx.Continue(func() {
@@ -1465,10 +2160,10 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreAABB bool) (succe
 		success = true
 	})
 	ctx.Stop(func() {
+		p.session().CorrectBlock(pos, "block place cancelled")
 		pos.Neighbours(func(neighbour cube.Pos) {
-			w.SetBlock(neighbour, w.Block(neighbour))
+			p.session().CorrectBlock(neighbour, "block place cancelled")
 		})
-		w.SetBlock(pos, w.Block(pos))
 	})
 	return
 }
@@ -1497,22 +2192,37 @@ func (p *Player) obstructedPos(pos cube.Pos, b world.Block) bool {
 
 // BreakBlock makes the player break a block in the world at a position passed. If the player is unable to
 // reach the block passed, the method returns immediately.
+// Unless the block breaks instantly with the item held, a player without a creative inventory must have been
+// breaking the block, as started using StartBreaking, for about as long as its break time. If not, the block
+// is resent to the player instead of being broken.
 func (p *Player) BreakBlock(pos cube.Pos) {
 	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+		p.session().CorrectBlock(pos, "block broken out of reach or without permission to edit")
 		return
 	}
 	w := p.World()
+	if !w.Border().Contains(pos.Vec3Centre()) {
+		p.session().CorrectBlock(pos, "block broken outside the world border")
+		return
+	}
 	b := w.Block(pos)
 	if _, air := b.(block.Air); air {
 		// Don't do anything if the position broken is already air.
 		return
 	}
 	if _, breakable := b.(block.Breakable); !breakable && !p.GameMode().CreativeInventory() {
-		// Block cannot be broken server-side. Set the block back so viewers have it resent and cancel all
-		// further action.
-		w.SetBlock(pos, w.Block(pos))
+		// Block cannot be broken server-side. Have the block resent and cancel all further action.
+		p.session().CorrectBlock(pos, "unbreakable block broken")
 		return
 	}
+	if held, _ := p.HeldItems(); !p.GameMode().CreativeInventory() && !block.BreaksInstantly(b, held) {
+		if progress, ok := p.brokenInTime(pos); !ok {
+			// The client broke the block sooner than possible, so the block is resent instead.
+			p.AbortBreaking()
+			p.session().CorrectBlock(pos, fmt.Sprintf("block broken too early (%.0f%% progress)", progress*100))
+			return
+		}
+	}
 
 	ctx := event.C()
 	p.handler().HandleBlockBreak(ctx, pos)
@@ -1537,7 +2247,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		}
 	})
 	ctx.Stop(func() {
-		w.SetBlock(pos, w.Block(pos))
+		p.session().CorrectBlock(pos, "block break cancelled")
 	})
 }
 
@@ -1569,8 +2279,12 @@ func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 }
 
 // PickBlock makes the player pick a block in the world at a position passed. If the player is unable to
-// pick the block, the method returns immediately.
-func (p *Player) PickBlock(pos cube.Pos) {
+// pick the block, the method returns immediately. If withData is true and the player has a creative
+// inventory, the item picked holds the block entity data of the block, such as the items in a chest, which is
+// applied to the block when it is placed.
+// A block already in the hotbar of the player is selected. Otherwise, the item is moved into or created in
+// an empty hotbar slot, or in the held slot if no hotbar slot is empty.
+func (p *Player) PickBlock(pos cube.Pos, withData bool) {
 	if !p.canReach(pos.Vec3()) {
 		return
 	}
@@ -1581,6 +2295,12 @@ func (p *Player) PickBlock(pos cube.Pos) {
 		copiedItem := item.NewStack(it, 1)
 
 		slot, found := p.Inventory().First(copiedItem)
+		if withData && p.GameMode().CreativeInventory() {
+			if stack, ok := withBlockEntityData(copiedItem, b); ok {
+				// Items with block entity data are always created anew, like in vanilla.
+				copiedItem, found = stack, false
+			}
+		}
 
 		if !found && !p.GameMode().CreativeInventory() {
 			return
@@ -1604,7 +2324,7 @@ func (p *Player) PickBlock(pos cube.Pos) {
 
 			if !emptyFound {
 				p.SetHeldItems(copiedItem, offhand)
-			} else if firstEmpty < 8 {
+			} else if firstEmpty < 9 {
 				_ = p.session().SetHeldSlot(firstEmpty)
 				_ = p.Inventory().SetItem(firstEmpty, copiedItem)
 			} else {
@@ -1616,11 +2336,18 @@ func (p *Player) PickBlock(pos cube.Pos) {
 }
 
 // Teleport teleports the player to a target position in the world. Unlike Move, it immediately changes the
-// position of the player, rather than showing an animation.
+// position of the player, rather than showing an animation. The player stops sneaking, sprinting and swimming
+// when teleported. The chunks around the position are sent to the player before it is moved, so that it does
+// not fall through unloaded terrain.
+// Teleport does nothing if the player is not in a world, for example because it was disconnected.
 func (p *Player) Teleport(pos mgl64.Vec3) {
+	if p.World() == nil {
+		return
+	}
 	ctx := event.C()
 	p.handler().HandleTeleport(ctx, pos)
 	ctx.Continue(func() {
+		p.resetMovementState()
 		p.teleport(pos)
 	})
 }
@@ -1634,6 +2361,45 @@ func (p *Player) teleport(pos mgl64.Vec3) {
 	p.pos.Store(pos)
 }
 
+// ChangeWorld moves the player to the world passed, at the position passed. The player is removed from the
+// world it is currently in and shown to the viewers of the new world, after which the chunks, time and spawn
+// of the new world are sent to the player. The player stops sneaking, sprinting and swimming when changing
+// worlds. If the world passed is the world the player is already in, ChangeWorld is equivalent to Teleport.
+// ChangeWorld does nothing if the player is not in a world, for example because it was disconnected.
+func (p *Player) ChangeWorld(w *world.World, pos mgl64.Vec3) {
+	before := p.World()
+	if w == nil || before == nil {
+		return
+	}
+	if before == w {
+		p.Teleport(pos)
+		return
+	}
+	ctx := event.C()
+	p.handler().HandleChangeWorld(ctx, before, w)
+	ctx.Continue(func() {
+		s := p.session()
+		p.resetMovementState()
+		p.pos.Store(pos)
+		w.AddEntity(p)
+		if s != session.Nop && p.session() != s {
+			// The player was disconnected while changing worlds, so its session might have removed it from the
+			// world it was in before it was added to the new one.
+			w.RemoveEntity(p)
+			return
+		}
+		// The session sends the chunks of the new world once it notices the player changed worlds.
+		s.ViewEntityTeleport(p, pos)
+	})
+}
+
+// MovedHorizontally checks if the player moved horizontally during the last tick, either because its client
+// moved it or because of its velocity. Blocks such as sweet berry bushes only affect players moving through
+// them.
+func (p *Player) MovedHorizontally() bool {
+	return p.movedHorizontally.Load()
+}
+
 // Move moves the player from one position to another in the world, by adding the delta passed to the current
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
@@ -1646,6 +2412,12 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	yaw, pitch := p.Rotation()
 
 	res, resYaw, resPitch := pos.Add(deltaPos), yaw+deltaYaw, pitch+deltaPitch
+	// Players crossing a border that does not hurt them are held back at the border. The client is teleported
+	// back once the movement is applied.
+	border, pushedBack := p.World().Border(), false
+	if border.Damage <= 0 && !border.Contains(res) {
+		res, pushedBack = border.Clamp(res), true
+	}
 
 	ctx := event.C()
 	p.handler().HandleMove(ctx, res, resYaw, resPitch)
@@ -1662,6 +2434,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		p.onGround.Store(p.checkOnGround())
 
 		p.updateFallState(deltaPos[1])
+		if pushedBack {
+			p.teleport(res)
+		}
 
 		// The vertical axis isn't relevant for calculation of exhaustion points.
 		deltaPos[1] = 0
@@ -1771,7 +2546,8 @@ func (p *Player) ShowEntity(e world.Entity) {
 
 // Latency returns a rolling average of latency between the sending and the receiving end of the connection of
 // the player.
-// The latency returned is updated continuously and is half the round trip time (RTT).
+// The latency returned is refreshed every second and is half the round trip time (RTT), so that it is cheap
+// to call, for example to display the ping of every player in a list.
 // If the Player does not have a session associated with it, Latency returns 0.
 func (p *Player) Latency() time.Duration {
 	if p.session() == session.Nop {
@@ -1780,13 +2556,26 @@ func (p *Player) Latency() time.Duration {
 	return p.session().Latency()
 }
 
+// Disconnected returns the reason that the player was disconnected for, such as the player leaving, timing
+// out or being kicked using Disconnect. If the player was kicked, the reason holds the message passed to
+// Disconnect. False is returned if the player was not yet disconnected.
+func (p *Player) Disconnected() (session.DisconnectReason, bool) {
+	reason, ok := p.disconnectReason.Load().(session.DisconnectReason)
+	return reason, ok
+}
+
 // Tick ticks the entity, performing actions such as checking if the player is still breaking a block.
 func (p *Player) Tick(current int64) {
+	p.debug.tick(p)
 	if p.Dead() {
 		return
 	}
 	w := p.World()
-	if _, ok := w.Liquid(cube.PosFromVec3(p.Position())); !ok {
+	pos, prev := p.Position(), p.tickPos.Load().(mgl64.Vec3)
+	p.tickPos.Store(pos)
+	p.movedHorizontally.Store(math.Abs(pos[0]-prev[0]) >= 0.003 || math.Abs(pos[2]-prev[2]) >= 0.003)
+
+	if _, ok := w.Liquid(cube.PosFromVec3(pos)); !ok {
 		p.StopSwimming()
 		if _, ok := p.Armour().Helmet().Item().(item.TurtleShell); ok {
 			p.AddEffect(effect.New(effect.WaterBreathing{}, 1, time.Second*10))
@@ -1796,28 +2585,53 @@ func (p *Player) Tick(current int64) {
 	p.checkBlockCollisions()
 	p.onGround.Store(p.checkOnGround())
 
+	if p.session() != session.Nop {
+		// The movement of players without a session is computed below, which pushes them out of blocks.
+		if pos, ok := entity.PushOutOfBlocks(p, p.Position()); ok {
+			p.teleport(pos)
+		}
+	}
+	if push := entity.EntityPush(p); push != (mgl64.Vec3{}) {
+		p.SetVelocity(p.Velocity().Add(push))
+	}
+
+	p.tickBorder(w, current)
 	p.tickFood()
 	p.effects.Tick(p)
-	if p.Position()[1] < cube.MinY && p.GameMode().AllowsTakingDamage() && current%10 == 0 {
+	if p.Position()[1] < cube.MinY && current%10 == 0 {
 		p.Hurt(4, damage.SourceVoid{})
 	}
+	p.tickAirSupply()
+
+	if current%10 == 0 && p.GameMode().AllowsTakingDamage() && p.suffocating() {
+		p.Hurt(1, damage.SourceSuffocation{})
+	}
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks.Sub(1)
 		if !p.GameMode().AllowsTakingDamage() || p.OnFireDuration() <= 0 {
 			p.Extinguish()
 		}
-		if p.OnFireDuration()%time.Second == 0 && !p.AttackImmune() {
+		if p.OnFireDuration()%time.Second == 0 {
 			p.Hurt(1, damage.SourceFireTick{})
 		}
 	}
 
-	if current%4 == 0 && p.usingItem.Load() {
+	if p.usingItem.Load() {
 		held, _ := p.HeldItems()
-		if _, ok := held.Item().(item.Consumable); ok {
-			// Eating particles seem to happen roughly every 4 ticks.
-			for _, v := range p.viewers() {
-				v.ViewEntityAction(p, action.Eat{})
+		if c, ok := held.Item().(item.Consumable); !ok {
+			// The item being used was removed from the hand of the player, for example because it was
+			// dropped or moved in the inventory, so the player stops using it.
+			p.ReleaseItem()
+		} else if current%4 == 0 {
+			if drinkable(c) {
+				p.World().PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Drink{})
+			} else {
+				// Eating particles and sounds seem to happen roughly every 4 ticks.
+				for _, v := range p.viewers() {
+					v.ViewEntityAction(p, action.Eat{})
+				}
+				p.World().PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Eat{})
 			}
 		}
 	}
@@ -1898,6 +2712,33 @@ func (p *Player) checkBlockCollisions() {
 	}
 }
 
+// suffocating checks if the eyes of the player are currently inside a block that suffocates them.
+func (p *Player) suffocating() bool {
+	w := p.World()
+	eyes := entity.EyePosition(p)
+	// The eyes of the player are a small box, with a width 80% of that of the player.
+	box := physics.NewAABB(eyes.Sub(mgl64.Vec3{0.24, 0.05, 0.24}), eyes.Add(mgl64.Vec3{0.24, 0.05, 0.24}))
+
+	min, max := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for y := min[1]; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				b := w.Block(pos)
+				if s, ok := b.(block.Suffocator); ok && !s.Suffocates() {
+					continue
+				}
+				for _, bb := range b.Model().AABB(pos, w) {
+					if bb.Translate(pos.Vec3()).IntersectsWith(box) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
 // checkOnGround checks if the player is currently considered to be on the ground.
 func (p *Player) checkOnGround() bool {
 	w := p.World()
@@ -1967,16 +2808,36 @@ func (p *Player) PlaySound(sound world.Sound) {
 	p.session().ViewSound(entity.EyePosition(p), sound)
 }
 
-// StopSound stop play a sound by sound name while sound playing to player
+// PlaySoundAt plays a world.Sound at the position passed that only this Player can hear. Sounds such as
+// sound.PlaySound are less loud the further away the position is from the Player.
+func (p *Player) PlaySoundAt(pos mgl64.Vec3, sound world.Sound) {
+	p.session().ViewSound(pos, sound)
+}
+
+// StopSound stops the sounds with the name passed, such as sounds played using sound.PlaySound, that are
+// playing for the Player. If the name is empty, all sounds are stopped.
 func (p *Player) StopSound(soundName string) (err error) {
-	p.session().StopSound(soundName, false)
+	p.session().ViewSoundStop(soundName)
 	return
 }
 
-// StopAllSound stop all play sound while sound playing to player
+// PlayMusic plays the music track with the name passed, such as 'record.cat', to the Player only, stopping any
+// music that was previously played using PlayMusic. The music is played as a sound at the head position of the
+// Player, which does not follow the Player as it moves. If loop is true, the track is played again every time
+// the length passed passes. See world.MusicPlayer for more information.
+func (p *Player) PlayMusic(name string, volume, pitch float64, length time.Duration, loop bool) {
+	p.music.Play(name, volume, pitch, length, loop)
+}
+
+// StopMusic stops the music played to the Player using PlayMusic.
+func (p *Player) StopMusic() {
+	p.music.Stop()
+}
+
+// StopAllSound stops all sounds playing for the Player. It is equivalent to calling StopSound with an empty
+// name.
 func (p *Player) StopAllSound() (err error) {
-	p.session().StopSound("", true)
-	return
+	return p.StopSound("")
 }
 
 // EditSign edits the sign at the cube.Pos passed and writes the text passed to a sign at that position. If no sign is
@@ -2001,6 +2862,49 @@ func (p *Player) EditSign(pos cube.Pos, text string) error {
 	return nil
 }
 
+// TurnLecternPage turns the book on the lectern at the cube.Pos passed to the page passed. The page is updated
+// for all players viewing the lectern. If no lectern with a book is present, if the Player cannot reach it or
+// if the book does not have the page, an error is returned.
+func (p *Player) TurnLecternPage(pos cube.Pos, page int) error {
+	w := p.World()
+	lectern, ok := w.Block(pos).(block.Lectern)
+	if !ok {
+		return fmt.Errorf("turn lectern page: no lectern at position %v", pos)
+	}
+	if !p.canReach(pos.Vec3Centre()) {
+		return fmt.Errorf("turn lectern page: lectern at position %v out of reach", pos)
+	}
+	lectern, err := lectern.TurnPage(page)
+	if err != nil {
+		return fmt.Errorf("turn lectern page: %w", err)
+	}
+	w.SetBlock(pos, lectern)
+	return nil
+}
+
+// TakeLecternBook takes the book from the lectern at the cube.Pos passed and adds it to the inventory of the
+// Player. If the inventory is full, the book is dropped instead. If no lectern with a book is present or if
+// the Player cannot reach it, an error is returned.
+func (p *Player) TakeLecternBook(pos cube.Pos) error {
+	w := p.World()
+	lectern, ok := w.Block(pos).(block.Lectern)
+	if !ok {
+		return fmt.Errorf("take lectern book: no lectern at position %v", pos)
+	}
+	if !p.canReach(pos.Vec3Centre()) {
+		return fmt.Errorf("take lectern book: lectern at position %v out of reach", pos)
+	}
+	lectern, book := lectern.RemoveBook()
+	if book.Empty() {
+		return fmt.Errorf("take lectern book: lectern at position %v holds no book", pos)
+	}
+	w.SetBlock(pos, lectern)
+	if _, err := p.inv.AddItem(book); err != nil {
+		p.Drop(book)
+	}
+	return nil
+}
+
 // updateState updates the state of the player to all viewers of the player.
 func (p *Player) updateState() {
 	for _, v := range p.viewers() {
@@ -2027,6 +2931,52 @@ func (p *Player) Breathing() bool {
 	return !submerged
 }
 
+// maxAirSupply is the maximum amount of ticks that a player may stay under water before it starts drowning.
+const maxAirSupply = 300
+
+// AirSupply returns the amount of ticks that the player may still stay under water before it starts drowning.
+// It is 300 for players that are not under water and becomes negative once the player is drowning.
+func (p *Player) AirSupply() int {
+	return int(p.airSupply.Load())
+}
+
+// MaxAirSupply returns the maximum air supply of the player in ticks.
+func (p *Player) MaxAirSupply() int {
+	return maxAirSupply
+}
+
+// tickAirSupply updates the air supply of the player. Players that cannot breathe lose one tick of air every
+// tick and are hurt by drowning every second once it runs out. Players that can breathe regain air quickly.
+func (p *Player) tickAirSupply() {
+	before := p.airSupply.Load()
+	after := before
+	if p.Breathing() {
+		if after = before + 4; after > maxAirSupply {
+			after = maxAirSupply
+		}
+	} else if after = before - 1; after <= -20 {
+		after = 0
+		p.Hurt(2, damage.SourceDrowning{})
+	}
+	if after == before {
+		return
+	}
+	p.airSupply.Store(after)
+	// The air supply is shown to the client as bubbles of 30 ticks each, so it is only sent when the amount of
+	// bubbles changes.
+	if bubbles(before) != bubbles(after) {
+		p.updateState()
+	}
+}
+
+// bubbles returns the amount of air bubbles shown to the client for the air supply passed.
+func bubbles(air int64) int64 {
+	if air <= 0 {
+		return 0
+	}
+	return (air + 29) / 30
+}
+
 // SwingArm makes the player swing its arm.
 func (p *Player) SwingArm() {
 	if p.Dead() {
@@ -2037,6 +2987,21 @@ func (p *Player) SwingArm() {
 	}
 }
 
+// Emote makes the player perform the emote with the UUID passed, showing it to all viewers of the player,
+// including the player itself.
+func (p *Player) Emote(emote uuid.UUID) {
+	if p.Dead() {
+		return
+	}
+	ctx := event.C()
+	p.handler().HandleEmote(ctx, emote)
+	ctx.Continue(func() {
+		for _, v := range p.viewers() {
+			v.ViewEmote(p, emote)
+		}
+	})
+}
+
 // PunchAir makes the player punch the air and plays the sound for attacking with no damage.
 func (p *Player) PunchAir() {
 	if p.Dead() {
@@ -2127,16 +3092,23 @@ func (p *Player) canReach(pos mgl64.Vec3) bool {
 	}
 	eyes := entity.EyePosition(p)
 
+	// Players using some input modes, such as touch, interact less precisely, so they are given extra range.
+	tolerance := p.InteractionTolerance(p.InputMode())
 	if p.GameMode().CreativeInventory() {
-		return world.Distance(eyes, pos) <= creativeRange && !p.Dead()
+		return world.Distance(eyes, pos) <= creativeRange+tolerance && !p.Dead()
 	}
-	return world.Distance(eyes, pos) <= survivalRange && !p.Dead()
+	return world.Distance(eyes, pos) <= survivalRange+tolerance && !p.Dead()
 }
 
 // close closed the player without disconnecting it. It executes code shared by both the closing and the
 // disconnecting of players.
 func (p *Player) close() {
-	p.handler().HandleQuit()
+	reason, ok := p.session().DisconnectReason()
+	if !ok {
+		reason = session.DisconnectReason{Kind: session.DisconnectClosed}
+	}
+	p.disconnectReason.Store(reason)
+	p.handler().HandleQuit(event.C(), reason)
 
 	p.Handle(NopHandler{})
 	chat.Global.Unsubscribe(p)
@@ -2150,6 +3122,8 @@ func (p *Player) close() {
 	_ = p.inv.Close()
 	_ = p.offHand.Close()
 	_ = p.armour.Close()
+	p.debug.Clear()
+	p.music.Stop()
 
 	if p.World() == nil {
 		return
@@ -2176,6 +3150,8 @@ func (p *Player) load(data Data) {
 	p.hunger.foodTick = data.FoodTick
 	p.hunger.exhaustionLevel, p.hunger.saturationLevel = data.ExhaustionLevel, data.SaturationLevel
 
+	p.experience.SetProgress(data.XPLevel, data.XPPercentage)
+
 	p.gameMode = data.GameMode
 	for _, potion := range data.Effects {
 		p.AddEffect(potion)
@@ -2209,6 +3185,7 @@ func (p *Player) Data() Data {
 
 	return Data{
 		UUID:            p.UUID(),
+		XUID:            p.XUID(),
 		Username:        p.Name(),
 		Position:        p.Position(),
 		Velocity:        mgl64.Vec3{},
@@ -2220,6 +3197,8 @@ func (p *Player) Data() Data {
 		FoodTick:        p.hunger.foodTick,
 		ExhaustionLevel: p.hunger.exhaustionLevel,
 		SaturationLevel: p.hunger.saturationLevel,
+		XPLevel:         p.Level(),
+		XPPercentage:    p.ExperienceProgress(),
 		GameMode:        p.GameMode(),
 		Inventory: InventoryData{
 			Items:        p.Inventory().Items(),
@@ -2251,10 +3230,7 @@ func (p *Player) session() *session.Session {
 
 // handler returns the Handler of the player.
 func (p *Player) handler() Handler {
-	p.hMutex.RLock()
-	handler := p.h
-	p.hMutex.RUnlock()
-	return handler
+	return handlerList(p.handlers.List())
 }
 
 // broadcastItems broadcasts the items held to viewers.
@@ -0,0 +1,132 @@
+package player_test
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/damage"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/tool"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestSessionlessGameMode(t *testing.T) {
+	// Players without a session must support changing their game mode and flight without a client to send
+	// them to.
+	p := player.New("test", skin.Skin{}, mgl64.Vec3{})
+	p.SetGameMode(world.GameModeSurvival{})
+	p.SetFlightAllowed(true)
+	p.StartFlying()
+	if !p.Flying() {
+		t.Fatalf("expected player to fly after allowing flight")
+	}
+	p.SetFlightAllowed(false)
+	if p.Flying() {
+		t.Fatalf("expected player to stop flying after disallowing flight")
+	}
+	p.SetGameMode(world.GameModeCreative{})
+	if !p.FlightAllowed() {
+		t.Fatalf("expected flight to be allowed in creative")
+	}
+}
+
+func TestAttackImmune(t *testing.T) {
+	attacker, victim := player.New("attacker", skin.Skin{}, mgl64.Vec3{}), player.New("victim", skin.Skin{}, mgl64.Vec3{1, 0, 0})
+	attacker.SetGameMode(world.GameModeSurvival{})
+	victim.SetGameMode(world.GameModeSurvival{})
+	sword := item.NewStack(item.Sword{Tier: tool.TierDiamond}, 1)
+	attacker.SetHeldItems(sword, item.Stack{})
+
+	attacker.AttackEntity(victim)
+	if !victim.AttackImmune() {
+		t.Fatalf("expected victim to be immune after being attacked")
+	}
+	held, _ := attacker.HeldItems()
+	durability := held.Durability()
+
+	// Attacks on an immune entity have no effect at all, including on the attacker.
+	attacker.StartSprinting()
+	attacker.AttackEntity(victim)
+	if !attacker.Sprinting() {
+		t.Errorf("expected attacker to keep sprinting after attacking an immune entity")
+	}
+	if held, _ := attacker.HeldItems(); held.Durability() != durability {
+		t.Errorf("expected durability %v after attacking an immune entity, got %v", durability, held.Durability())
+	}
+}
+
+func TestDamageGameRules(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	_ = w.SetGameRule(world.GameRuleFallDamage, false)
+	_ = w.SetGameRule(world.GameRulePVP, false)
+
+	attacker, victim := player.New("attacker", skin.Skin{}, mgl64.Vec3{}), player.New("victim", skin.Skin{}, mgl64.Vec3{1, 0, 0})
+	for _, p := range []*player.Player{attacker, victim} {
+		p.SetGameMode(world.GameModeSurvival{})
+		w.AddEntity(p)
+	}
+	victim.Hurt(5, damage.SourceFall{})
+	attacker.AttackEntity(victim)
+	if victim.Health() != victim.MaxHealth() {
+		t.Fatalf("expected no fall damage and pvp damage with the game rules disabled, got health %v", victim.Health())
+	}
+	victim.Hurt(5, damage.SourceVoid{})
+	if victim.Health() != victim.MaxHealth()-5 {
+		t.Errorf("expected damage not covered by game rules to be dealt, got health %v", victim.Health())
+	}
+}
+
+// messageRecorder is a chat.Subscriber that records the messages it receives.
+type messageRecorder struct {
+	messages []string
+}
+
+func (r *messageRecorder) Message(a ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprint(a...))
+}
+
+func TestDeathMessageGameRule(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	r := &messageRecorder{}
+	chat.Global.Subscribe(r)
+	defer chat.Global.Unsubscribe(r)
+
+	p := player.New("victim", skin.Skin{}, mgl64.Vec3{})
+	w.AddEntity(p)
+	_ = w.SetGameRule(world.GameRuleShowDeathMessages, false)
+	p.Kill(damage.SourceVoid{})
+	if len(r.messages) != 0 {
+		t.Errorf("expected no death message with showdeathmessages disabled, got %q", r.messages)
+	}
+}
+
+func TestSweetBerryBushMovement(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	w.SetBlock(cube.Pos{0, -1, 0}, block.Dirt{})
+	w.SetBlock(cube.Pos{1, -1, 0}, block.Dirt{})
+	w.SetBlock(cube.Pos{0, 0, 0}, block.SweetBerryBush{Age: 2})
+	w.SetBlock(cube.Pos{1, 0, 0}, block.SweetBerryBush{Age: 2})
+
+	p := player.New("test", skin.Skin{}, mgl64.Vec3{0.5, 0, 0.5})
+	p.SetGameMode(world.GameModeSurvival{})
+	w.AddEntity(p)
+	p.Tick(1)
+	p.Tick(2)
+	if p.Health() != p.MaxHealth() {
+		t.Fatalf("expected player standing still in a bush not to be hurt, got health %v", p.Health())
+	}
+	p.Move(mgl64.Vec3{0.5, 0, 0}, 0, 0)
+	p.Tick(3)
+	if p.Health() == p.MaxHealth() {
+		t.Errorf("expected player moving through a bush to be hurt")
+	}
+}
@@ -37,6 +37,10 @@ func dataToInv(data jsonInventoryData) player.InventoryData {
 		Items:        make([]item.Stack, 36),
 	}
 	for _, i := range data.Items {
+		if i.Slot < 0 || i.Slot >= len(d.Items) {
+			// Corrupted data: The slot is not part of the inventory, so we skip the item.
+			continue
+		}
 		d.Items[i.Slot] = decodeItem(i.Item)
 	}
 	d.Boots = decodeItem(data.Boots)
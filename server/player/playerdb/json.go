@@ -1,15 +1,21 @@
 package playerdb
 
 import (
+	"fmt"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"time"
 )
 
-func fromJson(d jsonData) player.Data {
+func fromJson(d jsonData) (player.Data, error) {
+	id, err := uuid.Parse(d.UUID)
+	if err != nil {
+		return player.Data{}, fmt.Errorf("decode player data: invalid UUID: %w", err)
+	}
 	return player.Data{
-		UUID:            uuid.MustParse(d.UUID),
+		UUID:            id,
+		XUID:            d.XUID,
 		Username:        d.Username,
 		Position:        d.Position,
 		Velocity:        d.Velocity,
@@ -30,12 +36,13 @@ func fromJson(d jsonData) player.Data {
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
 		Inventory:       dataToInv(d.Inventory),
-	}
+	}, nil
 }
 
 func toJson(d player.Data) jsonData {
 	return jsonData{
 		UUID:            d.UUID.String(),
+		XUID:            d.XUID,
 		Username:        d.Username,
 		Position:        d.Position,
 		Velocity:        d.Velocity,
@@ -61,6 +68,7 @@ func toJson(d player.Data) jsonData {
 
 type jsonData struct {
 	UUID                             string
+	XUID                             string
 	Username                         string
 	Position, Velocity               mgl64.Vec3
 	Yaw, Pitch                       float64
@@ -11,7 +11,9 @@ import (
 
 // Provider is a player data provider that uses a LevelDB database to store data. The data passed on
 // will first be converted to make sure it can be marshaled into JSON. This JSON (in bytes) will then
-// be stored in the database under a key that is the byte representation of the player's UUID.
+// be stored in the database under a key that holds the player's XUID.
+// Data stored by older versions under the byte representation of the player's UUID is moved to the XUID of
+// the player using MigrateUUID.
 type Provider struct {
 	db *leveldb.DB
 }
@@ -32,19 +34,36 @@ func NewProvider(path string) (*Provider, error) {
 }
 
 // Save ...
-func (p *Provider) Save(id uuid.UUID, d player.Data) error {
+func (p *Provider) Save(xuid string, d player.Data) error {
 	data := toJson(d)
 	jsondata, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	_ = p.db.Put(id[:], jsondata, nil)
-	return nil
+	return p.db.Put(xuidKey(xuid), jsondata, nil)
 }
 
 // Load ...
-func (p *Provider) Load(id uuid.UUID) (player.Data, error) {
-	jsondata, err := p.db.Get(id[:], nil)
+func (p *Provider) Load(xuid string) (player.Data, error) {
+	return p.load(xuidKey(xuid))
+}
+
+// MigrateUUID moves the data stored under the UUID passed, as done by older versions, to the XUID passed.
+func (p *Provider) MigrateUUID(id uuid.UUID, xuid string) (player.Data, error) {
+	d, err := p.load(id[:])
+	if err != nil {
+		return player.Data{}, err
+	}
+	d.XUID = xuid
+	if err := p.Save(xuid, d); err != nil {
+		return player.Data{}, err
+	}
+	return d, p.db.Delete(id[:], nil)
+}
+
+// load loads the player data stored under the key passed.
+func (p *Provider) load(key []byte) (player.Data, error) {
+	jsondata, err := p.db.Get(key, nil)
 	if err != nil {
 		return player.Data{}, err
 	}
@@ -54,7 +73,13 @@ func (p *Provider) Load(id uuid.UUID) (player.Data, error) {
 		return player.Data{}, err
 	}
 
-	return fromJson(d), nil
+	return fromJson(d)
+}
+
+// xuidKey returns the database key that the data of a player with the XUID passed is stored under. The key is
+// prefixed so that it never collides with the UUID keys of older versions.
+func xuidKey(xuid string) []byte {
+	return []byte("xuid:" + xuid)
 }
 
 // Close ...
@@ -8,28 +8,36 @@ import (
 
 // Provider represents a value that may provide data to a Player value. It usually does the reading and
 // writing of the player data so that the Player may use it.
+// Data is keyed by the XUID of the player, which, unlike its name or UUID, never changes for an account.
 type Provider interface {
 	// Save is called when the player leaves the server and passes on the current player data.
-	Save(UUID uuid.UUID, data Data) error
-	// Load is called when the player joins and passes the UUID of the player.
-	// It expects to the player data, and a bool that indicates whether or not the player has played before.
-	// If this bool is false the player will use default values and you can use an empty Data struct.
-	Load(UUID uuid.UUID) (Data, error)
+	Save(XUID string, data Data) error
+	// Load is called when the player joins and passes the XUID of the player.
+	// It expects to the player data, or an error if the player has not played before, in which case the
+	// player will use default values.
+	Load(XUID string) (Data, error)
 	// Closer is used on server close when the server calls Provider.Close() and
 	// is useful to safely close your database.
 	io.Closer
 }
 
+// UUIDMigrator may be implemented by a Provider that may hold data saved by older versions, which keyed the
+// data of players by their UUID. If no data is found for the XUID of a player that joins, MigrateUUID is
+// called to move the data stored for the UUID of the player to its XUID, after which the data is returned.
+type UUIDMigrator interface {
+	MigrateUUID(UUID uuid.UUID, XUID string) (Data, error)
+}
+
 // NopProvider is a player data provider that won't store any data and instead always return default values
 type NopProvider struct{}
 
 // Save ...
-func (NopProvider) Save(uuid.UUID, Data) error {
+func (NopProvider) Save(string, Data) error {
 	return nil
 }
 
 // Load ...
-func (NopProvider) Load(uuid.UUID) (Data, error) {
+func (NopProvider) Load(string) (Data, error) {
 	return Data{}, errors.New("player provider is not implemented")
 }
 
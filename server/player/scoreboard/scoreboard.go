@@ -3,23 +3,35 @@ package scoreboard
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // Scoreboard represents a scoreboard that may be sent to a player. The scoreboard is shown on the right side
 // of the player's screen.
 // Scoreboard implements the io.Writer and io.StringWriter interfaces. fmt.Fprintf and fmt.Fprint may be used
 // to write formatted text to the scoreboard.
+// Methods on Scoreboard may be called from multiple goroutines concurrently.
 type Scoreboard struct {
-	name  string
-	lines []string
+	name string
+
+	mu      sync.Mutex
+	lines   []string
+	viewers map[Viewer]struct{}
+}
+
+// Viewer is a viewer of a Scoreboard, such as a player that the Scoreboard was sent to. Viewers are notified
+// when the lines of the Scoreboard change, so that they may update the Scoreboard shown.
+type Viewer interface {
+	// ViewScoreboardUpdate is called when the lines of a Scoreboard viewed change.
+	ViewScoreboardUpdate(sb *Scoreboard)
 }
 
 // New returns a new scoreboard with the display name passed. Once returned, lines may be added to the
 // scoreboard to add text to it. The name is formatted according to the rules of fmt.Sprintln.
-// Changing the scoreboard after sending it to a player will not update the scoreboard of the player
-// automatically: Player.SendScoreboard() must be called again to update it.
+// Changing the scoreboard after sending it to a player updates the scoreboard of the player automatically:
+// Only the lines that changed are sent again.
 func New(name ...interface{}) *Scoreboard {
-	return &Scoreboard{name: strings.TrimSuffix(fmt.Sprintln(name...), "\n")}
+	return &Scoreboard{name: strings.TrimSuffix(fmt.Sprintln(name...), "\n"), viewers: map[Viewer]struct{}{}}
 }
 
 // Name returns the display name of the scoreboard, as passed during the construction of the scoreboard.
@@ -37,37 +49,85 @@ func (board *Scoreboard) Write(p []byte) (n int, err error) {
 // the scoreboard.
 func (board *Scoreboard) WriteString(s string) (n int, err error) {
 	lines := strings.Split(s, "\n")
-	board.lines = append(board.lines, lines...)
 
+	board.mu.Lock()
 	// Scoreboards can have up to 15 lines. (16 including the title.)
-	if len(board.lines) >= 15 {
-		return len(lines), fmt.Errorf("write scoreboard: maximum of 15 lines of text exceeded")
+	if len(board.lines)+len(lines) > 15 {
+		board.mu.Unlock()
+		return 0, fmt.Errorf("write scoreboard: maximum of 15 lines of text exceeded")
 	}
-	return len(lines), nil
+	board.lines = append(board.lines, lines...)
+	board.mu.Unlock()
+
+	board.update()
+	return len(s), nil
 }
 
 // Set changes a specific line in the scoreboard.
 func (board *Scoreboard) Set(index int, s string) (err error) {
+	board.mu.Lock()
 	if index < 0 || len(board.lines) <= index {
+		board.mu.Unlock()
 		return fmt.Errorf("index out of range %v", index)
 	}
 	// Remove new lines from the string
 	board.lines[index] = strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\n")
+	board.mu.Unlock()
 
+	board.update()
 	return nil
 }
 
 // Remove removes a specific line from the scoreboard.
 func (board *Scoreboard) Remove(index int) (err error) {
+	board.mu.Lock()
 	if index < 0 || len(board.lines) <= index {
+		board.mu.Unlock()
 		return fmt.Errorf("index out of range %v", index)
 	}
 	board.lines = append(board.lines[:index], board.lines[index+1:]...)
+	board.mu.Unlock()
 
+	board.update()
 	return nil
 }
 
 // Lines returns the data of the Scoreboard as a slice of strings.
 func (board *Scoreboard) Lines() []string {
-	return board.lines
+	board.mu.Lock()
+	defer board.mu.Unlock()
+	return append([]string(nil), board.lines...)
+}
+
+// AddViewer adds a Viewer to the Scoreboard, so that it is notified when the lines of the Scoreboard change.
+// AddViewer is called automatically when the Scoreboard is sent to a player.
+func (board *Scoreboard) AddViewer(v Viewer) {
+	board.mu.Lock()
+	defer board.mu.Unlock()
+	if board.viewers == nil {
+		board.viewers = map[Viewer]struct{}{}
+	}
+	board.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a Viewer from the Scoreboard, so that it is no longer notified of changes.
+func (board *Scoreboard) RemoveViewer(v Viewer) {
+	board.mu.Lock()
+	defer board.mu.Unlock()
+	delete(board.viewers, v)
+}
+
+// update notifies all viewers of the Scoreboard that its lines changed. The mutex of the Scoreboard must not
+// be held when update is called.
+func (board *Scoreboard) update() {
+	board.mu.Lock()
+	viewers := make([]Viewer, 0, len(board.viewers))
+	for v := range board.viewers {
+		viewers = append(viewers, v)
+	}
+	board.mu.Unlock()
+
+	for _, v := range viewers {
+		v.ViewScoreboardUpdate(board)
+	}
 }
@@ -0,0 +1,50 @@
+package scoreboard
+
+import "testing"
+
+type countViewer struct{ n int }
+
+func (c *countViewer) ViewScoreboardUpdate(*Scoreboard) { c.n++ }
+
+func TestScoreboardViewers(t *testing.T) {
+	sb := New("test")
+	v := &countViewer{}
+	sb.AddViewer(v)
+
+	if _, err := sb.WriteString("a\nb"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := sb.Set(1, "c"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := sb.Remove(0); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if v.n != 3 {
+		t.Fatalf("expected 3 updates, got %v", v.n)
+	}
+	if lines := sb.Lines(); len(lines) != 1 || lines[0] != "c" {
+		t.Fatalf("unexpected lines %v", lines)
+	}
+
+	sb.RemoveViewer(v)
+	_ = sb.Set(0, "d")
+	if v.n != 3 {
+		t.Fatalf("removed viewer was updated")
+	}
+}
+
+func TestScoreboardLineLimit(t *testing.T) {
+	sb := New("test")
+	for i := 0; i < 15; i++ {
+		if _, err := sb.WriteString("x"); err != nil {
+			t.Fatalf("write %v: %v", i, err)
+		}
+	}
+	if _, err := sb.WriteString("x"); err == nil {
+		t.Fatalf("expected error writing 16th line")
+	}
+	if len(sb.Lines()) != 15 {
+		t.Fatalf("expected 15 lines, got %v", len(sb.Lines()))
+	}
+}
@@ -22,6 +22,12 @@ func NewCape(width, height int) Cape {
 	return Cape{w: width, h: height, Pix: make([]uint8, width*height*4)}
 }
 
+// Exists checks if the Cape holds any data. A Cape without dimensions or with an amount of pixel data that does
+// not match its dimensions does not exist.
+func (c Cape) Exists() bool {
+	return c.w > 0 && c.h > 0 && len(c.Pix) == c.w*c.h*4
+}
+
 // ColorModel ...
 func (c Cape) ColorModel() color.Model {
 	return color.RGBAModel
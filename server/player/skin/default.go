@@ -0,0 +1,65 @@
+package skin
+
+import (
+	"bytes"
+	_ "embed"
+	"image/png"
+)
+
+var (
+	//go:embed steve.png
+	steveData []byte
+	//go:embed alex.png
+	alexData []byte
+	// geometryData holds the geometry of both the wide and the slim default skin, geometry.humanoid.custom
+	// and geometry.humanoid.customSlim.
+	//go:embed geometry.json
+	geometryData []byte
+
+	// steve and alex are the default skins returned by Default and DefaultSlim. They are decoded once and
+	// copied every time a default skin is requested.
+	steve = decodeDefault(steveData, "geometry.humanoid.custom", "wide")
+	alex  = decodeDefault(alexData, "geometry.humanoid.customSlim", "slim")
+)
+
+// Default returns the default skin with wide arms, resembling Steve. It may be used as a fallback if a skin
+// is invalid or if an entity, such as an NPC, should look like a player without a custom skin. A new copy
+// of the skin is returned every time, so that it may be changed freely.
+func Default() Skin {
+	return steve.copy()
+}
+
+// DefaultSlim returns the default skin with slim arms, resembling Alex. Like Default, a new copy of the skin
+// is returned every time.
+func DefaultSlim() Skin {
+	return alex.copy()
+}
+
+// decodeDefault decodes the PNG data of a default skin and returns a Skin using the geometry with the
+// identifier passed and the arm size passed.
+func decodeDefault(data []byte, geometry, armSize string) Skin {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		panic("decode default skin: " + err.Error())
+	}
+	s, err := FromImage(img)
+	if err != nil {
+		panic("decode default skin: " + err.Error())
+	}
+	s.Model = geometryData
+	s.ModelConfig = ModelConfig{Default: geometry}
+	s.ArmSize = armSize
+	return s
+}
+
+// copy returns a copy of the Skin, so that changing the pixels or model of the copy does not change the
+// original.
+func (s Skin) copy() Skin {
+	s.Pix = append([]uint8(nil), s.Pix...)
+	s.Model = append([]byte(nil), s.Model...)
+	s.Cape.Pix = append([]uint8(nil), s.Cape.Pix...)
+	s.Animations = append([]Animation(nil), s.Animations...)
+	s.PersonaPieces = append([]PersonaPiece(nil), s.PersonaPieces...)
+	s.PersonaTints = append([]PersonaTint(nil), s.PersonaTints...)
+	return s
+}
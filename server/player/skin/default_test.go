@@ -0,0 +1,21 @@
+package skin
+
+import "testing"
+
+func TestDefault(t *testing.T) {
+	for _, s := range []Skin{Default(), DefaultSlim()} {
+		if err := s.Validate(); err != nil {
+			t.Fatalf("expected default skin %v to be valid: %v", s.ModelConfig.Default, err)
+		}
+		if s.Bounds().Dx() != 64 || s.Bounds().Dy() != 64 {
+			t.Errorf("expected default skin to be 64x64, got %v", s.Bounds())
+		}
+	}
+
+	// Changing a default skin must not change the skins returned after.
+	s := Default()
+	s.Pix[0] = 255 - s.Pix[0]
+	if Default().Pix[0] == s.Pix[0] {
+		t.Errorf("expected Default to return a copy of the default skin")
+	}
+}
@@ -0,0 +1,40 @@
+package skin
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+)
+
+// ToImage returns an *image.NRGBA that shares its pixels with the Skin, so that the skin may be encoded, for
+// example using png.Encode, without copying it. Changing the pixels of the image changes those of the Skin.
+func (s Skin) ToImage() *image.NRGBA {
+	return &image.NRGBA{Pix: s.Pix, Stride: s.w * 4, Rect: s.Bounds()}
+}
+
+// FromImage creates a Skin with the pixels of the image passed, for example an image decoded from a PNG file
+// using png.Decode. The image must be 64x32, 64x64 or 128x128 pixels in size. The pixels are copied, so the
+// image may be changed after calling FromImage without changing the Skin. The model of the Skin returned is
+// left empty and must be set before the skin is valid.
+func FromImage(img image.Image) (Skin, error) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if !(w == 64 && h == 32) && !(w == 64 && h == 64) && !(w == 128 && h == 128) {
+		return Skin{}, fmt.Errorf("unsupported skin dimensions %vx%v: must be 64x32, 64x64 or 128x128", w, h)
+	}
+	s := New(w, h)
+	draw.Draw(s.ToImage(), s.Bounds(), img, b.Min, draw.Src)
+	return s, nil
+}
+
+// Face returns a new image of the face of the Skin, with the hat layer drawn over it. The image is 8x8 pixels
+// for skins 64 pixels wide, and larger for skins of a higher resolution. Face may be used to show players on
+// a web page, for example.
+func (s Skin) Face() *image.NRGBA {
+	scale := s.w / 64
+	face := image.NewNRGBA(image.Rect(0, 0, 8*scale, 8*scale))
+	src := s.ToImage()
+	draw.Draw(face, face.Bounds(), src, image.Point{X: 8 * scale, Y: 8 * scale}, draw.Src)
+	draw.Draw(face, face.Bounds(), src, image.Point{X: 40 * scale, Y: 8 * scale}, draw.Over)
+	return face
+}
@@ -0,0 +1,80 @@
+package skin
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+)
+
+func TestImageRoundTrip(t *testing.T) {
+	for _, name := range []string{"classic_64x32.png", "alpha_64x64.png", "hd_128x128.png"} {
+		data, err := os.ReadFile("testdata/" + name)
+		if err != nil {
+			t.Fatalf("read fixture %v: %v", name, err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decode fixture %v: %v", name, err)
+		}
+		s, err := FromImage(img)
+		if err != nil {
+			t.Fatalf("skin from fixture %v: %v", name, err)
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := png.Encode(buf, s.ToImage()); err != nil {
+			t.Fatalf("encode skin of fixture %v: %v", name, err)
+		}
+		decoded, err := png.Decode(buf)
+		if err != nil {
+			t.Fatalf("decode skin of fixture %v: %v", name, err)
+		}
+		// The skin encoded must hold exactly the same non-premultiplied pixels as the fixture.
+		other, err := FromImage(decoded)
+		if err != nil {
+			t.Fatalf("skin from encoded skin of fixture %v: %v", name, err)
+		}
+		if !bytes.Equal(other.Pix, s.Pix) {
+			t.Errorf("pixels of fixture %v changed in round trip", name)
+		}
+		if nrgba, ok := img.(*image.NRGBA); ok && !bytes.Equal(nrgba.Pix, s.Pix) {
+			t.Errorf("pixels of fixture %v changed when creating skin", name)
+		}
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	if _, err := FromImage(image.NewNRGBA(image.Rect(0, 0, 32, 32))); err == nil {
+		t.Errorf("expected error for 32x32 image")
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	s, err := FromImage(img)
+	if err != nil {
+		t.Fatalf("skin from 64x64 image: %v", err)
+	}
+	img.Pix[0] = 255
+	if s.Pix[0] != 0 {
+		t.Errorf("expected skin not to share the pixels of the image")
+	}
+}
+
+func TestFace(t *testing.T) {
+	s := New(64, 64)
+	img := s.ToImage()
+	img.SetNRGBA(8, 8, color.NRGBA{R: 255, A: 255})
+	img.SetNRGBA(41, 8, color.NRGBA{B: 255, A: 255})
+
+	face := s.Face()
+	if face.Bounds().Dx() != 8 || face.Bounds().Dy() != 8 {
+		t.Fatalf("expected 8x8 face, got %v", face.Bounds())
+	}
+	if c := face.NRGBAAt(0, 0); c != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("expected face pixel, got %v", c)
+	}
+	if c := face.NRGBAAt(1, 0); c != (color.NRGBA{B: 255, A: 255}) {
+		t.Errorf("expected hat pixel over face, got %v", c)
+	}
+}
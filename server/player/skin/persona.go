@@ -0,0 +1,25 @@
+package skin
+
+// PersonaPiece is a single piece of a persona skin, such as the hair, eyes or clothing of a skin created
+// using the character creator.
+type PersonaPiece struct {
+	// ID is the unique identifier of the piece.
+	ID string
+	// Type is the type of the piece, such as 'persona_hair' or 'persona_eyes'.
+	Type string
+	// PackID is the UUID of the pack that the piece is part of.
+	PackID string
+	// ProductID is the UUID of the marketplace product that the piece is from. It is empty for pieces that
+	// are free to use.
+	ProductID string
+	// Default specifies if the piece is one of the default pieces of a persona skin.
+	Default bool
+}
+
+// PersonaTint holds the colours applied to all pieces of a specific type in a persona skin.
+type PersonaTint struct {
+	// PieceType is the type of the pieces that the colours are applied to, such as 'persona_hair'.
+	PieceType string
+	// Colours holds the colours applied to the pieces as hex strings, such as '#ff5a3212'.
+	Colours []string
+}
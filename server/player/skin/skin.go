@@ -1,6 +1,8 @@
 package skin
 
 import (
+	"encoding/json"
+	"fmt"
 	"image"
 	"image/color"
 )
@@ -13,6 +15,13 @@ type Skin struct {
 	// Persona specifies if the skin uses the persona skin system.
 	Persona   bool
 	PlayFabID string
+	// PersonaPieces holds the pieces that a persona skin is made up of, and PersonaTints the colours applied
+	// to these pieces. Both are empty for skins that are not persona skins.
+	PersonaPieces []PersonaPiece
+	PersonaTints  []PersonaTint
+	// ArmSize is the size of the arms of the skin, either 'wide' or 'slim'. Colour is the colour of the skin
+	// of a persona skin as a hex string. Both may be empty.
+	ArmSize, Colour string
 
 	// Pix holds the raw pixel data of the skin. This is an RGBA byte slice, meaning that every first byte is
 	// a Red value, the second a Green value, the third a Blue value and the fourth an Alpha value.
@@ -74,3 +83,30 @@ func (s Skin) At(x, y int) color.Color {
 		A: s.Pix[offset+3],
 	}
 }
+
+// Validate checks if the Skin holds valid data: The amount of pixels of the skin, its cape and its animations
+// must match their dimensions, the model must be valid JSON and the ModelConfig must have a default model. An
+// error describing the first problem found is returned if the Skin is not valid.
+func (s Skin) Validate() error {
+	if s.w <= 0 || s.h <= 0 {
+		return fmt.Errorf("invalid skin dimensions %vx%v", s.w, s.h)
+	}
+	if len(s.Pix) != s.w*s.h*4 {
+		return fmt.Errorf("skin has %v bytes of pixel data, expected %v for %vx%v skin", len(s.Pix), s.w*s.h*4, s.w, s.h)
+	}
+	if len(s.Model) != 0 && !json.Valid(s.Model) {
+		return fmt.Errorf("skin model is not valid JSON")
+	}
+	if s.ModelConfig.Default == "" {
+		return fmt.Errorf("skin model config has no default model")
+	}
+	if len(s.Cape.Pix) != s.Cape.w*s.Cape.h*4 {
+		return fmt.Errorf("cape has %v bytes of pixel data, expected %v for %vx%v cape", len(s.Cape.Pix), s.Cape.w*s.Cape.h*4, s.Cape.w, s.Cape.h)
+	}
+	for i, a := range s.Animations {
+		if len(a.Pix) != a.w*a.h*4 {
+			return fmt.Errorf("animation %v has %v bytes of pixel data, expected %v for %vx%v animation", i, len(a.Pix), a.w*a.h*4, a.w, a.h)
+		}
+	}
+	return nil
+}
@@ -15,14 +15,10 @@ type Title struct {
 
 // New returns a new title using the text passed. The text is formatted according to the formatting rules of
 // fmt.Sprintln, but with no newline at the end.
-// The title has default durations set, which will generally suffice.
+// The title has no durations set, meaning the client's default durations are used, which will generally
+// suffice.
 func New(text ...interface{}) Title {
-	return Title{
-		text:            format(text),
-		fadeInDuration:  time.Second / 20,
-		fadeOutDuration: time.Second / 20,
-		duration:        time.Second * 2,
-	}
+	return Title{text: format(text)}
 }
 
 // Text returns the text of the title, as passed to New when created.
@@ -39,7 +35,7 @@ func (title Title) WithSubtitle(text ...interface{}) Title {
 	return title
 }
 
-// Subtitle returns the subtitle of the title, as passed to SetSubtitle. Subtitle returns an empty string if
+// Subtitle returns the subtitle of the title, as passed to WithSubtitle. Subtitle returns an empty string if
 // no subtitle was previously set.
 func (title Title) Subtitle() string {
 	return title.subtitle
@@ -47,7 +43,7 @@ func (title Title) Subtitle() string {
 
 // WithActionText sets the action text of the title. This text is roughly the same as sending a tip/popup, but
 // will synchronise with the title.
-// SetActionText will format the text passed using the formatting rules of fmt.Sprintln, but without newline.
+// WithActionText will format the text passed using the formatting rules of fmt.Sprintln, but without newline.
 // The new Title with the action text is returned.
 func (title Title) WithActionText(text ...interface{}) Title {
 	title.actionText = format(text)
@@ -61,12 +57,13 @@ func (title Title) ActionText() string {
 }
 
 // Duration returns the duration that the title will be visible for, without fading in or out. By default,
-// this is two seconds.
+// this is 0, meaning the client's default of three and a half seconds is used.
 func (title Title) Duration() time.Duration {
 	return title.duration
 }
 
-// WithDuration sets the duration that the title will be visible for without fading in or fading out.
+// WithDuration sets the duration that the title will be visible for without fading in or fading out. The
+// duration will be rounded to ticks. If 0, the client's default duration is used.
 // The new Title with the duration is returned.
 func (title Title) WithDuration(d time.Duration) Title {
 	title.duration = d
@@ -74,30 +71,30 @@ func (title Title) WithDuration(d time.Duration) Title {
 }
 
 // WithFadeInDuration sets the duration that the title takes to fade in on the screen. The duration will be
-// rounded to ticks.
+// rounded to ticks. If 0, the client's default duration is used.
 // The new Title with the fade-in duration is returned.
 func (title Title) WithFadeInDuration(d time.Duration) Title {
 	title.fadeInDuration = d
 	return title
 }
 
-// FadeInDuration returns the duration that the fade-in of the title takes. By default, this is a quarter of
-// a second.
+// FadeInDuration returns the duration that the fade-in of the title takes. By default, this is 0, meaning the
+// client's default of half a second is used.
 func (title Title) FadeInDuration() time.Duration {
 	return title.fadeInDuration
 }
 
 // WithFadeOutDuration sets the duration that the title takes to fade out of the screen. The duration will be
-// rounded to ticks.
+// rounded to ticks. If 0, the client's default duration is used.
 // The new Title with the fade-out duration is returned.
 func (title Title) WithFadeOutDuration(d time.Duration) Title {
 	title.fadeOutDuration = d
 	return title
 }
 
-// FadeOutDuration returns the duration that the fade-out of the title takes.By default, this is a quarter of
-// a second.
-func (title *Title) FadeOutDuration() time.Duration {
+// FadeOutDuration returns the duration that the fade-out of the title takes. By default, this is 0, meaning
+// the client's default of one second is used.
+func (title Title) FadeOutDuration() time.Duration {
 	return title.fadeOutDuration
 }
 
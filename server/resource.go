@@ -0,0 +1,78 @@
+package server
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/resource"
+	"net"
+)
+
+// PackInfo holds information about a connection that is about to receive resource packs, passed to the
+// functions registered using OnResourcePacks to select the packs sent to it.
+type PackInfo struct {
+	// XUID and Name are the XUID and name of the player connecting.
+	XUID, Name string
+	// Addr is the address that the player connects from.
+	Addr net.Addr
+	// Packs are the resource packs that are sent to the connection if the function does not change them.
+	// These are the packs set using SetResourcePacks, or those returned by the previous function if multiple
+	// functions are registered.
+	Packs []*resource.Pack
+}
+
+// PackListener is a Listener that negotiates resource packs with connections itself, before returning them
+// from Accept. A PackListener may send a different stack of resource packs to every connection: Server.Listen
+// sets the function that the PackListener must call to select the packs of a connection before negotiating.
+type PackListener interface {
+	Listener
+	// SetPackFunc sets the function that selects the resource packs sent to a connection. The function is
+	// called with the PackInfo of the connection and must be called before resource packs are negotiated.
+	SetPackFunc(f func(info PackInfo) []*resource.Pack)
+}
+
+// SetResourcePacks sets the resource packs sent to players joining the server, replacing the packs loaded from
+// the resource folder in the Config. Resource packs cannot be changed for players that are already online:
+// The client only negotiates packs when joining, so the packs set only apply once players reconnect.
+// The packs set after the server is started are only sent by PackListeners added using Listen. The built-in
+// RakNet listeners, started for the addresses in the Config, take a copy of the packs when the server is
+// started and keep sending those until it is restarted, so SetResourcePacks should be called before Start
+// to change the packs they send.
+func (server *Server) SetResourcePacks(packs ...*resource.Pack) {
+	server.packMu.Lock()
+	defer server.packMu.Unlock()
+	server.resources = append([]*resource.Pack(nil), packs...)
+}
+
+// ResourcePacks returns the resource packs sent to players joining the server, as loaded from the resource
+// folder in the Config or set using SetResourcePacks.
+func (server *Server) ResourcePacks() []*resource.Pack {
+	server.packMu.RLock()
+	defer server.packMu.RUnlock()
+	return append([]*resource.Pack(nil), server.resources...)
+}
+
+// OnResourcePacks registers a function that selects the resource packs sent to a connection, for example to
+// send the texture pack of a minigame to the players joining its server. The function is called before the
+// player is spawned and before resource packs are negotiated, with the PackInfo of the connection, and
+// returns the packs to send. OnResourcePacks may be called multiple times to register multiple functions,
+// which are called in the order they were registered.
+// The functions are only called for connections accepted by PackListeners added using Listen. They are never
+// called for the built-in RakNet listeners, which negotiate resource packs before the server sees the
+// connection and send the packs that were set when the server was started to every connection.
+func (server *Server) OnResourcePacks(f func(info PackInfo) []*resource.Pack) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.packHooks = append(server.packHooks, f)
+}
+
+// selectPacks returns the resource packs sent to the connection with the PackInfo passed, by calling all
+// functions registered using OnResourcePacks.
+func (server *Server) selectPacks(info PackInfo) []*resource.Pack {
+	info.Packs = server.ResourcePacks()
+
+	server.hookMu.RLock()
+	hooks := append([]func(info PackInfo) []*resource.Pack(nil), server.packHooks...)
+	server.hookMu.RUnlock()
+	for _, f := range hooks {
+		info.Packs = f(info)
+	}
+	return info.Packs
+}
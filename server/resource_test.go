@@ -0,0 +1,53 @@
+package server
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/resource"
+	"path/filepath"
+	"testing"
+)
+
+// packListener is a PackListener that stores the function passed to SetPackFunc.
+type packListener struct {
+	*testListener
+	f func(info PackInfo) []*resource.Pack
+}
+
+func (l *packListener) SetPackFunc(f func(info PackInfo) []*resource.Pack) {
+	l.f = f
+}
+
+func TestPackListener(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = ""
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	l := &packListener{testListener: &testListener{closed: make(chan struct{})}}
+	srv.Listen(l)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	defer srv.Close()
+	if l.f == nil {
+		t.Fatalf("expected pack function to be set on the listener")
+	}
+
+	// Packs set after the server was started are sent by PackListeners.
+	a, b, vip := &resource.Pack{}, &resource.Pack{}, &resource.Pack{}
+	srv.SetResourcePacks(a, b)
+	srv.OnResourcePacks(func(info PackInfo) []*resource.Pack {
+		if info.Name == "vip" {
+			return append(info.Packs, vip)
+		}
+		return info.Packs
+	})
+	if packs := l.f(PackInfo{Name: "player"}); len(packs) != 2 || packs[0] != a || packs[1] != b {
+		t.Errorf("expected packs set using SetResourcePacks, got %v", packs)
+	}
+	if packs := l.f(PackInfo{Name: "vip"}); len(packs) != 3 || packs[2] != vip {
+		t.Errorf("expected pack added by OnResourcePacks function, got %v", packs)
+	}
+}
@@ -2,21 +2,27 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 	_ "unsafe" // Imported for compiler directives.
 
 	_ "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/bridge"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/internal"
 	_ "github.com/df-mc/dragonfly/server/item" // Imported for compiler directives.
@@ -42,17 +48,36 @@ import (
 // Server implements a Dragonfly server. It runs the main server loop and handles the connections of players
 // trying to join the server.
 type Server struct {
-	started atomic.Bool
-	name    atomic.String
+	// lifeMu is held while the Server is starting or closing, so that Close waits for a Server that is
+	// starting to be running before closing it.
+	lifeMu sync.Mutex
+	state  atomic.Int32
+	// closing is closed when the Server starts closing. done is closed once all Listeners of the Server are
+	// closed and no more players will be accepted.
+	closing, done chan struct{}
+
+	name, sub atomic.String
 
 	joinMessage, quitMessage atomic.String
+	chatFormat               atomic.String
 	playerProvider           player.Provider
 
-	c         Config
-	log       internal.Logger
-	world     *world.World
-	players   chan *player.Player
+	c       Config
+	log     internal.Logger
+	world   *world.World
+	players chan *player.Player
+	packMu  sync.RWMutex
+	// resources holds the resource packs sent to players joining, as set using SetResourcePacks.
 	resources []*resource.Pack
+	// items and customBlocks hold the item and custom block entries sent to every player joining in the
+	// StartGame packet. They never change once the Server is started, so they are built once in start, after
+	// all custom blocks were registered.
+	items        []protocol.ItemEntry
+	customBlocks []protocol.BlockEntry
+
+	// worlds holds the additional worlds loaded using LoadWorld, indexed by their name.
+	worldMu sync.Mutex
+	worlds  map[string]*world.World
 
 	startTime time.Time
 
@@ -60,11 +85,70 @@ type Server struct {
 	// p holds a map of all players currently connected to the server. When they leave, they are removed from
 	// the map.
 	p map[uuid.UUID]*player.Player
+	// left holds a channel for every player in p, which is closed once the player has fully left the server
+	// and its data was saved.
+	left map[*player.Player]chan struct{}
+	// joining holds the UUIDs of all players that are joining the server, but that were not yet added to p.
+	joining map[uuid.UUID]struct{}
+	// reserved is the amount of player slots reserved by players that are joining, but that were not yet added
+	// to p.
+	reserved int
 
 	wg sync.WaitGroup
 
+	// conns holds all connections that are joining or playing on the server, so that Close can wait for them
+	// to be closed and force them closed if they take too long. connWg is done once all of them are.
+	connMu sync.Mutex
+	conns  map[session.Conn]struct{}
+	connWg sync.WaitGroup
+
 	listenMu  sync.Mutex
 	listeners []Listener
+
+	lan *lanAdvertiser
+	// throttle limits the amount of connections and joins per IP address.
+	throttle *throttler
+	// joinPool limits the amount of connections that are spawning simultaneously.
+	joinPool *joinPool
+	// packetRate calculates the packet rates returned by Stats.
+	packetRate packetRate
+	// blockActors sums the block entity update counts of the sessions of players returned by Stats.
+	blockActors blockActorCount
+	// initErr is the error that occurred while creating the Server, if any. It is returned when the Server is
+	// started.
+	initErr error
+
+	// ops holds the operator levels of players. perms is the player.PermissionChecker set using
+	// PermissionChecker, which is nil unless set.
+	ops    *operators
+	permMu sync.RWMutex
+	perms  player.PermissionChecker
+	// movementResponse is the response of sessions to invalid movement, parsed from the Config when the
+	// server is started.
+	movementResponse session.MovementResponse
+
+	// accepting is set to true once Accept is called for the first time. Players are only passed to Accept
+	// once it is.
+	accepting atomic.Bool
+	// consoleEnabled is set to true by EnableConsole. consoleStarted is set to true once the console was
+	// started, so that it is never started twice.
+	consoleEnabled, consoleStarted atomic.Bool
+
+	hookMu     sync.RWMutex
+	joinHooks  []func(p *player.Player)
+	quitHooks  []func(p *player.Player)
+	pingHooks  []func(entry *ServerListEntry)
+	fullHooks  []func(xuid, name string) bool
+	spawnHooks []func(info SpawnInfo) (*world.World, mgl64.Vec3)
+	packHooks  []func(info PackInfo) []*resource.Pack
+
+	// origin is a unique ID of the server used to recognise events published over the bridge by the server.
+	origin       string
+	bridgeMu     sync.RWMutex
+	bridge       bridge.Bridge
+	bridgeTopics map[string]bool
+	// remoteCounts holds the player counts of other servers published over the bridge, indexed by origin.
+	remoteCounts map[string]int
 }
 
 func init() {
@@ -73,7 +157,9 @@ func init() {
 }
 
 // New returns a new server using the Config passed. If nil is passed, a default configuration is returned.
-// (A call to server.DefaultConfig().)
+// (A call to server.DefaultConfig().) Fields left unset in the Config, such as an empty World.Folder, are
+// filled with their values from DefaultConfig. The Config is validated using Config.Validate once the server
+// is started.
 // The Logger passed will be used to log errors and information to. If nil is passed, a default Logger is
 // used by calling logrus.New().
 // Note that no two servers should be active at the same time. Doing so anyway will result in unexpected
@@ -82,48 +168,192 @@ func New(c *Config, log internal.Logger) *Server {
 	if log == nil {
 		log = logrus.New()
 	}
-	if c == nil {
-		conf := DefaultConfig()
-		c = &conf
+	conf := DefaultConfig()
+	if c != nil {
+		conf = c.withDefaults()
 	}
+	c = &conf
 	s := &Server{
 		c:              *c,
 		log:            log,
 		players:        make(chan *player.Player),
+		closing:        make(chan struct{}),
+		done:           make(chan struct{}),
 		world:          world.New(log, c.World.SimulationDistance),
+		worlds:         map[string]*world.World{},
 		p:              make(map[uuid.UUID]*player.Player),
+		left:           make(map[*player.Player]chan struct{}),
+		joining:        make(map[uuid.UUID]struct{}),
+		conns:          make(map[session.Conn]struct{}),
 		name:           *atomic.NewString(c.Server.Name),
+		sub:            *atomic.NewString(c.Server.SubName),
 		playerProvider: player.NopProvider{},
+		origin:         uuid.New().String(),
+		remoteCounts:   map[string]int{},
+		throttle:       newThrottler(c.Network.MaxConnectionsPerIP, c.Network.JoinsPerMinute),
+		joinPool:       newJoinPool(c.Network.JoinWorkers, c.Network.JoinQueueSize),
+		// The operators are replaced once loaded, but must be set if creating the Server fails before that.
+		ops: &operators{levels: map[string]int{}},
 	}
 	s.JoinMessage(c.Server.JoinMessage)
 	s.QuitMessage(c.Server.QuitMessage)
+	s.ChatFormat(c.Server.ChatFormat)
 
-	s.loadResources(c.Resources.Folder, log)
+	if s.initErr = s.loadResources(c.Resources.Folder, log); s.initErr != nil {
+		return s
+	}
 	s.checkNetIsolation()
 
+	ops, err := loadOperators(c.Players.OperatorsFile)
+	if err != nil {
+		s.initErr = err
+		return s
+	}
+	s.ops = ops
+
 	if !c.Players.SaveData {
 		return s
 	}
 	p, err := playerdb.NewProvider(c.Players.Folder)
 	if err != nil {
-		panic(err)
+		s.initErr = fmt.Errorf("open player provider: %w", err)
+		return s
 	}
 	s.PlayerProvider(p)
 	return s
 }
 
 // Accept accepts an incoming player into the server. It blocks until a player connects to the server.
-// Accept returns an error if the Server is closed using a call to Close.
+// Accept returns an error as soon as the Server starts closing through a call to Close.
+// Using Accept is optional: Players join the server regardless of whether Accept is called. OnPlayerJoin may
+// be used instead to be notified of players joining. Once Accept is called, however, it must continue to
+// be called for every player joining, or players will not be able to join.
 func (server *Server) Accept() (*player.Player, error) {
-	p, ok := <-server.players
-	if !ok {
-		return nil, errors.New("server closed")
+	server.accepting.Store(true)
+	select {
+	case p, ok := <-server.players:
+		if ok {
+			return p, nil
+		}
+	case <-server.closing:
 	}
-	server.playerMutex.Lock()
-	server.p[p.UUID()] = p
-	server.playerMutex.Unlock()
+	return nil, errors.New("server closed")
+}
+
+// OnPlayerJoin registers a function that is called when a player joins the server. The function is called
+// with the player once it is fully spawned and added to the server, before it is returned by Accept.
+// OnPlayerJoin may be called multiple times to register multiple functions, which are called in the order
+// they were registered. It is safe to call OnPlayerJoin while the server is running.
+func (server *Server) OnPlayerJoin(f func(p *player.Player)) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.joinHooks = append(server.joinHooks, f)
+}
+
+// OnPlayerQuit registers a function that is called when a player leaves the server. The function is called
+// just before the player is removed from the server, so that it is still returned by Server.Players() and
+// Server.Player(). The reason that the player left for is returned by Player.Disconnected() when the function
+// is called. OnPlayerQuit may be called multiple times to register multiple functions, which are called in the
+// order they were registered. It is safe to call OnPlayerQuit while the server is running.
+func (server *Server) OnPlayerQuit(f func(p *player.Player)) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.quitHooks = append(server.quitHooks, f)
+}
+
+// OnServerFull registers a function that is called when a player tries to join while the server is full. If
+// the function returns true, the player may join regardless, for example so that staff can always join.
+// Functions are called with the XUID and name of the player, which may be used to check against a list of
+// players that may bypass the limit. OnServerFull may be called multiple times to register multiple
+// functions, which are called in order until one returns true.
+func (server *Server) OnServerFull(f func(xuid, name string) bool) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.fullHooks = append(server.fullHooks, f)
+}
+
+// SpawnInfo holds information about a player that is joining the server, passed to the functions registered
+// using OnPlayerSpawn to decide where the player spawns.
+type SpawnInfo struct {
+	// UUID, XUID and Name are the UUID, XUID and name of the player joining.
+	UUID       uuid.UUID
+	XUID, Name string
+	// Data is the data of the player saved when it last left the server. It is nil if the player has no
+	// saved data, or if saving player data is disabled.
+	Data *player.Data
+	// World and Position are the world and position that the player spawns at if the function does not
+	// change them. This is the position in the saved Data of the player if present, or the spawn set in the
+	// Config or of the world otherwise. If multiple functions are registered, they are those returned by the
+	// previous function.
+	World    *world.World
+	Position mgl64.Vec3
+}
 
-	return p, nil
+// OnPlayerSpawn registers a function that decides the world and position that a player joining the server
+// spawns at. The function is called before the player is spawned, so the player itself does not exist yet.
+// Instead, it is called with the SpawnInfo of the player, which holds the location it would otherwise spawn
+// at. The world returned must be the world of the server or a world loaded using LoadWorld. If nil is
+// returned as world, the world of the server is used. OnPlayerSpawn may be called multiple times to register
+// multiple functions, which are called in the order they were registered.
+func (server *Server) OnPlayerSpawn(f func(info SpawnInfo) (*world.World, mgl64.Vec3)) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.spawnHooks = append(server.spawnHooks, f)
+}
+
+// spawnLocation returns the world and position that the player with the SpawnInfo passed spawns at. The
+// World and Position of the SpawnInfo passed are filled out by spawnLocation.
+func (server *Server) spawnLocation(info SpawnInfo) (*world.World, mgl64.Vec3) {
+	info.World, info.Position = server.world, server.world.Spawn().Vec3Middle()
+	if info.Data != nil {
+		info.Position = info.Data.Position
+	} else {
+		if name := server.c.Players.SpawnWorld; name != "" {
+			if w, ok := server.WorldByName(name); ok {
+				info.World, info.Position = w, w.Spawn().Vec3Middle()
+			} else {
+				server.log.Errorf("spawn world %q is not loaded: spawning player in default world", name)
+			}
+		}
+		if pos := server.c.Players.SpawnPosition; len(pos) == 3 {
+			info.Position = mgl64.Vec3{pos[0], pos[1], pos[2]}
+		}
+	}
+
+	server.hookMu.RLock()
+	hooks := append([]func(info SpawnInfo) (*world.World, mgl64.Vec3){}, server.spawnHooks...)
+	server.hookMu.RUnlock()
+	for _, f := range hooks {
+		w, pos := f(info)
+		if w == nil {
+			w = server.world
+		}
+		info.World, info.Position = w, pos
+	}
+	return info.World, info.Position
+}
+
+// primeSpawn loads the chunks around the position passed in the world passed, so that the area that a player
+// spawns in is ready by the time the player finishes joining.
+func primeSpawn(w *world.World, pos mgl64.Vec3) {
+	centre := cube.PosFromVec3(pos)
+	for x := -16; x <= 16; x += 16 {
+		for z := -16; z <= 16; z += 16 {
+			_ = w.Block(centre.Add(cube.Pos{x, 0, z}))
+		}
+	}
+}
+
+// callHooks calls all functions in the slice of hooks passed with the player passed. The slice is copied
+// while holding the hook mutex so that hooks may register other hooks without deadlocking.
+func (server *Server) callHooks(hooks *[]func(p *player.Player), p *player.Player) {
+	server.hookMu.RLock()
+	h := append([]func(p *player.Player){}, *hooks...)
+	server.hookMu.RUnlock()
+
+	for _, f := range h {
+		f(p)
+	}
 }
 
 // World returns the world of the server. Players will be spawned in this world and this world will be read
@@ -132,20 +362,73 @@ func (server *Server) World() *world.World {
 	return server.world
 }
 
+// LoadWorld loads an additional world from the folder passed, creating it if it does not yet exist, and adds
+// it to the server under the name passed. The world ticks independently of the other worlds of the server and
+// is closed when the server is closed. Players may be moved to the world using player.Player.ChangeWorld.
+// An error is returned if a world with the same name was already loaded, if the server is closed or if the
+// world could not be loaded.
+func (server *Server) LoadWorld(folder, name string) (*world.World, error) {
+	server.worldMu.Lock()
+	defer server.worldMu.Unlock()
+	if s := server.State(); s == StateClosing || s == StateClosed {
+		return nil, fmt.Errorf("load world %v: server closed", name)
+	}
+	if _, ok := server.worlds[name]; ok {
+		return nil, fmt.Errorf("load world %v: world with this name already loaded", name)
+	}
+	p, err := server.worldProvider(folder)
+	if err != nil {
+		return nil, fmt.Errorf("load world %v: %w", name, err)
+	}
+	g, err := server.worldGenerator()
+	if err != nil {
+		return nil, fmt.Errorf("load world %v: %w", name, err)
+	}
+	w := world.New(server.log, server.c.World.SimulationDistance)
+	w.Provider(p)
+	w.Generator(g)
+	if server.c.World.ReadOnly {
+		w.ReadOnly()
+	}
+	w.SetTickingAreaLimits(server.c.World.MaxTickingAreas, server.c.World.MaxTickingAreaChunks)
+	w.SaveInterval(time.Duration(server.c.World.SaveInterval) * time.Minute)
+	w.SetChunkCacheLimits(time.Duration(server.c.World.ChunkUnloadDelay)*time.Second, server.c.World.MaxLoadedChunks)
+	w.SetPauseTickingOnSave(server.c.World.PauseTickingOnSave)
+	server.configureTicking(w)
+	server.worlds[name] = w
+
+	server.log.Debugf("Loaded world '%v' from %v.", name, folder)
+	return w, nil
+}
+
+// WorldByName returns the world loaded using LoadWorld with the name passed. If no such world was loaded, false is
+// returned.
+func (server *Server) WorldByName(name string) (*world.World, bool) {
+	server.worldMu.Lock()
+	defer server.worldMu.Unlock()
+	w, ok := server.worlds[name]
+	return w, ok
+}
+
+// Worlds returns the world of the server and all additional worlds loaded using LoadWorld.
+func (server *Server) Worlds() []*world.World {
+	server.worldMu.Lock()
+	defer server.worldMu.Unlock()
+	worlds := make([]*world.World, 0, len(server.worlds)+1)
+	worlds = append(worlds, server.world)
+	for _, w := range server.worlds {
+		worlds = append(worlds, w)
+	}
+	return worlds
+}
+
 // Run runs the server and blocks until it is closed using a call to Close(). When called, the server will
 // accept incoming connections. Run will block the current goroutine until the server is stopped. To start
 // the server on a different goroutine, use (*Server).Start() instead.
-// After a call to Run, calls to Server.Accept() may be made to accept players into the server.
+// After a call to Run, calls to Server.Accept() may be made to accept players into the server, or functions
+// registered using OnPlayerJoin are called for players joining.
 func (server *Server) Run() error {
-	if !server.started.CAS(false, true) {
-		panic("server already running")
-	}
-
-	server.log.Infof("Starting Minecraft Bedrock Edition server for v%v...", protocol.CurrentVersion)
-	server.loadWorld()
-	server.registerTargetFunc()
-
-	if err := server.startListening(); err != nil {
+	if err := server.start(); err != nil {
 		return err
 	}
 	server.wait()
@@ -156,18 +439,67 @@ func (server *Server) Run() error {
 // goroutine. Connections will be accepted until the listener is closed using a call to Close.
 // Once started, players may be accepted using Server.Accept().
 func (server *Server) Start() error {
-	if !server.started.CAS(false, true) {
-		panic("server already running")
+	if err := server.start(); err != nil {
+		return err
+	}
+	go server.wait()
+	return nil
+}
+
+// State returns the current State of the Server in its lifecycle.
+func (server *Server) State() State {
+	return State(server.state.Load())
+}
+
+// start loads the world of the server and starts listening for players. It panics if the server was already
+// started or closed. If the world could not be loaded or listening fails, the server is closed and the error
+// is returned.
+func (server *Server) start() error {
+	server.lifeMu.Lock()
+	defer server.lifeMu.Unlock()
+	if !server.state.CAS(int32(StateCreated), int32(StateStarting)) {
+		panic("server already started")
 	}
 
+	if err := server.c.Validate(); err != nil {
+		server.closeData()
+		server.state.Store(int32(StateClosed))
+		return fmt.Errorf("start: invalid config: %w", err)
+	}
+	if server.initErr != nil {
+		server.closeData()
+		server.state.Store(int32(StateClosed))
+		return fmt.Errorf("start: %w", server.initErr)
+	}
 	server.log.Infof("Starting Minecraft Bedrock Edition server for v%v...", protocol.CurrentVersion)
-	server.loadWorld()
+	server.items, server.customBlocks = server.itemEntries(), server.blockEntries()
+	if name := server.c.Players.MovementResponse; name != "" {
+		r, ok := session.MovementResponseByName(name)
+		if !ok {
+			server.closeData()
+			server.state.Store(int32(StateClosed))
+			return fmt.Errorf("start: unknown movement response %q", name)
+		}
+		server.movementResponse = r
+	}
+	if err := server.loadWorld(); err != nil {
+		server.closeData()
+		server.state.Store(int32(StateClosed))
+		return err
+	}
 	server.registerTargetFunc()
+	server.registerBuiltinCommands()
 
 	if err := server.startListening(); err != nil {
+		server.closeListeners()
+		server.closeData()
+		server.state.Store(int32(StateClosed))
 		return err
 	}
-	go server.wait()
+	server.state.Store(int32(StateRunning))
+	if server.c.Server.Console || server.consoleEnabled.Load() {
+		server.startConsole()
+	}
 	return nil
 }
 
@@ -190,15 +522,58 @@ func (server *Server) PlayerCount() int {
 }
 
 // MaxPlayerCount returns the maximum amount of players that are allowed to play on the server at the same
-// time. Players trying to join when the server is full will be refused to enter.
-// If the config has a maximum player count set to 0, MaxPlayerCount will return Server.PlayerCount + 1.
+// time. Players trying to join when the server is full are disconnected, unless a function registered using
+// OnServerFull allows them to join. If the config has a maximum player count of 0, the amount of players is
+// unlimited and MaxPlayerCount returns -1.
 func (server *Server) MaxPlayerCount() int {
-	if server.c.Players.MaxCount == 0 {
-		return server.PlayerCount() + 1
+	if server.c.Players.MaxCount <= 0 {
+		return -1
 	}
 	return server.c.Players.MaxCount
 }
 
+// reserveSlot reserves a player slot for a player with the UUID, XUID and name passed that is joining the
+// server. False is returned if the server is full and the player may not bypass the limit. Reserving the slot
+// before the player is spawned ensures that two players joining at the same time cannot both take the last
+// slot. A reserved slot must be released using releaseSlot if the player fails to join, or taken over using
+// addPlayer if it does join.
+func (server *Server) reserveSlot(id uuid.UUID, xuid, name string) bool {
+	if server.takeSlot(id, false) {
+		return true
+	}
+	server.hookMu.RLock()
+	hooks := append([]func(xuid, name string) bool{}, server.fullHooks...)
+	server.hookMu.RUnlock()
+	for _, f := range hooks {
+		if f(xuid, name) {
+			return server.takeSlot(id, true)
+		}
+	}
+	return false
+}
+
+// takeSlot takes a player slot if one is available. If force is true, the slot is taken even if the server
+// is full. A player that is already online with the same UUID, and that will be replaced by the joining
+// player, may always take a slot.
+func (server *Server) takeSlot(id uuid.UUID, force bool) bool {
+	server.playerMutex.Lock()
+	defer server.playerMutex.Unlock()
+
+	_, online := server.p[id]
+	if max := server.MaxPlayerCount(); !force && !online && max >= 0 && len(server.p)+server.reserved >= max {
+		return false
+	}
+	server.reserved++
+	return true
+}
+
+// releaseSlot releases a slot reserved using reserveSlot for a player that failed to join.
+func (server *Server) releaseSlot() {
+	server.playerMutex.Lock()
+	defer server.playerMutex.Unlock()
+	server.reserved--
+}
+
 // Players returns a list of all players currently connected to the server. Note that the slice returned is
 // not updated when new players join or leave, so it is only valid for as long as no new players join or
 // players leave.
@@ -213,6 +588,20 @@ func (server *Server) Players() []*player.Player {
 	return players
 }
 
+// AddListEntry adds an entry with the name passed to the player list of all players, including players that
+// join later. The entry does not belong to any player, so it may be used to show information in the player
+// list, such as a header above the players. The UUID returned may be passed to RemoveListEntry to remove the
+// entry again.
+func (server *Server) AddListEntry(name string) uuid.UUID {
+	return session.AddListEntry(name)
+}
+
+// RemoveListEntry removes an entry added using AddListEntry from the player list of all players. Nothing
+// happens if no entry with the UUID passed exists.
+func (server *Server) RemoveListEntry(id uuid.UUID) {
+	session.RemoveListEntry(id)
+}
+
 // Player looks for a player on the server with the UUID passed. If found, the player is returned and the bool
 // returns holds a true value. If not, the bool returned is false and the player is nil.
 func (server *Server) Player(uuid uuid.UUID) (*player.Player, bool) {
@@ -236,16 +625,58 @@ func (server *Server) PlayerByName(name string) (*player.Player, bool) {
 	return nil, false
 }
 
+// PlayerByXUID looks for a player on the server with the XUID passed. If found, the player is returned and the
+// bool returned holds a true value. If not, the bool is false and the player is nil.
+// Unlike the name of a player, the XUID never changes, so PlayerByXUID should be preferred over PlayerByName
+// to find a player that was identified earlier.
+func (server *Server) PlayerByXUID(xuid string) (*player.Player, bool) {
+	for _, p := range server.Players() {
+		if p.XUID() == xuid {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// PlayerNameByXUID returns the last known name of the player with the XUID passed. If the player is online,
+// its current name is returned. Otherwise, the name is read from the data stored by the player provider. If
+// no player with the XUID is online and no data is stored for it, the bool returned is false.
+func (server *Server) PlayerNameByXUID(xuid string) (string, bool) {
+	if p, ok := server.PlayerByXUID(xuid); ok {
+		return p.Name(), true
+	}
+	d, err := server.playerProvider.Load(xuid)
+	if err != nil || d.Username == "" {
+		return "", false
+	}
+	return d.Username, true
+}
+
 // PlayerProvider changes the data provider of a player to the provider passed. The provider will dictate
 // the behaviour of player saving and loading. If nil is passed, the NopProvider will be used
-// which does not read or write any data.
+// which does not read or write any data. If the world is read-only in the config of the server, data saved to
+// the provider is discarded.
 func (server *Server) PlayerProvider(provider player.Provider) {
 	if provider == nil {
 		provider = player.NopProvider{}
 	}
+	if server.c.World.ReadOnly {
+		provider = readOnlyProvider{Provider: provider}
+	}
 	server.playerProvider = provider
 }
 
+// readOnlyProvider is a player.Provider used if the world is read-only. It loads player data from the
+// player.Provider it wraps, but discards all data saved to it.
+type readOnlyProvider struct {
+	player.Provider
+}
+
+// Save ...
+func (readOnlyProvider) Save(string, player.Data) error {
+	return nil
+}
+
 // SetNamef sets the name of the Server, also known as the MOTD. This name is displayed in the server list.
 // The formatting of the name passed follows the rules of fmt.Sprintf.
 func (server *Server) SetNamef(format string, a ...interface{}) {
@@ -258,6 +689,24 @@ func (server *Server) SetName(a ...interface{}) {
 	server.name.Store(fmt.Sprint(a...))
 }
 
+// SetSubNamef sets the sub-name of the Server, which is the second line of the MOTD. It is displayed below
+// the name of the server in the friends and LAN tab of the server list. If set to an empty string, the name
+// of the world is displayed instead. The sub-name is only advertised on the LAN, as described in
+// Config.Network.LANVisible.
+// The formatting of the sub-name passed follows the rules of fmt.Sprintf.
+func (server *Server) SetSubNamef(format string, a ...interface{}) {
+	server.sub.Store(fmt.Sprintf(format, a...))
+}
+
+// SetSubName sets the sub-name of the Server, which is the second line of the MOTD. It is displayed below
+// the name of the server in the friends and LAN tab of the server list. If set to an empty string, the name
+// of the world is displayed instead. The sub-name is only advertised on the LAN, as described in
+// Config.Network.LANVisible.
+// The formatting of the sub-name passed follows the rules of fmt.Sprint.
+func (server *Server) SetSubName(a ...interface{}) {
+	server.sub.Store(fmt.Sprint(a...))
+}
+
 // JoinMessage changes the join message for all players on the server. Leave this empty to disable it.
 // %v is the placeholder for the username of the player
 func (server *Server) JoinMessage(message string) {
@@ -270,34 +719,194 @@ func (server *Server) QuitMessage(message string) {
 	server.quitMessage.Store(message)
 }
 
-// Close closes the server, making any call to Run/Accept cancel immediately.
+// ChatFormat changes the format of chat messages sent by players that join the server after the call. %name%
+// is the placeholder for the name of the player and %message% for the message sent. If empty,
+// player.DefaultChatFormat is used. The format of players already online may be changed using
+// Player.SetChatFormat.
+func (server *Server) ChatFormat(format string) {
+	if format == "" {
+		format = player.DefaultChatFormat
+	}
+	server.chatFormat.Store(format)
+}
+
+// Broadcast sends a message to all players currently connected to the server and logs it to the console. The
+// message is formatted following the rules of fmt.Sprintf.
+func (server *Server) Broadcast(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	server.log.Infof("%v", msg)
+
+	server.playerMutex.RLock()
+	defer server.playerMutex.RUnlock()
+	for _, p := range server.p {
+		p.Message(msg)
+	}
+}
+
+// Close closes the server, making any call to Run/Accept cancel immediately. Close stops accepting new
+// connections, disconnects all players and waits for their sessions to close and their data to be saved.
+// Afterwards the world and player data are saved and all listeners are closed, after which a new Server may be
+// created using the same world folder.
+// Connections that are not closed within the ShutdownTimeout of the Config are closed forcibly. Close may be
+// called in any State. If the server was not yet started, Close only stops the world and player provider
+// created by New. Calling Close on a server that is already closing waits until it is closed, while calling it
+// on a server that is closed does nothing.
 func (server *Server) Close() error {
-	if !server.running() {
-		panic("server not yet running")
+	ctx := context.Background()
+	if t := server.c.Server.ShutdownTimeout; t > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(t)*time.Second)
+		defer cancel()
 	}
+	return server.CloseWithContext(ctx)
+}
+
+// CloseWithContext closes the server like Close, but waits for the connections of players to close until the
+// context.Context passed is done instead of using the ShutdownTimeout of the Config. Connections that are still
+// open at that point are closed forcibly and the data of their players is saved. An error wrapping the error
+// of the context is returned if that happened. The world is always saved completely, regardless of the context.
+func (server *Server) CloseWithContext(ctx context.Context) error {
+	server.lifeMu.Lock()
+	defer server.lifeMu.Unlock()
+
+	switch server.State() {
+	case StateCreated:
+		server.state.Store(int32(StateClosed))
+		server.closeData()
+		return nil
+	case StateClosing, StateClosed:
+		return nil
+	}
+	server.state.Store(int32(StateClosing))
+	// Closing the closing channel makes Accept return and stops new connections from being accepted. The
+	// listeners themselves are only closed later on: Closing a RakNet listener closes the connections of
+	// all players along with it, before they could be sent the shutdown message.
+	close(server.closing)
+	// Any connection tracked after this point sees that the server is closing, so connWg may be waited on
+	// safely.
+	server.connMu.Lock()
+	server.connMu.Unlock()
 
 	server.log.Infof("Server shutting down...")
 	defer server.log.Infof("Server stopped.")
 
 	server.log.Debugf("Disconnecting players...")
-	server.playerMutex.RLock()
-	for _, p := range server.p {
+	for _, p := range server.Players() {
 		p.Disconnect(text.Colourf("<yellow>%v</yellow>", server.c.Server.ShutdownMessage))
 	}
-	server.playerMutex.RUnlock()
+	server.publish(bridge.TopicPlayerCount, bridgeEvent{Count: 0})
+
+	var err error
+	if n := server.waitConns(ctx); n != 0 {
+		err = fmt.Errorf("close server: %v connections closed forcibly: %w", n, ctx.Err())
+		server.log.Errorf("Connections did not close in time: %v connections closed forcibly.", n)
+	}
+	server.closeData()
+
+	if server.lan != nil {
+		_ = server.lan.Close()
+	}
+
+	server.log.Debugf("Closing listeners...")
+	server.closeListeners()
+	<-server.done
+
+	server.state.Store(int32(StateClosed))
+	return err
+}
+
+// waitConns waits until all connections of the server are closed, or until the context.Context passed is
+// done. In the latter case, the remaining connections are closed forcibly and the data of the players that
+// were not yet saved is saved. waitConns returns the amount of connections that were closed forcibly.
+func (server *Server) waitConns(ctx context.Context) int {
+	closed := make(chan struct{})
+	go func() {
+		server.connWg.Wait()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		return 0
+	case <-ctx.Done():
+	}
+
+	server.connMu.Lock()
+	n := len(server.conns)
+	for conn := range server.conns {
+		_ = conn.Close()
+	}
+	server.connMu.Unlock()
+
+	// The sessions of these players are stuck, so their data is saved here instead. They are removed from
+	// the server, including from left, so that handleSessionClose does nothing once their session does close:
+	// By then, the providers that their data would be saved to may already be closed.
+	server.playerMutex.Lock()
+	left := server.left
+	server.p = make(map[uuid.UUID]*player.Player)
+	server.left = make(map[*player.Player]chan struct{})
+	server.playerMutex.Unlock()
+	for p, l := range left {
+		if err := server.playerProvider.Save(p.XUID(), p.Data()); err != nil {
+			server.log.Errorf("Error while saving data: %v", err)
+		}
+		if err := server.ops.set(p.XUID(), p.OperatorLevel()); err != nil {
+			server.log.Errorf("Error while saving operators: %v", err)
+		}
+		close(l)
+	}
+	return n
+}
 
+// trackConn adds the session.Conn passed to the connections that Close waits for. It returns false if the
+// server is closing, in which case the connection should be refused.
+func (server *Server) trackConn(conn session.Conn) bool {
+	server.connMu.Lock()
+	defer server.connMu.Unlock()
+	select {
+	case <-server.closing:
+		return false
+	default:
+	}
+	server.conns[conn] = struct{}{}
+	server.connWg.Add(1)
+	return true
+}
+
+// untrackConn removes a session.Conn added using trackConn once it is closed.
+func (server *Server) untrackConn(conn session.Conn) {
+	server.connMu.Lock()
+	defer server.connMu.Unlock()
+	if _, ok := server.conns[conn]; ok {
+		delete(server.conns, conn)
+		server.connWg.Done()
+	}
+}
+
+// closeData closes the player provider and the worlds of the server, saving their data.
+func (server *Server) closeData() {
 	server.log.Debugf("Closing player provider...")
-	err := server.playerProvider.Close()
-	if err != nil {
+	if err := server.playerProvider.Close(); err != nil {
 		server.log.Errorf("Error while closing player provider: %v", err)
 	}
 
 	server.log.Debugf("Closing world...")
-	if err = server.world.Close(); err != nil {
+	if err := server.world.Close(); err != nil {
 		server.log.Errorf("Error while closing world: %v", err)
 	}
 
-	server.log.Debugf("Closing listeners...")
+	server.worldMu.Lock()
+	defer server.worldMu.Unlock()
+	for name, w := range server.worlds {
+		server.log.Debugf("Closing world '%v'...", name)
+		if err := w.Close(); err != nil {
+			server.log.Errorf("Error while closing world '%v': %v", name, err)
+		}
+	}
+	server.worlds = map[string]*world.World{}
+}
+
+// closeListeners closes all Listeners added to the server.
+func (server *Server) closeListeners() {
 	server.listenMu.Lock()
 	defer server.listenMu.Unlock()
 	for _, l := range server.listeners {
@@ -305,16 +914,20 @@ func (server *Server) Close() error {
 			server.log.Errorf("Error closing listener: %v", err)
 		}
 	}
-	return nil
 }
 
 // Listen makes the Server listen for new connections from the Listener passed. This may be used to listen for players
 // on different interfaces. Note that the maximum player count of additional Listeners added is not enforced
 // automatically. The limit must be enforced by the Listener.
+// Listen may be called multiple times to listen on multiple Listeners at once. All Listeners are closed when the
+// Server is closed.
 func (server *Server) Listen(l Listener) {
 	server.listenMu.Lock()
 	server.listeners = append(server.listeners, l)
 	server.listenMu.Unlock()
+	if pl, ok := l.(PackListener); ok {
+		pl.SetPackFunc(server.selectPacks)
+	}
 
 	server.wg.Add(1)
 
@@ -330,6 +943,13 @@ func (server *Server) Listen(l Listener) {
 				server.wg.Done()
 				return
 			}
+			if !server.joinPool.admit() {
+				// Too many connections are already spawning. Rejecting the connection straight away keeps a
+				// flood of connections from piling up.
+				_ = l.Disconnect(c, "The server is busy. Please try again later.")
+				server.log.Debugf("connection %v refused: server busy\n", c.RemoteAddr())
+				continue
+			}
 			wg.Add(1)
 			go server.finaliseConn(c, l, wg)
 		}
@@ -351,27 +971,56 @@ func (server *Server) CloseOnProgramEnd() {
 
 // running checks if the server is currently running.
 func (server *Server) running() bool {
-	return server.started.Load()
+	return server.State() == StateRunning
 }
 
 // startListening starts making the EncodeBlock listener listen, accepting new connections from players.
 func (server *Server) startListening() error {
 	server.startTime = time.Now()
+	if !server.c.Server.AuthEnabled {
+		server.log.Infof("WARNING: XBOX Live authentication is disabled. Players may join with any name and impersonate other players.")
+	}
+	addresses := server.c.Network.Addresses
+	if server.c.Network.Address != "" {
+		addresses = append([]string{server.c.Network.Address}, addresses...)
+	}
+	if len(addresses) == 0 {
+		// No RakNet listener should be started: Connections are only accepted from Listeners added using Listen.
+		server.listenMu.Lock()
+		defer server.listenMu.Unlock()
+		if len(server.listeners) == 0 {
+			return fmt.Errorf("no network address configured and no listeners added using Server.Listen")
+		}
+		return nil
+	}
 
 	cfg := minecraft.ListenConfig{
-		MaximumPlayers:         server.c.Players.MaxCount,
+		// The listener does not limit the amount of players itself: The server does, so that players may
+		// bypass the limit using OnServerFull.
 		StatusProvider:         statusProvider{s: server},
 		AuthenticationDisabled: !server.c.Server.AuthEnabled,
-		ResourcePacks:          server.resources,
+		ResourcePacks:          server.ResourcePacks(),
 	}
 
-	l, err := cfg.Listen("raknet", server.c.Network.Address)
-	if err != nil {
-		return fmt.Errorf("listening on address failed: %w", err)
+	// A listener is created for every address, all of which pass their connections to the same Server. If
+	// any of them fails to listen, the listeners already created are closed by the caller.
+	port := 0
+	for i, addr := range addresses {
+		l, err := cfg.Listen("raknet", addr)
+		if err != nil {
+			return fmt.Errorf("listening on address %q failed: %w", addr, err)
+		}
+		server.Listen(listener{Listener: l})
+		if i == 0 {
+			port = l.Addr().(*net.UDPAddr).Port
+		}
+		server.log.Infof("Server running on %v.\n", l.Addr())
+	}
+	// The server is only advertised once all listeners are up, so that the advertiser never needs to be
+	// closed again if one of them fails.
+	if server.c.Network.LANVisible {
+		server.advertiseLAN(port)
 	}
-	server.Listen(listener{Listener: l})
-
-	server.log.Infof("Server running on %v.\n", l.Addr())
 	return nil
 }
 
@@ -380,45 +1029,138 @@ func (server *Server) startListening() error {
 func (server *Server) wait() {
 	server.wg.Wait()
 	close(server.players)
+	close(server.done)
 }
 
-// finaliseConn finalises the session.Conn passed and subtracts from the sync.WaitGroup once done.
-func (server *Server) finaliseConn(conn session.Conn, l Listener, wg *sync.WaitGroup) {
-	defer wg.Done()
-	data := minecraft.GameData{
+// gameRules holds the game rules sent to every player joining the server, regardless of the game rules of the
+// world that the player spawns in.
+var gameRules = []protocol.GameRule{{Name: "naturalregeneration", Value: false}}
+
+// gameData returns the minecraft.GameData sent to a player joining the server in the world passed, before the
+// position and data of the player itself are applied to it. The item entries and custom block entries are
+// shared between all connections and must not be modified.
+func (server *Server) gameData(w *world.World) minecraft.GameData {
+	return minecraft.GameData{
 		Yaw:            90,
 		WorldName:      server.c.World.Name,
-		PlayerPosition: vec64To32(server.world.Spawn().Vec3Centre().Add(mgl64.Vec3{0, 1.62})),
-		PlayerGameMode: 1,
+		PlayerGameMode: session.GameModeType(w.DefaultGameMode()),
 		// We set these IDs to 1, because that's how the session will treat them.
 		EntityUniqueID:               1,
 		EntityRuntimeID:              1,
-		Time:                         int64(server.world.Time()),
-		GameRules:                    []protocol.GameRule{{Name: "naturalregeneration", Value: false}},
-		Difficulty:                   2,
-		Items:                        server.itemEntries(),
+		Time:                         int64(w.Time()),
+		GameRules:                    append(session.GameRules(w.GameRules()), gameRules...),
+		Difficulty:                   session.DifficultyType(w.Difficulty()),
+		Items:                        server.items,
+		CustomBlocks:                 server.customBlocks,
 		PlayerMovementSettings:       protocol.PlayerMovementSettings{MovementType: protocol.PlayerMovementModeServer, ServerAuthoritativeBlockBreaking: true},
 		ServerAuthoritativeInventory: true,
 	}
-	// UUID is validated by gophertunnel.
-	id, _ := uuid.Parse(conn.IdentityData().Identity)
+}
+
+// finaliseConn finalises the session.Conn passed and subtracts from the sync.WaitGroup once done. The
+// session.Conn must have been admitted to the joinPool.
+func (server *Server) finaliseConn(conn session.Conn, l Listener, wg *sync.WaitGroup) {
+	defer wg.Done()
+	p, ok := server.spawnConn(conn, l)
+	if !ok {
+		return
+	}
+
+	select {
+	case <-server.closing:
+		// The server started closing while the player was joining, so it might have missed being disconnected
+		// along with the other players.
+		p.Disconnect(text.Colourf("<yellow>%v</yellow>", server.c.Server.ShutdownMessage))
+		return
+	default:
+	}
+
+	server.callHooks(&server.joinHooks, p)
+	if server.accepting.Load() {
+		select {
+		case server.players <- p:
+		case <-server.closing:
+			// The server is closing and Accept might no longer be called, so the player is not passed to it.
+		}
+	}
+}
+
+// spawnConn spawns the session.Conn passed and creates its player. Only a limited amount of connections are
+// spawned at the same time, as set in the Config, so spawnConn first waits for its turn in the joinPool. If
+// the connection could not be spawned, it is disconnected and false is returned.
+func (server *Server) spawnConn(conn session.Conn, l Listener) (*player.Player, bool) {
+	if !server.joinPool.wait(server.closing) {
+		_ = l.Disconnect(conn, text.Colourf("<yellow>%v</yellow>", server.c.Server.ShutdownMessage))
+		return nil, false
+	}
+	defer server.joinPool.done()
+
+	if !server.trackConn(conn) {
+		_ = l.Disconnect(conn, text.Colourf("<yellow>%v</yellow>", server.c.Server.ShutdownMessage))
+		return nil, false
+	}
+	addr := conn.RemoteAddr()
+	if !server.throttle.acquire(addr, time.Now()) {
+		// Only log at debug level: Logging every rejected connection would make log spam a way to slow down
+		// the server in itself.
+		_ = l.Disconnect(conn, "Too many connections from your address. Please try again later.")
+		server.untrackConn(conn)
+		server.log.Debugf("connection %v throttled\n", addr)
+		return nil, false
+	}
+	id, xuid := server.identity(conn)
+	if !server.reserveSlot(id, xuid, conn.IdentityData().DisplayName) {
+		server.throttle.release(addr)
+		_ = l.Disconnect(conn, server.c.Players.FullMessage)
+		server.untrackConn(conn)
+		server.log.Debugf("connection %v refused: server full\n", addr)
+		return nil, false
+	}
+	if !server.startJoining(id) {
+		server.throttle.release(addr)
+		server.releaseSlot()
+		_ = l.Disconnect(conn, "You are already logging in from another location.")
+		server.untrackConn(conn)
+		server.log.Debugf("connection %v refused: already joining\n", addr)
+		return nil, false
+	}
+	defer server.stopJoining(id)
+	// A player with the same UUID might still be online, for example if its connection dropped without the
+	// server noticing yet. It must have fully left before its data is loaded for the new connection.
+	if !server.replacePlayer(id) {
+		server.throttle.release(addr)
+		server.releaseSlot()
+		_ = l.Disconnect(conn, text.Colourf("<yellow>%v</yellow>", server.c.Server.ShutdownMessage))
+		server.untrackConn(conn)
+		return nil, false
+	}
 
 	var playerData *player.Data
-	if d, err := server.playerProvider.Load(id); err == nil {
-		data.PlayerPosition = vec64To32(d.Position).Add(mgl32.Vec3{0, 1.62})
-		data.Yaw, data.Pitch = float32(d.Yaw), float32(d.Pitch)
+	if d, err := server.loadPlayerData(id, xuid); err == nil {
 		playerData = &d
 	}
+	// The world and position that the player spawns at must be known before starting the game, as the
+	// position is sent in the StartGame packet.
+	w, pos := server.spawnLocation(SpawnInfo{UUID: id, XUID: xuid, Name: conn.IdentityData().DisplayName, Data: playerData})
+	primeSpawn(w, pos)
+
+	data := server.gameData(w)
+	data.PlayerPosition = vec64To32(pos).Add(mgl32.Vec3{0, 1.62})
+	if playerData != nil {
+		playerData.Position = pos
+		data.Yaw, data.Pitch = float32(playerData.Yaw), float32(playerData.Pitch)
+		data.PlayerGameMode = session.GameModeType(playerData.GameMode)
+	}
 
 	if err := conn.StartGame(data); err != nil {
+		server.throttle.release(addr)
+		server.releaseSlot()
 		_ = l.Disconnect(conn, "Connection timeout.")
+		server.untrackConn(conn)
 		server.log.Debugf("connection %v failed spawning: %v\n", conn.RemoteAddr(), err)
-		return
+		return nil, false
 	}
-	if p, ok := server.Player(id); ok {
-		p.Disconnect("Logged in from another location.")
-	}
-	server.players <- server.createPlayer(id, conn, playerData)
+	return server.createPlayer(id, xuid, conn, w, pos, playerData), true
 }
 
 // checkNetIsolation checks if a loopback exempt is in place to allow the hosting device to join the server. This is
@@ -436,44 +1178,276 @@ func (server *Server) checkNetIsolation() {
 	server.log.Infof("You are currently unable to join the server on this machine. Run %v in an admin PowerShell session to be able to.\n", loopbackExemptCmd)
 }
 
+// startJoining marks the player with the UUID passed as joining. False is returned if another connection
+// with the same UUID is already joining.
+func (server *Server) startJoining(id uuid.UUID) bool {
+	server.playerMutex.Lock()
+	defer server.playerMutex.Unlock()
+	if _, ok := server.joining[id]; ok {
+		return false
+	}
+	server.joining[id] = struct{}{}
+	return true
+}
+
+// stopJoining unmarks the player with the UUID passed as joining.
+func (server *Server) stopJoining(id uuid.UUID) {
+	server.playerMutex.Lock()
+	defer server.playerMutex.Unlock()
+	delete(server.joining, id)
+}
+
+// replacePlayer disconnects the player with the UUID passed if it is online and waits until it has fully left
+// the server, so that its data is saved and it is no longer in its world. False is returned if the server
+// started closing while waiting.
+func (server *Server) replacePlayer(id uuid.UUID) bool {
+	server.playerMutex.RLock()
+	p, ok := server.p[id]
+	left := server.left[p]
+	server.playerMutex.RUnlock()
+	if !ok {
+		return true
+	}
+	p.Disconnect("Logged in from another location.")
+	select {
+	case <-left:
+		return true
+	case <-server.closing:
+		return false
+	}
+}
+
+// addPlayer adds a player that joined the server, taking over the slot it reserved using reserveSlot.
+func (server *Server) addPlayer(id uuid.UUID, p *player.Player) {
+	server.playerMutex.Lock()
+	defer server.playerMutex.Unlock()
+	server.p[id] = p
+	server.left[p] = make(chan struct{})
+	server.reserved--
+}
+
 // handleSessionClose handles the closing of a session. It removes the player of the session from the server.
 func (server *Server) handleSessionClose(controllable session.Controllable) {
+	p, _ := controllable.(*player.Player)
+	server.playerMutex.RLock()
+	left, ok := server.left[p]
+	server.playerMutex.RUnlock()
+	if !ok {
+		// The player was never added to the server.
+		return
+	}
+	if reason, ok := p.Disconnected(); ok {
+		server.log.Debugf("Player %v left the server (%v).", p.Name(), reason)
+	}
+	server.callHooks(&server.quitHooks, p)
+
 	server.playerMutex.Lock()
-	p, ok := server.p[controllable.UUID()]
-	delete(server.p, controllable.UUID())
+	if _, ok := server.left[p]; !ok {
+		// waitConns gave up on the session while the quit hooks ran and already saved the data of the player.
+		server.playerMutex.Unlock()
+		return
+	}
+	defer close(left)
+	delete(server.left, p)
+	// The map entry is compared with the player rather than removed by UUID only, so that a player with the
+	// same UUID that already replaced this one is never removed.
+	if server.p[p.UUID()] == p {
+		delete(server.p, p.UUID())
+	}
 	server.playerMutex.Unlock()
-	if ok {
-		err := server.playerProvider.Save(controllable.UUID(), p.Data())
-		if err != nil {
-			server.log.Errorf("Error while saving data: %v", err)
+
+	if err := server.playerProvider.Save(p.XUID(), p.Data()); err != nil {
+		server.log.Errorf("Error while saving data: %v", err)
+	}
+	if err := server.ops.set(p.XUID(), p.OperatorLevel()); err != nil {
+		server.log.Errorf("Error while saving operators: %v", err)
+	}
+}
+
+// identity returns the UUID and XUID of the player connected through the session.Conn passed. If XBOX Live
+// authentication is disabled, the identity sent by the client cannot be trusted. In that case, the UUID is
+// derived from the display name of the player, so that it is the same every time the player joins, and the
+// XUID is the one returned by player.OfflineXUID.
+func (server *Server) identity(conn session.Conn) (uuid.UUID, string) {
+	d := conn.IdentityData()
+	if !server.c.Server.AuthEnabled {
+		return uuid.NewMD5(uuid.NameSpaceOID, []byte("OfflinePlayer:"+d.DisplayName)), player.OfflineXUID(d.DisplayName)
+	}
+	// UUID is validated by gophertunnel.
+	id, _ := uuid.Parse(d.Identity)
+	return id, d.XUID
+}
+
+// loadPlayerData loads the data of the player with the UUID and XUID passed from the player provider. If no
+// data is stored for the XUID, data stored for the UUID by older versions is migrated, if the provider
+// supports it.
+func (server *Server) loadPlayerData(id uuid.UUID, xuid string) (player.Data, error) {
+	d, err := server.playerProvider.Load(xuid)
+	if err != nil {
+		if m, ok := server.playerProvider.(player.UUIDMigrator); ok {
+			return m.MigrateUUID(id, xuid)
 		}
 	}
+	return d, err
 }
 
 // createPlayer creates a new player instance using the UUID and connection passed.
-func (server *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player.Data) *player.Player {
+func (server *Server) createPlayer(id uuid.UUID, xuid string, conn session.Conn, w *world.World, pos mgl64.Vec3, data *player.Data) *player.Player {
 	s := session.New(conn, server.c.Players.MaximumChunkRadius, server.log, &server.joinMessage, &server.quitMessage)
-	p := player.NewWithSession(conn.IdentityData().DisplayName, conn.IdentityData().XUID, id, server.createSkin(conn.ClientData()), s, server.world.Spawn().Vec3Middle(), data)
-	gm := server.world.DefaultGameMode()
+	s.SetCorrectionLogging(server.c.Players.LogCorrections)
+	s.SetMovementResponse(server.movementResponse)
+	s.SetMinimumChunkRadius(server.c.Players.MinimumChunkRadius)
+	p := player.NewWithSession(conn.IdentityData().DisplayName, xuid, id, server.createSkin(conn.ClientData()), s, pos, data)
+	p.SetChatFormat(server.chatFormat.Load())
+	p.SetChatFunc(func(message string) {
+		server.chat(p, message)
+	})
+	gm := w.DefaultGameMode()
 	if data != nil {
 		gm = data.GameMode
 	}
-	s.Start(p, server.world, gm, server.handleSessionClose)
+	// The player is added before the session is started, so that it is always added before the session is
+	// closed and removes it again.
+	server.addPlayer(id, p)
+	p.SetPermissionChecker(server.permissionChecker())
+	server.blockActors.add(s)
+	s.Start(p, w, gm, func(controllable session.Controllable) {
+		server.blockActors.remove(s)
+		server.throttle.release(conn.RemoteAddr())
+		server.handleSessionClose(controllable)
+		server.untrackConn(conn)
+	})
+	// The operator level is set once the session is started, so that the permission level sent to the client
+	// along with the game mode is updated.
+	p.SetOperator(server.ops.level(xuid))
 	return p
 }
 
-// loadWorld loads the world of the server, ending the program if the world could not be loaded.
-func (server *Server) loadWorld() {
+// loadWorld loads the world of the server. If the world could not be loaded, an error is returned.
+func (server *Server) loadWorld() error {
 	server.log.Debugf("Loading world...")
 
-	p, err := mcdb.New(server.c.World.Folder)
+	p, err := server.worldProvider(server.c.World.Folder)
 	if err != nil {
-		server.log.Fatalf("error loading world: %v", err)
+		return fmt.Errorf("load world: %w", err)
 	}
 	server.world.Provider(p)
-	server.world.Generator(generator.Flat{})
+	if server.c.World.ReadOnly {
+		server.world.ReadOnly()
+	}
+	g, err := server.worldGenerator()
+	if err != nil {
+		return fmt.Errorf("load world: %w", err)
+	}
+	server.world.Generator(g)
+
+	if name := server.c.World.DefaultGameMode; name != "" {
+		mode, ok := world.GameModeByName(name)
+		if !ok {
+			return fmt.Errorf("load world: unknown default game mode %q", name)
+		}
+		server.world.SetDefaultGameMode(mode)
+	}
+	if name := server.c.World.Difficulty; name != "" {
+		d, ok := world.DifficultyByName(name)
+		if !ok {
+			return fmt.Errorf("load world: unknown difficulty %q", name)
+		}
+		server.world.SetDifficulty(d)
+	}
+	if server.c.World.KeepInventory {
+		server.world.SetKeepInventory(true)
+	}
+	server.world.SetTickingAreaLimits(server.c.World.MaxTickingAreas, server.c.World.MaxTickingAreaChunks)
+	server.world.SaveInterval(time.Duration(server.c.World.SaveInterval) * time.Minute)
+	server.world.SetChunkCacheLimits(time.Duration(server.c.World.ChunkUnloadDelay)*time.Second, server.c.World.MaxLoadedChunks)
+	server.world.SetPauseTickingOnSave(server.c.World.PauseTickingOnSave)
+	server.configureTicking(server.world)
 
 	server.log.Debugf("Loaded world '%v'.", server.world.Name())
+	return nil
+}
+
+// configureTicking applies the tick rate and pausing settings in the config of the server to the world passed.
+func (server *Server) configureTicking(w *world.World) {
+	if rate := server.c.World.TickRate; rate > 0 {
+		w.SetTickRate(rate)
+	}
+	if speed := server.c.World.RandomTickSpeed; speed >= 0 {
+		w.SetRandomTickSpeed(speed)
+	}
+	w.SetWeatherCycle(server.c.World.WeatherCycle)
+	w.SetPauseWhenEmpty(server.c.World.PauseWhenEmpty)
+	w.SetCatchUpBlockUpdates(server.c.World.CatchUpBlockUpdates)
+}
+
+// worldProvider opens the world in the folder passed. If the world is read-only in the config of the server,
+// the world is opened using mcdb.NewReadOnly, so that its files are never written to. Otherwise, if the folder
+// holds a Java Edition world, it is first converted using mcdb.ConvertAnvil.
+func (server *Server) worldProvider(folder string) (*mcdb.Provider, error) {
+	if server.c.World.ReadOnly {
+		return mcdb.NewReadOnly(folder)
+	}
+	if isDir(filepath.Join(folder, "region")) && !isDir(filepath.Join(folder, "db")) {
+		server.log.Infof("Converting Java Edition world in %v...", folder)
+		if err := mcdb.ConvertAnvil(folder, folder, server.log); err != nil {
+			return nil, err
+		}
+	}
+	return mcdb.New(folder)
+}
+
+// isDir checks if a directory exists at the path passed.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// worldGenerator returns the world.Generator set in the config of the server. An error is returned if the
+// generator or its layers were not valid.
+func (server *Server) worldGenerator() (world.Generator, error) {
+	switch strings.ToLower(server.c.World.Generator) {
+	case "", "flat":
+		layers, err := parseFlatLayers(server.c.World.FlatLayers)
+		if err != nil {
+			return nil, err
+		}
+		return generator.Flat{Layers: layers}, nil
+	case "void":
+		return world.NopGenerator{}, nil
+	}
+	return nil, fmt.Errorf("unknown world generator %q", server.c.World.Generator)
+}
+
+// parseFlatLayers parses layers of a flat world in the format 'minecraft:bedrock,2*minecraft:dirt'. Each
+// layer is the name of a block item, optionally prefixed with the amount of times that it is repeated.
+func parseFlatLayers(s string) ([]world.Block, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var layers []world.Block
+	for _, layer := range strings.Split(s, ",") {
+		n, name := 1, strings.TrimSpace(layer)
+		if i := strings.Index(name, "*"); i != -1 {
+			count, err := strconv.Atoi(name[:i])
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid layer count in flat layer %q", layer)
+			}
+			n, name = count, name[i+1:]
+		}
+		it, ok := world.ItemByName(name, 0)
+		if !ok {
+			return nil, fmt.Errorf("unknown block %q in flat layer %q", name, layer)
+		}
+		b, ok := it.(world.Block)
+		if !ok {
+			return nil, fmt.Errorf("item %q in flat layer %q is not a block", name, layer)
+		}
+		for i := 0; i < n; i++ {
+			layers = append(layers, b)
+		}
+	}
+	return layers, nil
 }
 
 // createSkin creates a new skin using the skin data found in the client data in the login, and returns it.
@@ -491,9 +1465,24 @@ func (server *Server) createSkin(data login.ClientData) skin.Skin {
 	playerSkin.Model = modelData
 	playerSkin.ModelConfig = modelConfig
 	playerSkin.PlayFabID = data.PlayFabID
+	playerSkin.ArmSize, playerSkin.Colour = data.ArmSize, data.SkinColour
 
-	playerSkin.Cape = skin.NewCape(data.CapeImageWidth, data.CapeImageHeight)
-	playerSkin.Cape.Pix = capeData
+	if cape := skin.NewCape(data.CapeImageWidth, data.CapeImageHeight); len(capeData) == len(cape.Pix) && len(capeData) != 0 {
+		cape.Pix = capeData
+		playerSkin.Cape = cape
+	}
+	for _, piece := range data.PersonaPieces {
+		playerSkin.PersonaPieces = append(playerSkin.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			ProductID: piece.ProductID,
+			Default:   piece.Default,
+		})
+	}
+	for _, tint := range data.PieceTintColours {
+		playerSkin.PersonaTints = append(playerSkin.PersonaTints, skin.PersonaTint{PieceType: tint.PieceType, Colours: tint.Colours[:]})
+	}
 
 	for _, animation := range data.AnimatedImageData {
 		var t skin.AnimationType
@@ -513,6 +1502,13 @@ func (server *Server) createSkin(data login.ClientData) skin.Skin {
 		playerSkin.Animations = append(playerSkin.Animations, anim)
 	}
 
+	if err := playerSkin.Validate(); err != nil {
+		server.log.Debugf("invalid skin of %v, using default skin: %v", data.ThirdPartyName, err)
+		if data.ArmSize == "slim" {
+			return skin.DefaultSlim()
+		}
+		return skin.Default()
+	}
 	return playerSkin
 }
 
@@ -554,14 +1550,28 @@ func (server *Server) itemEntries() (entries []protocol.ItemEntry) {
 	return
 }
 
-// loadResources loads resource packs from path of specifed directory.
-func (server *Server) loadResources(p string, log internal.Logger) {
+// blockEntries loads a list of all custom block entries of the server, ready to be sent in the StartGame
+// packet.
+func (server *Server) blockEntries() (entries []protocol.BlockEntry) {
+	for _, b := range world.CustomBlocks() {
+		name, _ := b.EncodeBlock()
+		entries = append(entries, protocol.BlockEntry{
+			Name:       name,
+			Properties: world.CustomBlockProperties(b),
+		})
+	}
+	return
+}
+
+// loadResources loads resource packs from path of specifed directory. An error is returned if the directory
+// could not be read.
+func (server *Server) loadResources(p string, log internal.Logger) error {
 	if _, err := os.Stat(p); os.IsNotExist(err) {
 		_ = os.Mkdir(p, 0777)
 	}
 	resources, err := os.ReadDir(p)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("load resources: %w", err)
 	}
 	for _, entry := range resources {
 		r, err := resource.Compile(filepath.Join(p, entry.Name()))
@@ -572,4 +1582,5 @@ func (server *Server) loadResources(p string, log internal.Logger) {
 
 		server.resources = append(server.resources, r)
 	}
+	return nil
 }
@@ -0,0 +1,527 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testListener is a Listener that accepts the connections sent to its conns channel, if any.
+type testListener struct {
+	once   sync.Once
+	closed chan struct{}
+	conns  chan session.Conn
+}
+
+func (l *testListener) Accept() (session.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *testListener) Disconnect(session.Conn, string) error {
+	return nil
+}
+
+func (l *testListener) Addr() net.Addr {
+	return &net.UDPAddr{}
+}
+
+func (l *testListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func TestServerRestart(t *testing.T) {
+	dir := t.TempDir()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 5; i++ {
+		conf := DefaultConfig()
+		conf.Network.Address = ""
+		conf.World.Folder = filepath.Join(dir, "world")
+		conf.Players.SaveData = false
+		conf.Resources.Folder = filepath.Join(dir, "resources")
+
+		srv := New(&conf, log)
+		if srv.State() != StateCreated {
+			t.Fatalf("run %v: expected state %v, got %v", i, StateCreated, srv.State())
+		}
+		srv.Listen(&testListener{closed: make(chan struct{})})
+		if err := srv.Start(); err != nil {
+			t.Fatalf("run %v: error starting server: %v", i, err)
+		}
+		if srv.State() != StateRunning {
+			t.Fatalf("run %v: expected state %v, got %v", i, StateRunning, srv.State())
+		}
+		if err := srv.Close(); err != nil {
+			t.Fatalf("run %v: error closing server: %v", i, err)
+		}
+		if srv.State() != StateClosed {
+			t.Fatalf("run %v: expected state %v, got %v", i, StateClosed, srv.State())
+		}
+		// Closing again should do nothing.
+		_ = srv.Close()
+	}
+
+	// Goroutines may take a moment to return after they were stopped.
+	deadline := time.Now().Add(time.Second * 5)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("expected at most %v goroutines after closing, got %v", before, n)
+	}
+}
+
+func TestServerCloseBeforeStart(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(t.TempDir(), "resources")
+
+	srv := New(&conf, nil)
+	if err := srv.Close(); err != nil {
+		t.Fatalf("error closing server: %v", err)
+	}
+	if srv.State() != StateClosed {
+		t.Fatalf("expected state %v, got %v", StateClosed, srv.State())
+	}
+}
+
+func TestReserveLastSlot(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.MaxCount = 1
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	defer srv.Close()
+
+	// Two players joining at the same time must never both take the last slot.
+	for i := 0; i < 100; i++ {
+		var wg sync.WaitGroup
+		var reserved atomic.Int32
+		for j := 0; j < 2; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if srv.reserveSlot(uuid.New(), "", "player") {
+					reserved.Inc()
+				}
+			}()
+		}
+		wg.Wait()
+		if n := reserved.Load(); n != 1 {
+			t.Fatalf("run %v: expected exactly 1 player to get the last slot, got %v", i, n)
+		}
+		srv.releaseSlot()
+	}
+
+	if !srv.reserveSlot(uuid.New(), "", "player") {
+		t.Fatalf("expected a slot to be available on an empty server")
+	}
+	srv.OnServerFull(func(xuid, name string) bool {
+		return name == "staff"
+	})
+	if srv.reserveSlot(uuid.New(), "", "player") {
+		t.Errorf("expected player to be refused on a full server")
+	}
+	if !srv.reserveSlot(uuid.New(), "", "staff") {
+		t.Errorf("expected staff to bypass the player limit")
+	}
+}
+
+// hangConn is a session.Conn of which StartGame hangs until the connection is closed.
+type hangConn struct {
+	once            sync.Once
+	started, closed chan struct{}
+}
+
+func (c *hangConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+func (c *hangConn) StartGame(minecraft.GameData) error {
+	close(c.started)
+	<-c.closed
+	return net.ErrClosed
+}
+func (c *hangConn) IdentityData() login.IdentityData   { return login.IdentityData{DisplayName: "test"} }
+func (c *hangConn) ClientData() login.ClientData       { return login.ClientData{} }
+func (c *hangConn) ClientCacheEnabled() bool           { return false }
+func (c *hangConn) ChunkRadius() int                   { return 4 }
+func (c *hangConn) Latency() time.Duration             { return 0 }
+func (c *hangConn) Flush() error                       { return nil }
+func (c *hangConn) RemoteAddr() net.Addr               { return &net.UDPAddr{} }
+func (c *hangConn) ReadPacket() (packet.Packet, error) { return nil, net.ErrClosed }
+func (c *hangConn) WritePacket(packet.Packet) error    { return nil }
+
+// silentConn is a session.Conn that joins successfully, but never sends any packets, like a connection that
+// dropped without the server noticing. Reading from it blocks until it is closed.
+type silentConn struct {
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (c *silentConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+func (c *silentConn) ReadPacket() (packet.Packet, error) {
+	<-c.closed
+	return nil, net.ErrClosed
+}
+func (c *silentConn) StartGame(minecraft.GameData) error { return nil }
+func (c *silentConn) IdentityData() login.IdentityData {
+	return login.IdentityData{DisplayName: "test"}
+}
+func (c *silentConn) ClientData() login.ClientData    { return login.ClientData{} }
+func (c *silentConn) ClientCacheEnabled() bool        { return false }
+func (c *silentConn) ChunkRadius() int                { return 4 }
+func (c *silentConn) Latency() time.Duration          { return 0 }
+func (c *silentConn) Flush() error                    { return nil }
+func (c *silentConn) RemoteAddr() net.Addr            { return &net.UDPAddr{} }
+func (c *silentConn) WritePacket(packet.Packet) error { return nil }
+
+func TestRejoinAfterSilentDrop(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = ""
+	conf.Server.AuthEnabled = false
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	l := &testListener{closed: make(chan struct{}), conns: make(chan session.Conn)}
+	srv.Listen(l)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	defer srv.Close()
+	// Players are only passed to Accept once it was called for the first time. The connections below are
+	// passed to the listener before calling Accept, so the server must already be accepting players.
+	srv.accepting.Store(true)
+
+	accept := func(conn session.Conn) *player.Player {
+		l.conns <- conn
+		p, err := srv.Accept()
+		if err != nil {
+			t.Fatalf("error accepting player: %v", err)
+		}
+		return p
+	}
+	oldConn := &silentConn{closed: make(chan struct{})}
+	old := accept(oldConn)
+	// The old connection never reports being closed by itself, so the new connection joins while the old
+	// session is still connected.
+	p := accept(&silentConn{closed: make(chan struct{})})
+
+	select {
+	case <-oldConn.closed:
+	default:
+		t.Fatalf("expected old connection to be closed")
+	}
+	if old.World() != nil {
+		t.Errorf("expected old player to be removed from its world")
+	}
+	if found, ok := srv.Player(p.UUID()); !ok || found != p {
+		t.Errorf("expected new player to be online, got %v", found)
+	}
+	if n := srv.PlayerCount(); n != 1 {
+		t.Errorf("expected 1 player online, got %v", n)
+	}
+}
+
+func TestStartWorldLoadError(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = ""
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.World.Generator = "bogus"
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	if err := srv.Start(); err == nil {
+		t.Fatalf("expected error starting server with unknown generator")
+	}
+	if srv.State() != StateClosed {
+		t.Fatalf("expected state %v, got %v", StateClosed, srv.State())
+	}
+}
+
+func TestStartAddressInUse(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	defer conn.Close()
+	inUse := conn.LocalAddr().String()
+
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = "127.0.0.1:0"
+	conf.Network.Addresses = []string{inUse}
+	conf.Network.LANVisible = true
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	err = srv.Start()
+	if err == nil || !strings.Contains(err.Error(), inUse) {
+		t.Fatalf("expected error naming address %v in use, got %v", inUse, err)
+	}
+	if srv.State() != StateClosed {
+		t.Fatalf("expected state %v, got %v", StateClosed, srv.State())
+	}
+	if srv.lan != nil {
+		t.Errorf("expected server not to be advertised on the LAN if listening failed")
+	}
+}
+
+func BenchmarkGameData(b *testing.B) {
+	conf := DefaultConfig()
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(b.TempDir(), "resources")
+
+	srv := New(&conf, nil)
+	defer srv.Close()
+	srv.items, srv.customBlocks = srv.itemEntries(), srv.blockEntries()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data := srv.gameData(srv.world)
+		if len(data.Items) != len(srv.items) {
+			b.Fatalf("expected %v item entries, got %v", len(srv.items), len(data.Items))
+		}
+	}
+}
+
+func TestSpawnLocation(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Players.SaveData = false
+	conf.Players.SpawnPosition = []float64{0.5, 80, 0.5}
+	conf.Resources.Folder = filepath.Join(t.TempDir(), "resources")
+
+	srv := New(&conf, nil)
+	defer srv.Close()
+
+	if w, pos := srv.spawnLocation(SpawnInfo{}); w != srv.world || pos != (mgl64.Vec3{0.5, 80, 0.5}) {
+		t.Fatalf("expected spawn position from config, got %v", pos)
+	}
+	if _, pos := srv.spawnLocation(SpawnInfo{Data: &player.Data{Position: mgl64.Vec3{10, 70, 10}}}); pos != (mgl64.Vec3{10, 70, 10}) {
+		t.Fatalf("expected saved position to be used, got %v", pos)
+	}
+	srv.OnPlayerSpawn(func(info SpawnInfo) (*world.World, mgl64.Vec3) {
+		return nil, info.Position.Add(mgl64.Vec3{0, 10})
+	})
+	if w, pos := srv.spawnLocation(SpawnInfo{}); w != srv.world || pos != (mgl64.Vec3{0.5, 90, 0.5}) {
+		t.Fatalf("expected spawn position from hook, got %v", pos)
+	}
+}
+
+func TestCloseWithContextTimeout(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = ""
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	l := &testListener{closed: make(chan struct{}), conns: make(chan session.Conn)}
+	srv.Listen(l)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	accepted := make(chan error, 1)
+	go func() {
+		_, err := srv.Accept()
+		accepted <- err
+	}()
+
+	conn := &hangConn{started: make(chan struct{}), closed: make(chan struct{})}
+	l.conns <- conn
+	<-conn.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/2)
+	defer cancel()
+	closed := make(chan error, 1)
+	go func() {
+		closed <- srv.CloseWithContext(ctx)
+	}()
+
+	// Accept must return as soon as the server starts closing, not once it is closed.
+	select {
+	case err := <-accepted:
+		if err == nil {
+			t.Errorf("expected Accept to return an error once the server is closing")
+		}
+	case <-closed:
+		t.Fatalf("expected Accept to return before the server was closed")
+	case <-time.After(time.Second / 4):
+		t.Fatalf("expected Accept to return once the server started closing")
+	}
+
+	if err := <-closed; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error wrapping %v after closing forcibly, got %v", context.DeadlineExceeded, err)
+	}
+	select {
+	case <-conn.closed:
+	default:
+		t.Errorf("expected hanging connection to be closed forcibly")
+	}
+	if srv.State() != StateClosed {
+		t.Errorf("expected state %v, got %v", StateClosed, srv.State())
+	}
+}
+
+// countProvider is a player.Provider that counts the calls to Save.
+type countProvider struct {
+	player.NopProvider
+	saves *atomic.Int32
+}
+
+func (p countProvider) Save(string, player.Data) error {
+	p.saves.Inc()
+	return nil
+}
+
+func TestLateCloseAfterContextTimeout(t *testing.T) {
+	dir := t.TempDir()
+	conf := DefaultConfig()
+	conf.Network.Address = ""
+	conf.Server.AuthEnabled = false
+	conf.World.Folder = filepath.Join(dir, "world")
+	conf.Players.SaveData = false
+	conf.Resources.Folder = filepath.Join(dir, "resources")
+
+	srv := New(&conf, nil)
+	saves := atomic.NewInt32(0)
+	srv.PlayerProvider(countProvider{saves: saves})
+	l := &testListener{closed: make(chan struct{}), conns: make(chan session.Conn)}
+	srv.Listen(l)
+	if err := srv.Start(); err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	srv.accepting.Store(true)
+
+	// The quit hook keeps the session from closing until the server gave up waiting for it.
+	release, quit := make(chan struct{}), make(chan struct{})
+	srv.OnPlayerQuit(func(*player.Player) {
+		<-release
+		close(quit)
+	})
+	l.conns <- &silentConn{closed: make(chan struct{})}
+	if _, err := srv.Accept(); err != nil {
+		t.Fatalf("error accepting player: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/4)
+	defer cancel()
+	if err := srv.CloseWithContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error wrapping %v after closing forcibly, got %v", context.DeadlineExceeded, err)
+	}
+	if n := saves.Load(); n != 1 {
+		t.Fatalf("expected data of the player to be saved once when closing forcibly, got %v", n)
+	}
+
+	// The session finishing closing after the server was closed must not save the data of the player again.
+	close(release)
+	<-quit
+	time.Sleep(time.Second / 10)
+	if n := saves.Load(); n != 1 {
+		t.Errorf("expected data of the player not to be saved after the server was closed, got %v saves", n)
+	}
+}
+
+func TestParseFlatLayers(t *testing.T) {
+	layers, err := parseFlatLayers("minecraft:bedrock,2*minecraft:dirt,minecraft:grass")
+	if err != nil {
+		t.Fatalf("parse valid layers: %v", err)
+	}
+	if len(layers) != 4 {
+		t.Fatalf("expected 4 layers, got %v", len(layers))
+	}
+	for _, s := range []string{"minecraft:nonexistent", "0*minecraft:dirt", "x*minecraft:dirt", "minecraft:stick"} {
+		if _, err := parseFlatLayers(s); err == nil {
+			t.Errorf("expected error parsing layers %q", s)
+		}
+	}
+}
+
+// testClientData returns client data with a 64x64 skin, and a cape of the size passed with the amount of cape
+// data passed.
+func testClientData(capeWidth, capeHeight, capeData int) login.ClientData {
+	return login.ClientData{
+		SkinData:          base64.StdEncoding.EncodeToString(make([]byte, 64*64*4)),
+		SkinImageWidth:    64,
+		SkinImageHeight:   64,
+		SkinGeometry:      base64.StdEncoding.EncodeToString([]byte(`{"format_version":"1.12.0"}`)),
+		SkinResourcePatch: base64.StdEncoding.EncodeToString([]byte(`{"geometry":{"default":"geometry.humanoid.custom"}}`)),
+		CapeData:          base64.StdEncoding.EncodeToString(make([]byte, capeData)),
+		CapeImageWidth:    capeWidth,
+		CapeImageHeight:   capeHeight,
+		ArmSize:           "wide",
+	}
+}
+
+func TestCreateSkin(t *testing.T) {
+	srv := &Server{log: logrus.New()}
+
+	classic := srv.createSkin(testClientData(0, 0, 0))
+	if err := classic.Validate(); err != nil || classic.Cape.Exists() || classic.Persona {
+		t.Errorf("expected valid classic skin without cape, got error %v, cape %v", err, classic.Cape.Exists())
+	}
+
+	caped := srv.createSkin(testClientData(64, 32, 64*32*4))
+	if !caped.Cape.Exists() || caped.Cape.Bounds().Dx() != 64 || caped.Cape.Bounds().Dy() != 32 {
+		t.Errorf("expected skin with 64x32 cape, got %v", caped.Cape.Bounds())
+	}
+	if broken := srv.createSkin(testClientData(64, 32, 100)); broken.Cape.Exists() || len(broken.Cape.Pix) != 0 {
+		t.Errorf("expected cape with invalid data to be dropped, got %v bytes", len(broken.Cape.Pix))
+	}
+
+	data := testClientData(0, 0, 0)
+	data.PersonaSkin, data.ArmSize, data.SkinColour = true, "slim", "#ffb37b62"
+	data.PersonaPieces = []login.PersonaPiece{{PieceID: "hair", PieceType: "persona_hair", PackID: "pack", Default: true}}
+	data.PieceTintColours = []login.PersonaPieceTintColour{{PieceType: "persona_hair", Colours: [4]string{"#ff000000"}}}
+	persona := srv.createSkin(data)
+	if !persona.Persona || persona.ArmSize != "slim" || persona.Colour != "#ffb37b62" {
+		t.Errorf("expected slim persona skin with colour, got %+v", persona)
+	}
+	if len(persona.PersonaPieces) != 1 || persona.PersonaPieces[0].Type != "persona_hair" || !persona.PersonaPieces[0].Default {
+		t.Errorf("expected persona hair piece, got %+v", persona.PersonaPieces)
+	}
+	if len(persona.PersonaTints) != 1 || persona.PersonaTints[0].Colours[0] != "#ff000000" {
+		t.Errorf("expected persona hair tint, got %+v", persona.PersonaTints)
+	}
+}
@@ -0,0 +1,151 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"go.uber.org/atomic"
+	"reflect"
+	"sync"
+)
+
+// BlockActorCount returns the total amount of block entity updates sent to the client of the session, the
+// amount of updates that were replaced by a later update of the same block in the same tick, and the amount of
+// updates not sent because the client already had the same data, since the session was created.
+func (s *Session) BlockActorCount() (sent, coalesced, unchanged uint64) {
+	return s.blockActors.sentCount.Load(), s.blockActors.coalescedCount.Load(), s.blockActors.unchangedCount.Load()
+}
+
+// blockActors holds the block entity data queued to be sent to the client. Block entities such as signs may
+// be updated every tick, so updates are sent at most once per tick per block, and only if the data changed.
+type blockActors struct {
+	mu sync.Mutex
+	// positions holds the positions of the blocks with a queued update, in the order that they were first
+	// queued, and pending the latest update queued for each of those blocks.
+	positions []cube.Pos
+	pending   map[cube.Pos]blockActor
+	// sent holds the update last sent for every block, grouped by chunk, so that unchanged data is not sent
+	// again. Chunks far enough from the client to be unloaded are removed from it using evict.
+	sent map[world.ChunkPos]map[cube.Pos]blockActor
+	// centre is the chunk that the client was in the last time evict was called.
+	centre world.ChunkPos
+
+	// sentCount, coalescedCount and unchangedCount count the updates sent, the updates replaced by a later
+	// update of the same block in the same tick and the updates not sent because the data was unchanged.
+	sentCount, coalescedCount, unchangedCount atomic.Uint64
+}
+
+// blockActor is the data of a block entity sent to the client, along with the runtime ID of the block it was
+// sent for. The client may reset a block entity if the block changes, so data is only considered unchanged if
+// the runtime ID is equal too.
+type blockActor struct {
+	runtimeID uint32
+	data      map[string]interface{}
+}
+
+// queue queues the block entity data passed to be sent for the block at the position passed. If data was
+// already queued for the block in this tick, it is replaced.
+func (b *blockActors) queue(pos cube.Pos, runtimeID uint32, data map[string]interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending == nil {
+		b.pending = map[cube.Pos]blockActor{}
+	}
+	if _, ok := b.pending[pos]; ok {
+		b.coalescedCount.Inc()
+	} else {
+		b.positions = append(b.positions, pos)
+	}
+	b.pending[pos] = blockActor{runtimeID: runtimeID, data: data}
+}
+
+// remove removes any queued and sent block entity data for the block at the position passed. It is called
+// when a block without block entity is sent to the client.
+func (b *blockActors) remove(pos cube.Pos) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.pending, pos)
+	delete(b.sent[chunkPosFromBlockPos(pos)], pos)
+}
+
+// flush returns packets for all block entity data queued, skipping data that is equal to the data last sent
+// for the same block.
+func (b *blockActors) flush() []*packet.BlockActorData {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	positions, pending := b.positions, b.pending
+	b.positions, b.pending = nil, nil
+	if b.sent == nil {
+		b.sent = map[world.ChunkPos]map[cube.Pos]blockActor{}
+	}
+	var pks []*packet.BlockActorData
+	for _, pos := range positions {
+		actor, ok := pending[pos]
+		if !ok {
+			// The block entity was removed after it was queued.
+			continue
+		}
+		chunkPos := chunkPosFromBlockPos(pos)
+		if prev, ok := b.sent[chunkPos][pos]; ok && prev.runtimeID == actor.runtimeID && reflect.DeepEqual(prev.data, actor.data) {
+			b.unchangedCount.Inc()
+			continue
+		}
+		if b.sent[chunkPos] == nil {
+			b.sent[chunkPos] = map[cube.Pos]blockActor{}
+		}
+		b.sent[chunkPos][pos] = actor
+		b.sentCount.Inc()
+		pks = append(pks, &packet.BlockActorData{
+			Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+			NBTData:  actor.data,
+		})
+	}
+	return pks
+}
+
+// reset forgets the block entity data sent for the blocks in the chunk passed, so that updates are always
+// sent after the client receives the chunk again.
+func (b *blockActors) reset(pos world.ChunkPos) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.sent, pos)
+}
+
+// evict forgets the block entity data sent for the blocks in chunks that are further than the radius passed
+// away from the chunk that the client is in, as the client unloads those chunks. It does nothing if the client
+// did not move to another chunk since the last call.
+func (b *blockActors) evict(centre world.ChunkPos, radius int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if centre == b.centre {
+		return
+	}
+	b.centre = centre
+	for pos := range b.sent {
+		if dx, dz := pos[0]-centre[0], pos[1]-centre[1]; dx*dx+dz*dz > radius*radius {
+			delete(b.sent, pos)
+		}
+	}
+}
+
+// resetAll forgets all block entity data queued and sent, for example when the client changes worlds.
+func (b *blockActors) resetAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent, b.positions, b.pending = nil, nil, nil
+}
+
+// flushBlockActors sends all block entity data queued to the client and forgets the data sent for chunks that
+// the client unloaded. It is called every tick.
+func (s *Session) flushBlockActors() {
+	for _, pk := range s.blockActors.flush() {
+		s.writePacket(pk)
+	}
+	s.blockActors.evict(world.ChunkPosFromVec3(s.c.Position()), s.chunkRadius.Load()+1)
+}
+
+// chunkPosFromBlockPos returns the position of the chunk that the block position passed is in.
+func chunkPosFromBlockPos(pos cube.Pos) world.ChunkPos {
+	return world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}
+}
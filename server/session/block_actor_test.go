@@ -0,0 +1,61 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"testing"
+)
+
+func TestBlockActorsCoalesce(t *testing.T) {
+	var b blockActors
+	pos, other := cube.Pos{1, 2, 3}, cube.Pos{40, 2, 3}
+	b.queue(pos, 1, map[string]interface{}{"Text": "a"})
+	b.queue(other, 1, map[string]interface{}{"Text": "x"})
+	b.queue(pos, 1, map[string]interface{}{"Text": "b"})
+
+	pks := b.flush()
+	if len(pks) != 2 || pks[0].NBTData["Text"] != "b" || pks[1].NBTData["Text"] != "x" {
+		t.Fatalf("expected the last update of every block in queue order, got %v", pks)
+	}
+
+	// Unchanged data is not sent again, unless the block changed or the chunk was sent again.
+	b.queue(pos, 1, map[string]interface{}{"Text": "b"})
+	if pks := b.flush(); len(pks) != 0 {
+		t.Fatalf("expected unchanged data not to be sent, got %v", pks)
+	}
+	b.queue(pos, 2, map[string]interface{}{"Text": "b"})
+	if pks := b.flush(); len(pks) != 1 {
+		t.Fatalf("expected data to be sent after the block changed, got %v", pks)
+	}
+	b.reset(world.ChunkPos{0, 0})
+	b.queue(pos, 2, map[string]interface{}{"Text": "b"})
+	if pks := b.flush(); len(pks) != 1 {
+		t.Fatalf("expected data to be sent after the chunk was sent again, got %v", pks)
+	}
+
+	b.queue(other, 1, map[string]interface{}{"Text": "y"})
+	b.remove(other)
+	if pks := b.flush(); len(pks) != 0 {
+		t.Fatalf("expected data of removed block entity not to be sent, got %v", pks)
+	}
+	if sent, coalesced, unchanged := b.sentCount.Load(), b.coalescedCount.Load(), b.unchangedCount.Load(); sent != 4 || coalesced != 1 || unchanged != 1 {
+		t.Errorf("expected 4 updates sent, 1 coalesced and 1 unchanged, got %v, %v and %v", sent, coalesced, unchanged)
+	}
+}
+
+func TestBlockActorsEvict(t *testing.T) {
+	var b blockActors
+	near, far := cube.Pos{1, 2, 3}, cube.Pos{100, 2, 3}
+	b.queue(near, 1, map[string]interface{}{"Text": "a"})
+	b.queue(far, 1, map[string]interface{}{"Text": "a"})
+	b.flush()
+
+	// The chunk of the far block is 6 chunks away, so it is unloaded with a radius of 4.
+	b.evict(world.ChunkPos{0, 1}, 4)
+	if _, ok := b.sent[world.ChunkPos{6, 0}]; ok {
+		t.Errorf("expected data sent in unloaded chunk to be forgotten")
+	}
+	if _, ok := b.sent[world.ChunkPos{0, 0}]; !ok {
+		t.Errorf("expected data sent in loaded chunk to be kept")
+	}
+}
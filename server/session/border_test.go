@@ -0,0 +1,61 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+func TestBorderPushesBack(t *testing.T) {
+	conn, p, w := startSession(t, player.NopHandler{})
+	w.SetBorder(mgl32.Vec2{0, 0}, 4)
+	conn.waitForCorrections()
+	conn.clear()
+
+	p.Move(mgl64.Vec3{10, 0, 0}, 0, 0)
+	if pos := p.Position(); pos[0] != 4 {
+		t.Fatalf("expected player to be held back at the border, got position %v", pos)
+	}
+	conn.mu.Lock()
+	teleported := false
+	for _, pk := range conn.written {
+		if pk, ok := pk.(*packet.MovePlayer); ok && pk.Mode == packet.MoveModeTeleport {
+			teleported = true
+		}
+	}
+	conn.mu.Unlock()
+	if !teleported {
+		t.Errorf("expected client to be teleported back inside the border")
+	}
+
+	// Shrinking the border pushes back the player on its next tick.
+	w.SetBorder(mgl32.Vec2{0, 0}, 2)
+	p.Tick(1)
+	if pos := p.Position(); pos[0] != 2 {
+		t.Fatalf("expected player to be pushed inside the shrunk border, got position %v", pos)
+	}
+}
+
+func TestBorderPreventsEditing(t *testing.T) {
+	pos := cube.Pos{6, 0, 0}
+	conn, p, w := startSession(t, player.NopHandler{})
+	w.SetBlock(pos, block.Stone{})
+	w.SetBorder(mgl32.Vec2{0, 0}, 4)
+	_ = p.Inventory().SetItem(0, item.NewStack(block.Stone{}, 1))
+
+	p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+	p.BreakBlock(pos)
+	conn.waitForCorrections()
+
+	if _, ok := w.Block(pos.Side(cube.FaceUp)).(block.Air); !ok {
+		t.Errorf("block was placed outside the border")
+	}
+	if _, ok := w.Block(pos).(block.Stone); !ok {
+		t.Errorf("block was broken outside the border")
+	}
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"bytes"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"sync"
+)
+
+// encodedWriter is implemented by connections that accept packets that were already encoded, such as
+// *minecraft.Conn. Like packets written using WritePacket, the data is buffered and sent to the client in one
+// compressed batch once the connection is flushed.
+type encodedWriter interface {
+	Write(b []byte) (n int, err error)
+}
+
+// sharedPacket holds the encoding of the last packet written using writeShared with it. Packets such as block
+// updates and sounds are typically written to every viewer of a position right after each other with the
+// exact same content, so remembering only the last one is enough to encode the packet only once for all of
+// them.
+type sharedPacket struct {
+	mu   sync.Mutex
+	key  interface{}
+	data []byte
+}
+
+// encode returns the encoding of the packet passed, including its header. The key passed must be a
+// comparable value that is equal for packets that encode to the same data, such as the dereferenced packet
+// itself. If it is equal to the key of the previous call, the data encoded then is returned.
+func (p *sharedPacket) encode(key interface{}, pk packet.Packet) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.data != nil && p.key == key {
+		return p.data
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 32))
+	hdr := &packet.Header{PacketID: pk.ID()}
+	_ = hdr.Write(buf)
+	// Packets written using writeShared never hold items, so the shield ID does not matter.
+	pk.Marshal(protocol.NewWriter(buf, 0))
+
+	// The data is never changed after being written to a connection, so it is shared between all of them.
+	p.key, p.data = key, buf.Bytes()
+	return p.data
+}
+
+// updateBlocks and levelSounds hold the encoding of the last block update and sound written to any session.
+var updateBlocks, levelSounds sharedPacket
+
+// writeShared writes a packet that is written with the same content to many sessions in a row, such as to all
+// viewers of a block. If the connection of the Session accepts encoded packets, the encoding is shared with
+// other sessions through the sharedPacket passed, instead of encoding the packet again for every session.
+// The key passed is used as described in sharedPacket.encode.
+func (s *Session) writeShared(p *sharedPacket, key interface{}, pk packet.Packet) {
+	if s == Nop {
+		return
+	}
+	w, ok := s.conn.(encodedWriter)
+	if handlers, _ := s.packetHandlers.Load().([]PacketHandler); !ok || len(handlers) != 0 {
+		// Packet handlers may change the packet, so it cannot share its encoding with other sessions.
+		s.writePacket(pk)
+		return
+	}
+	s.record(pk, DirectionServer)
+	_, _ = w.Write(p.encode(key, pk))
+	packetsWritten.Inc()
+}
@@ -0,0 +1,146 @@
+package session_test
+
+import (
+	"bytes"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// encodingConn is a session.Conn that encodes every packet written to it, like a *minecraft.Conn does. It
+// counts the bytes of the block updates and sounds written to it.
+type encodingConn struct {
+	written *atomic.Int64
+	id      uuid.UUID
+
+	once   sync.Once
+	closed chan struct{}
+}
+
+// sharedConn is an encodingConn that also accepts packets that were already encoded, so that sessions may
+// share the encoding of packets.
+type sharedConn struct {
+	*encodingConn
+}
+
+func (c sharedConn) Write(b []byte) (int, error) {
+	if len(b) > 0 && (b[0] == packet.IDUpdateBlock || b[0] == packet.IDLevelSoundEvent) {
+		c.written.Add(int64(len(b)))
+	}
+	return len(b), nil
+}
+
+func (c *encodingConn) WritePacket(pk packet.Packet) error {
+	buf := bytes.NewBuffer(make([]byte, 0, 32))
+	hdr := &packet.Header{PacketID: pk.ID()}
+	_ = hdr.Write(buf)
+	pk.Marshal(protocol.NewWriter(buf, 0))
+	switch pk.(type) {
+	case *packet.UpdateBlock, *packet.LevelSoundEvent:
+		c.written.Add(int64(buf.Len()))
+	}
+	return nil
+}
+func (c *encodingConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+func (c *encodingConn) IdentityData() login.IdentityData {
+	return login.IdentityData{DisplayName: "test", Identity: c.id.String()}
+}
+func (c *encodingConn) ClientData() login.ClientData       { return login.ClientData{} }
+func (c *encodingConn) ClientCacheEnabled() bool           { return false }
+func (c *encodingConn) ChunkRadius() int                   { return 4 }
+func (c *encodingConn) Latency() time.Duration             { return 0 }
+func (c *encodingConn) Flush() error                       { return nil }
+func (c *encodingConn) RemoteAddr() net.Addr               { return &net.UDPAddr{} }
+func (c *encodingConn) StartGame(minecraft.GameData) error { return nil }
+func (c *encodingConn) ReadPacket() (packet.Packet, error) {
+	<-c.closed
+	return nil, net.ErrClosed
+}
+
+// startViewers starts n sessions in a new world and waits until all of them view the chunk at the origin.
+// If shared is true, the connections of the sessions accept encoded packets. The bytes of block updates and
+// sounds written to all connections are counted in the atomic.Int64 returned.
+func startViewers(b *testing.B, n int, shared bool) (*world.World, *atomic.Int64) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	w := world.New(log, 4)
+
+	written := atomic.NewInt64(0)
+	var conns []*encodingConn
+	for i := 0; i < n; i++ {
+		c := &encodingConn{written: written, id: uuid.New(), closed: make(chan struct{})}
+		conns = append(conns, c)
+		var conn session.Conn = c
+		if shared {
+			conn = sharedConn{c}
+		}
+		s := session.New(conn, 4, log, atomic.NewString(""), atomic.NewString(""))
+		p := player.NewWithSession("test", "", c.id, skin.Skin{}, s, mgl64.Vec3{0.5, 0, 0.5}, nil)
+		s.Start(p, w, world.GameModeSurvival{}, func(session.Controllable) {})
+	}
+	b.Cleanup(func() {
+		for _, c := range conns {
+			_ = c.Close()
+		}
+		_ = w.Close()
+	})
+
+	// Sessions load the chunks around them asynchronously, after which they view the blocks in them.
+	deadline := time.Now().Add(time.Second * 5)
+	for len(w.Viewers(mgl64.Vec3{})) < n {
+		if time.Now().After(deadline) {
+			b.Fatalf("sessions did not view the chunk at the origin in time")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	return w, written
+}
+
+// BenchmarkBroadcast measures the bytes encoded and the allocations made per tick to send 16 block updates and
+// a sound to 20 viewers, with every session encoding the packets itself and with the encoding shared between
+// sessions.
+func BenchmarkBroadcast(b *testing.B) {
+	for _, bench := range []struct {
+		name   string
+		shared bool
+	}{{"PerSession", false}, {"Shared", true}} {
+		b.Run(bench.name, func(b *testing.B) {
+			w, written := startViewers(b, 20, bench.shared)
+			written.Store(0)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var bl world.Block = block.Stone{}
+				if i%2 == 1 {
+					bl = block.Air{}
+				}
+				for x := 0; x < 16; x++ {
+					w.SetBlock(cube.Pos{x, 10, 0}, bl)
+				}
+				w.PlaySound(mgl64.Vec3{0, 10, 0}, sound.Explosion{})
+			}
+			b.ReportMetric(float64(written.Load())/float64(b.N), "bytes/tick")
+		})
+	}
+}
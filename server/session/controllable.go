@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/menu"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -19,11 +20,13 @@ type Controllable interface {
 	world.Entity
 	item.Carrier
 	form.Submitter
+	menu.Clicker
 	cmd.Source
 	SetHeldItems(right, left item.Stack)
 
 	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
 	Speed() float64
+	Velocity() mgl64.Vec3
 	Facing() cube.Direction
 
 	Chat(msg ...interface{})
@@ -37,11 +40,12 @@ type Controllable interface {
 	UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3)
 	UseItemOnEntity(e world.Entity)
 	BreakBlock(pos cube.Pos)
-	PickBlock(pos cube.Pos)
+	PickBlock(pos cube.Pos, withData bool)
 	AttackEntity(e world.Entity)
 	Drop(s item.Stack) (n int)
 	SwingArm()
 	PunchAir()
+	Emote(emote uuid.UUID)
 
 	Respawn()
 
@@ -57,6 +61,7 @@ type Controllable interface {
 	StartFlying()
 	Flying() bool
 	StopFlying()
+	FlightAllowed() bool
 
 	StartBreaking(pos cube.Pos, face cube.Face)
 	ContinueBreaking(face cube.Face)
@@ -66,6 +71,8 @@ type Controllable interface {
 	Exhaust(points float64)
 
 	EditSign(pos cube.Pos, text string) error
+	TurnLecternPage(pos cube.Pos, page int) error
+	TakeLecternBook(pos cube.Pos) error
 
 	// UUID returns the UUID of the controllable. It must be unique for all controllable entities present in
 	// the server.
@@ -73,8 +80,17 @@ type Controllable interface {
 	// XUID returns the XBOX Live User ID of the controllable. Every controllable must have one of these if
 	// they are authenticated via XBOX Live, as they must be connected to an XBOX Live account.
 	XUID() string
+	// Authenticated checks if the controllable is authenticated with XBOX Live. The XUID of controllables that
+	// are not authenticated is not sent to other players.
+	Authenticated() bool
 	// Skin returns the skin of the controllable. Each controllable must have a skin, as it defines how the
 	// entity looks in the world.
 	Skin() skin.Skin
-	SetSkin(skin.Skin)
+	SetSkin(skin.Skin) error
+	// UpdateInputMode updates the InputMode of the controllable after the client switched input modes, for
+	// example from touch to a controller.
+	UpdateInputMode(mode InputMode)
+	// OperatorLevel returns the operator level of the controllable, ranging from 0 for players that are not
+	// an operator to 4.
+	OperatorLevel() int
 }
@@ -0,0 +1,121 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"sync"
+)
+
+// corrections holds the corrections queued for actions that the client predicted, but that were rejected by the
+// server. Every correction is queued with the cause of the rejection. Corrections queued multiple times before
+// they are flushed are only sent once.
+type corrections struct {
+	mu sync.Mutex
+	// blocks holds the positions of blocks that must be resent, in the order that they were queued, and
+	// blockCauses the cause of the first correction of each of those blocks.
+	blocks      []cube.Pos
+	blockCauses map[cube.Pos]string
+	// inventory and container are the causes of a queued inventory resync and container close. They are
+	// empty if neither is queued.
+	inventory, container string
+}
+
+// SetCorrectionLogging enables or disables the logging of every correction sent to the client, along with its
+// cause. Logging corrections helps finding actions that the client predicts differently from the server.
+func (s *Session) SetCorrectionLogging(enabled bool) {
+	s.logCorrections.Store(enabled)
+}
+
+// CorrectBlock queues a resend of the block at the position passed, reverting any change to the block that the
+// client predicted, for example when the placing or breaking of the block is cancelled. The cause is logged if
+// correction logging is enabled.
+func (s *Session) CorrectBlock(pos cube.Pos, cause string) {
+	if s == Nop {
+		return
+	}
+	s.corrections.mu.Lock()
+	defer s.corrections.mu.Unlock()
+	if _, ok := s.corrections.blockCauses[pos]; ok {
+		return
+	}
+	if s.corrections.blockCauses == nil {
+		s.corrections.blockCauses = map[cube.Pos]string{}
+	}
+	s.corrections.blocks = append(s.corrections.blocks, pos)
+	s.corrections.blockCauses[pos] = cause
+}
+
+// CorrectInventory queues a resend of all inventories of the client, reverting any item movement that the client
+// predicted. The cause is logged if correction logging is enabled.
+func (s *Session) CorrectInventory(cause string) {
+	if s == Nop {
+		return
+	}
+	s.corrections.mu.Lock()
+	defer s.corrections.mu.Unlock()
+	if s.corrections.inventory == "" {
+		s.corrections.inventory = cause
+	}
+}
+
+// CorrectContainer queues the closing of the container that the client has opened, for when the client uses a
+// container that is not opened server-side. The cause is logged if correction logging is enabled.
+func (s *Session) CorrectContainer(cause string) {
+	if s == Nop {
+		return
+	}
+	s.corrections.mu.Lock()
+	defer s.corrections.mu.Unlock()
+	if s.corrections.container == "" {
+		s.corrections.container = cause
+	}
+}
+
+// flushCorrections sends all corrections queued to the client. It is called after handling every packet and
+// every tick, so that corrections are sent in the same tick as the action they correct.
+func (s *Session) flushCorrections() {
+	s.corrections.mu.Lock()
+	blocks, causes, inv, container := s.corrections.blocks, s.corrections.blockCauses, s.corrections.inventory, s.corrections.container
+	s.corrections.blocks, s.corrections.blockCauses, s.corrections.inventory, s.corrections.container = nil, nil, "", ""
+	s.corrections.mu.Unlock()
+
+	if container != "" {
+		s.logCorrection("container close", container)
+		if s.containerOpened.Load() {
+			s.closeCurrentContainer()
+		} else {
+			s.writePacket(&packet.ContainerClose{WindowID: byte(s.openedWindowID.Load())})
+		}
+	}
+	if inv != "" {
+		s.logCorrection("inventory resync", inv)
+		s.sendInv(s.inv, protocol.WindowIDInventory)
+		s.sendInv(s.ui, protocol.WindowIDUI)
+		s.sendInv(s.offHand, protocol.WindowIDOffHand)
+		s.sendInv(s.armour.Inv(), protocol.WindowIDArmour)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	w := s.c.World()
+	if w == nil {
+		return
+	}
+	for _, pos := range blocks {
+		s.logCorrection(fmt.Sprintf("block resend at %v", pos), causes[pos])
+		b := w.Block(pos)
+		s.ViewBlockUpdate(pos, b, 0)
+		if liq, ok := w.Liquid(pos); ok && liq != b {
+			s.ViewBlockUpdate(pos, liq, 1)
+		}
+	}
+}
+
+// logCorrection logs a correction sent to the client if correction logging is enabled.
+func (s *Session) logCorrection(correction, cause string) {
+	if s.logCorrections.Load() {
+		s.log.Debugf("correction for %v (%v): %v: %v\n", s.conn.RemoteAddr(), s.c.Name(), correction, cause)
+	}
+}
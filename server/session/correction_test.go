@@ -0,0 +1,217 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cancelHandler is a player.Handler that cancels all block placements and block breaks.
+type cancelHandler struct {
+	player.NopHandler
+}
+
+func (cancelHandler) HandleBlockPlace(ctx *event.Context, _ cube.Pos, _ world.Block) { ctx.Cancel() }
+func (cancelHandler) HandleBlockBreak(ctx *event.Context, _ cube.Pos)                { ctx.Cancel() }
+
+// cancelUseHandler is a player.Handler that cancels all item uses on blocks.
+type cancelUseHandler struct {
+	player.NopHandler
+}
+
+func (cancelUseHandler) HandleItemUseOnBlock(ctx *event.Context, _ cube.Pos, _ cube.Face, _ mgl64.Vec3) {
+	ctx.Cancel()
+}
+
+func TestCancelledPlaceCorrectedOnce(t *testing.T) {
+	// The block is placed on top of another block rather than at the bottom of the world, so that every
+	// neighbour is corrected: Neighbours skips positions outside the world.
+	pos := cube.Pos{2, 1, 2}
+	conn, p, w := startSession(t, cancelHandler{}, func(w *world.World) {
+		w.SetBlock(pos.Side(cube.FaceDown), block.Stone{})
+	})
+	_ = p.Inventory().SetItem(0, item.NewStack(block.Stone{}, 1))
+
+	p.UseItemOnBlock(pos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{})
+	conn.waitForCorrections()
+
+	if _, ok := w.Block(pos).(block.Air); !ok {
+		t.Fatalf("block was placed even though the placement was cancelled")
+	}
+	for _, c := range []cube.Pos{pos, pos.Side(cube.FaceDown), pos.Side(cube.FaceNorth)} {
+		if n := conn.blockUpdates(c); n != 1 {
+			t.Errorf("expected exactly 1 block update at %v after cancelled placement, got %v", c, n)
+		}
+	}
+}
+
+func TestCancelledItemUseCorrectedOnce(t *testing.T) {
+	conn, p, _ := startSession(t, cancelUseHandler{})
+	_ = p.Inventory().SetItem(0, item.NewStack(block.Stone{}, 1))
+
+	pos := cube.Pos{2, 0, 2}
+	p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+	conn.waitForCorrections()
+
+	for _, c := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if n := conn.blockUpdates(c); n != 1 {
+			t.Errorf("expected exactly 1 block update at %v after cancelled item use, got %v", c, n)
+		}
+	}
+}
+
+func TestCancelledBreakCorrectedOnce(t *testing.T) {
+	pos := cube.Pos{2, 0, 2}
+	conn, p, w := startSession(t, cancelHandler{}, func(w *world.World) {
+		w.SetBlock(pos, block.Stone{})
+	})
+	// Creative players break blocks instantly, so that the break reaches the handler without breaking the
+	// block first.
+	p.SetGameMode(world.GameModeCreative{})
+
+	p.BreakBlock(pos)
+	conn.waitForCorrections()
+
+	if _, ok := w.Block(pos).(block.Stone); !ok {
+		t.Fatalf("block was broken even though the break was cancelled")
+	}
+	if n := conn.blockUpdates(pos); n != 1 {
+		t.Errorf("expected exactly 1 block update at %v after cancelled break, got %v", pos, n)
+	}
+}
+
+func TestBreakBlockProgress(t *testing.T) {
+	pos := cube.Pos{2, 0, 2}
+	conn, p, w := startSession(t, player.NopHandler{}, func(w *world.World) {
+		w.SetBlock(pos, block.Dirt{})
+	})
+	// Haste shortens the break time of the dirt to 0.375 seconds for a player in the air.
+	p.AddEffect(effect.New(effect.Haste{}, 9, time.Minute))
+
+	startBreak := &packet.PlayerAction{
+		EntityRuntimeID: 1,
+		ActionType:      protocol.PlayerActionStartBreak,
+		BlockPosition:   protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		BlockFace:       int32(cube.FaceUp),
+	}
+	breakBlock := &packet.InventoryTransaction{TransactionData: &protocol.UseItemTransactionData{
+		ActionType:    protocol.UseItemActionBreakBlock,
+		BlockPosition: startBreak.BlockPosition,
+	}}
+
+	// Breaking the block right after starting to break it, like a client that ignores the break time would,
+	// resends the block.
+	conn.read <- startBreak
+	conn.read <- breakBlock
+	conn.waitForCorrections()
+	if _, ok := w.Block(pos).(block.Dirt); !ok {
+		t.Fatalf("block was broken before its break time passed")
+	}
+	if n := conn.blockUpdates(pos); n != 1 {
+		t.Errorf("expected exactly 1 block update at %v after breaking too early, got %v", pos, n)
+	}
+
+	conn.read <- startBreak
+	time.Sleep(time.Second / 2)
+	conn.read <- breakBlock
+	conn.waitForCorrections()
+	if _, ok := w.Block(pos).(block.Air); !ok {
+		t.Errorf("block was not broken after its break time passed")
+	}
+}
+
+// startSession starts a session for a player in a new world, with the handler passed attached to the player.
+// The functions passed are called on the world before the session is started.
+func startSession(t *testing.T, h player.Handler, setup ...func(w *world.World)) (*recordConn, *player.Player, *world.World) {
+	log := logrus.New()
+	w := world.New(log, 4)
+	for _, f := range setup {
+		f(w)
+	}
+	conn := &recordConn{read: make(chan packet.Packet), closed: make(chan struct{})}
+	s := session.New(conn, 4, log, atomic.NewString(""), atomic.NewString(""))
+	p := player.NewWithSession("test", "", uuid.New(), skin.Skin{}, s, mgl64.Vec3{0.5, 0, 0.5}, nil)
+	s.Start(p, w, world.GameModeSurvival{}, func(session.Controllable) {})
+	p.Handle(h)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = w.Close()
+	})
+	return conn, p, w
+}
+
+// recordConn is a session.Conn that records all packets written to it. Reading from it returns the packets
+// sent to read.
+type recordConn struct {
+	mu      sync.Mutex
+	written []packet.Packet
+	read    chan packet.Packet
+
+	once   sync.Once
+	closed chan struct{}
+}
+
+// waitForCorrections waits long enough for the corrections queued to be flushed by the tick of the session.
+func (c *recordConn) waitForCorrections() {
+	time.Sleep(time.Second / 5)
+}
+
+// blockUpdates returns the amount of block updates on the first layer written for the position passed.
+func (c *recordConn) blockUpdates(pos cube.Pos) (n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pk := range c.written {
+		if u, ok := pk.(*packet.UpdateBlock); ok && u.Layer == 0 && u.Position == (protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}) {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *recordConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+func (c *recordConn) IdentityData() login.IdentityData {
+	return login.IdentityData{DisplayName: "test"}
+}
+func (c *recordConn) ClientData() login.ClientData       { return login.ClientData{} }
+func (c *recordConn) ClientCacheEnabled() bool           { return false }
+func (c *recordConn) ChunkRadius() int                   { return 4 }
+func (c *recordConn) Latency() time.Duration             { return 0 }
+func (c *recordConn) Flush() error                       { return nil }
+func (c *recordConn) RemoteAddr() net.Addr               { return &net.UDPAddr{} }
+func (c *recordConn) StartGame(minecraft.GameData) error { return nil }
+func (c *recordConn) ReadPacket() (packet.Packet, error) {
+	select {
+	case pk := <-c.read:
+		return pk, nil
+	case <-c.closed:
+		return nil, net.ErrClosed
+	}
+}
+func (c *recordConn) WritePacket(pk packet.Packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = append(c.written, pk)
+	return nil
+}
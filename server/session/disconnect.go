@@ -0,0 +1,106 @@
+package session
+
+import (
+	"time"
+)
+
+// DisconnectReason describes why a Session was closed. It is available once the Session is closed, using
+// Session.DisconnectReason.
+type DisconnectReason struct {
+	// Kind is the kind of disconnection, such as the client leaving or being kicked.
+	Kind DisconnectKind
+	// Message is the message passed to Session.Disconnect if the Kind is DisconnectKicked. It is empty for
+	// other kinds.
+	Message string
+	// Err is the error that caused the connection to be closed, if any. It is the error returned reading from
+	// the connection for DisconnectQuit and DisconnectTimeout, and the error returned handling a packet for
+	// DisconnectError.
+	Err error
+}
+
+// String returns a human-readable description of the DisconnectReason.
+func (r DisconnectReason) String() string {
+	switch r.Kind {
+	case DisconnectKicked:
+		return "kicked: " + r.Message
+	case DisconnectError:
+		if r.Err != nil {
+			return "error: " + r.Err.Error()
+		}
+	}
+	return r.Kind.String()
+}
+
+// DisconnectKind is the kind of a DisconnectReason.
+type DisconnectKind uint8
+
+const (
+	// DisconnectClosed is the kind of disconnection of Sessions that were closed by the server without a
+	// message, such as by closing the connection directly.
+	DisconnectClosed DisconnectKind = iota
+	// DisconnectQuit is the kind of disconnection of clients that left the server by themselves.
+	DisconnectQuit
+	// DisconnectTimeout is the kind of disconnection of clients that stopped sending packets, for example because
+	// they lost their connection, until the connection timed out.
+	DisconnectTimeout
+	// DisconnectKicked is the kind of disconnection of clients that were disconnected by the server using
+	// Session.Disconnect, for example when kicked or when the server shuts down.
+	DisconnectKicked
+	// DisconnectError is the kind of disconnection of clients that sent a packet which could not be handled.
+	DisconnectError
+)
+
+// String returns the name of the DisconnectKind.
+func (k DisconnectKind) String() string {
+	switch k {
+	case DisconnectQuit:
+		return "quit"
+	case DisconnectTimeout:
+		return "timeout"
+	case DisconnectKicked:
+		return "kicked"
+	case DisconnectError:
+		return "error"
+	}
+	return "closed"
+}
+
+// timeoutThreshold is the time that must have passed since the last packet received from a client for a
+// connection closing to be considered a timeout rather than the client quitting. Clients send input every
+// tick, so a client that left by itself never goes this long without sending packets.
+const timeoutThreshold = time.Second * 5
+
+// setDisconnectReason sets the DisconnectReason of the Session if it was not yet set. The reason first set
+// is kept, so that a client that is kicked and then closes its connection is still considered kicked.
+func (s *Session) setDisconnectReason(r DisconnectReason) {
+	s.disconnectMu.Lock()
+	defer s.disconnectMu.Unlock()
+	if s.disconnectReason == nil {
+		s.disconnectReason = &r
+	}
+}
+
+// DisconnectReason returns the reason that the Session was disconnected for. False is returned if the Session
+// was not yet disconnected.
+func (s *Session) DisconnectReason() (DisconnectReason, bool) {
+	if s == Nop {
+		return DisconnectReason{}, false
+	}
+	s.disconnectMu.Lock()
+	defer s.disconnectMu.Unlock()
+	if s.disconnectReason == nil {
+		return DisconnectReason{}, false
+	}
+	return *s.disconnectReason, true
+}
+
+// readError sets the DisconnectReason of the Session after reading a packet from the connection failed with
+// the error passed. The client is considered to have timed out if no packets were received from it for
+// timeoutThreshold.
+func (s *Session) readError(err error) {
+	kind := DisconnectQuit
+	if time.Since(time.Unix(0, s.lastPacket.Load())) >= timeoutThreshold {
+		kind = DisconnectTimeout
+	}
+	s.setDisconnectReason(DisconnectReason{Kind: kind, Err: err})
+}
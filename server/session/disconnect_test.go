@@ -0,0 +1,54 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"testing"
+	"time"
+)
+
+// quitHandler is a player.Handler that sends the reason passed to HandleQuit to a channel.
+type quitHandler struct {
+	player.NopHandler
+	reasons chan session.DisconnectReason
+}
+
+func (h quitHandler) HandleQuit(_ *event.Context, reason session.DisconnectReason) {
+	h.reasons <- reason
+}
+
+func TestDisconnectReason(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		disconnect func(conn *recordConn, p *player.Player)
+		want       session.DisconnectReason
+	}{
+		{"quit", func(conn *recordConn, p *player.Player) { _ = conn.Close() }, session.DisconnectReason{Kind: session.DisconnectQuit}},
+		{"kicked", func(conn *recordConn, p *player.Player) {
+			p.Disconnect("Kicked by an operator: spamming")
+			// The reason is kept once the client closes its connection after receiving the message.
+			_ = conn.Close()
+		}, session.DisconnectReason{Kind: session.DisconnectKicked, Message: "Kicked by an operator: spamming"}},
+	} {
+		h := quitHandler{reasons: make(chan session.DisconnectReason, 1)}
+		conn, p, _ := startSession(t, h)
+		if _, ok := p.Disconnected(); ok {
+			t.Fatalf("%v: player connected was considered disconnected", test.name)
+		}
+		test.disconnect(conn, p)
+
+		var reason session.DisconnectReason
+		select {
+		case reason = <-h.reasons:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%v: player was not closed", test.name)
+		}
+		if reason.Kind != test.want.Kind || reason.Message != test.want.Message {
+			t.Errorf("%v: HandleQuit got reason %v, want %v", test.name, reason, test.want)
+		}
+		if r, ok := p.Disconnected(); !ok || r.Kind != test.want.Kind || r.Message != test.want.Message {
+			t.Errorf("%v: Disconnected() = %v, %v, want %v", test.name, r, ok, test.want)
+		}
+	}
+}
@@ -52,12 +52,22 @@ func parseEntityMetadata(e world.Entity) entityMetadata {
 	if s, ok := e.(scaled); ok {
 		m[dataKeyScale] = float32(s.Scale())
 	}
+	if a, ok := e.(airSupplier); ok {
+		m[dataKeyAir] = int16(a.AirSupply())
+		m[dataKeyMaxAir] = int16(a.MaxAirSupply())
+	}
 	if n, ok := e.(named); ok {
 		m[dataKeyNameTag] = n.NameTag()
 		m[dataKeyAlwaysShowNameTag] = uint8(1)
 		m.setFlag(dataKeyFlags, dataFlagAlwaysShowNameTag)
 		m.setFlag(dataKeyFlags, dataFlagCanShowNameTag)
 	}
+	if w, ok := world.OfEntity(e); ok {
+		if l, ok := w.Riding(e); ok {
+			m.setFlag(dataKeyFlags, dataFlagRiding)
+			m[dataKeySeatOffset] = vec64To32(l.Seat)
+		}
+	}
 	if eff, ok := e.(effectBearer); ok && len(eff.Effects()) > 0 {
 		colour, am := effect.ResultingColour(eff.Effects())
 		if (colour != color.RGBA{}) {
@@ -96,8 +106,10 @@ const (
 	dataKeyPotionColour
 	dataKeyPotionAmbient
 	dataKeyScale             = 38
+	dataKeyMaxAir            = 42
 	dataKeyBoundingBoxWidth  = 53
 	dataKeyBoundingBoxHeight = 54
+	dataKeySeatOffset        = 56
 	dataKeyAlwaysShowNameTag = 81
 )
 
@@ -134,6 +146,11 @@ type breather interface {
 	Breathing() bool
 }
 
+type airSupplier interface {
+	AirSupply() int
+	MaxAirSupply() int
+}
+
 type immobile interface {
 	Immobile() bool
 }
@@ -15,13 +15,15 @@ func (a AdventureSettingsHandler) Handle(p packet.Packet, s *Session) error {
 
 	mode := s.c.GameMode()
 	if pk.Flags&packet.AdventureFlagFlying != 0 {
-		if !mode.AllowsFlying() {
+		if !s.c.FlightAllowed() {
 			s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: flying flag enabled while not being able to fly\n", s.conn.RemoteAddr(), s.c.Name())
 			return nil
 		}
 		s.c.StartFlying()
+	} else if s.c.Flying() {
+		s.c.StopFlying()
 	}
-	if pk.Flags&packet.AdventureFlagAllowFlight != 0 && !mode.AllowsFlying() {
+	if pk.Flags&packet.AdventureFlagAllowFlight != 0 && !s.c.FlightAllowed() {
 		s.log.Debugf("failed processing packet from %v (%v): AdventureSettings: allow flight flag enabled while not being able to fly\n", s.conn.RemoteAddr(), s.c.Name())
 		return nil
 	}
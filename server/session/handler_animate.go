@@ -0,0 +1,30 @@
+package session
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"time"
+)
+
+// AnimateHandler handles the Animate packet.
+type AnimateHandler struct{}
+
+// Handle ...
+func (AnimateHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.Animate)
+
+	if pk.EntityRuntimeID != selfEntityRuntimeID {
+		return ErrSelfRuntimeID
+	}
+	if pk.ActionType != packet.AnimateActionSwingArm {
+		return nil
+	}
+	if time.Since(time.Unix(0, s.lastSwing.Load())) < time.Second/10 {
+		// The arm swing was already shown to viewers, for example because the player punched the air, so we
+		// don't show it again.
+		return nil
+	}
+	s.swingingArm.Store(true)
+	defer s.swingingArm.Store(false)
+	s.c.SwingArm()
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/text"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"strings"
 	"unicode/utf8"
@@ -34,24 +35,24 @@ func (b BlockActorDataHandler) handleSign(pk *packet.BlockActorData, pos cube.Po
 		return nil
 	}
 
-	var text string
+	var signText string
 	pkText, ok := pk.NBTData["Text"]
 	if !ok {
 		return fmt.Errorf("sign block actor data had no 'Text' tag")
 	}
-	if text, ok = pkText.(string); !ok {
+	if signText, ok = pkText.(string); !ok {
 		return fmt.Errorf("sign block actor data 'Text' tag was not a string: %#v", pkText)
 	}
 
 	// Verify that the text was valid. It must be valid UTF8 and not more than 100 characters long.
-	text = strings.TrimRight(text, "\n")
-	if len(text) > 256 {
+	signText = strings.TrimRight(signText, "\n")
+	if len(signText) > 256 {
 		return fmt.Errorf("sign block actor data text was longer than 256 characters")
 	}
-	if !utf8.ValidString(text) {
+	if !utf8.ValidString(signText) {
 		return fmt.Errorf("sign block actor data text was not valid UTF8")
 	}
-	if err := s.c.EditSign(pos, text); err != nil {
+	if err := s.c.EditSign(pos, text.Clean(signText, text.SignPolicy)); err != nil {
 		return err
 	}
 	return nil
@@ -11,6 +11,6 @@ type BlockPickRequestHandler struct{}
 // Handle ...
 func (b BlockPickRequestHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.BlockPickRequest)
-	s.c.PickBlock(cube.Pos{int(pk.Position.X()), int(pk.Position.Y()), int(pk.Position.Z())})
+	s.c.PickBlock(cube.Pos{int(pk.Position.X()), int(pk.Position.Y()), int(pk.Position.Z())}, pk.AddBlockNBT)
 	return nil
 }
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/text"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// BookEditHandler handles the BookEdit packet, sent by the client when it edits or signs a writable book.
+type BookEditHandler struct{}
+
+// Handle ...
+func (BookEditHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.BookEdit)
+
+	slot := int(pk.InventorySlot)
+	if held := int(s.heldSlot.Load()); slot != held {
+		return fmt.Errorf("book edit in slot %v, but held slot is %v", slot, held)
+	}
+	it, err := s.inv.Item(slot)
+	if err != nil {
+		return err
+	}
+	book, ok := it.Item().(item.WritableBook)
+	if !ok {
+		return fmt.Errorf("book edit on item %v that is not a writable book", it)
+	}
+
+	// The limits on the amount of pages and the length of pages are enforced by the methods of the book, so
+	// that clients cannot create oversized books.
+	page, secondaryPage := int(pk.PageNumber), int(pk.SecondaryPageNumber)
+	pageText := text.Clean(pk.Text, text.BookPolicy)
+	switch pk.ActionType {
+	case packet.BookActionReplacePage:
+		book, err = book.SetPage(page, pageText)
+	case packet.BookActionAddPage:
+		book, err = book.InsertPage(page, pageText)
+	case packet.BookActionDeletePage:
+		book = book.DeletePage(page)
+	case packet.BookActionSwapPages:
+		book = book.SwapPages(page, secondaryPage)
+	case packet.BookActionSign:
+		// The author sent by the client may be any name, so the name of the controllable is used instead.
+		written, err := book.Sign(text.Clean(pk.Title, text.NamePolicy), s.c.Name())
+		if err != nil {
+			return fmt.Errorf("book edit: %w", err)
+		}
+		return s.inv.SetItem(slot, item.NewStack(written, 1))
+	default:
+		return fmt.Errorf("unknown book edit action type %v", pk.ActionType)
+	}
+	if err != nil {
+		return fmt.Errorf("book edit: %w", err)
+	}
+	return s.inv.SetItem(slot, item.NewStack(book, 1))
+}
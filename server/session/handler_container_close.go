@@ -12,6 +12,10 @@ type ContainerCloseHandler struct{}
 func (h *ContainerCloseHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.ContainerClose)
 
+	// Items left on the cursor or in a crafting grid are returned to the inventory whenever a window is
+	// closed, so that they are not lost or duplicated.
+	s.returnUIItems()
+
 	switch pk.WindowID {
 	case 0:
 		// Closing of the normal inventory.
@@ -20,7 +24,7 @@ func (h *ContainerCloseHandler) Handle(p packet.Packet, s *Session) error {
 	case byte(s.openedWindowID.Load()):
 		s.closeCurrentContainer()
 	case 0xff:
-		// TODO: Handle closing the crafting grid.
+		// Closing of the crafting grid: UI items were already returned above.
 	default:
 		return fmt.Errorf("unexpected close request for unopened container %v", pk.WindowID)
 	}
@@ -18,16 +18,24 @@ func (h *EmoteHandler) Handle(p packet.Packet, s *Session) error {
 	if pk.EntityRuntimeID != selfEntityRuntimeID {
 		return ErrSelfRuntimeID
 	}
+	// Clients are able to send emotes at any rate, so we limit them to one emote per second to prevent
+	// players from flooding their viewers with them.
 	if time.Since(h.LastEmote) < time.Second {
 		return nil
 	}
-	h.LastEmote = time.Now()
 	emote, err := uuid.Parse(pk.EmoteID)
 	if err != nil {
 		return err
 	}
-	for _, viewer := range s.c.World().Viewers(s.c.Position()) {
-		viewer.ViewEmote(s.c, emote)
+	if !s.hasEmote(emote) {
+		// The client may only perform emotes that it announced in the EmoteList packet.
+		s.log.Debugf("failed processing packet from %v (%v): Emote: emote %v not in emote list\n", s.conn.RemoteAddr(), s.c.Name(), emote)
+		return nil
 	}
+	h.LastEmote = time.Now()
+
+	s.emoting.Store(true)
+	defer s.emoting.Store(false)
+	s.c.Emote(emote)
 	return nil
 }
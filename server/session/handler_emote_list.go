@@ -0,0 +1,34 @@
+package session
+
+import (
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// EmoteListHandler handles the EmoteList packet.
+type EmoteListHandler struct{}
+
+// Handle ...
+func (EmoteListHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.EmoteList)
+
+	if pk.PlayerRuntimeID != selfEntityRuntimeID {
+		return ErrSelfRuntimeID
+	}
+	emotes := make(map[uuid.UUID]struct{}, len(pk.EmotePieces))
+	for _, emote := range pk.EmotePieces {
+		emotes[emote] = struct{}{}
+	}
+	s.emoteMu.Lock()
+	s.emotes = emotes
+	s.emoteMu.Unlock()
+	return nil
+}
+
+// hasEmote checks if the emote passed was announced by the client in its emote list.
+func (s *Session) hasEmote(emote uuid.UUID) bool {
+	s.emoteMu.Lock()
+	defer s.emoteMu.Unlock()
+	_, ok := s.emotes[emote]
+	return ok
+}
@@ -27,7 +27,7 @@ func (h *InventoryTransactionHandler) Handle(p packet.Packet, s *Session) error
 		return nil
 	case *protocol.MismatchTransactionData:
 		// Just resend the inventory and don't do anything.
-		h.resendInventories(s)
+		s.CorrectInventory("client reported an inventory mismatch")
 		return nil
 	case *protocol.UseItemOnEntityTransactionData:
 		held, _ := s.c.HeldItems()
@@ -48,6 +48,9 @@ func (h *ItemStackRequestHandler) Handle(p packet.Packet, s *Session) error {
 
 	for _, req := range pk.Requests {
 		h.currentRequest = req.RequestID
+		if h.handleMenuRequest(req, s) {
+			continue
+		}
 		if err := h.handleRequest(req, s); err != nil {
 			// Item stacks being out of sync isn't uncommon, so don't error. Just debug the error and let the
 			// revert do its work.
@@ -98,6 +101,50 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 	return
 }
 
+// handleMenuRequest handles a request sent while the client has a menu.Menu opened. If any of the actions in
+// the request involve a slot of the menu, the request is rejected, the inventories are resent and the slot
+// is clicked. handleMenuRequest returns false if the request did not involve the menu.
+func (h *ItemStackRequestHandler) handleMenuRequest(req protocol.ItemStackRequest, s *Session) bool {
+	m, ok := s.currentMenu()
+	if !ok {
+		return false
+	}
+	slot, ok := menuSlot(req)
+	if !ok {
+		return false
+	}
+	h.reject(req.RequestID, s)
+	s.resendMenu()
+	m.Click(s.c, slot)
+	return true
+}
+
+// menuSlot returns the first slot of a menu.Menu that an action in the request passed involves. If none of
+// the actions involve the menu, false is returned.
+func menuSlot(req protocol.ItemStackRequest) (int, bool) {
+	for _, action := range req.Actions {
+		var slots []protocol.StackRequestSlotInfo
+		switch a := action.(type) {
+		case *protocol.TakeStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.PlaceStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.SwapStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source, a.Destination}
+		case *protocol.DropStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source}
+		case *protocol.DestroyStackRequestAction:
+			slots = []protocol.StackRequestSlotInfo{a.Source}
+		}
+		for _, slot := range slots {
+			if slot.ContainerID == containerChest {
+				return int(slot.Slot), true
+			}
+		}
+	}
+	return 0, false
+}
+
 // handleTake handles a Take stack request action.
 func (h *ItemStackRequestHandler) handleTake(a *protocol.TakeStackRequestAction, s *Session) error {
 	return h.handleTransfer(a.Source, a.Destination, a.Count, s)
@@ -393,6 +440,7 @@ func (h *ItemStackRequestHandler) tryAcknowledgeChanges(slot protocol.StackReque
 func (h *ItemStackRequestHandler) itemInSlot(slot protocol.StackRequestSlotInfo, s *Session) (item.Stack, error) {
 	inventory, ok := s.invByID(int32(slot.ContainerID))
 	if !ok {
+		s.CorrectContainer(fmt.Sprintf("item stack request for container %v that is not opened", slot.ContainerID))
 		return item.Stack{}, fmt.Errorf("unable to find container with ID %v", slot.ContainerID)
 	}
 
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// LecternUpdateHandler handles the LecternUpdate packet, sent by the client when it turns the page of a book on
+// a lectern or takes the book from it.
+type LecternUpdateHandler struct{}
+
+// Handle ...
+func (LecternUpdateHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.LecternUpdate)
+	pos := cube.Pos{int(pk.Position.X()), int(pk.Position.Y()), int(pk.Position.Z())}
+	if pk.DropBook {
+		return s.c.TakeLecternBook(pos)
+	}
+	return s.c.TurnLecternPage(pos, int(pk.Page))
+}
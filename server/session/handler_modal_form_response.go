@@ -2,6 +2,7 @@ package session
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
@@ -11,8 +12,11 @@ import (
 
 // ModalFormResponseHandler handles the ModalFormResponse packet.
 type ModalFormResponseHandler struct {
-	mu        sync.Mutex
-	forms     map[uint32]form.Form
+	mu    sync.Mutex
+	forms map[uint32]form.Form
+	// queue holds forms sent while the client still had another form opened. They are sent to the client
+	// one by one once it responds to the form currently opened.
+	queue     []form.Form
 	currentID atomic.Uint32
 }
 
@@ -26,6 +30,13 @@ func (h *ModalFormResponseHandler) Handle(p packet.Packet, s *Session) error {
 	h.mu.Lock()
 	f, ok := h.forms[pk.FormID]
 	delete(h.forms, pk.FormID)
+	if ok && len(h.queue) > 0 {
+		// Send the next form in the queue before submitting this one, so that forms sent while submitting are
+		// added to the back of the queue.
+		next := h.queue[0]
+		h.queue = h.queue[1:]
+		h.send(next, s)
+	}
 	h.mu.Unlock()
 
 	if !ok && bytes.Equal(pk.ResponseData, nullBytes) {
@@ -45,3 +56,19 @@ func (h *ModalFormResponseHandler) Handle(p packet.Packet, s *Session) error {
 	}
 	return nil
 }
+
+// send sends a form to the client and stores it so that it may be submitted once the client responds. The
+// mutex of the handler must be held when send is called.
+func (h *ModalFormResponseHandler) send(f form.Form, s *Session) {
+	b, _ := json.Marshal(f)
+	id := h.currentID.Add(1)
+	h.forms[id] = f
+
+	s.writePacket(&packet.ModalFormRequest{
+		FormID:   id,
+		FormData: b,
+	})
+	// Forms are usually sent in response to the player doing something, so they are sent immediately rather
+	// than waiting for the connection to be flushed at the end of the tick.
+	_ = s.conn.Flush()
+}
@@ -17,6 +17,9 @@ type PlayerAuthInputHandler struct{}
 // Handle ...
 func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.PlayerAuthInput)
+	if mode := inputMode(pk.InputMode); mode != InputModeUnknown {
+		s.c.UpdateInputMode(mode)
+	}
 	if err := h.handleMovement(pk, s); err != nil {
 		return err
 	}
@@ -57,6 +60,10 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 	}
 	s.teleportMu.Unlock()
 
+	if reason := s.validateMovement(deltaPos); reason != "" && s.rejectMovement(reason) {
+		return nil
+	}
+
 	_, submergedBefore := s.c.World().Liquid(cube.PosFromVec3(entity.EyePosition(s.c)))
 
 	s.c.Move(deltaPos, deltaYaw, deltaPitch)
@@ -69,10 +76,13 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 		s.ViewEntityState(s.c)
 	}
 
+	s.checkMenuRange()
+
+	s.chunkLoader.Face(float64(pk.Yaw))
 	s.chunkLoader.Move(s.c.Position())
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
 		Position: protocol.BlockPos{int32(pk.Position[0]), int32(pk.Position[1]), int32(pk.Position[2])},
-		Radius:   uint32(s.chunkRadius) << 4,
+		Radius:   uint32(s.chunkRadius.Load()) << 4,
 	})
 	return nil
 }
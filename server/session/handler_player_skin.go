@@ -17,7 +17,8 @@ func (PlayerSkinHandler) Handle(p packet.Packet, s *Session) error {
 		return fmt.Errorf("error decoding skin: %w", err)
 	}
 
-	s.c.SetSkin(playerSkin)
-
+	if err := s.c.SetSkin(playerSkin); err != nil {
+		return fmt.Errorf("invalid skin: %w", err)
+	}
 	return nil
 }
@@ -11,13 +11,10 @@ type RequestChunkRadiusHandler struct{}
 func (*RequestChunkRadiusHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.RequestChunkRadius)
 
-	if pk.ChunkRadius > s.maxChunkRadius {
-		pk.ChunkRadius = s.maxChunkRadius
-	}
-	s.chunkRadius = pk.ChunkRadius
+	s.visualsMu.Lock()
+	s.requestedChunkRadius = s.clampChunkRadius(pk.ChunkRadius)
+	s.visualsMu.Unlock()
 
-	s.chunkLoader.ChangeRadius(int(pk.ChunkRadius))
-
-	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: s.chunkRadius})
+	s.updateChunkRadius(s.currentVisuals())
 	return nil
 }
@@ -2,7 +2,9 @@ package session
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/text"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"strings"
 )
 
 // TextHandler handles the Text packet.
@@ -21,6 +23,12 @@ func (TextHandler) Handle(p packet.Packet, s *Session) error {
 	if pk.XUID != s.conn.IdentityData().XUID {
 		return fmt.Errorf("XUID must be equal to player's XUID")
 	}
-	s.c.Chat(pk.Message)
+	msg := text.Clean(pk.Message, text.ChatPolicy)
+	if strings.TrimSpace(msg) == "" {
+		// Nothing is left of the message after cleaning it, for example because it consisted of zero-width
+		// characters only.
+		return nil
+	}
+	s.c.Chat(msg)
 	return nil
 }
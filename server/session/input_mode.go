@@ -0,0 +1,61 @@
+package session
+
+import "github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+
+// InputMode is the way that a player controls the game, such as using a mouse and keyboard or a touch screen.
+// Players may switch between input modes while playing, for example by connecting a controller.
+type InputMode uint32
+
+const (
+	// InputModeUnknown is the InputMode of players whose input mode is not known, such as players without a
+	// session.
+	InputModeUnknown InputMode = iota
+	// InputModeMouse is the InputMode of players using a mouse and keyboard.
+	InputModeMouse
+	// InputModeTouch is the InputMode of players using a touch screen.
+	InputModeTouch
+	// InputModeGamePad is the InputMode of players using a controller.
+	InputModeGamePad
+	// InputModeMotionController is the InputMode of players using motion controllers in virtual reality.
+	InputModeMotionController
+)
+
+// inputMode converts an input mode sent by the client to an InputMode. InputModeUnknown is returned for
+// input modes that are not known.
+func inputMode(mode uint32) InputMode {
+	switch mode {
+	case packet.InputModeMouse:
+		return InputModeMouse
+	case packet.InputModeTouch:
+		return InputModeTouch
+	case packet.InputModeGamePad:
+		return InputModeGamePad
+	case packet.InputModeMotionController:
+		return InputModeMotionController
+	}
+	return InputModeUnknown
+}
+
+// String returns the name of the InputMode, such as 'touch'.
+func (m InputMode) String() string {
+	switch m {
+	case InputModeMouse:
+		return "mouse"
+	case InputModeTouch:
+		return "touch"
+	case InputModeGamePad:
+		return "gamepad"
+	case InputModeMotionController:
+		return "motion controller"
+	}
+	return "unknown"
+}
+
+// JoinInputMode returns the InputMode that the client used when it joined. Changes of the input mode after
+// joining are passed to Controllable.UpdateInputMode.
+func (s *Session) JoinInputMode() InputMode {
+	if s == Nop {
+		return InputModeUnknown
+	}
+	return inputMode(uint32(s.conn.ClientData().CurrentInputMode))
+}
@@ -0,0 +1,160 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/menu"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"time"
+)
+
+// openMenu holds the menu.Menu currently opened by a session, together with the positions of the fake chests
+// sent to open it.
+type openMenu struct {
+	m   menu.Menu
+	pos []cube.Pos
+}
+
+const (
+	// menuOpenDelay is the delay between sending the fake chests of a menu and opening the menu. The client
+	// fails to open containers that it has not yet received the block entity of.
+	menuOpenDelay = time.Second / 10
+	// menuRange is the maximum distance a player may be from the fake chests of a menu before it is closed.
+	menuRange = 8.0
+)
+
+// SendMenu opens a menu.Menu for the client. Any container or menu currently opened is closed. The menu is
+// shown as a fake chest, or double chest, placed client-side only below the player.
+func (s *Session) SendMenu(m menu.Menu) {
+	if s == Nop {
+		return
+	}
+	s.closeCurrentContainer()
+
+	base := cube.PosFromVec3(s.c.Position()).Add(cube.Pos{0, -2, 0})
+	if base[1] < 0 {
+		base[1] = 0
+	}
+	positions := []cube.Pos{base}
+	if m.Size() == menu.Large {
+		positions = append(positions, base.Add(cube.Pos{1, 0, 0}))
+	}
+	chest := s.blockRuntimeID(block.NewChest())
+	for i, pos := range positions {
+		blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+		s.writePacket(&packet.UpdateBlock{Position: blockPos, NewBlockRuntimeID: chest, Flags: packet.BlockUpdateNetwork})
+
+		data := map[string]interface{}{
+			"id":         "Chest",
+			"CustomName": m.Title(),
+			"x":          int32(pos[0]),
+			"y":          int32(pos[1]),
+			"z":          int32(pos[2]),
+		}
+		if len(positions) == 2 {
+			pair := positions[1-i]
+			data["pairx"], data["pairz"] = int32(pair[0]), int32(pair[2])
+			if i == 0 {
+				data["pairlead"] = uint8(1)
+			}
+		}
+		s.writePacket(&packet.BlockActorData{Position: blockPos, NBTData: data})
+	}
+
+	inv := inventory.New(m.Size(), nil)
+	for slot, it := range m.Items() {
+		_ = inv.SetItem(slot, it)
+	}
+
+	s.menuMu.Lock()
+	s.menu = &openMenu{m: m, pos: positions}
+	s.menuMu.Unlock()
+	id := s.menuID.Inc()
+
+	time.AfterFunc(menuOpenDelay, func() {
+		if s.menuID.Load() != id {
+			// Another menu was opened or the menu was closed in the meantime.
+			return
+		}
+		nextID := s.nextWindowID()
+		s.containerOpened.Store(true)
+		s.openedWindow.Store(inv)
+		s.openedPos.Store(base)
+
+		s.writePacket(&packet.ContainerOpen{
+			WindowID:                nextID,
+			ContainerPosition:       protocol.BlockPos{int32(base[0]), int32(base[1]), int32(base[2])},
+			ContainerEntityUniqueID: -1,
+		})
+		s.sendInv(inv, uint32(nextID))
+	})
+}
+
+// CloseMenu closes the menu.Menu currently opened by the client. If no menu is opened, CloseMenu does
+// nothing.
+func (s *Session) CloseMenu() {
+	s.menuMu.Lock()
+	opened := s.menu != nil
+	s.menuMu.Unlock()
+	if opened {
+		s.closeCurrentContainer()
+	}
+}
+
+// closeMenu closes the menu.Menu currently opened, if any, removing the fake chests sent for it. closeMenu
+// returns false if no menu was opened.
+func (s *Session) closeMenu() bool {
+	s.menuMu.Lock()
+	m := s.menu
+	s.menu = nil
+	s.menuMu.Unlock()
+	if m == nil {
+		return false
+	}
+	// Invalidate the ID so that a menu that has not yet been opened client-side will not be opened anymore.
+	s.menuID.Inc()
+	s.closeWindow()
+
+	if w := s.c.World(); w != nil {
+		for _, pos := range m.pos {
+			s.ViewBlockUpdate(pos, w.Block(pos), 0)
+		}
+	}
+	m.m.Close(s.c)
+	return true
+}
+
+// currentMenu returns the menu.Menu currently opened by the client. If no menu is opened, or if it has not
+// yet been opened client-side, the bool returned is false.
+func (s *Session) currentMenu() (menu.Menu, bool) {
+	s.menuMu.Lock()
+	defer s.menuMu.Unlock()
+	if s.menu == nil || !s.containerOpened.Load() {
+		return menu.Menu{}, false
+	}
+	return s.menu.m, true
+}
+
+// checkMenuRange closes the menu.Menu currently opened if the client moved too far away from the fake chests
+// of the menu.
+func (s *Session) checkMenuRange() {
+	s.menuMu.Lock()
+	m := s.menu
+	s.menuMu.Unlock()
+	if m == nil {
+		return
+	}
+	if s.c.Position().Sub(m.pos[0].Vec3Centre()).Len() > menuRange {
+		s.closeCurrentContainer()
+	}
+}
+
+// resendMenu resends the contents of the menu.Menu currently opened and of the inventory of the client, so
+// that any item movement predicted by the client is reverted.
+func (s *Session) resendMenu() {
+	s.sendInv(s.openedWindow.Load().(*inventory.Inventory), s.openedWindowID.Load())
+	s.sendInv(s.inv, protocol.WindowIDInventory)
+	s.sendInv(s.ui, protocol.WindowIDUI)
+}
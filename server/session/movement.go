@@ -0,0 +1,164 @@
+package session
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+	"strings"
+	"time"
+)
+
+// MovementResponse is the response of a Session to movement of the client that fails the basic sanity checks
+// of the server, such as moving tens of blocks in a single tick or walking while a form is opened.
+type MovementResponse uint32
+
+const (
+	// MovementResponseCorrect rejects invalid movement and moves the client back to its last valid position.
+	MovementResponseCorrect MovementResponse = iota
+	// MovementResponseLog accepts invalid movement, but logs it at debug level.
+	MovementResponseLog
+	// MovementResponseNone accepts all movement without checking it.
+	MovementResponseNone
+)
+
+// MovementResponseByName returns the MovementResponse with the name passed, which is one of 'correct', 'log'
+// or 'none'. False is returned if no MovementResponse with the name exists.
+func MovementResponseByName(name string) (MovementResponse, bool) {
+	switch strings.ToLower(name) {
+	case "correct":
+		return MovementResponseCorrect, true
+	case "log":
+		return MovementResponseLog, true
+	case "none":
+		return MovementResponseNone, true
+	}
+	return 0, false
+}
+
+const (
+	// maxMovementDelta is the maximum distance in blocks that a client may move horizontally or vertically in
+	// a single tick, on top of the velocity set by the server. Clients move well below this distance, even
+	// when sprinting or falling at terminal velocity, so only movement that resembles a teleport exceeds it.
+	maxMovementDelta = 8.0
+	// maxFormMovementDelta is the maximum horizontal distance in blocks that a client may move in a single tick
+	// while a form is opened, on top of the velocity set by the server. Clients cannot walk with a form opened,
+	// but may still be moved by knockback.
+	maxFormMovementDelta = 0.1
+	// horizontalDrag and verticalDrag are the factors by which the velocity of a player is multiplied every
+	// tick. They are used to calculate how far a velocity set by the server moves the client in total.
+	horizontalDrag, verticalDrag = 0.91, 0.98
+	// velocityBudgetDuration is the time after which the movement allowed by a velocity set by the server
+	// expires, so that a client cannot save it up to move further later on.
+	velocityBudgetDuration = time.Second * 3
+)
+
+// velocityBudget tracks the movement beyond the limits of the movement checks that a client may make, because
+// the server set the velocity of the player, for example by knockback or an explosion. The velocity cannot be
+// read from the player, as the client computes its own movement. The first index of every vector holds the
+// horizontal movement, the second the vertical movement.
+type velocityBudget struct {
+	// peak holds the highest velocity set since the budget was last empty. The client cannot move further than
+	// this in a single tick.
+	peak mgl64.Vec2
+	// left holds the distance that the client may still move in total.
+	left mgl64.Vec2
+	// expiry is the time at which the budget expires.
+	expiry time.Time
+}
+
+// fill adds the movement caused by the velocity passed to the budget. The client may move the velocity in the
+// first tick, after which the velocity is slowed down by drag in the ticks that follow.
+func (b *velocityBudget) fill(vel mgl64.Vec3) {
+	v := mgl64.Vec2{mgl64.Vec2{vel[0], vel[2]}.Len(), math.Abs(vel[1])}
+	b.peak = mgl64.Vec2{math.Max(b.peak[0], v[0]), math.Max(b.peak[1], v[1])}
+	b.left = b.left.Add(mgl64.Vec2{v[0] / (1 - horizontalDrag), v[1] / (1 - verticalDrag)})
+	b.expiry = time.Now().Add(velocityBudgetDuration)
+}
+
+// spend spends the movement passed, beyond the limit of a check, from the budget for the axis passed: 0 for
+// horizontal and 1 for vertical movement. False is returned if the budget does not cover the movement.
+func (b *velocityBudget) spend(axis int, excess float64) bool {
+	if excess <= 0 {
+		return true
+	}
+	if time.Now().After(b.expiry) {
+		*b = velocityBudget{}
+	}
+	if excess > b.peak[axis] || excess > b.left[axis] {
+		return false
+	}
+	b.left[axis] -= excess
+	return true
+}
+
+// SetMovementResponse sets the MovementResponse of the Session to movement that fails the movement checks. By
+// default, MovementResponseCorrect is used.
+func (s *Session) SetMovementResponse(r MovementResponse) {
+	s.movementResponse.Store(uint32(r))
+}
+
+// validateMovement checks if the movement by the delta passed is valid. It returns a description of the check
+// that the movement failed, or an empty string if it is valid or if movement is not checked.
+// Movement while a teleport is not yet confirmed by the client must not be passed to validateMovement.
+func (s *Session) validateMovement(delta mgl64.Vec3) string {
+	if MovementResponse(s.movementResponse.Load()) == MovementResponseNone {
+		return ""
+	}
+	horizontal, vertical := mgl64.Vec2{delta[0], delta[2]}.Len(), math.Abs(delta[1])
+	formOpen, limit := s.formOpen(), maxMovementDelta
+	if formOpen {
+		limit = maxFormMovementDelta
+	}
+
+	// Movement beyond the limits is spent from the velocity set by the server, so that players flung by
+	// knockback or explosions are not flagged. Falling players move at most ~4 blocks per tick, well within
+	// the limit.
+	s.velocityMu.Lock()
+	defer s.velocityMu.Unlock()
+	if !s.velocity.spend(0, horizontal-limit) {
+		if !formOpen || horizontal > maxMovementDelta+s.velocity.peak[0] {
+			return fmt.Sprintf("moved %.2f blocks horizontally in one tick", horizontal)
+		}
+		return "moved while a form was opened"
+	}
+	if !s.velocity.spend(1, vertical-maxMovementDelta) {
+		return fmt.Sprintf("moved %.2f blocks vertically in one tick", delta[1])
+	}
+	return ""
+}
+
+// rejectMovement handles movement that failed a check with the reason passed, following the MovementResponse
+// of the Session. True is returned if the movement was rejected and must not be applied.
+func (s *Session) rejectMovement(reason string) bool {
+	if MovementResponse(s.movementResponse.Load()) == MovementResponseLog {
+		s.log.Debugf("invalid movement from %v (%v): %v\n", s.conn.RemoteAddr(), s.c.Name(), reason)
+		return false
+	}
+	s.logCorrection("movement reset", reason)
+
+	pos := s.c.Position()
+	yaw, pitch := s.c.Rotation()
+	// The client is reset like after a teleport, so that its movement is ignored until it confirms the
+	// position.
+	s.teleportMu.Lock()
+	s.teleportPos = &pos
+	s.teleportMu.Unlock()
+	s.writePacket(&packet.MovePlayer{
+		EntityRuntimeID: selfEntityRuntimeID,
+		Position:        vec64To32(pos.Add(entityOffset(s.c))),
+		Pitch:           float32(pitch),
+		Yaw:             float32(yaw),
+		HeadYaw:         float32(yaw),
+		Mode:            packet.MoveModeReset,
+	})
+	return true
+}
+
+// formOpen checks if the client currently has a form opened.
+func (s *Session) formOpen() bool {
+	h := s.handlers[packet.IDModalFormResponse].(*ModalFormResponseHandler)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.forms) > 0
+}
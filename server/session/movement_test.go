@@ -0,0 +1,39 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+func TestMovementVelocityBudget(t *testing.T) {
+	conn, p, _ := startSession(t, player.NopHandler{})
+	move := func(pos mgl64.Vec3, yaw float32) {
+		// The position in the packet is that of the eyes of the player.
+		conn.read <- &packet.PlayerAuthInput{Position: vec64To32(pos).Add(mgl32.Vec3{0, 1.62}), Yaw: yaw}
+		conn.waitForCorrections()
+	}
+
+	// Moving 12 blocks in one tick without a velocity set by the server resembles a teleport.
+	move(mgl64.Vec3{12.5, 0, 0.5}, 0)
+	if pos := p.Position(); pos[0] != 0.5 {
+		t.Fatalf("expected movement of 12 blocks to be rejected, got position %v", pos)
+	}
+	// The client confirms the reset to its last valid position. The rotation is changed, so that the
+	// movement is not ignored for being the same as the current position.
+	move(mgl64.Vec3{0.5, 0, 0.5}, 1)
+
+	// A launch by the server allows the client to move as far.
+	p.SetVelocity(mgl64.Vec3{12, 0, 0})
+	move(mgl64.Vec3{12.5, 0, 0.5}, 2)
+	if pos := p.Position(); mgl64.Abs(pos[0]-12.5) > 1e-4 {
+		t.Errorf("expected movement after a launch of 12 blocks to be accepted, got position %v", pos)
+	}
+}
+
+// vec64To32 converts a mgl64.Vec3 to a mgl32.Vec3.
+func vec64To32(vec mgl64.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{float32(vec[0]), float32(vec[1]), float32(vec[2])}
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"runtime/debug"
+)
+
+// PacketHandler handles raw packets received from and sent to the client of a Session. It may be used to
+// handle packets that the Session does not implement itself.
+type PacketHandler interface {
+	// HandleClientPacket handles a packet received from the client, before the Session handles it. Handlers
+	// are called on the goroutine that reads packets from the client, so they should not block. Cancelling
+	// the context prevents the Session from handling the packet.
+	HandleClientPacket(ctx *event.Context, pk packet.Packet)
+	// HandleServerPacket handles a packet about to be sent to the client. Cancelling the context prevents
+	// the packet from being sent.
+	HandleServerPacket(ctx *event.Context, pk packet.Packet)
+}
+
+// AddPacketHandler adds a PacketHandler to the Session. Handlers are called in the order that they were
+// added in. Panics in handlers are recovered and logged.
+func (s *Session) AddPacketHandler(h PacketHandler) {
+	if s == Nop {
+		return
+	}
+	s.packetHandlerMu.Lock()
+	defer s.packetHandlerMu.Unlock()
+	handlers, _ := s.packetHandlers.Load().([]PacketHandler)
+	s.packetHandlers.Store(append(append([]PacketHandler(nil), handlers...), h))
+}
+
+// RemovePacketHandler removes a PacketHandler previously added using AddPacketHandler from the Session.
+func (s *Session) RemovePacketHandler(h PacketHandler) {
+	if s == Nop {
+		return
+	}
+	s.packetHandlerMu.Lock()
+	defer s.packetHandlerMu.Unlock()
+	handlers, _ := s.packetHandlers.Load().([]PacketHandler)
+	n := make([]PacketHandler, 0, len(handlers))
+	for _, handler := range handlers {
+		if handler != h {
+			n = append(n, handler)
+		}
+	}
+	s.packetHandlers.Store(n)
+}
+
+// WritePacket writes a packet to the client, passing it through the PacketHandlers of the Session first. It
+// may be used to send packets that the Session does not implement itself.
+func (s *Session) WritePacket(pk packet.Packet) {
+	s.writePacket(pk)
+}
+
+// packetCancelled passes the packet through all PacketHandlers of the Session and returns true if one of
+// them cancelled it. If client is true, the packet was received from the client. No context is created if
+// the Session has no PacketHandlers.
+func (s *Session) packetCancelled(pk packet.Packet, client bool) bool {
+	handlers, _ := s.packetHandlers.Load().([]PacketHandler)
+	if len(handlers) == 0 {
+		return false
+	}
+	ctx := event.C()
+	for _, h := range handlers {
+		s.callPacketHandler(h, ctx, pk, client)
+	}
+	return ctx.Cancelled()
+}
+
+// callPacketHandler calls the PacketHandler passed with the packet, recovering and logging any panic that
+// occurs in it.
+func (s *Session) callPacketHandler(h PacketHandler, ctx *event.Context, pk packet.Packet, client bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Errorf("packet handler %T panicked handling %T: %v\n%s", h, pk, r, debug.Stack())
+		}
+	}()
+	if client {
+		h.HandleClientPacket(ctx, pk)
+		return
+	}
+	h.HandleServerPacket(ctx, pk)
+}
@@ -20,7 +20,7 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"go.uber.org/atomic"
 	"math"
-	"net"
+	"sort"
 	"time"
 	_ "unsafe" // Imported for compiler directives.
 )
@@ -47,6 +47,9 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer() {
+	if s.closeMenu() {
+		return
+	}
 	if !s.containerOpened.Load() {
 		return
 	}
@@ -57,6 +60,19 @@ func (s *Session) closeCurrentContainer() {
 	}
 }
 
+// creativeOutputSlot is the slot in the UI inventory that holds the output of creative crafting.
+const creativeOutputSlot = 50
+
+// returnUIItems returns all items left in the UI inventory, such as the item held by the cursor and items in
+// the crafting grid, to the inventory of the controllable. Items that do not fit are dropped. The output of
+// creative crafting is not returned, as it was never actually obtained.
+func (s *Session) returnUIItems() {
+	_ = s.ui.SetItem(creativeOutputSlot, item.Stack{})
+	for _, it := range s.ui.MoveTo(s.inv) {
+		s.c.Drop(it)
+	}
+}
+
 // SendRespawn spawns the controllable of the session client-side in the world, provided it is has died.
 func (s *Session) SendRespawn() {
 	s.writePacket(&packet.Respawn{
@@ -134,9 +150,10 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 }
 
 // Disconnect disconnects the client and ultimately closes the session. If the message passed is non-empty,
-// it will be shown to the client.
+// it will be shown to the client. The message is returned as part of the DisconnectReason of the session.
 func (s *Session) Disconnect(message string) {
 	if s != Nop {
+		s.setDisconnectReason(DisconnectReason{Kind: DisconnectKicked, Message: message})
 		s.writePacket(&packet.Disconnect{
 			HideDisconnectionScreen: message == "",
 			Message:                 message,
@@ -145,6 +162,40 @@ func (s *Session) Disconnect(message string) {
 	}
 }
 
+// SetVisuals overrides the Visuals of the world that the session is in with the Visuals passed, until it
+// is called again. Passing nil resets the Visuals to those of the world.
+func (s *Session) SetVisuals(v *world.Visuals) {
+	if s == Nop {
+		return
+	}
+	s.visualsMu.Lock()
+	s.visuals = v
+	s.visualsMu.Unlock()
+	s.applyVisuals()
+}
+
+// ChunkRadius returns the chunk radius currently used by the client. It is the chunk radius requested by the
+// client, limited by the server, the Visuals of the world and the radius set using SetChunkRadius.
+func (s *Session) ChunkRadius() int {
+	return int(s.chunkRadius.Load())
+}
+
+// SetChunkRadius limits the chunk radius of the session to r, so that chunks further away are no longer sent.
+// If the client requests a lower chunk radius, that radius is used instead. Passing 0 removes the limit.
+func (s *Session) SetChunkRadius(r int) {
+	if s == Nop {
+		return
+	}
+	s.visualsMu.Lock()
+	if r > 0 {
+		s.chunkRadiusLimit = s.clampChunkRadius(int32(r))
+	} else {
+		s.chunkRadiusLimit = 0
+	}
+	s.visualsMu.Unlock()
+	s.updateChunkRadius(s.currentVisuals())
+}
+
 // SendSpeed sends the speed of the player in an UpdateAttributes packet, so that it is updated client-side.
 func (s *Session) SendSpeed(speed float64) {
 	s.writePacket(&packet.UpdateAttributes{
@@ -194,6 +245,9 @@ func (s *Session) SendFood(food int, saturation, exhaustion float64) {
 
 // SendVelocity sends the velocity of the player to the client.
 func (s *Session) SendVelocity(velocity mgl64.Vec3) {
+	s.velocityMu.Lock()
+	s.velocity.fill(velocity)
+	s.velocityMu.Unlock()
 	s.writePacket(&packet.SetActorMotion{
 		EntityRuntimeID: selfEntityRuntimeID,
 		Velocity:        vec64To32(velocity),
@@ -201,52 +255,65 @@ func (s *Session) SendVelocity(velocity mgl64.Vec3) {
 }
 
 // SendForm sends a form to the client of the connection. The Submit method of the form is called when the
-// client submits the form.
+// client submits the form. If the client still has another form opened, the form is queued and sent once
+// the client responds to the forms sent before it.
 func (s *Session) SendForm(f form.Form) {
-	b, _ := json.Marshal(f)
-
 	h := s.handlers[packet.IDModalFormResponse].(*ModalFormResponseHandler)
-	id := h.currentID.Add(1)
 
 	h.mu.Lock()
-	if len(h.forms) > 10 {
-		s.log.Debugf("SendForm %v: more than 10 active forms: dropping an existing one.", s.c.Name())
-		for k := range h.forms {
-			delete(h.forms, k)
-			break
-		}
+	defer h.mu.Unlock()
+	if len(h.forms) == 0 {
+		h.send(f, s)
+		return
 	}
-	h.forms[id] = f
-	h.mu.Unlock()
-
-	s.writePacket(&packet.ModalFormRequest{
-		FormID:   id,
-		FormData: b,
-	})
+	if len(h.queue) >= 10 {
+		s.log.Debugf("SendForm %v: more than 10 queued forms: dropping the oldest one.", s.c.Name())
+		h.queue = h.queue[1:]
+	}
+	h.queue = append(h.queue, f)
 }
 
-// Transfer transfers the player to a server with the IP and port passed.
-func (s *Session) Transfer(ip net.IP, port int) {
+// Transfer transfers the player to a server with the host and port passed. The packet is sent immediately.
+func (s *Session) Transfer(host string, port uint16) {
+	if s == Nop {
+		return
+	}
 	s.writePacket(&packet.Transfer{
-		Address: ip.String(),
-		Port:    uint16(port),
+		Address: host,
+		Port:    port,
 	})
+	_ = s.conn.Flush()
 }
 
-// StopSound stop a sound playing to the player.
-// if all sounds currently playing to the player should be stopped set stopAll to true and soundName left empty.
-func (s *Session) StopSound(soundName string, stopAll bool) {
+// ViewSoundStop ...
+func (s *Session) ViewSoundStop(name string) {
 	s.writePacket(&packet.StopSound{
-		SoundName: soundName,
-		StopAll:   stopAll,
+		SoundName: name,
+		StopAll:   name == "",
+	})
+}
+
+// ViewMusic ...
+func (s *Session) ViewMusic(name string, volume, pitch float64) {
+	// Clients have no music channel that the server can play tracks through, so the track is played as a
+	// sound at the position of the player's head, where it is not quieter because of the distance to the
+	// sound. The sound does not follow the player as it moves.
+	s.writePacket(&packet.PlaySound{
+		SoundName: name,
+		Position:  vec64To32(entity.EyePosition(s.c)),
+		Volume:    float32(volume),
+		Pitch:     float32(pitch),
 	})
 }
 
 // SendGameMode sends the game mode of the Controllable of the session to the client. It makes sure the right
 // flags are set to create the full game mode.
 func (s *Session) SendGameMode(mode world.GameMode) {
-	flags, id, perms := uint32(0), int32(packet.GameTypeSurvivalSpectator), uint32(0)
-	if mode.AllowsFlying() {
+	if s == Nop {
+		return
+	}
+	flags, perms := uint32(0), uint32(0)
+	if mode.AllowsFlying() || s.c.FlightAllowed() {
 		flags |= packet.AdventureFlagAllowFlight
 		if s.c.Flying() {
 			flags |= packet.AdventureFlagFlying
@@ -268,21 +335,65 @@ func (s *Session) SendGameMode(mode world.GameMode) {
 	if !mode.Visible() {
 		flags |= packet.AdventureFlagMuted
 	}
+	// Operators are sent the operator permission level, so that the client shows the operator UI, such as the
+	// commands in the command list that require a higher level.
+	permissionLevel, commandLevel := uint32(packet.PermissionLevelMember), uint32(0)
+	if level := s.c.OperatorLevel(); level > 0 {
+		permissionLevel, commandLevel = packet.PermissionLevelOperator, uint32(level)
+	}
+	s.writePacket(&packet.AdventureSettings{
+		Flags:                  flags,
+		CommandPermissionLevel: commandLevel,
+		PermissionLevel:        permissionLevel,
+		PlayerUniqueID:         selfEntityRuntimeID,
+		ActionPermissions:      perms,
+	})
+	s.writePacket(&packet.SetPlayerGameType{GameType: GameModeType(mode)})
+}
+
+// GameModeType returns the game type ID that is sent to the client for the world.GameMode passed. The ID is
+// derived from the capabilities of the game mode, so that custom game modes are handled the same way as the
+// built-in ones.
+func GameModeType(mode world.GameMode) int32 {
 	// Creative or spectator players:
 	if mode.AllowsFlying() && mode.CreativeInventory() {
-		id = packet.GameTypeCreative
 		// Cannot interact with the world, so this is a spectator.
 		if !mode.AllowsEditing() && !mode.AllowsInteraction() {
-			id = packet.GameTypeCreativeSpectator
+			return packet.GameTypeCreativeSpectator
 		}
+		return packet.GameTypeCreative
 	}
-	s.writePacket(&packet.AdventureSettings{
-		Flags:             flags,
-		PermissionLevel:   packet.PermissionLevelMember,
-		PlayerUniqueID:    selfEntityRuntimeID,
-		ActionPermissions: perms,
+	return packet.GameTypeSurvivalSpectator
+}
+
+// DifficultyType returns the difficulty ID that is sent to the client for the world.Difficulty passed.
+// Difficulties other than the built-in ones are sent as normal.
+func DifficultyType(d world.Difficulty) int32 {
+	switch d.(type) {
+	case world.DifficultyPeaceful:
+		return 0
+	case world.DifficultyEasy:
+		return 1
+	case world.DifficultyHard:
+		return 3
+	}
+	return 2
+}
+
+// GameRules returns the game rules sent to the client for the world game rules passed, sorted by their name.
+// Game rules with an int value are sent as an uint32.
+func GameRules(rules map[string]interface{}) []protocol.GameRule {
+	r := make([]protocol.GameRule, 0, len(rules))
+	for name, v := range rules {
+		if i, ok := v.(int); ok {
+			v = uint32(i)
+		}
+		r = append(r, protocol.GameRule{Name: name, Value: v})
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Name < r[j].Name
 	})
-	s.writePacket(&packet.SetPlayerGameType{GameType: id})
+	return r
 }
 
 // SendHealth sends the health and max health to the player.
@@ -298,6 +409,25 @@ func (s *Session) SendHealth(health *entity.HealthManager) {
 	})
 }
 
+// SendExperience sends the experience level and the progress towards the next level passed to the player.
+func (s *Session) SendExperience(level int, progress float64) {
+	s.writePacket(&packet.UpdateAttributes{
+		EntityRuntimeID: selfEntityRuntimeID,
+		Attributes: []protocol.Attribute{
+			{
+				Name:  "minecraft:player.level",
+				Value: float32(level),
+				Max:   float32(math.MaxInt32),
+			},
+			{
+				Name:  "minecraft:player.experience",
+				Value: float32(progress),
+				Max:   1,
+			},
+		},
+	})
+}
+
 // SendAbsorption sends the absorption value passed to the player.
 func (s *Session) SendAbsorption(value float64) {
 	max := value
@@ -316,11 +446,23 @@ func (s *Session) SendAbsorption(value float64) {
 
 // SendEffect sends an effects passed to the player.
 func (s *Session) SendEffect(e effect.Effect) {
-	s.SendEffectRemoval(e.Type())
-	id, _ := effect.ID(e.Type())
+	s.sendEffect(e, packet.MobEffectAdd)
+}
+
+// SendEffectModification sends an effect that replaces an effect of the same type already sent.
+func (s *Session) SendEffectModification(e effect.Effect) {
+	s.sendEffect(e, packet.MobEffectModify)
+}
+
+// sendEffect sends a MobEffect packet with the operation passed for an effect.
+func (s *Session) sendEffect(e effect.Effect, op byte) {
+	id, ok := effect.ID(e.Type())
+	if !ok {
+		panic(fmt.Sprintf("unregistered effect type %T", e.Type()))
+	}
 	s.writePacket(&packet.MobEffect{
 		EntityRuntimeID: selfEntityRuntimeID,
-		Operation:       packet.MobEffectAdd,
+		Operation:       op,
 		EffectType:      int32(id),
 		Amplifier:       int32(e.Level() - 1),
 		Particles:       !e.ParticlesHidden(),
@@ -374,15 +516,13 @@ func (s *Session) addToPlayerList(session *Session) {
 	s.entities[runtimeID] = c
 	s.entityMutex.Unlock()
 
+	if session.unlisted {
+		// The player is hidden from the player list, but its runtime ID must still be known to show it.
+		return
+	}
 	s.writePacket(&packet.PlayerList{
 		ActionType: packet.PlayerListActionAdd,
-		Entries: []protocol.PlayerListEntry{{
-			UUID:           c.UUID(),
-			EntityUniqueID: int64(runtimeID),
-			Username:       c.Name(),
-			XUID:           c.XUID(),
-			Skin:           skinToProtocol(c.Skin()),
-		}},
+		Entries:    []protocol.PlayerListEntry{session.listEntry(runtimeID)},
 	})
 }
 
@@ -408,6 +548,26 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		animations = append(animations, protocolAnim)
 	}
 
+	var pieces []protocol.PersonaPiece
+	for _, piece := range s.PersonaPieces {
+		pieces = append(pieces, protocol.PersonaPiece{
+			PieceID:   piece.ID,
+			PieceType: piece.Type,
+			PackID:    piece.PackID,
+			Default:   piece.Default,
+			ProductID: piece.ProductID,
+		})
+	}
+	var tints []protocol.PersonaPieceTintColour
+	for _, tint := range s.PersonaTints {
+		tints = append(tints, protocol.PersonaPieceTintColour{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
+	cape := s.Cape
+	if !cape.Exists() {
+		// Clients expect no cape data at all for skins without a cape.
+		cape = skin.Cape{}
+	}
+
 	return protocol.Skin{
 		PlayFabID:         s.PlayFabID,
 		SkinID:            uuid.New().String(),
@@ -415,11 +575,15 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		SkinImageWidth:    uint32(s.Bounds().Max.X),
 		SkinImageHeight:   uint32(s.Bounds().Max.Y),
 		SkinData:          s.Pix,
-		CapeImageWidth:    uint32(s.Cape.Bounds().Max.X),
-		CapeImageHeight:   uint32(s.Cape.Bounds().Max.Y),
-		CapeData:          s.Cape.Pix,
+		CapeImageWidth:    uint32(cape.Bounds().Max.X),
+		CapeImageHeight:   uint32(cape.Bounds().Max.Y),
+		CapeData:          cape.Pix,
 		SkinGeometry:      s.Model,
 		PersonaSkin:       s.Persona,
+		PersonaPieces:     pieces,
+		PieceTintColours:  tints,
+		ArmSize:           s.ArmSize,
+		SkinColour:        s.Colour,
 		CapeID:            uuid.New().String(),
 		FullSkinID:        uuid.New().String(),
 		Animations:        animations,
@@ -437,6 +601,10 @@ func (s *Session) removeFromPlayerList(session *Session) {
 	delete(s.entityRuntimeIDs, c)
 	s.entityMutex.Unlock()
 
+	if session.unlisted {
+		// The player was never added to the player list, so there is nothing to remove.
+		return
+	}
 	s.writePacket(&packet.PlayerList{
 		ActionType: packet.PlayerListActionRemove,
 		Entries: []protocol.PlayerListEntry{{
@@ -506,7 +674,10 @@ func (s *Session) SetHeldSlot(slot int) error {
 		return fmt.Errorf("slot exceeds hotbar range 0-8: slot is %v", slot)
 	}
 
-	s.heldSlot.Store(uint32(slot))
+	if s.heldSlot.Swap(uint32(slot)) != uint32(slot) {
+		// The held item changed, so the item that was being used can no longer be used.
+		s.c.ReleaseItem()
+	}
 
 	for _, viewer := range s.c.World().Viewers(s.c.Position()) {
 		viewer.ViewEntityItems(s.c)
@@ -611,8 +782,23 @@ func protocolToSkin(sk protocol.Skin) (s skin.Skin, err error) {
 	s.Model = sk.SkinGeometry
 	s.PlayFabID = sk.PlayFabID
 
-	s.Cape = skin.NewCape(int(sk.CapeImageWidth), int(sk.CapeImageHeight))
-	s.Cape.Pix = sk.CapeData
+	s.ArmSize, s.Colour = sk.ArmSize, sk.SkinColour
+	if len(sk.CapeData) != 0 {
+		s.Cape = skin.NewCape(int(sk.CapeImageWidth), int(sk.CapeImageHeight))
+		s.Cape.Pix = sk.CapeData
+	}
+	for _, piece := range sk.PersonaPieces {
+		s.PersonaPieces = append(s.PersonaPieces, skin.PersonaPiece{
+			ID:        piece.PieceID,
+			Type:      piece.PieceType,
+			PackID:    piece.PackID,
+			ProductID: piece.ProductID,
+			Default:   piece.Default,
+		})
+	}
+	for _, tint := range sk.PieceTintColours {
+		s.PersonaTints = append(s.PersonaTints, skin.PersonaTint{PieceType: tint.PieceType, Colours: tint.Colours})
+	}
 
 	m := make(map[string]interface{})
 	if err = json.Unmarshal(sk.SkinGeometry, &m); err != nil {
@@ -0,0 +1,159 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// listEntries holds the entries added to the player list of all sessions using AddListEntry, in the order that
+// they were added. It is protected by sessionMu.
+var listEntries []protocol.PlayerListEntry
+
+// listEntryID is the entity unique ID of the last entry added using AddListEntry. It is protected by
+// sessionMu. Entries are given negative IDs, so that they never collide with the IDs of entities.
+var listEntryID int64
+
+// AddListEntry adds an entry with the name passed to the player list of all sessions, including sessions that
+// are opened later. These entries do not belong to any player, so they may be used to show information in
+// the player list, such as a header above the players. The entry has a blank skin. The UUID returned may be
+// passed to RemoveListEntry to remove the entry again.
+func AddListEntry(name string) uuid.UUID {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	listEntryID--
+	entry := protocol.PlayerListEntry{
+		UUID:           uuid.New(),
+		EntityUniqueID: listEntryID,
+		Username:       name,
+		Skin:           skinToProtocol(skin.New(64, 64)),
+	}
+	listEntries = append(listEntries, entry)
+	for _, s := range sessions {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{entry}})
+	}
+	return entry.UUID
+}
+
+// RemoveListEntry removes an entry added using AddListEntry from the player list of all sessions. Nothing
+// happens if no entry with the UUID passed exists.
+func RemoveListEntry(id uuid.UUID) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	for i, entry := range listEntries {
+		if entry.UUID != id {
+			continue
+		}
+		listEntries = append(listEntries[:i], listEntries[i+1:]...)
+		for _, s := range sessions {
+			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{UUID: id}}})
+		}
+		return
+	}
+}
+
+// SetListName changes the name shown for the Controllable of the Session in the player list of all sessions.
+// Passing an empty name shows the name of the Controllable again.
+func (s *Session) SetListName(name string) {
+	if s == Nop {
+		return
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	if s.listName == name {
+		return
+	}
+	s.listName = name
+	if !s.inPlayerList || s.unlisted {
+		return
+	}
+	for _, session := range sessions {
+		// The entry is removed first, as clients do not update entries that are already in the list.
+		session.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{UUID: s.c.UUID()}}})
+		session.writeListEntry(s)
+	}
+}
+
+// ListName returns the name shown for the Controllable of the Session in the player list. It is the name of
+// the Controllable unless changed using SetListName.
+func (s *Session) ListName() string {
+	if s == Nop {
+		return ""
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	if s.listName == "" && s.c != nil {
+		return s.c.Name()
+	}
+	return s.listName
+}
+
+// SetListed changes if the Controllable of the Session is shown in the player list of all sessions, including
+// its own. Controllables hidden from the player list can still be seen in the world.
+func (s *Session) SetListed(listed bool) {
+	if s == Nop {
+		return
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	if s.unlisted == !listed {
+		return
+	}
+	s.unlisted = !listed
+	if !s.inPlayerList {
+		return
+	}
+	for _, session := range sessions {
+		if listed {
+			session.writeListEntry(s)
+			continue
+		}
+		session.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{UUID: s.c.UUID()}}})
+	}
+}
+
+// Listed checks if the Controllable of the Session is shown in the player list. It is true unless changed
+// using SetListed.
+func (s *Session) Listed() bool {
+	if s == Nop {
+		return false
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	return !s.unlisted
+}
+
+// writeListEntry writes the player list entry of the Controllable of the session passed to the Session. The
+// Controllable must already have a runtime ID in the Session. sessionMu must be held when calling
+// writeListEntry.
+func (s *Session) writeListEntry(session *Session) {
+	s.entityMutex.RLock()
+	runtimeID := s.entityRuntimeIDs[session.c]
+	s.entityMutex.RUnlock()
+
+	s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{session.listEntry(runtimeID)}})
+}
+
+// listEntry returns the player list entry of the Controllable of the Session, using the runtime ID passed as
+// the entity unique ID. sessionMu must be held when calling listEntry.
+func (s *Session) listEntry(runtimeID uint64) protocol.PlayerListEntry {
+	c := s.c
+	var xuid string
+	if c.Authenticated() {
+		xuid = c.XUID()
+	}
+	name := s.listName
+	if name == "" {
+		name = c.Name()
+	}
+	return protocol.PlayerListEntry{
+		UUID:           c.UUID(),
+		EntityUniqueID: int64(runtimeID),
+		Username:       name,
+		XUID:           xuid,
+		Skin:           skinToProtocol(c.Skin()),
+	}
+}
@@ -0,0 +1,71 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+	"time"
+)
+
+func TestPlayerList(t *testing.T) {
+	conn, _, _ := startSession(t, player.NopHandler{})
+	otherConn, other, _ := startSession(t, player.NopHandler{})
+	if _, ok := conn.listEntry(other.UUID(), packet.PlayerListActionAdd); !ok {
+		t.Fatalf("player joining was not added to the player list of other players")
+	}
+
+	other.SetListName("Renamed")
+	if name, _ := conn.listEntry(other.UUID(), packet.PlayerListActionAdd); name != "Renamed" {
+		t.Errorf("expected list name %q, got %q", "Renamed", name)
+	}
+
+	id := session.AddListEntry("Lobby")
+	defer session.RemoveListEntry(id)
+	if _, ok := otherConn.listEntry(id, packet.PlayerListActionAdd); !ok {
+		t.Errorf("entry added was not sent to players online")
+	}
+	lateConn, _, _ := startSession(t, player.NopHandler{})
+	if name, _ := lateConn.listEntry(id, packet.PlayerListActionAdd); name != "Lobby" {
+		t.Errorf("entry added was not sent to player joining later")
+	}
+	if name, _ := lateConn.listEntry(other.UUID(), packet.PlayerListActionAdd); name != "Renamed" {
+		t.Errorf("expected list name %q sent to player joining later, got %q", "Renamed", name)
+	}
+
+	other.HideFromList()
+	if _, ok := conn.listEntry(other.UUID(), packet.PlayerListActionRemove); !ok {
+		t.Errorf("player hidden was not removed from the player list")
+	}
+	conn.clear()
+	_ = otherConn.Close()
+	time.Sleep(time.Second / 5)
+	if _, ok := conn.listEntry(other.UUID(), packet.PlayerListActionRemove); ok {
+		t.Errorf("player hidden from the list was removed from the player list again when leaving")
+	}
+}
+
+// listEntry returns the name in the last player list entry written with the UUID and action passed. False is
+// returned if no such entry was written.
+func (c *recordConn) listEntry(id uuid.UUID, action byte) (name string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pk := range c.written {
+		if l, isList := pk.(*packet.PlayerList); isList && l.ActionType == action {
+			for _, entry := range l.Entries {
+				if entry.UUID == id {
+					name, ok = entry.Username, true
+				}
+			}
+		}
+	}
+	return name, ok
+}
+
+// clear clears the packets written to the recordConn.
+func (c *recordConn) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = nil
+}
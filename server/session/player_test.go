@@ -0,0 +1,31 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+// flyingSurvival is a custom game mode that behaves like creative mode, to make sure game modes not part of
+// the world package are mapped by their capabilities.
+type flyingSurvival struct {
+	world.GameModeCreative
+}
+
+func TestGameModeType(t *testing.T) {
+	tests := []struct {
+		mode world.GameMode
+		want int32
+	}{
+		{mode: world.GameModeSurvival{}, want: packet.GameTypeSurvivalSpectator},
+		{mode: world.GameModeAdventure{}, want: packet.GameTypeSurvivalSpectator},
+		{mode: world.GameModeCreative{}, want: packet.GameTypeCreative},
+		{mode: world.GameModeSpectator{}, want: packet.GameTypeCreative},
+		{mode: flyingSurvival{}, want: packet.GameTypeCreative},
+	}
+	for _, test := range tests {
+		if got := GameModeType(test.mode); got != test.want {
+			t.Errorf("GameModeType(%T) = %v, want %v", test.mode, got, test.want)
+		}
+	}
+}
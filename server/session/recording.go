@@ -0,0 +1,199 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"io"
+	"sync"
+	"time"
+)
+
+// Direction is the direction in which a recorded packet was sent.
+type Direction uint8
+
+const (
+	// DirectionClient is the Direction of packets sent by the client to the server.
+	DirectionClient Direction = iota
+	// DirectionServer is the Direction of packets sent by the server to the client.
+	DirectionServer
+)
+
+// recordShieldID is the shield runtime ID used to encode and decode item stacks in recordings. The runtime ID
+// of shields only affects whether an extra field is present for them, so any ID works as long as the same
+// one is used for recording and replaying. 0 is the runtime ID of air, which is never encoded with it.
+const recordShieldID = 0
+
+// StartRecording starts recording all packets received from and sent to the client of the Session to the
+// io.Writer passed. Every packet is written with the time at which it was sent and its Direction, so that
+// the recording may be read back and replayed using a ReplayReader. If the Session was already recording,
+// the previous recording is stopped first. Recording stops when StopRecording is called or when the Session
+// is closed. The io.Writer is not closed by the Session.
+func (s *Session) StartRecording(w io.Writer) {
+	if s == Nop {
+		return
+	}
+	if old, _ := s.recorder.Swap(&recorder{w: w}).(*recorder); old != nil {
+		_ = old.stop()
+	}
+}
+
+// StopRecording stops a recording started using StartRecording. The first error encountered while writing
+// the recording, if any, is returned.
+func (s *Session) StopRecording() error {
+	if s == Nop {
+		return nil
+	}
+	if r, _ := s.recorder.Swap((*recorder)(nil)).(*recorder); r != nil {
+		return r.stop()
+	}
+	return nil
+}
+
+// record writes a packet to the recording of the Session, if it is recording.
+func (s *Session) record(pk packet.Packet, d Direction) {
+	if r, _ := s.recorder.Load().(*recorder); r != nil {
+		r.record(pk, d)
+	}
+}
+
+// recorder writes packets to an io.Writer in the format read by ReplayReader. Every packet is prefixed with
+// its Direction, the Unix time in nanoseconds at which it was sent and its length.
+type recorder struct {
+	mu      sync.Mutex
+	w       io.Writer
+	buf     bytes.Buffer
+	err     error
+	stopped bool
+}
+
+// record encodes the packet passed and writes it to the io.Writer of the recorder. Once writing fails, all
+// following packets are dropped.
+func (r *recorder) record(pk packet.Packet, d Direction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil || r.stopped {
+		return
+	}
+	r.buf.Reset()
+	r.buf.Write(make([]byte, 13))
+	_ = (&packet.Header{PacketID: pk.ID()}).Write(&r.buf)
+	pk.Marshal(protocol.NewWriter(&r.buf, recordShieldID))
+
+	b := r.buf.Bytes()
+	b[0] = byte(d)
+	binary.LittleEndian.PutUint64(b[1:9], uint64(time.Now().UnixNano()))
+	binary.LittleEndian.PutUint32(b[9:13], uint32(len(b)-13))
+	_, r.err = r.w.Write(b)
+}
+
+// stop stops the recorder and returns the first error encountered writing to it.
+func (r *recorder) stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	return r.err
+}
+
+// RecordedPacket is a packet read from a recording by a ReplayReader.
+type RecordedPacket struct {
+	// Time is the time at which the packet was sent.
+	Time time.Time
+	// Direction is the direction in which the packet was sent.
+	Direction Direction
+	// Packet is the packet that was sent.
+	Packet packet.Packet
+}
+
+// PacketWriter is a connection that packets may be written to, such as a *minecraft.Conn or a Conn.
+type PacketWriter interface {
+	// WritePacket writes a packet to the connection.
+	WritePacket(pk packet.Packet) error
+}
+
+// ReplayReader reads the packets of a recording made using Session.StartRecording.
+type ReplayReader struct {
+	r    *bufio.Reader
+	pool packet.Pool
+}
+
+// NewReplayReader returns a ReplayReader that reads a recording from the io.Reader passed.
+func NewReplayReader(r io.Reader) *ReplayReader {
+	return &ReplayReader{r: bufio.NewReader(r), pool: packet.NewPool()}
+}
+
+// Next reads the next packet of the recording. io.EOF is returned once the end of the recording is reached.
+func (r *ReplayReader) Next() (RecordedPacket, error) {
+	prefix := make([]byte, 13)
+	if _, err := io.ReadFull(r.r, prefix); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return RecordedPacket{}, fmt.Errorf("read recorded packet: %w", err)
+		}
+		return RecordedPacket{}, err
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(prefix[9:13]))
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return RecordedPacket{}, fmt.Errorf("read recorded packet: %w", err)
+	}
+
+	buf := bytes.NewBuffer(data)
+	h := &packet.Header{}
+	if err := h.Read(buf); err != nil {
+		return RecordedPacket{}, fmt.Errorf("read recorded packet header: %w", err)
+	}
+	newPacket, ok := r.pool[h.PacketID]
+	if !ok {
+		return RecordedPacket{}, fmt.Errorf("read recorded packet: unknown packet ID %v", h.PacketID)
+	}
+	pk := newPacket()
+	if err := readRecordedPacket(pk, buf); err != nil {
+		return RecordedPacket{}, err
+	}
+	return RecordedPacket{
+		Time:      time.Unix(0, int64(binary.LittleEndian.Uint64(prefix[1:9]))),
+		Direction: Direction(prefix[0]),
+		Packet:    pk,
+	}, nil
+}
+
+// readRecordedPacket decodes the payload in the buffer passed into the packet passed, recovering from the
+// panics that malformed packets cause.
+func readRecordedPacket(pk packet.Packet, buf *bytes.Buffer) (err error) {
+	defer func() {
+		if recoveredErr := recover(); recoveredErr != nil {
+			err = fmt.Errorf("read recorded packet %T: %v", pk, recoveredErr)
+		}
+	}()
+	pk.Unmarshal(protocol.NewReader(buf, recordShieldID))
+	return nil
+}
+
+// Replay writes all packets in the rest of the recording that were sent by the server to the PacketWriter
+// passed, in the order they were sent in. If realTime is true, Replay waits between packets for as long as
+// passed between them in the recording. The amount of packets written is returned. Replay returns once the end
+// of the recording is reached, or if reading the recording or writing to the PacketWriter fails.
+func (r *ReplayReader) Replay(w PacketWriter, realTime bool) (n int, err error) {
+	var last time.Time
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if rec.Direction != DirectionServer {
+			continue
+		}
+		if realTime && !last.IsZero() {
+			time.Sleep(rec.Time.Sub(last))
+		}
+		last = rec.Time
+		if err := w.WritePacket(rec.Packet); err != nil {
+			return n, fmt.Errorf("replay packet %T: %w", rec.Packet, err)
+		}
+		n++
+	}
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"bytes"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	r := &recorder{w: buf}
+	r.record(&packet.Text{TextType: packet.TextTypeRaw, Message: "hello"}, DirectionServer)
+	r.record(&packet.RequestChunkRadius{ChunkRadius: 8}, DirectionClient)
+	r.record(&packet.SetTime{Time: 6000}, DirectionServer)
+	if err := r.stop(); err != nil {
+		t.Fatalf("stop recording: %v", err)
+	}
+	// Packets recorded after stopping must not end up in the recording.
+	r.record(&packet.SetTime{Time: 12000}, DirectionServer)
+
+	// Every packet recorded must be decoded into a new packet equal to the one recorded.
+	replay := NewReplayReader(bytes.NewReader(buf.Bytes()))
+	for _, want := range []packet.Packet{
+		&packet.Text{TextType: packet.TextTypeRaw, Message: "hello"},
+		&packet.RequestChunkRadius{ChunkRadius: 8},
+		&packet.SetTime{Time: 6000},
+	} {
+		rec, err := replay.Next()
+		if err != nil {
+			t.Fatalf("read recorded packet: %v", err)
+		}
+		if !reflect.DeepEqual(rec.Packet, want) {
+			t.Fatalf("expected recorded packet %#v, got %#v", want, rec.Packet)
+		}
+	}
+	if _, err := replay.Next(); err != io.EOF {
+		t.Fatalf("expected end of recording, got %v", err)
+	}
+
+	conn := &replayConn{}
+	n, err := NewReplayReader(bytes.NewReader(buf.Bytes())).Replay(conn, false)
+	if err != nil {
+		t.Fatalf("replay recording: %v", err)
+	}
+	if n != 2 || len(conn.written) != 2 {
+		t.Fatalf("expected 2 server packets replayed, got %v", n)
+	}
+	if pk, ok := conn.written[1].(*packet.SetTime); !ok || pk.Time != 6000 {
+		t.Fatalf("expected set time packet with time 6000, got %#v", conn.written[1])
+	}
+
+	r = &recorder{w: failWriter{}}
+	r.record(&packet.SetTime{}, DirectionServer)
+	if err := r.stop(); err != io.ErrShortWrite {
+		t.Fatalf("expected write error from stop, got %v", err)
+	}
+}
+
+// replayConn is a PacketWriter that stores all packets written to it.
+type replayConn struct {
+	written []packet.Packet
+}
+
+// WritePacket ...
+func (c *replayConn) WritePacket(pk packet.Packet) error {
+	c.written = append(c.written, pk)
+	return nil
+}
+
+// failWriter is an io.Writer that always fails.
+type failWriter struct{}
+
+// Write ...
+func (failWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
@@ -7,10 +7,13 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
@@ -36,14 +39,51 @@ type Session struct {
 	// session controls.
 	onStop func(controllable Controllable)
 
-	scoreboardObj atomic.String
-
-	chunkBuf                    *bytes.Buffer
-	chunkLoader                 *world.Loader
-	chunkRadius, maxChunkRadius int32
+	scoreboardMu    sync.Mutex
+	scoreboard      *scoreboard.Scoreboard
+	scoreboardObj   string
+	scoreboardLines []string
+
+	bossBarMu     sync.Mutex
+	bossBar       *bossbar.BossBar
+	bossBarID     uint64
+	bossBarText   string
+	bossBarHealth float64
+	bossBarColour bossbar.Colour
+
+	chunkBuf    *bytes.Buffer
+	chunkLoader *world.Loader
+	// chunkRadius is the chunk radius currently used by the client. It is limited by the requested chunk
+	// radius, the maximum chunk radius of the server and the Visuals of the world.
+	chunkRadius    atomic.Int32
+	minChunkRadius int32
+	maxChunkRadius int32
+
+	visualsMu sync.Mutex
+	// visuals overrides the Visuals of the world if non-nil.
+	visuals *world.Visuals
+	// requestedChunkRadius is the chunk radius requested by the client, limited to minChunkRadius and
+	// maxChunkRadius.
+	requestedChunkRadius int32
+	// chunkRadiusLimit is the chunk radius set using SetChunkRadius, which the chunk radius may not exceed. It is
+	// 0 if the chunk radius is not limited.
+	chunkRadiusLimit int32
+
+	packetHandlerMu sync.Mutex
+	// packetHandlers holds a []PacketHandler. It is replaced rather than modified when handlers are added or
+	// removed, so that it can be read without locking for every packet.
+	packetHandlers atomic.Value
+	// recorder holds the *recorder that packets are recorded to. It holds nil if the Session is not recording.
+	recorder atomic.Value
 
 	teleportMu  sync.Mutex
 	teleportPos *mgl64.Vec3
+	// movementResponse holds the MovementResponse to movement of the client that fails the movement checks.
+	movementResponse atomic.Uint32
+	velocityMu       sync.Mutex
+	// velocity is the movement beyond the limits of the movement checks that the client may make because its
+	// velocity was set by the server.
+	velocity velocityBudget
 
 	entityMutex sync.RWMutex
 	// currentEntityRuntimeID holds the runtime ID assigned to the last entity. It is incremented for every
@@ -64,7 +104,27 @@ type Session struct {
 	openedWindowID                 atomic.Uint32
 	inTransaction, containerOpened atomic.Bool
 	openedWindow, openedPos        atomic.Value
+	menuMu                         sync.Mutex
+	menu                           *openMenu
+	menuID                         atomic.Uint32
 	swingingArm                    atomic.Bool
+	// lastSwing is the time in Unix nanoseconds at which the arm swing of the controllable was last shown to
+	// other viewers.
+	lastSwing atomic.Int64
+
+	emoteMu sync.Mutex
+	// emotes holds the emotes that the client announced in its emote list.
+	emotes map[uuid.UUID]struct{}
+	// emoting is true while an emote performed by the client is being shown to viewers, so that it isn't sent
+	// back to the client.
+	emoting atomic.Bool
+
+	// corrections holds the corrections queued for rejected actions predicted by the client.
+	corrections    corrections
+	logCorrections atomic.Bool
+
+	// blockActors holds the block entity data queued to be sent to the client.
+	blockActors blockActors
 
 	blobMu                sync.Mutex
 	blobs                 map[uint64][]byte
@@ -72,6 +132,23 @@ type Session struct {
 	invOpened             bool
 
 	joinMessage, quitMessage *atomic.String
+
+	// latency is the latency of the connection, refreshed every second so that reading it is cheap.
+	latency atomic.Duration
+	// lastPacket is the time in Unix nanoseconds at which the last packet was received from the client.
+	lastPacket atomic.Int64
+
+	disconnectMu sync.Mutex
+	// disconnectReason is the reason that the Session was disconnected for, or nil if it was not yet
+	// disconnected.
+	disconnectReason *DisconnectReason
+
+	// listName is the name shown for the Controllable in the player list if non-empty. unlisted specifies if
+	// the Controllable is hidden from the player list of all sessions. inPlayerList is true while the Session
+	// is part of the player list. These fields are protected by sessionMu.
+	listName     string
+	unlisted     bool
+	inPlayerList bool
 }
 
 // Conn represents a connection that packets are read from and written to by a Session. In addition, it holds some
@@ -139,8 +216,9 @@ func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMe
 		entities:               map[uint64]world.Entity{},
 		hiddenEntities:         map[world.Entity]struct{}{},
 		blobs:                  map[uint64][]byte{},
-		chunkRadius:            int32(r),
+		chunkRadius:            *atomic.NewInt32(int32(r)),
 		maxChunkRadius:         int32(maxChunkRadius),
+		requestedChunkRadius:   int32(r),
 		conn:                   conn,
 		log:                    log,
 		currentEntityRuntimeID: 1,
@@ -161,11 +239,14 @@ func New(conn Conn, maxChunkRadius int, log internal.Logger, joinMessage, quitMe
 func (s *Session) Start(c Controllable, w *world.World, gm world.GameMode, onStop func(controllable Controllable)) {
 	s.onStop = onStop
 	s.c = c
+	s.latency.Store(s.conn.Latency())
+	s.lastPacket.Store(time.Now().UnixNano())
 	s.entityRuntimeIDs[c] = selfEntityRuntimeID
 	s.entities[selfEntityRuntimeID] = c
 
-	s.chunkLoader = world.NewLoader(int(s.chunkRadius), w, s)
-	s.chunkLoader.Move(w.Spawn().Vec3Middle())
+	s.chunkLoader = world.NewLoader(int(s.chunkRadius.Load()), w, s)
+	s.chunkLoader.Move(c.Position())
+	s.applyVisuals()
 
 	s.initPlayerList()
 
@@ -193,9 +274,27 @@ func (s *Session) Start(c Controllable, w *world.World, gm world.GameMode, onSto
 // Close closes the session, which in turn closes the controllable and the connection that the session
 // manages.
 func (s *Session) Close() error {
+	// Sessions closed without a reason being set were closed by the server directly.
+	s.setDisconnectReason(DisconnectReason{Kind: DisconnectClosed})
 	s.closeCurrentContainer()
+	// Return items held on the cursor or in crafting grids before the controllable is closed, so that they
+	// are saved along with the rest of the inventory.
+	s.returnUIItems()
+
+	s.scoreboardMu.Lock()
+	if s.scoreboard != nil {
+		s.scoreboard.RemoveViewer(s)
+	}
+	s.scoreboardMu.Unlock()
+
+	s.bossBarMu.Lock()
+	if s.bossBar != nil {
+		s.bossBar.RemoveViewer(s)
+	}
+	s.bossBarMu.Unlock()
 
 	_ = s.conn.Close()
+	_ = s.StopRecording()
 	_ = s.chunkLoader.Close()
 	_ = s.c.Close()
 
@@ -225,6 +324,7 @@ func (s *Session) Close() error {
 // CloseConnection closes the underlying connection of the session so that the session ends up being closed
 // eventually.
 func (s *Session) CloseConnection() {
+	s.setDisconnectReason(DisconnectReason{Kind: DisconnectClosed})
 	_ = s.conn.Close()
 }
 
@@ -233,9 +333,9 @@ func (s *Session) Addr() net.Addr {
 	return s.conn.RemoteAddr()
 }
 
-// Latency returns the latency of the connection.
+// Latency returns the latency of the connection. It is refreshed every second, so calling Latency is cheap.
 func (s *Session) Latency() time.Duration {
-	return s.conn.Latency()
+	return s.latency.Load()
 }
 
 // ClientData returns the login.ClientData of the underlying *minecraft.Conn.
@@ -255,21 +355,29 @@ func (s *Session) handlePackets() {
 		if err := recover(); err != nil {
 			panic(err)
 		}
-		c <- struct{}{}
+		// Closing the channel rather than sending to it makes sure this never blocks, even if sendChunks
+		// already returned.
+		close(c)
 		_ = s.Close()
 	}()
 	go s.sendChunks(c)
 	for {
 		pk, err := s.conn.ReadPacket()
 		if err != nil {
+			s.readError(err)
 			return
 		}
+		s.lastPacket.Store(time.Now().UnixNano())
+		packetsRead.Inc()
+		s.record(pk, DirectionClient)
 		if err := s.handlePacket(pk); err != nil {
 			// An error occurred during the handling of a packet. Print the error and stop handling any more
 			// packets.
 			s.log.Debugf("failed processing packet from %v (%v): %v\n", s.conn.RemoteAddr(), s.c.Name(), err)
+			s.setDisconnectReason(DisconnectReason{Kind: DisconnectError, Err: err})
 			return
 		}
+		s.flushCorrections()
 	}
 }
 
@@ -278,12 +386,18 @@ func (s *Session) sendChunks(stop <-chan struct{}) {
 	const maxChunkTransactions = 8
 	t := time.NewTicker(time.Second / 20)
 	defer t.Stop()
-	for {
+	for tick := 1; ; tick++ {
 		select {
 		case <-t.C:
+			if tick%20 == 0 {
+				s.latency.Store(s.conn.Latency())
+			}
+			s.flushCorrections()
+			s.flushBlockActors()
+
 			s.blobMu.Lock()
-			if s.chunkLoader.World() != s.c.World() && s.c.World() != nil {
-				s.handleWorldSwitch()
+			if w := s.c.World(); w != nil && s.chunkLoader.World() != w {
+				s.handleWorldSwitch(w)
 			}
 
 			toLoad := maxChunkTransactions - len(s.openChunkTransactions)
@@ -303,8 +417,9 @@ func (s *Session) sendChunks(stop <-chan struct{}) {
 	}
 }
 
-// handleWorldSwitch handles the player of the Session switching worlds.
-func (s *Session) handleWorldSwitch() {
+// handleWorldSwitch handles the player of the Session switching to the world passed. Chunks of the old world
+// that are still being sent are discarded, after which the chunks, time and spawn of the new world are sent.
+func (s *Session) handleWorldSwitch(w *world.World) {
 	if s.conn.ClientCacheEnabled() {
 		// Force out all blobs before changing worlds. This ensures no outdated chunk loading in the new world.
 		resp := &packet.ClientCacheMissResponse{Blobs: make([]protocol.CacheBlob, 0, len(s.blobs))}
@@ -317,12 +432,22 @@ func (s *Session) handleWorldSwitch() {
 		s.openChunkTransactions = nil
 	}
 
-	s.chunkLoader.ChangeWorld(s.c.World())
+	s.blockActors.resetAll()
+	s.chunkLoader.ChangeWorld(w)
+	s.chunkLoader.Move(s.c.Position())
+	s.ViewTime(w.Time())
+	s.ViewWorldSpawn(w.Spawn())
+	s.ViewWeather(w.Raining(), w.Thundering())
+	s.ViewGameRules(w.GameRules())
+	s.applyVisuals()
 }
 
 // handlePacket handles an incoming packet, processing it accordingly. If the packet had invalid data or was
 // otherwise not valid in its context, an error is returned.
 func (s *Session) handlePacket(pk packet.Packet) error {
+	if s.packetCancelled(pk, true) {
+		return nil
+	}
 	handler, ok := s.handlers[pk.ID()]
 	if !ok {
 		s.log.Debugf("unhandled packet %T%v from %v\n", pk, fmt.Sprintf("%+v", pk)[1:], s.conn.RemoteAddr())
@@ -343,18 +468,20 @@ func (s *Session) registerHandlers() {
 	s.handlers = map[uint32]packetHandler{
 		packet.IDActorEvent:            nil,
 		packet.IDAdventureSettings:     &AdventureSettingsHandler{},
-		packet.IDAnimate:               nil,
+		packet.IDAnimate:               AnimateHandler{},
 		packet.IDBlockActorData:        &BlockActorDataHandler{},
 		packet.IDBlockPickRequest:      &BlockPickRequestHandler{},
+		packet.IDBookEdit:              &BookEditHandler{},
 		packet.IDBossEvent:             nil,
 		packet.IDClientCacheBlobStatus: &ClientCacheBlobStatusHandler{},
 		packet.IDCommandRequest:        &CommandRequestHandler{},
 		packet.IDContainerClose:        &ContainerCloseHandler{},
 		packet.IDEmote:                 &EmoteHandler{},
-		packet.IDEmoteList:             nil,
+		packet.IDEmoteList:             EmoteListHandler{},
 		packet.IDInteract:              &InteractHandler{},
 		packet.IDInventoryTransaction:  &InventoryTransactionHandler{},
 		packet.IDItemStackRequest:      &ItemStackRequestHandler{changes: make(map[byte]map[byte]changeInfo), responseChanges: map[int32]map[byte]map[byte]responseChange{}},
+		packet.IDLecternUpdate:         &LecternUpdateHandler{},
 		packet.IDLevelSoundEvent:       &LevelSoundEventHandler{},
 		packet.IDMobEquipment:          &MobEquipmentHandler{},
 		packet.IDModalFormResponse:     &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
@@ -374,7 +501,28 @@ func (s *Session) writePacket(pk packet.Packet) {
 	if s == Nop {
 		return
 	}
+	if s.packetCancelled(pk, false) {
+		return
+	}
+	s.record(pk, DirectionServer)
 	_ = s.conn.WritePacket(pk)
+	packetsWritten.Inc()
+}
+
+// BroadcastSkin sends the current skin of the Controllable of the session to all sessions currently open,
+// including the session itself. Unlike Viewer.ViewSkin, this also updates the skin in the player list of
+// players that are not viewing the Controllable, so that they see the new skin once they do.
+func (s *Session) BroadcastSkin() {
+	if s == Nop {
+		return
+	}
+	pk := &packet.PlayerSkin{UUID: s.c.UUID(), Skin: skinToProtocol(s.c.Skin())}
+
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	for _, session := range sessions {
+		session.writePacket(pk)
+	}
 }
 
 // initPlayerList initialises the player list of the session and sends the session itself to all other
@@ -382,12 +530,16 @@ func (s *Session) writePacket(pk packet.Packet) {
 func (s *Session) initPlayerList() {
 	sessionMu.Lock()
 	sessions = append(sessions, s)
+	s.inPlayerList = true
 	for _, session := range sessions {
 		// AddStack the player of the session to all sessions currently open, and add the players of all sessions
 		// currently open to the player list of the new session.
 		session.addToPlayerList(s)
 		s.addToPlayerList(session)
 	}
+	if len(listEntries) != 0 {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: append([]protocol.PlayerListEntry(nil), listEntries...)})
+	}
 	sessionMu.Unlock()
 }
 
@@ -404,5 +556,6 @@ func (s *Session) closePlayerList() {
 		session.removeFromPlayerList(s)
 	}
 	sessions = n
+	s.inPlayerList = false
 	sessionMu.Unlock()
 }
@@ -0,0 +1,29 @@
+package session
+
+import "go.uber.org/atomic"
+
+// packetsRead and packetsWritten count the packets read from and written to the connections of all sessions.
+var packetsRead, packetsWritten atomic.Uint64
+
+// PacketCount returns the total amount of packets read from and written to the connections of all sessions
+// since the start of the program.
+func PacketCount() (read, written uint64) {
+	return packetsRead.Load(), packetsWritten.Load()
+}
+
+// BlobCacheSize returns the amount of bytes of chunk data cached by all open sessions, so that it may be sent
+// to clients with the client cache enabled when they request it.
+func BlobCacheSize() (n int) {
+	sessionMu.Lock()
+	all := append([]*Session(nil), sessions...)
+	sessionMu.Unlock()
+
+	for _, s := range all {
+		s.blobMu.Lock()
+		for _, blob := range s.blobs {
+			n += len(blob)
+		}
+		s.blobMu.Unlock()
+	}
+	return n
+}
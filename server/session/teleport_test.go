@@ -0,0 +1,71 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"testing"
+)
+
+func TestTeleportSendsChunks(t *testing.T) {
+	conn, p, _ := startSession(t, player.NopHandler{})
+	conn.waitForCorrections()
+	conn.clear()
+
+	// The destination is far outside the chunk radius of the session.
+	p.Teleport(mgl64.Vec3{1000.5, 10, 1000.5})
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	chunkSent := false
+	for _, pk := range conn.written {
+		switch pk := pk.(type) {
+		case *packet.LevelChunk:
+			if pk.ChunkX == 62 && pk.ChunkZ == 62 {
+				chunkSent = true
+			}
+		case *packet.MovePlayer:
+			if pk.Mode != packet.MoveModeTeleport {
+				continue
+			}
+			if !chunkSent {
+				t.Fatalf("expected chunk of the destination to be sent before the player was teleported")
+			}
+			return
+		}
+	}
+	t.Fatalf("expected player to be teleported")
+}
+
+func TestSetFlightAllowed(t *testing.T) {
+	conn, p, _ := startSession(t, player.NopHandler{})
+
+	p.StartFlying()
+	if p.Flying() {
+		t.Fatalf("expected player in survival not to be able to fly")
+	}
+	p.SetFlightAllowed(true)
+	if !conn.flightAllowed() {
+		t.Errorf("expected flight to be allowed for the client after allowing flight")
+	}
+	p.StartFlying()
+	if !p.Flying() {
+		t.Fatalf("expected player to fly after allowing flight")
+	}
+	p.SetFlightAllowed(false)
+	if p.Flying() || conn.flightAllowed() {
+		t.Errorf("expected player to stop flying after disallowing flight")
+	}
+}
+
+// flightAllowed checks if flight was allowed in the last adventure settings written.
+func (c *recordConn) flightAllowed() (allowed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, pk := range c.written {
+		if pk, ok := pk.(*packet.AdventureSettings); ok {
+			allowed = pk.Flags&packet.AdventureFlagAllowFlight != 0
+		}
+	}
+	return allowed
+}
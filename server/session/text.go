@@ -1,7 +1,9 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
+	"github.com/df-mc/dragonfly/server/player/title"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
@@ -51,11 +53,16 @@ func (s *Session) SendJukeboxPopup(message string) {
 
 // SendScoreboard ...
 func (s *Session) SendScoreboard(sb *scoreboard.Scoreboard) {
-	if s.scoreboardObj.Load() != "" {
-		s.RemoveScoreboard()
+	if s == Nop {
+		return
 	}
+	s.scoreboardMu.Lock()
+	defer s.scoreboardMu.Unlock()
+
+	s.removeScoreboard()
 	obj := uuid.New().String()
-	s.scoreboardObj.Store(obj)
+	s.scoreboard, s.scoreboardObj = sb, obj
+	sb.AddViewer(s)
 
 	s.writePacket(&packet.SetDisplayObjective{
 		DisplaySlot:   "sidebar",
@@ -63,22 +70,72 @@ func (s *Session) SendScoreboard(sb *scoreboard.Scoreboard) {
 		DisplayName:   sb.Name(),
 		CriteriaName:  "dummy",
 	})
-	pk := &packet.SetScore{
-		ActionType: packet.ScoreboardActionModify,
+	s.scoreboardLines = renderScoreboardLines(sb)
+	pk := &packet.SetScore{ActionType: packet.ScoreboardActionModify}
+	for k, line := range s.scoreboardLines {
+		pk.Entries = append(pk.Entries, s.scoreboardEntry(k, line))
+	}
+	if len(pk.Entries) > 0 {
+		s.writePacket(pk)
 	}
-	for k, line := range sb.Lines() {
-		if len(line) == 0 {
-			line = "§" + colours[k]
+}
+
+// ViewScoreboardUpdate updates the lines of the scoreboard currently shown if sb is that scoreboard. Only
+// the lines that changed are removed and sent again.
+func (s *Session) ViewScoreboardUpdate(sb *scoreboard.Scoreboard) {
+	s.scoreboardMu.Lock()
+	defer s.scoreboardMu.Unlock()
+	if s.scoreboard != sb {
+		return
+	}
+	lines := renderScoreboardLines(sb)
+
+	remove, modify := &packet.SetScore{ActionType: packet.ScoreboardActionRemove}, &packet.SetScore{ActionType: packet.ScoreboardActionModify}
+	for k := 0; k < len(s.scoreboardLines) || k < len(lines); k++ {
+		changed := k >= len(s.scoreboardLines) || k >= len(lines) || s.scoreboardLines[k] != lines[k]
+		if !changed {
+			continue
 		}
-		pk.Entries = append(pk.Entries, protocol.ScoreboardEntry{
-			EntryID:       int64(k),
-			ObjectiveName: s.scoreboardObj.Load(),
-			Score:         int32(k),
-			IdentityType:  protocol.ScoreboardIdentityFakePlayer,
-			DisplayName:   padScoreboardString(sb, line),
-		})
+		if k < len(s.scoreboardLines) {
+			remove.Entries = append(remove.Entries, s.scoreboardEntry(k, s.scoreboardLines[k]))
+		}
+		if k < len(lines) {
+			modify.Entries = append(modify.Entries, s.scoreboardEntry(k, lines[k]))
+		}
+	}
+	s.scoreboardLines = lines
+	if len(remove.Entries) > 0 {
+		s.writePacket(remove)
+	}
+	if len(modify.Entries) > 0 {
+		s.writePacket(modify)
+	}
+}
+
+// scoreboardEntry returns a scoreboard entry for the line passed at index k of the scoreboard currently shown.
+func (s *Session) scoreboardEntry(k int, line string) protocol.ScoreboardEntry {
+	return protocol.ScoreboardEntry{
+		EntryID:       int64(k),
+		ObjectiveName: s.scoreboardObj,
+		Score:         int32(k),
+		IdentityType:  protocol.ScoreboardIdentityFakePlayer,
+		DisplayName:   line,
 	}
-	s.writePacket(pk)
+}
+
+// renderScoreboardLines renders the lines of a scoreboard as they should be shown to the client. The client
+// does not show empty lines or lines with the same text twice, so these receive a unique colour code suffix.
+func renderScoreboardLines(sb *scoreboard.Scoreboard) []string {
+	lines := sb.Lines()
+	seen := make(map[string]struct{}, len(lines))
+	for k, line := range lines {
+		if _, ok := seen[line]; ok || len(line) == 0 {
+			line += "§" + colours[k]
+		}
+		seen[line] = struct{}{}
+		lines[k] = padScoreboardString(sb, line)
+	}
+	return lines
 }
 
 // pad pads the string passed for as much as needed to achieve the same length as the name of the scoreboard.
@@ -96,35 +153,158 @@ var colours = [15]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "a", "b",
 
 // RemoveScoreboard ...
 func (s *Session) RemoveScoreboard() {
-	s.writePacket(&packet.RemoveObjective{
-		ObjectiveName: s.scoreboardObj.Load(),
-	})
+	s.scoreboardMu.Lock()
+	defer s.scoreboardMu.Unlock()
+	s.removeScoreboard()
 }
 
-// SendBossBar sends a boss bar to the player with the text passed and the health percentage of the bar.
-// SendBossBar removes any boss bar that might be active before sending the new one.
-func (s *Session) SendBossBar(text string, healthPercentage float64) {
-	s.RemoveBossBar()
+// removeScoreboard removes the scoreboard currently shown, if any. s.scoreboardMu must be held when calling
+// removeScoreboard.
+func (s *Session) removeScoreboard() {
+	if s.scoreboard == nil {
+		return
+	}
+	s.scoreboard.RemoveViewer(s)
+	s.writePacket(&packet.RemoveObjective{ObjectiveName: s.scoreboardObj})
+	s.scoreboard, s.scoreboardObj, s.scoreboardLines = nil, "", nil
+}
+
+// SendBossBar sends a boss bar to the player. The boss bar is attached to a dummy entity spawned for the
+// player only. SendBossBar removes any boss bar that might be active before sending the new one.
+func (s *Session) SendBossBar(bar *bossbar.BossBar) {
+	if s == Nop {
+		return
+	}
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+
+	s.removeBossBar()
+
+	s.entityMutex.Lock()
+	s.currentEntityRuntimeID += 1
+	id := s.currentEntityRuntimeID
+	s.entityMutex.Unlock()
+
+	metadata := entityMetadata{}
+	metadata.setFlag(dataKeyFlags, dataFlagInvisible)
+	metadata.setFlag(dataKeyFlags, dataFlagNoAI)
+	metadata[dataKeyScale] = float32(0)
+	metadata[dataKeyBoundingBoxWidth] = float32(0)
+	metadata[dataKeyBoundingBoxHeight] = float32(0)
+
+	s.writePacket(&packet.AddActor{
+		EntityUniqueID:  int64(id),
+		EntityRuntimeID: id,
+		EntityType:      "minecraft:slime",
+		EntityMetadata:  metadata,
+		Position:        vec64To32(s.c.Position()),
+	})
+
+	s.bossBar, s.bossBarID = bar, id
+	s.bossBarText, s.bossBarHealth, s.bossBarColour = bar.Text(), bar.HealthPercentage(), bar.Colour()
+	bar.AddViewer(s)
+
 	s.writePacket(&packet.BossEvent{
-		BossEntityUniqueID: selfEntityRuntimeID,
+		BossEntityUniqueID: int64(id),
 		EventType:          packet.BossEventShow,
-		BossBarTitle:       text,
-		HealthPercentage:   float32(healthPercentage),
+		BossBarTitle:       s.bossBarText,
+		HealthPercentage:   float32(s.bossBarHealth),
+		Colour:             uint32(s.bossBarColour.Uint8()),
 	})
 }
 
+// ViewBossBarUpdate updates the boss bar currently shown if bar is that boss bar. Only the properties of the
+// bar that changed are sent.
+func (s *Session) ViewBossBarUpdate(bar *bossbar.BossBar) {
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	if s.bossBar != bar {
+		return
+	}
+	if text := bar.Text(); text != s.bossBarText {
+		s.bossBarText = text
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(s.bossBarID),
+			EventType:          packet.BossEventTitle,
+			BossBarTitle:       text,
+		})
+	}
+	if health := bar.HealthPercentage(); health != s.bossBarHealth {
+		s.bossBarHealth = health
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(s.bossBarID),
+			EventType:          packet.BossEventHealthPercentage,
+			HealthPercentage:   float32(health),
+		})
+	}
+	if colour := bar.Colour(); colour != s.bossBarColour {
+		s.bossBarColour = colour
+		s.writePacket(&packet.BossEvent{
+			BossEntityUniqueID: int64(s.bossBarID),
+			EventType:          packet.BossEventTexture,
+			Colour:             uint32(colour.Uint8()),
+		})
+	}
+}
+
 // RemoveBossBar removes any boss bar currently active on the player's screen.
 func (s *Session) RemoveBossBar() {
+	s.bossBarMu.Lock()
+	defer s.bossBarMu.Unlock()
+	s.removeBossBar()
+}
+
+// removeBossBar hides the boss bar currently shown, if any, and despawns the dummy entity it is attached to.
+// s.bossBarMu must be held when calling removeBossBar.
+func (s *Session) removeBossBar() {
+	if s.bossBar == nil {
+		return
+	}
+	s.bossBar.RemoveViewer(s)
 	s.writePacket(&packet.BossEvent{
-		BossEntityUniqueID: selfEntityRuntimeID,
+		BossEntityUniqueID: int64(s.bossBarID),
 		EventType:          packet.BossEventHide,
 	})
+	s.writePacket(&packet.RemoveActor{EntityUniqueID: int64(s.bossBarID)})
+	s.bossBar, s.bossBarID = nil, 0
 }
 
 const tickLength = time.Second / 20
 
+// Default durations of titles used by the client. These are used for durations of a title that are 0.
+const (
+	defaultTitleFadeIn  = time.Second / 2
+	defaultTitleRemain  = time.Second * 7 / 2
+	defaultTitleFadeOut = time.Second
+)
+
+// ShowTitle shows a title.Title to the player. The durations of the title are sent first, followed by the
+// subtitle and the title, because the client ignores a subtitle sent after the title. Durations of 0 are
+// replaced with the client's defaults.
+func (s *Session) ShowTitle(t title.Title) {
+	s.SetTitleDurations(t.FadeInDuration(), t.Duration(), t.FadeOutDuration())
+	if t.Text() != "" || t.Subtitle() != "" {
+		if t.Subtitle() != "" {
+			s.SendSubtitle(t.Subtitle())
+		}
+		s.SendTitle(t.Text())
+	}
+	if t.ActionText() != "" {
+		s.SendActionBarMessage(t.ActionText())
+	}
+}
+
 // SetTitleDurations ...
 func (s *Session) SetTitleDurations(fadeInDuration, remainDuration, fadeOutDuration time.Duration) {
+	if fadeInDuration == 0 {
+		fadeInDuration = defaultTitleFadeIn
+	}
+	if remainDuration == 0 {
+		remainDuration = defaultTitleRemain
+	}
+	if fadeOutDuration == 0 {
+		fadeOutDuration = defaultTitleFadeOut
+	}
 	s.writePacket(&packet.SetTitle{
 		ActionType:      packet.TitleActionSetDurations,
 		FadeInDuration:  int32(fadeInDuration / tickLength),
@@ -2,6 +2,8 @@ package session
 
 import (
 	"bytes"
+	"math/rand"
+	"time"
 
 	"github.com/cespare/xxhash"
 	"github.com/df-mc/dragonfly/server/block"
@@ -25,6 +27,8 @@ import (
 
 // ViewChunk ...
 func (s *Session) ViewChunk(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) {
+	// The chunk holds the current data of its block entities, which the client may not have had before.
+	s.blockActors.reset(pos)
 	if !s.conn.ClientCacheEnabled() {
 		s.sendNetworkChunk(pos, c, blockEntities)
 		return
@@ -171,17 +175,19 @@ func (s *Session) ViewEntity(e world.Entity) {
 	id := e.EncodeEntity()
 	switch v := e.(type) {
 	case Controllable:
-		actualPlayer := false
+		// Players that are not in the player list, such as players without a session or players hidden from
+		// the list, are added to it briefly, so that their skin is shown.
+		listed := false
 
 		sessionMu.Lock()
 		for _, s := range sessions {
 			if uuid.MustParse(s.conn.IdentityData().Identity) == v.UUID() {
-				actualPlayer = true
+				listed = !s.unlisted
 				break
 			}
 		}
 		sessionMu.Unlock()
-		if !actualPlayer {
+		if !listed {
 			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{{
 				UUID:           v.UUID(),
 				EntityUniqueID: int64(runtimeID),
@@ -199,7 +205,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 			Yaw:             float32(yaw),
 			HeadYaw:         float32(yaw),
 		})
-		if !actualPlayer {
+		if !listed {
 			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{
 				UUID: v.UUID(),
 			}}})
@@ -311,6 +317,30 @@ func (s *Session) ViewTime(time int) {
 	s.writePacket(&packet.SetTime{Time: int32(time)})
 }
 
+// ViewGameRules ...
+func (s *Session) ViewGameRules(rules map[string]interface{}) {
+	s.sendGameRules(GameRules(rules))
+}
+
+// ViewWeather ...
+func (s *Session) ViewWeather(raining, thundering bool) {
+	pk := &packet.LevelEvent{EventType: packet.EventStopRain}
+	if raining {
+		pk.EventType, pk.EventData = packet.EventStartRain, int32(rand.Intn(50000)+10000)
+	}
+	s.writePacket(pk)
+
+	pk = &packet.LevelEvent{EventType: packet.EventStopThunder}
+	if thundering {
+		pk.EventType, pk.EventData = packet.EventStartThunder, int32(rand.Intn(50000)+10000)
+	}
+	s.writePacket(pk)
+}
+
+// teleportChunks is the amount of chunks around the destination of a teleport that are sent to the player
+// before it is teleported: The chunk of the destination and the chunks directly around it.
+const teleportChunks = 9
+
 // ViewEntityTeleport ...
 func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	id := s.entityRuntimeID(e)
@@ -320,6 +350,11 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 
 	if id == selfEntityRuntimeID {
 		s.chunkLoader.Move(position)
+		if world.ChunkPosFromVec3(e.Position()) != world.ChunkPosFromVec3(position) {
+			// The chunks around the destination are sent before the player is moved, so that it does not fall
+			// through terrain that is not yet loaded, even if the destination is far outside its view.
+			_ = s.chunkLoader.Load(teleportChunks)
+		}
 
 		s.teleportMu.Lock()
 		s.teleportPos = &position
@@ -457,6 +492,13 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.EventParticleEvaporateWater,
 			Position:  vec64To32(pos),
 		})
+	case particle.Effect:
+		s.writePacket(&packet.SpawnParticleEffect{
+			Dimension:      packet.DimensionOverworld,
+			EntityUniqueID: -1,
+			Position:       vec64To32(pos),
+			ParticleName:   pa.Name,
+		})
 	}
 }
 
@@ -504,6 +546,12 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		pk.SoundType = packet.SoundEventIgnite
 	case sound.Burp:
 		pk.SoundType = packet.SoundEventBurp
+	case sound.LevelUp:
+		pk.SoundType = packet.SoundEventLevelUp
+	case sound.Drink:
+		pk.SoundType = packet.SoundEventDrink
+	case sound.Eat:
+		pk.SoundType = packet.SoundEventEat
 	case sound.Door:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.EventSoundDoor,
@@ -522,6 +570,10 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		pk.SoundType = packet.SoundEventBlockBarrelClose
 	case sound.BarrelOpen:
 		pk.SoundType = packet.SoundEventBlockBarrelOpen
+	case sound.LecternBookPlace:
+		pk.SoundType = packet.SoundEventItemBookPut
+	case sound.SweetBerryBushPick:
+		pk.SoundType = packet.SoundEventBlockSweetBerryBushPick
 	case sound.BlockBreaking:
 		pk.SoundType, pk.ExtraData = packet.SoundEventHit, int32(s.blockRuntimeID(so.Block))
 	case sound.ItemBreak:
@@ -548,27 +600,32 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 		}
 		pk.SoundType = packet.SoundEventBucketEmptyLava
 	}
-	s.writePacket(pk)
+	s.writeShared(&levelSounds, *pk, pk)
 }
 
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	runtimeID, _ := world.BlockRuntimeID(b)
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
-	s.writePacket(&packet.UpdateBlock{
+	pk := &packet.UpdateBlock{
 		Position:          blockPos,
 		NewBlockRuntimeID: runtimeID,
 		Flags:             packet.BlockUpdateNetwork,
 		Layer:             uint32(layer),
-	})
+	}
+	s.writeShared(&updateBlocks, *pk, pk)
+	if layer != 0 {
+		return
+	}
 	if v, ok := b.(world.NBTer); ok {
 		NBTData := v.EncodeNBT()
 		NBTData["x"], NBTData["y"], NBTData["z"] = int32(pos.X()), int32(pos.Y()), int32(pos.Z())
-		s.writePacket(&packet.BlockActorData{
-			Position: blockPos,
-			NBTData:  NBTData,
-		})
+		// Block entities such as signs may be updated every tick, so their data is sent at most once per tick
+		// and only if it changed.
+		s.blockActors.queue(pos, runtimeID, NBTData)
+		return
 	}
+	s.blockActors.remove(pos)
 }
 
 // ViewEntityAction ...
@@ -576,8 +633,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a action.Action) {
 	switch act := a.(type) {
 	case action.SwingArm:
 		if _, ok := e.(Controllable); ok {
-			if s.entityRuntimeID(e) == selfEntityRuntimeID && s.swingingArm.Load() {
-				return
+			if s.entityRuntimeID(e) == selfEntityRuntimeID {
+				s.lastSwing.Store(time.Now().UnixNano())
+				if s.swingingArm.Load() {
+					return
+				}
 			}
 			s.writePacket(&packet.Animate{
 				ActionType:      packet.AnimateActionSwingArm,
@@ -620,6 +680,33 @@ func (s *Session) ViewEntityAction(e world.Entity, a action.Action) {
 	}
 }
 
+// ViewEntityLink ...
+func (s *Session) ViewEntityLink(l world.EntityLink) {
+	s.writeEntityLink(l, protocol.EntityLinkPassenger)
+}
+
+// ViewEntityUnlink ...
+func (s *Session) ViewEntityUnlink(l world.EntityLink) {
+	s.writeEntityLink(l, protocol.EntityLinkRemove)
+}
+
+// writeEntityLink writes an entity link of the type passed between the rider and mount of the world.EntityLink
+// to the client. The state of the rider is updated too, so that it is seated at the seat offset of the link.
+func (s *Session) writeEntityLink(l world.EntityLink, t byte) {
+	rider, mount := s.entityRuntimeID(l.Rider), s.entityRuntimeID(l.Mount)
+	if rider == 0 || mount == 0 || s.entityHidden(l.Rider) || s.entityHidden(l.Mount) {
+		// One of the entities was never shown to the client, so it cannot be linked.
+		return
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(mount),
+		RiderEntityUniqueID:  int64(rider),
+		Type:                 t,
+		Immediate:            true,
+	}})
+	s.ViewEntityState(l.Rider)
+}
+
 // ViewEntityState ...
 func (s *Session) ViewEntityState(e world.Entity) {
 	s.writePacket(&packet.SetActorData{
@@ -733,6 +820,10 @@ func (s *Session) ViewBlockAction(pos cube.Pos, a blockAction.Action) {
 
 // ViewEmote ...
 func (s *Session) ViewEmote(player world.Entity, emote uuid.UUID) {
+	if s.entityRuntimeID(player) == selfEntityRuntimeID && s.emoting.Load() {
+		// The client performed the emote itself, so it is already showing it.
+		return
+	}
 	s.writePacket(&packet.Emote{
 		EntityRuntimeID: s.entityRuntimeID(player),
 		EmoteID:         emote.String(),
@@ -762,6 +853,79 @@ func (s *Session) ViewWorldSpawn(pos cube.Pos) {
 	})
 }
 
+// brightnessFog is the fog stack rendered on top of the fog of a world to counteract video settings of the
+// client that increase brightness.
+var brightnessFog = []string{"minecraft:fog_the_end", "minecraft:fog_soulsand_valley"}
+
+// ViewVisuals ...
+func (s *Session) ViewVisuals(world.Visuals) {
+	s.applyVisuals()
+}
+
+// currentVisuals returns the Visuals currently applying to the session: The Visuals overridden using
+// SetVisuals or otherwise the Visuals of the world viewed.
+func (s *Session) currentVisuals() world.Visuals {
+	s.visualsMu.Lock()
+	defer s.visualsMu.Unlock()
+	if s.visuals != nil {
+		return *s.visuals
+	}
+	return s.chunkLoader.World().Visuals()
+}
+
+// applyVisuals sends the fog stack and chunk radius of the Visuals currently applying to the session.
+// Sending an empty fog stack resets the fog of the client.
+func (s *Session) applyVisuals() {
+	v := s.currentVisuals()
+	fog := append([]string{}, v.Fog...)
+	if v.CounteractBrightness {
+		fog = append(fog, brightnessFog...)
+	}
+	s.writePacket(&packet.PlayerFog{Stack: fog})
+	s.updateChunkRadius(v)
+}
+
+// updateChunkRadius updates the chunk radius of the session to the chunk radius requested by the client,
+// limited by the chunk radius set using SetChunkRadius and the maximum render distance of the Visuals passed.
+func (s *Session) updateChunkRadius(v world.Visuals) {
+	s.visualsMu.Lock()
+	r := s.requestedChunkRadius
+	if s.chunkRadiusLimit > 0 && s.chunkRadiusLimit < r {
+		r = s.chunkRadiusLimit
+	}
+	s.visualsMu.Unlock()
+	if v.MaxRenderDistance > 0 && int32(v.MaxRenderDistance) < r {
+		r = int32(v.MaxRenderDistance)
+	}
+	s.chunkRadius.Store(r)
+	s.chunkLoader.ChangeRadius(int(r))
+	s.writePacket(&packet.ChunkRadiusUpdated{ChunkRadius: r})
+}
+
+// SetMinimumChunkRadius sets the minimum chunk radius that the client may request. It must be called before
+// the Session is started. A minimum above the maximum chunk radius passed to New is lowered to the maximum.
+func (s *Session) SetMinimumChunkRadius(r int) {
+	s.visualsMu.Lock()
+	defer s.visualsMu.Unlock()
+	s.minChunkRadius = 0
+	if r > 0 {
+		s.minChunkRadius = s.clampChunkRadius(int32(r))
+	}
+	s.requestedChunkRadius = s.clampChunkRadius(s.requestedChunkRadius)
+	s.chunkRadius.Store(s.requestedChunkRadius)
+}
+
+// clampChunkRadius limits the chunk radius passed to the minimum and maximum chunk radius of the Session.
+func (s *Session) clampChunkRadius(r int32) int32 {
+	if r > s.maxChunkRadius {
+		return s.maxChunkRadius
+	}
+	if r < s.minChunkRadius {
+		return s.minChunkRadius
+	}
+	return r
+}
+
 // nextWindowID produces the next window ID for a new window. It is an int of 1-99.
 func (s *Session) nextWindowID() byte {
 	if s.openedWindowID.CAS(99, 1) {
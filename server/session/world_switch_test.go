@@ -0,0 +1,70 @@
+package session_test
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+func TestChangeWorld(t *testing.T) {
+	conn, p, w := startSession(t, player.NopHandler{})
+	other := world.New(logrus.New(), 4)
+	t.Cleanup(func() {
+		_ = other.Close()
+	})
+
+	p.ChangeWorld(other, mgl64.Vec3{100.5, 0, 100.5})
+	if p.World() != other {
+		t.Fatalf("expected player to be in the new world after changing worlds")
+	}
+	if inWorld(w, p) {
+		t.Errorf("expected player to be removed from the old world after changing worlds")
+	}
+	conn.waitForCorrections()
+
+	conn.mu.Lock()
+	spawnSent := false
+	for _, pk := range conn.written {
+		if _, ok := pk.(*packet.SetSpawnPosition); ok {
+			spawnSent = true
+		}
+	}
+	conn.mu.Unlock()
+	if !spawnSent {
+		t.Errorf("expected spawn of the new world to be sent after changing worlds")
+	}
+}
+
+func TestDisconnectWhileChangingWorld(t *testing.T) {
+	conn, p, w := startSession(t, player.NopHandler{})
+	other := world.New(logrus.New(), 4)
+	t.Cleanup(func() {
+		_ = other.Close()
+	})
+
+	go p.ChangeWorld(other, mgl64.Vec3{100.5, 0, 100.5})
+	_ = conn.Close()
+
+	// The session must close, leaving the player in neither world.
+	deadline := time.Now().Add(time.Second * 5)
+	for (inWorld(w, p) || inWorld(other, p)) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if inWorld(w, p) || inWorld(other, p) {
+		t.Fatalf("expected player to be removed from all worlds after disconnecting while changing worlds")
+	}
+}
+
+// inWorld checks if the player passed is one of the entities in the world passed.
+func inWorld(w *world.World, p *player.Player) bool {
+	for _, e := range w.Entities() {
+		if e == p {
+			return true
+		}
+	}
+	return false
+}
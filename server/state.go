@@ -0,0 +1,36 @@
+package server
+
+// State is a state in the lifecycle of a Server. A Server goes through the states in order and never returns
+// to an earlier state: A closed Server cannot be started again. Instead, a new Server should be created.
+type State int32
+
+const (
+	// StateCreated is the state of a Server that was created using New but was not yet started.
+	StateCreated State = iota
+	// StateStarting is the state of a Server while its world is loaded and it starts listening for players.
+	StateStarting
+	// StateRunning is the state of a Server that accepts players.
+	StateRunning
+	// StateClosing is the state of a Server while it disconnects its players and saves its world.
+	StateClosing
+	// StateClosed is the state of a Server that was closed. Its world and player data were saved and all its
+	// listeners were closed.
+	StateClosed
+)
+
+// String ...
+func (s State) String() string {
+	switch s {
+	case StateCreated:
+		return "created"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateClosing:
+		return "closing"
+	case StateClosed:
+		return "closed"
+	}
+	panic("invalid server state")
+}
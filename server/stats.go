@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Stats holds statistics of a Server and its world, as returned by Server.Stats. The packet counts are shared by
+// all servers running in the same program.
+type Stats struct {
+	// World holds the statistics of the world of the server.
+	World world.Stats
+	// PlayerCount is the amount of players currently online on the server.
+	PlayerCount int
+	// Uptime is the duration that the server has been running for.
+	Uptime time.Duration
+	// PacketsIn and PacketsOut are the total amount of packets received from and sent to players.
+	PacketsIn, PacketsOut uint64
+	// PacketsInPerSecond and PacketsOutPerSecond are the amount of packets received and sent per second,
+	// measured over the time since the previous call to Stats, with a minimum of one second.
+	PacketsInPerSecond, PacketsOutPerSecond float64
+	// BlobCacheMemory is the amount of bytes of memory used by the chunk caches of players with the client
+	// cache enabled.
+	BlobCacheMemory int
+	// BlockActorsSent is the total amount of block entity updates, such as sign text changes, sent to
+	// players. BlockActorsCoalesced is the amount of updates replaced by a later update of the same block in
+	// the same tick, and BlockActorsUnchanged the amount of updates not sent because the player already had
+	// the same data.
+	BlockActorsSent, BlockActorsCoalesced, BlockActorsUnchanged uint64
+}
+
+// Stats returns a snapshot of the statistics of the server. The statistics are aggregated when Stats is called,
+// so collecting them costs next to nothing while they are not used.
+func (server *Server) Stats() Stats {
+	in, out := session.PacketCount()
+	s := Stats{
+		World:           server.world.Stats(),
+		PlayerCount:     server.PlayerCount(),
+		Uptime:          server.Uptime(),
+		PacketsIn:       in,
+		PacketsOut:      out,
+		BlobCacheMemory: session.BlobCacheSize(),
+	}
+	s.BlockActorsSent, s.BlockActorsCoalesced, s.BlockActorsUnchanged = server.blockActors.count()
+	s.PacketsInPerSecond, s.PacketsOutPerSecond = server.packetRate.update(time.Now(), in, out)
+	return s
+}
+
+// MetricsHandler returns an http.Handler that writes the Stats of the server in the Prometheus text format, so
+// that they may be scraped by Prometheus. The handler is not served by the server itself: It should be added to
+// an http.Server by the user, for example under the /metrics path.
+func (server *Server) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write(server.Stats().prometheus())
+	})
+}
+
+// prometheus encodes the Stats in the Prometheus text format.
+func (s Stats) prometheus() []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, 2048))
+	metric := func(name, typ, help string, values ...string) {
+		_, _ = fmt.Fprintf(buf, "# HELP dragonfly_%v %v\n# TYPE dragonfly_%v %v\n", name, help, name, typ)
+		for _, v := range values {
+			_, _ = fmt.Fprintf(buf, "dragonfly_%v%v\n", name, v)
+		}
+	}
+	d := s.World.TickDuration
+	metric("tps", "gauge", "Average amount of ticks per second of the world.", fmt.Sprintf(" %v", s.World.TPS))
+	metric("tick_duration_seconds", "gauge", "Duration of the last 100 ticks of the world.",
+		fmt.Sprintf(`{quantile="0.5"} %v`, d.P50.Seconds()),
+		fmt.Sprintf(`{quantile="0.95"} %v`, d.P95.Seconds()),
+		fmt.Sprintf(`{quantile="0.99"} %v`, d.P99.Seconds()),
+		fmt.Sprintf(`{quantile="1"} %v`, d.Max.Seconds()),
+	)
+	metric("chunks_loaded", "gauge", "Amount of chunks loaded in the world.", fmt.Sprintf(" %v", s.World.Chunks))
+	metric("entities", "gauge", "Amount of entities in the world.", fmt.Sprintf(" %v", s.World.Entities))
+	metric("chunk_memory_bytes", "gauge", "Estimated memory used by chunks loaded in the world.", fmt.Sprintf(" %v", s.World.ChunkMemory))
+	metric("players_online", "gauge", "Amount of players online.", fmt.Sprintf(" %v", s.PlayerCount))
+	metric("uptime_seconds", "gauge", "Time that the server has been running for.", fmt.Sprintf(" %v", s.Uptime.Seconds()))
+	metric("packets_received_total", "counter", "Packets received from players.", fmt.Sprintf(" %v", s.PacketsIn))
+	metric("packets_sent_total", "counter", "Packets sent to players.", fmt.Sprintf(" %v", s.PacketsOut))
+	metric("blob_cache_bytes", "gauge", "Memory used by the chunk caches of players.", fmt.Sprintf(" %v", s.BlobCacheMemory))
+	metric("block_actor_updates_total", "counter", "Block entity updates sent to players, or skipped because they were coalesced or unchanged.",
+		fmt.Sprintf(`{result="sent"} %v`, s.BlockActorsSent),
+		fmt.Sprintf(`{result="coalesced"} %v`, s.BlockActorsCoalesced),
+		fmt.Sprintf(`{result="unchanged"} %v`, s.BlockActorsUnchanged),
+	)
+	return buf.Bytes()
+}
+
+// packetRate calculates the amount of packets received and sent per second from the total packet counts.
+type packetRate struct {
+	mu sync.Mutex
+	// t, in and out are the time and packet counts of the last sample taken.
+	t       time.Time
+	in, out uint64
+	// inRate and outRate are the rates calculated when the last sample was taken.
+	inRate, outRate float64
+}
+
+// update takes a new sample of the packet counts passed if at least a second passed since the last sample and
+// returns the rates calculated over the time between the two.
+func (r *packetRate) update(now time.Time, in, out uint64) (inRate, outRate float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.t.IsZero() {
+		r.t, r.in, r.out = now, in, out
+		return 0, 0
+	}
+	if elapsed := now.Sub(r.t).Seconds(); elapsed >= 1 {
+		r.inRate, r.outRate = float64(in-r.in)/elapsed, float64(out-r.out)/elapsed
+		r.t, r.in, r.out = now, in, out
+	}
+	return r.inRate, r.outRate
+}
+
+// blockActorCount sums the block entity update counts of the sessions of a server. The counts of sessions that
+// were closed are kept, so that the totals never decrease.
+type blockActorCount struct {
+	mu sync.Mutex
+	// open holds the sessions of the server that are currently open.
+	open map[*session.Session]struct{}
+	// sent, coalesced and unchanged are the counts of the sessions that were closed.
+	sent, coalesced, unchanged uint64
+}
+
+// add adds a session of which the block entity update counts should be summed.
+func (c *blockActorCount) add(s *session.Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.open == nil {
+		c.open = map[*session.Session]struct{}{}
+	}
+	c.open[s] = struct{}{}
+}
+
+// remove removes a session added using add once it is closed, keeping its counts in the totals.
+func (c *blockActorCount) remove(s *session.Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.open[s]; !ok {
+		return
+	}
+	delete(c.open, s)
+	sent, coalesced, unchanged := s.BlockActorCount()
+	c.sent, c.coalesced, c.unchanged = c.sent+sent, c.coalesced+coalesced, c.unchanged+unchanged
+}
+
+// count returns the total block entity update counts of all sessions added, open or closed.
+func (c *blockActorCount) count() (sent, coalesced, unchanged uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sent, coalesced, unchanged = c.sent, c.coalesced, c.unchanged
+	for s := range c.open {
+		a, b, d := s.BlockActorCount()
+		sent, coalesced, unchanged = sent+a, coalesced+b, unchanged+d
+	}
+	return sent, coalesced, unchanged
+}
@@ -2,18 +2,102 @@ package server
 
 import (
 	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"time"
 )
 
-// statusProvider handles the way the server shows up in the server list. The online players and maximum
-// players are not changeable from outside of the server, but the server name may be changed at any time.
+// Status holds a snapshot of the status of a Server, as returned by Server.Status.
+type Status struct {
+	// Name and SubName are the name and sub-name of the server as displayed in the server list.
+	Name, SubName string
+	// PlayerCount is the amount of players currently online on the server.
+	PlayerCount int
+	// MaxPlayers is the maximum amount of players allowed to be online at the same time. It is -1 if the
+	// amount of players is unlimited.
+	MaxPlayers int
+	// Uptime is the duration that the server has been running for.
+	Uptime time.Duration
+	// TPS is the average amount of ticks per second of the world of the server. It is at most 20.
+	TPS float64
+	// WorldName is the name of the world of the server.
+	WorldName string
+}
+
+// Status returns a snapshot of the current Status of the server, which may be used for status pages.
+func (server *Server) Status() Status {
+	return Status{
+		Name:        server.name.Load(),
+		SubName:     server.subName(),
+		PlayerCount: server.PlayerCount(),
+		MaxPlayers:  server.MaxPlayerCount(),
+		Uptime:      server.Uptime(),
+		TPS:         server.world.TPS(),
+		WorldName:   server.world.Name(),
+	}
+}
+
+// ServerListEntry is the entry of the server shown in the server list of a client that pinged the server.
+// Functions registered using OnServerListPing may change it for every ping.
+type ServerListEntry struct {
+	// Name and SubName are the two lines of the MOTD of the server. SubName is only used for the LAN list:
+	// The RakNet listener always advertises the sub-name 'Minecraft Server' and the creative game mode.
+	Name, SubName string
+	// PlayerCount and MaxPlayers are the player counts displayed.
+	PlayerCount, MaxPlayers int
+	// Protocol and Version are the protocol version and game version displayed. They are only used for the
+	// LAN list: The RakNet listener always advertises the protocol and version it supports.
+	Protocol int32
+	Version  string
+}
+
+// OnServerListPing registers a function that is called every time a client pings the server for its entry in
+// the server list, both over RakNet and over the LAN. The function may change the entry passed to change the
+// way the server is displayed. OnServerListPing may be called multiple times to register multiple functions,
+// which are called in the order they were registered. The functions may be called concurrently, so they
+// should return quickly.
+func (server *Server) OnServerListPing(f func(entry *ServerListEntry)) {
+	server.hookMu.Lock()
+	defer server.hookMu.Unlock()
+	server.pingHooks = append(server.pingHooks, f)
+}
+
+// serverListEntry returns the ServerListEntry of the server after calling all functions registered using
+// OnServerListPing.
+func (server *Server) serverListEntry() ServerListEntry {
+	e := ServerListEntry{
+		Name:        server.name.Load(),
+		SubName:     server.subName(),
+		PlayerCount: server.PlayerCount(),
+		MaxPlayers:  server.MaxPlayerCount(),
+		Protocol:    protocol.CurrentProtocol,
+		Version:     protocol.CurrentVersion,
+	}
+	if e.MaxPlayers < 0 {
+		// The server list cannot show an unlimited amount of players, so it always shows one free slot.
+		e.MaxPlayers = e.PlayerCount + 1
+	}
+	server.hookMu.RLock()
+	hooks := append([]func(entry *ServerListEntry){}, server.pingHooks...)
+	server.hookMu.RUnlock()
+	for _, f := range hooks {
+		f(&e)
+	}
+	return e
+}
+
+// statusProvider handles the way the server shows up in the server list. The player counts displayed are
+// those of the server rather than those of the listener, so that they include players that joined through
+// other listeners.
 type statusProvider struct {
 	s *Server
 }
 
-func (s statusProvider) ServerStatus(playerCount, maxPlayers int) minecraft.ServerStatus {
+// ServerStatus ...
+func (s statusProvider) ServerStatus(int, int) minecraft.ServerStatus {
+	e := s.s.serverListEntry()
 	return minecraft.ServerStatus{
-		ServerName:  s.s.name.Load(),
-		PlayerCount: playerCount,
-		MaxPlayers:  maxPlayers,
+		ServerName:  e.Name,
+		PlayerCount: e.PlayerCount,
+		MaxPlayers:  e.MaxPlayers,
 	}
 }
@@ -0,0 +1,115 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ColourCodes holds the characters of all formatting codes that change the colour of text. A formatting code
+// is made up of the '§' character followed by one of these characters.
+const ColourCodes = "0123456789abcdefg"
+
+// StyleCodes holds the characters of all formatting codes that change the style of text: Obfuscated ('k'),
+// bold ('l'), italic ('o') and reset ('r').
+const StyleCodes = "klor"
+
+// AllCodes holds the characters of all formatting codes.
+const AllCodes = ColourCodes + StyleCodes
+
+// Policy specifies how text sent by players is sanitised by Clean. The zero value of Policy strips all
+// formatting codes and newlines, but does not limit the length of text.
+type Policy struct {
+	// Formatting holds the characters of the formatting codes that are kept. All other formatting codes are
+	// stripped. ColourCodes, StyleCodes and AllCodes may be used here.
+	Formatting string
+	// Newlines specifies if newlines are kept. If false, newlines are replaced with spaces.
+	Newlines bool
+	// MaxLength is the maximum amount of characters, including formatting codes, that text may have. Text
+	// that is longer is cut off. If 0, the length of text is not limited.
+	MaxLength int
+	// MaxCombining is the maximum amount of combining characters, such as accents, that may follow a single
+	// character. Combining characters beyond this amount are removed, which prevents text from being stacked
+	// far beyond its line. If 0, the amount of combining characters is not limited.
+	MaxCombining int
+}
+
+var (
+	// ChatPolicy is the Policy used for chat messages. It strips all formatting codes.
+	ChatPolicy = Policy{MaxLength: 512, MaxCombining: 3}
+	// SignPolicy is the Policy used for the text written on signs.
+	SignPolicy = Policy{Formatting: AllCodes, Newlines: true, MaxLength: 256, MaxCombining: 3}
+	// BookPolicy is the Policy used for the pages of books. Their length is limited by the book itself.
+	BookPolicy = Policy{Formatting: AllCodes, Newlines: true, MaxCombining: 3}
+	// NamePolicy is the Policy used for names given by players, such as the titles of books. It keeps colour
+	// codes only.
+	NamePolicy = Policy{Formatting: ColourCodes, MaxLength: 64, MaxCombining: 3}
+)
+
+// Clean sanitises the text s according to the Policy passed and returns it. Invalid UTF-8, control characters
+// and invisible formatting characters, such as zero-width spaces and right-to-left overrides, are always
+// removed. Formatting codes, newlines, combining characters and the length of the text are then limited as
+// specified by the Policy.
+func Clean(s string, p Policy) string {
+	runes := []rune(strings.ToValidUTF8(s, ""))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	n, combining := 0, 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '§' {
+			if i+1 == len(runes) || !strings.ContainsRune(AllCodes, runes[i+1]) {
+				// A '§' without a valid code after it has no meaning and is removed.
+				continue
+			}
+			i++
+			if !strings.ContainsRune(p.Formatting, runes[i]) {
+				continue
+			}
+			if p.MaxLength > 0 && n+2 > p.MaxLength {
+				break
+			}
+			b.WriteRune(r)
+			b.WriteRune(runes[i])
+			n, combining = n+2, 0
+			continue
+		}
+		switch {
+		case r == '\n':
+			if !p.Newlines {
+				r = ' '
+			}
+		case unicode.IsControl(r) || unicode.Is(unicode.Cf, r) || r == unicode.ReplacementChar:
+			continue
+		}
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			if combining++; p.MaxCombining > 0 && combining > p.MaxCombining {
+				continue
+			}
+		} else {
+			combining = 0
+		}
+		if p.MaxLength > 0 && n >= p.MaxLength {
+			break
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return b.String()
+}
+
+// ReplaceAmpersand replaces every '&' followed by the character of a formatting code in s with '§', so that
+// messages written using '&' codes, such as those in configuration files, are formatted. Any other '&' is
+// left as is.
+func ReplaceAmpersand(s string) string {
+	if !strings.ContainsRune(s, '&') {
+		return s
+	}
+	runes := []rune(s)
+	for i, r := range runes[:len(runes)-1] {
+		if r == '&' && strings.ContainsRune(AllCodes, runes[i+1]) {
+			runes[i] = '§'
+		}
+	}
+	return string(runes)
+}
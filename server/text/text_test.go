@@ -0,0 +1,37 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		in, want string
+		p        Policy
+	}{
+		{in: "§chello §lworld", want: "hello world", p: ChatPolicy},
+		{in: "§chello §lworld", want: "§chello world", p: NamePolicy},
+		{in: "§chello §lworld", want: "§chello §lworld", p: SignPolicy},
+		{in: "a§", want: "a", p: SignPolicy},
+		{in: "a§zb", want: "azb", p: SignPolicy},
+		{in: "zero\u200bwidth\u202eoverride", want: "zerowidthoverride", p: ChatPolicy},
+		{in: "line\nbreak", want: "line break", p: ChatPolicy},
+		{in: "line\nbreak", want: "line\nbreak", p: SignPolicy},
+		{in: "e" + strings.Repeat("\u0301", 10), want: "e" + strings.Repeat("\u0301", 3), p: ChatPolicy},
+		{in: "abcdef", want: "abc", p: Policy{MaxLength: 3}},
+		{in: "ab§cdef", want: "ab", p: Policy{Formatting: AllCodes, MaxLength: 3}},
+		{in: "invalid\xffutf8", want: "invalidutf8", p: ChatPolicy},
+	}
+	for _, test := range tests {
+		if got := Clean(test.in, test.p); got != test.want {
+			t.Errorf("Clean(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestReplaceAmpersand(t *testing.T) {
+	if got, want := ReplaceAmpersand("&cRed & &lbold&"), "§cRed & §lbold&"; got != want {
+		t.Errorf("ReplaceAmpersand() = %q, want %q", got, want)
+	}
+}
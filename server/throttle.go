@@ -0,0 +1,105 @@
+package server
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// throttler limits the amount of simultaneous connections and the rate of joins per IP address, so that a
+// single address cannot exhaust the resources of the server by opening many connections.
+type throttler struct {
+	// maxConns is the maximum amount of simultaneous connections from one address. joinsPerMinute is the
+	// maximum amount of joins from one address within a minute. Either limit is disabled if it is 0 or lower.
+	maxConns, joinsPerMinute int
+
+	mu          sync.Mutex
+	addrs       map[string]*addrThrottle
+	lastCleanup time.Time
+}
+
+// addrThrottle holds the throttle state of a single IP address.
+type addrThrottle struct {
+	// conns is the amount of connections from the address currently open.
+	conns int
+	// joins holds the times of joins from the address within the last minute, oldest first.
+	joins []time.Time
+}
+
+// newThrottler returns a throttler that enforces the limits passed.
+func newThrottler(maxConns, joinsPerMinute int) *throttler {
+	return &throttler{maxConns: maxConns, joinsPerMinute: joinsPerMinute, addrs: map[string]*addrThrottle{}}
+}
+
+// acquire attempts to register a new connection from the address passed at the time passed. It returns
+// false if the address exceeded one of the limits of the throttler, in which case the connection must be
+// rejected. If true is returned, release must be called once the connection is closed.
+func (t *throttler) acquire(addr net.Addr, now time.Time) bool {
+	ip := addrIP(addr)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.lastCleanup) >= time.Minute {
+		t.cleanup(now)
+	}
+	a, ok := t.addrs[ip]
+	if !ok {
+		a = &addrThrottle{}
+		t.addrs[ip] = a
+	}
+	a.prune(now)
+	if t.maxConns > 0 && a.conns >= t.maxConns {
+		return false
+	}
+	if t.joinsPerMinute > 0 && len(a.joins) >= t.joinsPerMinute {
+		return false
+	}
+	a.conns++
+	a.joins = append(a.joins, now)
+	return true
+}
+
+// release releases a connection from the address passed previously registered using acquire.
+func (t *throttler) release(addr net.Addr) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if a, ok := t.addrs[addrIP(addr)]; ok && a.conns > 0 {
+		a.conns--
+	}
+}
+
+// cleanup removes the state of all addresses that have no connections open and have not joined in the last
+// minute, so that the memory used by the throttler does not grow indefinitely.
+func (t *throttler) cleanup(now time.Time) {
+	t.lastCleanup = now
+	for ip, a := range t.addrs {
+		a.prune(now)
+		if a.conns == 0 && len(a.joins) == 0 {
+			delete(t.addrs, ip)
+		}
+	}
+}
+
+// prune removes all joins that happened more than a minute before the time passed.
+func (a *addrThrottle) prune(now time.Time) {
+	i := 0
+	for i < len(a.joins) && now.Sub(a.joins[i]) >= time.Minute {
+		i++
+	}
+	a.joins = a.joins[i:]
+}
+
+// addrIP returns the IP of the net.Addr passed as a string. If the address has no IP, the string form of the
+// full address is returned.
+func addrIP(addr net.Addr) string {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP.String()
+	case *net.TCPAddr:
+		return a.IP.String()
+	}
+	if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return host
+	}
+	return addr.String()
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestThrottler(t *testing.T) {
+	th := newThrottler(2, 3)
+	a := &net.UDPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 1000}
+	b := &net.UDPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 2000}
+	other := &net.UDPAddr{IP: net.IPv4(5, 6, 7, 8), Port: 1000}
+	now := time.Now()
+
+	if !th.acquire(a, now) || !th.acquire(b, now) {
+		t.Fatalf("first two connections should be accepted")
+	}
+	if th.acquire(a, now) {
+		t.Errorf("third simultaneous connection from the same IP should be rejected")
+	}
+	if !th.acquire(other, now) {
+		t.Errorf("connection from a different IP should be accepted")
+	}
+	th.release(a)
+	if !th.acquire(a, now) {
+		t.Errorf("connection after release should be accepted")
+	}
+	th.release(a)
+	if th.acquire(a, now) {
+		t.Errorf("fourth join within a minute should be rejected")
+	}
+	if !th.acquire(a, now.Add(time.Minute)) {
+		t.Errorf("join after a minute should be accepted")
+	}
+
+	th.release(a)
+	th.release(b)
+	th.release(other)
+	th.cleanup(now.Add(time.Minute * 3))
+	if len(th.addrs) != 0 {
+		t.Errorf("cleanup left %v addresses, want 0", len(th.addrs))
+	}
+}
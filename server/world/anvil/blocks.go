@@ -0,0 +1,191 @@
+package anvil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// renames holds Java blocks, without the 'minecraft:' prefix, that map to a single Bedrock block state
+// regardless of their properties.
+var renames = map[string]state{
+	"cave_air":               {name: "air"},
+	"void_air":               {name: "air"},
+	"grass_block":            {name: "grass"},
+	"stone":                  {name: "stone", properties: map[string]interface{}{"stone_type": "stone"}},
+	"granite":                {name: "stone", properties: map[string]interface{}{"stone_type": "granite"}},
+	"polished_granite":       {name: "stone", properties: map[string]interface{}{"stone_type": "granite_smooth"}},
+	"diorite":                {name: "stone", properties: map[string]interface{}{"stone_type": "diorite"}},
+	"polished_diorite":       {name: "stone", properties: map[string]interface{}{"stone_type": "diorite_smooth"}},
+	"andesite":               {name: "stone", properties: map[string]interface{}{"stone_type": "andesite"}},
+	"polished_andesite":      {name: "stone", properties: map[string]interface{}{"stone_type": "andesite_smooth"}},
+	"dirt":                   {name: "dirt", properties: map[string]interface{}{"dirt_type": "normal"}},
+	"coarse_dirt":            {name: "dirt", properties: map[string]interface{}{"dirt_type": "coarse"}},
+	"sand":                   {name: "sand", properties: map[string]interface{}{"sand_type": "normal"}},
+	"red_sand":               {name: "sand", properties: map[string]interface{}{"sand_type": "red"}},
+	"sandstone":              {name: "sandstone", properties: map[string]interface{}{"sand_stone_type": "default"}},
+	"chiseled_sandstone":     {name: "sandstone", properties: map[string]interface{}{"sand_stone_type": "heiroglyphs"}},
+	"cut_sandstone":          {name: "sandstone", properties: map[string]interface{}{"sand_stone_type": "cut"}},
+	"smooth_sandstone":       {name: "sandstone", properties: map[string]interface{}{"sand_stone_type": "smooth"}},
+	"red_sandstone":          {name: "red_sandstone", properties: map[string]interface{}{"sand_stone_type": "default"}},
+	"chiseled_red_sandstone": {name: "red_sandstone", properties: map[string]interface{}{"sand_stone_type": "heiroglyphs"}},
+	"cut_red_sandstone":      {name: "red_sandstone", properties: map[string]interface{}{"sand_stone_type": "cut"}},
+	"smooth_red_sandstone":   {name: "red_sandstone", properties: map[string]interface{}{"sand_stone_type": "smooth"}},
+	"stone_bricks":           {name: "stonebrick", properties: map[string]interface{}{"stone_brick_type": "default"}},
+	"mossy_stone_bricks":     {name: "stonebrick", properties: map[string]interface{}{"stone_brick_type": "mossy"}},
+	"cracked_stone_bricks":   {name: "stonebrick", properties: map[string]interface{}{"stone_brick_type": "cracked"}},
+	"chiseled_stone_bricks":  {name: "stonebrick", properties: map[string]interface{}{"stone_brick_type": "chiseled"}},
+	"bricks":                 {name: "brick_block"},
+	"terracotta":             {name: "hardened_clay"},
+	"snow_block":             {name: "snow"},
+	"magma_block":            {name: "magma"},
+	"nether_quartz_ore":      {name: "quartz_ore"},
+	"melon":                  {name: "melon_block"},
+	"sea_lantern":            {name: "seaLantern"},
+	"dead_bush":              {name: "deadbush"},
+	"grass":                  {name: "tallgrass", properties: map[string]interface{}{"tall_grass_type": "tall"}},
+	"fern":                   {name: "tallgrass", properties: map[string]interface{}{"tall_grass_type": "fern"}},
+	"dandelion":              {name: "yellow_flower"},
+	"poppy":                  {name: "red_flower", properties: map[string]interface{}{"flower_type": "poppy"}},
+	"blue_orchid":            {name: "red_flower", properties: map[string]interface{}{"flower_type": "orchid"}},
+	"allium":                 {name: "red_flower", properties: map[string]interface{}{"flower_type": "allium"}},
+	"azure_bluet":            {name: "red_flower", properties: map[string]interface{}{"flower_type": "houstonia"}},
+	"red_tulip":              {name: "red_flower", properties: map[string]interface{}{"flower_type": "tulip_red"}},
+	"orange_tulip":           {name: "red_flower", properties: map[string]interface{}{"flower_type": "tulip_orange"}},
+	"white_tulip":            {name: "red_flower", properties: map[string]interface{}{"flower_type": "tulip_white"}},
+	"pink_tulip":             {name: "red_flower", properties: map[string]interface{}{"flower_type": "tulip_pink"}},
+	"oxeye_daisy":            {name: "red_flower", properties: map[string]interface{}{"flower_type": "oxeye"}},
+	"cornflower":             {name: "red_flower", properties: map[string]interface{}{"flower_type": "cornflower"}},
+	"lily_of_the_valley":     {name: "red_flower", properties: map[string]interface{}{"flower_type": "lily_of_the_valley"}},
+	"sugar_cane":             {name: "reeds", properties: map[string]interface{}{"age": int32(0)}},
+	"lily_pad":               {name: "waterlily"},
+	"cobweb":                 {name: "web"},
+	"spawner":                {name: "mob_spawner"},
+}
+
+// doublePlants holds the Java names of two block high plants, indexed to their Bedrock double_plant_type.
+var doublePlants = map[string]string{
+	"sunflower":  "sunflower",
+	"lilac":      "syringa",
+	"tall_grass": "grass",
+	"large_fern": "fern",
+	"rose_bush":  "rose",
+	"peony":      "paeonia",
+}
+
+// colouredBlocks holds Java suffixes of coloured blocks, indexed to the name of the Bedrock block with a
+// colour property.
+var colouredBlocks = map[string]string{
+	"_wool":            "wool",
+	"_terracotta":      "stained_hardened_clay",
+	"_stained_glass":   "stained_glass",
+	"_concrete":        "concrete",
+	"_concrete_powder": "concretePowder",
+	"_carpet":          "carpet",
+}
+
+// colours holds the colours of Java Edition. The Bedrock names of these colours are the same, except for
+// light gray, which is silver.
+var colours = []string{"white", "orange", "magenta", "light_blue", "yellow", "lime", "pink", "gray", "light_gray", "cyan", "purple", "blue", "brown", "green", "red", "black"}
+
+// state is a Bedrock block state, consisting of a name without the 'minecraft:' prefix and properties.
+type state struct {
+	name       string
+	properties map[string]interface{}
+}
+
+// bedrockState converts a Java block state, of which the name has no 'minecraft:' prefix, to the name and
+// properties of a Bedrock block state. Blocks not explicitly converted keep their name and are converted to
+// the default state of the Bedrock block with the same name, if it exists.
+func bedrockState(name string, p map[string]string) (string, map[string]interface{}) {
+	if s, ok := renames[name]; ok {
+		return "minecraft:" + s.name, s.properties
+	}
+	if t, ok := doublePlants[name]; ok {
+		return "minecraft:double_plant", map[string]interface{}{"double_plant_type": t, "upper_block_bit": bit(p["half"] == "upper")}
+	}
+	for suffix, bedrockName := range colouredBlocks {
+		if colour := strings.TrimSuffix(name, suffix); colour != name && validColour(colour) {
+			if colour == "light_gray" {
+				colour = "silver"
+			}
+			return "minecraft:" + bedrockName, map[string]interface{}{"color": colour}
+		}
+	}
+	switch {
+	case name == "water" || name == "lava":
+		depth, _ := strconv.Atoi(p["level"])
+		return "minecraft:" + name, map[string]interface{}{"liquid_depth": int32(depth)}
+	case name == "snow":
+		layers, _ := strconv.Atoi(p["layers"])
+		if layers < 1 {
+			layers = 1
+		}
+		return "minecraft:snow_layer", map[string]interface{}{"height": int32(layers - 1), "covered_bit": uint8(0)}
+	case name == "farmland":
+		moisture, _ := strconv.Atoi(p["moisture"])
+		return "minecraft:farmland", map[string]interface{}{"moisturized_amount": int32(moisture)}
+	case name == "cactus":
+		age, _ := strconv.Atoi(p["age"])
+		return "minecraft:cactus", map[string]interface{}{"age": int32(age)}
+	case name == "redstone_ore":
+		if p["lit"] == "true" {
+			return "minecraft:lit_redstone_ore", nil
+		}
+		return "minecraft:redstone_ore", nil
+	case name == "torch":
+		return "minecraft:torch", map[string]interface{}{"torch_facing_direction": "top"}
+	case name == "wall_torch":
+		return "minecraft:torch", map[string]interface{}{"torch_facing_direction": p["facing"]}
+	case strings.HasSuffix(name, "_planks"):
+		return "minecraft:planks", map[string]interface{}{"wood_type": strings.TrimSuffix(name, "_planks")}
+	case strings.HasSuffix(name, "_log") && !strings.HasPrefix(name, "stripped_"):
+		return logState(strings.TrimSuffix(name, "_log"), p["axis"])
+	case strings.HasSuffix(name, "_wood") && !strings.HasPrefix(name, "stripped_"):
+		return "minecraft:wood", map[string]interface{}{"wood_type": strings.TrimSuffix(name, "_wood"), "stripped_bit": uint8(0), "pillar_axis": axis(p["axis"])}
+	case strings.HasSuffix(name, "_leaves"):
+		return leavesState(strings.TrimSuffix(name, "_leaves"), p["persistent"] == "true")
+	}
+	return "minecraft:" + name, nil
+}
+
+// logState returns the Bedrock state of a log of the wood type passed, with the axis passed.
+func logState(wood, a string) (string, map[string]interface{}) {
+	if wood == "acacia" || wood == "dark_oak" {
+		return "minecraft:log2", map[string]interface{}{"new_log_type": wood, "pillar_axis": axis(a)}
+	}
+	return "minecraft:log", map[string]interface{}{"old_log_type": wood, "pillar_axis": axis(a)}
+}
+
+// leavesState returns the Bedrock state of leaves of the wood type passed.
+func leavesState(wood string, persistent bool) (string, map[string]interface{}) {
+	if wood == "acacia" || wood == "dark_oak" {
+		return "minecraft:leaves2", map[string]interface{}{"new_leaf_type": wood, "persistent_bit": bit(persistent), "update_bit": uint8(0)}
+	}
+	return "minecraft:leaves", map[string]interface{}{"old_leaf_type": wood, "persistent_bit": bit(persistent), "update_bit": uint8(0)}
+}
+
+// axis returns the Bedrock pillar axis for the Java axis passed, defaulting to y.
+func axis(a string) string {
+	if a == "x" || a == "z" {
+		return a
+	}
+	return "y"
+}
+
+// validColour checks if the colour passed is one of the colours of Java Edition.
+func validColour(colour string) bool {
+	for _, c := range colours {
+		if c == colour {
+			return true
+		}
+	}
+	return false
+}
+
+// bit converts a bool to a uint8 as used in Bedrock block states.
+func bit(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
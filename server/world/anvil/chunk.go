@@ -0,0 +1,156 @@
+package anvil
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/bits"
+	"strings"
+)
+
+// Data versions of Java Edition chunks at which the format of the chunks changed.
+const (
+	// dataVersionFlattening is the data version of 1.13, the first version with block states stored in a
+	// palette. Older chunks use numeric block IDs and are not supported.
+	dataVersionFlattening = 1451
+	// dataVersionPadded is the data version from which block states no longer span multiple longs, but are
+	// padded instead.
+	dataVersionPadded = 2527
+)
+
+// Chunk decodes the NBT data of a Java Edition chunk, as returned by Region.Chunk, into a chunk.Chunk. The block
+// states of the chunk are converted to their Bedrock Edition equivalents. The position of the chunk is returned
+// too. An error is returned if the chunk is not fully generated or if its format is not supported.
+// Blocks outside the height range of Bedrock Edition chunks are discarded.
+func (conv *Converter) Chunk(data map[string]interface{}) (world.ChunkPos, *chunk.Chunk, error) {
+	version := nbtconv.MapInt32(data, "DataVersion")
+	if version < dataVersionFlattening {
+		return world.ChunkPos{}, nil, fmt.Errorf("decode chunk: unsupported data version %v", version)
+	}
+	// Chunks from before 1.18 store their data in a 'Level' compound, later chunks store it in the root.
+	level, ok := data["Level"].(map[string]interface{})
+	if !ok {
+		level = data
+	}
+	pos := world.ChunkPos{nbtconv.MapInt32(level, "xPos"), nbtconv.MapInt32(level, "zPos")}
+	if status := strings.TrimPrefix(nbtconv.MapString(level, "Status"), "minecraft:"); status != "" && status != "full" {
+		return pos, nil, fmt.Errorf("decode chunk %v: chunk not fully generated (%v)", pos, status)
+	}
+
+	c := chunk.New(conv.air)
+	sections := nbtconv.MapSlice(level, "Sections")
+	if sections == nil {
+		sections = nbtconv.MapSlice(level, "sections")
+	}
+	for _, s := range sections {
+		section, _ := s.(map[string]interface{})
+		y := int16(int8(nbtconv.MapByte(section, "Y"))) << 4
+		if y < 0 || y > 240 {
+			continue
+		}
+		palette, states := nbtconv.MapSlice(section, "Palette"), int64s(section["BlockStates"])
+		if blockStates, ok := section["block_states"].(map[string]interface{}); ok {
+			palette, states = nbtconv.MapSlice(blockStates, "palette"), int64s(blockStates["data"])
+		}
+		conv.decodeSection(c, y, palette, states, version >= dataVersionPadded)
+	}
+	conv.decodeBiomes(c, level["Biomes"])
+	return pos, c, nil
+}
+
+// decodeSection decodes the palette and packed block states of a section at the base Y passed into the chunk.
+func (conv *Converter) decodeSection(c *chunk.Chunk, y int16, palette []interface{}, states []int64, padded bool) {
+	if len(palette) == 0 {
+		return
+	}
+	rids := make([]uint32, len(palette))
+	layers := make([]uint32, len(palette))
+	for i, p := range palette {
+		entry, _ := p.(map[string]interface{})
+		properties := map[string]string{}
+		if m, ok := entry["Properties"].(map[string]interface{}); ok {
+			for k, v := range m {
+				properties[k], _ = v.(string)
+			}
+		}
+		rids[i], layers[i] = conv.State(nbtconv.MapString(entry, "Name"), properties)
+	}
+	if len(palette) == 1 {
+		if rids[0] == conv.air {
+			return
+		}
+		for i := 0; i < 4096; i++ {
+			conv.set(c, y, i, rids[0], layers[0])
+		}
+		return
+	}
+
+	bitsPerBlock := bits.Len(uint(len(palette) - 1))
+	if bitsPerBlock < 4 {
+		bitsPerBlock = 4
+	}
+	mask := uint64(1)<<bitsPerBlock - 1
+	perLong := 64 / bitsPerBlock
+	for i := 0; i < 4096; i++ {
+		var v uint64
+		if padded {
+			index := i / perLong
+			if index >= len(states) {
+				return
+			}
+			v = (uint64(states[index]) >> ((i % perLong) * bitsPerBlock)) & mask
+		} else {
+			// Before 1.16, block states could span two longs.
+			bit := i * bitsPerBlock
+			index, offset := bit/64, bit%64
+			if index >= len(states) {
+				return
+			}
+			v = uint64(states[index]) >> offset
+			if offset+bitsPerBlock > 64 && index+1 < len(states) {
+				v |= uint64(states[index+1]) << (64 - offset)
+			}
+			v &= mask
+		}
+		if int(v) >= len(rids) || rids[v] == conv.air {
+			continue
+		}
+		conv.set(c, y, i, rids[v], layers[v])
+	}
+}
+
+// set sets the runtime ID passed at the index i, in the YZX order of Java sections, of the section at the base
+// Y passed. If the layer runtime ID is not air, it is set on the second layer, such as for waterlogged blocks.
+func (conv *Converter) set(c *chunk.Chunk, y int16, i int, rid, layer uint32) {
+	x, z := uint8(i&0xf), uint8((i>>4)&0xf)
+	by := y + int16(i>>8)
+	c.SetRuntimeID(x, by, z, 0, rid)
+	if layer != conv.air {
+		c.SetRuntimeID(x, by, z, 1, layer)
+	}
+}
+
+// decodeBiomes decodes the biomes of a chunk from before 1.18, which are stored as an int array of either 256
+// biomes, one per column, or 1024 biomes, one per 4x4x4 area. In the latter case the biomes at sea level are
+// used. Biomes with IDs that do not exist in Bedrock Edition are replaced with plains.
+func (conv *Converter) decodeBiomes(c *chunk.Chunk, v interface{}) {
+	biomes := int32s(v)
+	if len(biomes) != 256 && len(biomes) != 1024 {
+		return
+	}
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			var id int32
+			if len(biomes) == 256 {
+				id = biomes[int(z)*16+int(x)]
+			} else {
+				id = biomes[16*16+int(z/4)*4+int(x/4)]
+			}
+			if id < 0 || id >= 40 {
+				id = 1
+			}
+			c.SetBiomeID(x, z, uint8(id))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package anvil
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"sort"
+	"strings"
+)
+
+// Converter converts the block states of Java Edition chunks to the block states of Bedrock Edition. Java
+// block states that have no Bedrock equivalent are replaced with stone. A Converter caches the states it
+// converted, so a single Converter should be used for all chunks of a world. A Converter is not safe for
+// concurrent use.
+type Converter struct {
+	air, water, fallback uint32
+
+	// states holds the states converted so far, indexed by the Java state.
+	states map[string]convertedState
+	// defaults holds the runtime ID of the default state of every Bedrock block, indexed by the name of the
+	// block.
+	defaults map[string]uint32
+	// unmapped holds the amount of times a Java block could not be converted, indexed by its name.
+	unmapped map[string]int
+}
+
+// convertedState is a Java block state converted to Bedrock Edition. It consists of the runtime ID of the
+// block and the runtime ID of the block on the second layer, which is air unless the block was waterlogged.
+// If the state could not be converted, unmapped is true.
+type convertedState struct {
+	rid, layer uint32
+	unmapped   bool
+}
+
+// NewConverter creates a Converter ready to convert block states.
+func NewConverter() *Converter {
+	conv := &Converter{
+		states:   map[string]convertedState{},
+		defaults: map[string]uint32{},
+		unmapped: map[string]int{},
+	}
+	for rid := uint32(0); ; rid++ {
+		b, ok := world.BlockByRuntimeID(rid)
+		if !ok {
+			break
+		}
+		name, _ := b.EncodeBlock()
+		if _, ok := conv.defaults[name]; !ok {
+			conv.defaults[name] = rid
+		}
+	}
+	conv.air, _ = chunk.StateToRuntimeID("minecraft:air", nil)
+	conv.water, _ = chunk.StateToRuntimeID("minecraft:water", map[string]interface{}{"liquid_depth": int32(0)})
+	conv.fallback = conv.defaults["minecraft:stone"]
+	return conv
+}
+
+// State converts a Java block state with the name and properties passed to a Bedrock block state. It returns
+// the runtime ID of the state and the runtime ID of the block on the second layer at the same position, which
+// is water for waterlogged blocks and air otherwise.
+func (conv *Converter) State(name string, properties map[string]string) (rid, layer uint32) {
+	key := stateKey(name, properties)
+	s, ok := conv.states[key]
+	if !ok {
+		s = conv.convert(name, properties)
+		conv.states[key] = s
+	}
+	if s.unmapped {
+		conv.unmapped[name]++
+	}
+	return s.rid, s.layer
+}
+
+// convert converts the Java block state passed to a convertedState.
+func (conv *Converter) convert(name string, properties map[string]string) convertedState {
+	s := convertedState{rid: conv.fallback, layer: conv.air}
+
+	bedrockName, bedrockProperties := bedrockState(strings.TrimPrefix(name, "minecraft:"), properties)
+	if r, ok := chunk.StateToRuntimeID(bedrockName, bedrockProperties); ok {
+		s.rid = r
+	} else if r, ok := conv.defaults[bedrockName]; ok {
+		// The properties could not be converted, so we use the default state of the block instead.
+		s.rid = r
+	} else {
+		s.unmapped = true
+	}
+	if properties["waterlogged"] == "true" {
+		s.layer = conv.water
+	}
+	return s
+}
+
+// Unmapped returns the Java blocks that could not be converted and were replaced with stone, together with
+// the amount of times they were encountered in the palettes of chunks.
+func (conv *Converter) Unmapped() map[string]int {
+	m := make(map[string]int, len(conv.unmapped))
+	for k, v := range conv.unmapped {
+		m[k] = v
+	}
+	return m
+}
+
+// stateKey returns a key unique to the block state with the name and properties passed.
+func stateKey(name string, properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteByte(',')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(properties[k])
+	}
+	return b.String()
+}
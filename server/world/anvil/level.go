@@ -0,0 +1,56 @@
+package anvil
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io/ioutil"
+	"os"
+)
+
+// Level holds the data of a Java Edition world that is relevant to Bedrock Edition, as stored in its level.dat.
+type Level struct {
+	// Name is the name of the world.
+	Name string
+	// Spawn is the spawn position of the world.
+	Spawn cube.Pos
+	// Time is the time of day of the world.
+	Time int64
+	// Seed is the seed that the world was generated with.
+	Seed int64
+}
+
+// ReadLevel reads the Level from the gzip compressed level.dat file at the path passed.
+func ReadLevel(path string) (Level, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Level{}, fmt.Errorf("read level.dat: %w", err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return Level{}, fmt.Errorf("read level.dat: %w", err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Level{}, fmt.Errorf("read level.dat: %w", err)
+	}
+	var m map[string]interface{}
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.BigEndian); err != nil {
+		return Level{}, fmt.Errorf("read level.dat: decode NBT: %w", err)
+	}
+	data, _ := m["Data"].(map[string]interface{})
+	l := Level{
+		Name:  nbtconv.MapString(data, "LevelName"),
+		Spawn: cube.Pos{int(nbtconv.MapInt32(data, "SpawnX")), int(nbtconv.MapInt32(data, "SpawnY")), int(nbtconv.MapInt32(data, "SpawnZ"))},
+		Time:  nbtconv.MapInt64(data, "DayTime"),
+		Seed:  nbtconv.MapInt64(data, "RandomSeed"),
+	}
+	if settings, ok := data["WorldGenSettings"].(map[string]interface{}); ok {
+		// Since 1.16, the seed is stored in the world generation settings.
+		l.Seed = nbtconv.MapInt64(settings, "seed")
+	}
+	return l, nil
+}
@@ -0,0 +1,35 @@
+package anvil
+
+import (
+	"reflect"
+)
+
+// int64s converts a long array decoded from NBT to an int64 slice. Arrays decoded from NBT are fixed size Go
+// arrays rather than slices, so int64s accepts both.
+func int64s(v interface{}) []int64 {
+	if s, ok := v.([]int64); ok {
+		return s
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Int64 {
+		return nil
+	}
+	s := make([]int64, rv.Len())
+	reflect.Copy(reflect.ValueOf(s), rv)
+	return s
+}
+
+// int32s converts an int array decoded from NBT to an int32 slice. Like int64s, it accepts both arrays and
+// slices.
+func int32s(v interface{}) []int32 {
+	if s, ok := v.([]int32); ok {
+		return s
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Int32 {
+		return nil
+	}
+	s := make([]int32, rv.Len())
+	reflect.Copy(reflect.ValueOf(s), rv)
+	return s
+}
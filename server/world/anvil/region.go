@@ -0,0 +1,106 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// Region is a region file of a Java Edition world in the Anvil format, with the .mca extension. A region holds
+// up to 32x32 chunks. Chunks are read from the file one at a time, so that the region is never held in memory
+// as a whole.
+type Region struct {
+	f *os.File
+	// locations holds the location of every chunk in the region, indexed by x + z*32. The first 3 bytes of a
+	// location hold the offset of the chunk in sectors, the last byte the amount of sectors the chunk spans.
+	locations [1024]uint32
+}
+
+// sectorSize is the size of a sector in a region file. The header of a region and the data of its chunks are
+// aligned to sectors.
+const sectorSize = 4096
+
+// Compression types that chunks in a region file may be compressed with.
+const (
+	compressionGzip = iota + 1
+	compressionZlib
+	compressionNone
+)
+
+// OpenRegion opens the region file at the path passed. An error is returned if the file could not be opened
+// or if its header could not be read.
+func OpenRegion(path string) (*Region, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open region: %w", err)
+	}
+	r := &Region{f: f}
+	if err := binary.Read(f, binary.BigEndian, &r.locations); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open region %v: read header: %w", path, err)
+	}
+	return r, nil
+}
+
+// Chunk reads the NBT data of the chunk at the x and z passed, which are relative to the region and must be
+// in the range 0-31. If the region does not hold a chunk at this position, false is returned.
+func (r *Region) Chunk(x, z int) (map[string]interface{}, bool, error) {
+	loc := r.locations[x+z*32]
+	offset, sectors := int64(loc>>8), loc&0xff
+	if offset == 0 || sectors == 0 {
+		return nil, false, nil
+	}
+	header := make([]byte, 5)
+	if _, err := r.f.ReadAt(header, offset*sectorSize); err != nil {
+		return nil, true, fmt.Errorf("read chunk %v, %v: read header: %w", x, z, err)
+	}
+	length, compression := binary.BigEndian.Uint32(header), header[4]
+	if length <= 1 || length > sectors*sectorSize {
+		return nil, true, fmt.Errorf("read chunk %v, %v: invalid length %v", x, z, length)
+	}
+	data := make([]byte, length-1)
+	if _, err := r.f.ReadAt(data, offset*sectorSize+5); err != nil {
+		return nil, true, fmt.Errorf("read chunk %v, %v: %w", x, z, err)
+	}
+
+	var rd io.Reader
+	switch compression {
+	case compressionGzip:
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, true, fmt.Errorf("read chunk %v, %v: %w", x, z, err)
+		}
+		rd = gz
+	case compressionZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, true, fmt.Errorf("read chunk %v, %v: %w", x, z, err)
+		}
+		rd = zr
+	case compressionNone:
+		rd = bytes.NewReader(data)
+	default:
+		// Chunks stored in separate .mcc files and newer compression types are not supported.
+		return nil, true, fmt.Errorf("read chunk %v, %v: unsupported compression type %v", x, z, compression)
+	}
+	b, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, true, fmt.Errorf("read chunk %v, %v: decompress: %w", x, z, err)
+	}
+	var m map[string]interface{}
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.BigEndian); err != nil {
+		return nil, true, fmt.Errorf("read chunk %v, %v: decode NBT: %w", x, z, err)
+	}
+	return m, true, nil
+}
+
+// Close closes the region file.
+func (r *Region) Close() error {
+	return r.f.Close()
+}
@@ -0,0 +1,97 @@
+package anvil_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertChunk(t *testing.T) {
+	var states [256]int64
+	// The first block is grass, the second an unknown block.
+	states[0] = 1 | 2<<4
+	data := map[string]interface{}{
+		"DataVersion": int32(2586),
+		"Level": map[string]interface{}{
+			"xPos":   int32(3),
+			"zPos":   int32(-2),
+			"Status": "full",
+			"Sections": []interface{}{map[string]interface{}{
+				"Y": uint8(0),
+				"Palette": []interface{}{
+					map[string]interface{}{"Name": "minecraft:air"},
+					map[string]interface{}{"Name": "minecraft:grass_block", "Properties": map[string]interface{}{"snowy": "false"}},
+					map[string]interface{}{"Name": "minecraft:unknown_block"},
+				},
+				"BlockStates": states,
+			}},
+		},
+	}
+	path := writeRegion(t, data)
+
+	r, err := anvil.OpenRegion(path)
+	if err != nil {
+		t.Fatalf("open region: %v", err)
+	}
+	defer r.Close()
+	if _, ok, err := r.Chunk(1, 0); ok || err != nil {
+		t.Fatalf("read missing chunk: ok=%v, err=%v", ok, err)
+	}
+	m, ok, err := r.Chunk(0, 0)
+	if !ok || err != nil {
+		t.Fatalf("read chunk: ok=%v, err=%v", ok, err)
+	}
+
+	conv := anvil.NewConverter()
+	pos, c, err := conv.Chunk(m)
+	if err != nil {
+		t.Fatalf("convert chunk: %v", err)
+	}
+	if pos != (world.ChunkPos{3, -2}) {
+		t.Fatalf("chunk position %v, want %v", pos, world.ChunkPos{3, -2})
+	}
+	grass, _ := chunk.StateToRuntimeID("minecraft:grass", nil)
+	if rid := c.RuntimeID(0, 0, 0, 0); rid != grass {
+		t.Fatalf("block at 0, 0, 0 has runtime ID %v, want grass (%v)", rid, grass)
+	}
+	stone, _ := chunk.StateToRuntimeID("minecraft:stone", map[string]interface{}{"stone_type": "stone"})
+	if rid := c.RuntimeID(1, 0, 0, 0); rid != stone {
+		t.Fatalf("unknown block at 1, 0, 0 has runtime ID %v, want stone (%v)", rid, stone)
+	}
+	if n := conv.Unmapped()["minecraft:unknown_block"]; n != 1 {
+		t.Fatalf("unknown block counted %v times, want 1", n)
+	}
+}
+
+// writeRegion writes a region file holding the chunk data passed at 0, 0 and returns its path.
+func writeRegion(t *testing.T, data map[string]interface{}) string {
+	b, err := nbt.MarshalEncoding(data, nbt.BigEndian)
+	if err != nil {
+		t.Fatalf("encode chunk: %v", err)
+	}
+	compressed := bytes.NewBuffer(nil)
+	w := zlib.NewWriter(compressed)
+	_, _ = w.Write(b)
+	_ = w.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 8192))
+	// The chunk starts at the third sector, right after the header.
+	binary.BigEndian.PutUint32(buf.Bytes(), 2<<8|uint32(compressed.Len()/4096+1))
+	_ = binary.Write(buf, binary.BigEndian, uint32(compressed.Len()+1))
+	buf.WriteByte(2)
+	buf.Write(compressed.Bytes())
+	buf.Write(make([]byte, 4096-buf.Len()%4096))
+
+	path := filepath.Join(t.TempDir(), "r.0.0.mca")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("write region: %v", err)
+	}
+	return path
+}
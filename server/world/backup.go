@@ -0,0 +1,82 @@
+package world
+
+import (
+	"fmt"
+	"time"
+)
+
+// BackupProvider is a Provider that is able to take snapshots of the data it provides. Worlds with a
+// BackupProvider may be backed up while they are running using World.Backup.
+type BackupProvider interface {
+	Provider
+	// Snapshot takes a consistent snapshot of the data currently stored by the provider. Data saved to the
+	// provider after the call is not part of the Snapshot. Snapshot returns ErrProviderClosed if the provider
+	// was closed.
+	Snapshot() (Snapshot, error)
+}
+
+// Snapshot is a consistent, read-only view of the data of a BackupProvider at the moment it was taken. A
+// Snapshot must be released using Release once it is no longer used.
+type Snapshot interface {
+	// Backup writes the data of the Snapshot to dst. The format of the backup is up to the implementation.
+	// The Chunks and Bytes of the BackupStats returned are filled out.
+	Backup(dst string) (BackupStats, error)
+	// Release releases the Snapshot. The Snapshot may no longer be used after calling Release.
+	Release()
+}
+
+// BackupStats holds statistics of a backup made using World.Backup, which may be used to log it.
+type BackupStats struct {
+	// Chunks is the amount of chunks written to the backup.
+	Chunks int
+	// Bytes is the size of the backup on disk in bytes.
+	Bytes int64
+	// Duration is the time it took to make the backup, including saving the world before it.
+	Duration time.Duration
+}
+
+// Backup makes a consistent copy of the world while it is running and writes it to dst. All chunks changed
+// since they were last saved are saved first, after which a snapshot of the provider is taken during a short
+// pause of writes to the provider. The snapshot is then written to dst while the world keeps running, so
+// blocks may freely be changed during the backup. The format of the backup depends on the provider: The
+// mcdb provider writes a world folder, or a .mcworld file if dst ends with .mcworld.
+// Backup returns an error if the provider of the world does not implement BackupProvider, if the world was
+// closed or if the backup could not be written to dst.
+func (w *World) Backup(dst string) (BackupStats, error) {
+	p, ok := w.provider().(BackupProvider)
+	if !ok {
+		return BackupStats{}, fmt.Errorf("backup world: provider %T does not support backups", w.provider())
+	}
+	start := time.Now()
+
+	// The world may not be closed while the backup is made, so saveMu is held until it is done. Saves
+	// requested in the meantime are performed once the backup finishes.
+	w.saveMu.Lock()
+	defer w.saveMu.Unlock()
+	select {
+	case <-w.closing:
+		return BackupStats{}, fmt.Errorf("backup world: %w", ErrProviderClosed)
+	default:
+	}
+	if !w.rdonly.Load() {
+		w.save()
+	}
+
+	// Chunks unloaded from the cache may be written at any time. Writes are paused while taking the
+	// snapshot, so that the snapshot never holds only part of a chunk.
+	w.backupMu.Lock()
+	s, err := p.Snapshot()
+	w.backupMu.Unlock()
+	if err != nil {
+		return BackupStats{}, fmt.Errorf("backup world: %w", err)
+	}
+	defer s.Release()
+
+	stats, err := s.Backup(dst)
+	if err != nil {
+		return BackupStats{}, err
+	}
+	stats.Duration = time.Since(start)
+	w.log.Debugf("Backed up %v chunks (%v bytes) to %v in %v.", stats.Chunks, stats.Bytes, dst, stats.Duration)
+	return stats, nil
+}
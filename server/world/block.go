@@ -5,6 +5,7 @@ import (
 	"github.com/brentp/intintmap"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world/chunk"
+	"image/color"
 	"math"
 	"math/rand"
 )
@@ -65,12 +66,16 @@ func RegisterBlock(b Block) {
 	if _, ok := blocks[rid].(unknownBlock); !ok {
 		panic(fmt.Sprintf("block with name and properties %v {%#v} already registered", name, properties))
 	}
-	hash := int64(b.Hash())
-	if _, ok := hashes.Get(hash); ok {
+	// Blocks with a hash of math.MaxUint64 are looked up using their name and properties instead, so they are
+	// not added to the hashes map.
+	hash := b.Hash()
+	if _, ok := hashes.Get(int64(hash)); ok && hash != math.MaxUint64 {
 		panic(fmt.Sprintf("block %#v with hash %v already registered", b, hash))
 	}
 	blocks[rid] = b
-	hashes.Put(hash, int64(rid))
+	if hash != math.MaxUint64 {
+		hashes.Put(int64(hash), int64(rid))
+	}
 
 	if diffuser, ok := b.(lightDiffuser); ok {
 		chunk.FilteringBlocks[rid] = diffuser.LightDiffusionLevel()
@@ -84,6 +89,9 @@ func RegisterBlock(b Block) {
 	if _, ok := b.(RandomTicker); ok {
 		randomTickBlocks[rid] = true
 	}
+	if c, ok := b.(MapColourer); ok {
+		mapColours[rid] = c.MapColour()
+	}
 }
 
 // BlockRuntimeID attempts to return a runtime ID of a block previously registered using RegisterBlock().
@@ -136,6 +144,14 @@ func air() Block {
 	return b
 }
 
+// MapColourer represents a block that has a colour on maps, such as those rendered using World.RenderTopDown.
+// Blocks that do not implement MapColourer are shown in a neutral grey.
+type MapColourer interface {
+	// MapColour returns the colour of the block when seen from above. If the colour is fully transparent,
+	// the block below it is shown instead.
+	MapColour() color.RGBA
+}
+
 // RandomTicker represents a block that executes an action when it is ticked randomly. Every 20th of a second,
 // one random block in each sub chunk are picked to receive a random tick.
 type RandomTicker interface {
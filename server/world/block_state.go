@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"image/color"
 	"math"
 	"sort"
 	"strings"
@@ -26,8 +27,14 @@ var (
 	// randomTickBlocks holds a list of RandomTicker implementations for blocks registered that implement the RandomTicker interface.
 	// These are indexed by their runtime IDs. Blocks that do not implement RandomTicker have a false value in this slice.
 	randomTickBlocks []bool
+	// mapColours holds the colours of blocks on maps, indexed by their runtime IDs. Blocks that do not implement
+	// MapColourer have the colour defaultMapColour, except for air, which is fully transparent.
+	mapColours []color.RGBA
 	// airRID is the runtime ID of an air block.
 	airRID uint32
+	// blockVersion is the version of the vanilla block states registered, which custom block states are
+	// registered with too.
+	blockVersion int32
 )
 
 func init() {
@@ -67,15 +74,59 @@ func registerBlockState(s blockState) {
 	if s.Name == "minecraft:air" {
 		airRID = rid
 	}
+	if s.Version > blockVersion {
+		blockVersion = s.Version
+	}
 	stateRuntimeIDs[h] = rid
 	blocks = append(blocks, unknownBlock{s})
 
 	nbtBlocks = append(nbtBlocks, false)
 	randomTickBlocks = append(randomTickBlocks, false)
+	if s.Name == "minecraft:air" {
+		mapColours = append(mapColours, color.RGBA{})
+	} else {
+		mapColours = append(mapColours, defaultMapColour)
+	}
 	chunk.FilteringBlocks = append(chunk.FilteringBlocks, 15)
 	chunk.LightBlocks = append(chunk.LightBlocks, 0)
 }
 
+// sortBlockStates sorts all registered block states by their lower case name, like the client sorts its block
+// palette, so that the runtime IDs of custom block states match those of the client. States with the same name
+// keep their order. All data indexed by runtime ID is moved along with the states.
+func sortBlockStates() {
+	names := make([]string, len(blocks))
+	order := make([]int, len(blocks))
+	for i, b := range blocks {
+		name, _ := b.EncodeBlock()
+		names[i], order[i] = strings.ToLower(name), i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return names[order[i]] < names[order[j]]
+	})
+
+	sortedBlocks, sortedNBT, sortedRandomTick := make([]Block, len(blocks)), make([]bool, len(blocks)), make([]bool, len(blocks))
+	sortedColours := make([]color.RGBA, len(blocks))
+	sortedFiltering, sortedLight := make([]uint8, len(blocks)), make([]uint8, len(blocks))
+	for rid, prev := range order {
+		b := blocks[prev]
+		sortedBlocks[rid], sortedNBT[rid], sortedRandomTick[rid] = b, nbtBlocks[prev], randomTickBlocks[prev]
+		sortedColours[rid] = mapColours[prev]
+		sortedFiltering[rid], sortedLight[rid] = chunk.FilteringBlocks[prev], chunk.LightBlocks[prev]
+
+		name, properties := b.EncodeBlock()
+		if name == "minecraft:air" {
+			airRID = uint32(rid)
+		}
+		stateRuntimeIDs[stateHash{name: name, properties: hashProperties(properties)}] = uint32(rid)
+		if _, ok := b.(unknownBlock); !ok && b.Hash() != math.MaxUint64 {
+			hashes.Put(int64(b.Hash()), int64(rid))
+		}
+	}
+	blocks, nbtBlocks, randomTickBlocks, mapColours = sortedBlocks, sortedNBT, sortedRandomTick, sortedColours
+	chunk.FilteringBlocks, chunk.LightBlocks = sortedFiltering, sortedLight
+}
+
 // unknownBlock represents a block that has not yet been implemented. It is used for registering block
 // states that haven't yet been added.
 type unknownBlock struct {
@@ -0,0 +1,108 @@
+package world
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// Border is a square border around a centre in a World. Bedrock Edition clients have no world border of their
+// own, so the server enforces it: Players cannot edit blocks outside the border and are pushed back or hurt
+// when they cross it, depending on its Damage. Chunks entirely outside a border that does not deal damage are
+// not sent to players, as they cannot enter them.
+// The zero value of Border is a disabled border.
+type Border struct {
+	// Center is the centre of the border on the X and Z axes.
+	Center mgl32.Vec2
+	// Radius is the distance in blocks from the centre to each of the sides of the border. The border is
+	// disabled if the radius is 0 or lower.
+	Radius float64
+	// Damage is the damage dealt every second to players outside the border. If 0 or lower, players are
+	// pushed back inside the border instead. Players may walk through a border that deals damage, so the
+	// chunks outside it are sent like the chunks inside it.
+	Damage float64
+}
+
+// Enabled checks if the Border is enabled, which is the case if its Radius is greater than 0.
+func (b Border) Enabled() bool {
+	return b.Radius > 0
+}
+
+// Contains checks if the position passed is inside the Border. The Y axis of the position is ignored. If the
+// Border is disabled, Contains always returns true.
+func (b Border) Contains(pos mgl64.Vec3) bool {
+	if !b.Enabled() {
+		return true
+	}
+	minX, minZ, maxX, maxZ := b.bounds()
+	return pos[0] >= minX && pos[0] <= maxX && pos[2] >= minZ && pos[2] <= maxZ
+}
+
+// Clamp returns the position inside the Border closest to the position passed. Positions that are already
+// inside the Border are returned as is.
+func (b Border) Clamp(pos mgl64.Vec3) mgl64.Vec3 {
+	if !b.Enabled() {
+		return pos
+	}
+	minX, minZ, maxX, maxZ := b.bounds()
+	return mgl64.Vec3{math.Max(minX, math.Min(maxX, pos[0])), pos[1], math.Max(minZ, math.Min(maxZ, pos[2]))}
+}
+
+// containsChunk checks if any part of the chunk at the position passed is inside the Border.
+func (b Border) containsChunk(pos ChunkPos) bool {
+	if !b.Enabled() {
+		return true
+	}
+	minX, minZ, maxX, maxZ := b.bounds()
+	x, z := float64(pos[0]<<4), float64(pos[1]<<4)
+	return x+16 > minX && x <= maxX && z+16 > minZ && z <= maxZ
+}
+
+// bounds returns the minimum and maximum X and Z coordinates inside the Border.
+func (b Border) bounds() (minX, minZ, maxX, maxZ float64) {
+	x, z := float64(b.Center[0]), float64(b.Center[1])
+	return x - b.Radius, z - b.Radius, x + b.Radius, z + b.Radius
+}
+
+// SetBorder sets a border around the centre passed, with sides at a distance of radius blocks from the centre.
+// Passing a radius of 0 or lower removes the border. The damage dealt by the border, as set using
+// SetBorderDamage, is kept.
+// Changing the border of a World that players are in takes effect right away: Players that are outside the new
+// border are pushed back or hurt from their next tick, and chunks that are now inside the border are sent to
+// them.
+// The border is saved with the settings of the World, so that it remains after restarting.
+func (w *World) SetBorder(center mgl32.Vec2, radius float64) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Border.Center, w.set.Border.Radius = center, radius
+	w.mu.Unlock()
+}
+
+// SetBorderDamage sets the damage dealt every second to players outside the border of the World. If 0 or
+// lower, players are pushed back inside the border instead of being hurt, which is the default.
+func (w *World) SetBorderDamage(dmg float64) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Border.Damage = dmg
+	w.mu.Unlock()
+}
+
+// RemoveBorder removes the border of the World, if it had one.
+func (w *World) RemoveBorder() {
+	w.SetBorder(mgl32.Vec2{}, 0)
+}
+
+// Border returns the border of the World, as set using SetBorder and SetBorderDamage. The Border returned is
+// disabled if the World has no border.
+func (w *World) Border() Border {
+	if w == nil {
+		return Border{}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.set.Border
+}
@@ -0,0 +1,76 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestBorder(t *testing.T) {
+	b := world.Border{Center: mgl32.Vec2{10, -10}, Radius: 5}
+	for pos, inside := range map[mgl64.Vec3]bool{
+		{10, 0, -10}:   true,
+		{15, 100, -5}:  true,
+		{15.1, 0, -10}: false,
+		{10, 0, -15.1}: false,
+	} {
+		if b.Contains(pos) != inside {
+			t.Errorf("expected Contains(%v) to return %v", pos, inside)
+		}
+	}
+	if got := b.Clamp(mgl64.Vec3{20, 64, -30}); got != (mgl64.Vec3{15, 64, -15}) {
+		t.Errorf("expected position to be clamped to the corner of the border, got %v", got)
+	}
+	if !(world.Border{}).Contains(mgl64.Vec3{1e6, 0, -1e6}) {
+		t.Errorf("expected a disabled border to contain every position")
+	}
+}
+
+func TestLoaderBorder(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	// The border lies within the four chunks around the origin, so that no other chunks are inside it.
+	w.SetBorder(mgl32.Vec2{0, 0}, 4)
+
+	v := &chunkViewer{viewed: map[world.ChunkPos]int{}}
+	l := world.NewLoader(2, w, v)
+	defer l.Close()
+	if err := l.Load(1000); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if len(v.viewed) != 4 {
+		t.Errorf("viewed %v chunks inside the border, want 4", len(v.viewed))
+	}
+	if _, ok := v.viewed[world.ChunkPos{1, 0}]; ok {
+		t.Errorf("chunk outside the border was viewed")
+	}
+
+	// Growing the border sends the chunks that are now inside it.
+	w.SetBorder(mgl32.Vec2{0, 0}, 1000)
+	if err := l.Load(1000); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if len(v.viewed) != 21 {
+		t.Errorf("viewed %v chunks after growing the border, want 21", len(v.viewed))
+	}
+	for pos, n := range v.viewed {
+		if n != 1 {
+			t.Errorf("chunk %v viewed %v times, want once", pos, n)
+		}
+	}
+
+	// Players may walk through a border that deals damage, so chunks outside it are sent too.
+	v2 := &chunkViewer{viewed: map[world.ChunkPos]int{}}
+	w.SetBorder(mgl32.Vec2{0, 0}, 4)
+	w.SetBorderDamage(1)
+	l2 := world.NewLoader(2, w, v2)
+	defer l2.Close()
+	if err := l2.Load(1000); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if len(v2.viewed) != 21 {
+		t.Errorf("viewed %v chunks with a damaging border, want 21", len(v2.viewed))
+	}
+}
@@ -48,6 +48,17 @@ func newBlockStorage(blocks []uint32, palette *Palette) *BlockStorage {
 	return &BlockStorage{blocks: blocks, bitsPerBlock: bitsPerBlock, filledBitsPerWord: filledBitsPerWord, blockMask: blockMask, palette: palette, blocksStart: blocksStart}
 }
 
+// clone returns a deep copy of the block storage.
+func (storage *BlockStorage) clone() *BlockStorage {
+	c := *storage
+	c.blocks = append([]uint32(nil), storage.blocks...)
+	if len(c.blocks) > 0 {
+		c.blocksStart = unsafe.Pointer(&c.blocks[0])
+	}
+	c.palette = storage.palette.clone()
+	return &c
+}
+
 // Palette returns the Palette of the block storage.
 func (storage *BlockStorage) Palette() *Palette {
 	return storage.palette
@@ -177,6 +177,34 @@ func (chunk *Chunk) Compact() {
 	}
 }
 
+// Clone returns a deep copy of the chunk. The copy may be used, for example to save it to disk, while the
+// original chunk continues to be modified.
+func (chunk *Chunk) Clone() *Chunk {
+	c := New(chunk.air)
+	c.biomes = chunk.biomes
+	for i, sub := range chunk.sub {
+		if sub != nil {
+			c.sub[i] = sub.clone()
+		}
+	}
+	for pos, data := range chunk.blockEntities {
+		c.blockEntities[pos] = data
+	}
+	return c
+}
+
+// Size returns an estimate of the amount of bytes of memory used by the blocks, light and biomes of the chunk.
+// The NBT of block entities in the chunk is not included.
+func (chunk *Chunk) Size() int {
+	n := len(chunk.biomes)
+	for _, sub := range chunk.sub {
+		if sub != nil {
+			n += sub.size()
+		}
+	}
+	return n
+}
+
 // columnOffset returns the offset in a byte slice that the column at a specific x and z may be found.
 func columnOffset(x, z uint8) uint8 {
 	return (x & 15) | (z&15)<<4
@@ -45,7 +45,12 @@ func NetworkDecode(air uint32, data []byte, subChunkCount int) (*Chunk, error) {
 // DiskDecode decodes the data from a SerialisedData object into a chunk and returns it. If the data was
 // invalid, an error is returned.
 func DiskDecode(data SerialisedData) (*Chunk, error) {
-	air, ok := StateToRuntimeID("minecraft:air", nil)
+	return diskDecode(data, DiskEncoding)
+}
+
+// diskDecode decodes the data from a SerialisedData object into a chunk using the diskEncoding passed.
+func diskDecode(data SerialisedData, e diskEncoding) (*Chunk, error) {
+	air, ok := e.runtimeID("minecraft:air", nil)
 	if !ok {
 		panic("cannot find air runtime ID")
 	}
@@ -63,7 +68,7 @@ func DiskDecode(data SerialisedData) (*Chunk, error) {
 			// No data for this sub chunk.
 			continue
 		}
-		c.sub[y], err = decodeSubChunk(bytes.NewBuffer(sub), air, DiskEncoding)
+		c.sub[y], err = decodeSubChunk(bytes.NewBuffer(sub), air, e)
 		if err != nil {
 			return nil, err
 		}
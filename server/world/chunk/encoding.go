@@ -25,19 +25,39 @@ var DiskEncoding diskEncoding
 // NetworkEncoding is the Encoding used for sending a Chunk over network. It does not use NBT and writes varints.
 var NetworkEncoding networkEncoding
 
-// diskEncoding implements the Chunk encoding for writing to disk.
-type diskEncoding struct{}
+// diskEncoding implements the Chunk encoding for writing to disk. Runtime IDs are converted to and from block
+// states using RuntimeIDToState and StateToRuntimeID, unless different functions are set in the diskEncoding.
+type diskEncoding struct {
+	toState     func(runtimeID uint32) (name string, properties map[string]interface{}, found bool)
+	toRuntimeID func(name string, properties map[string]interface{}) (runtimeID uint32, found bool)
+}
+
+// state returns the name and properties of the block state with the runtime ID passed.
+func (e diskEncoding) state(runtimeID uint32) (string, map[string]interface{}, bool) {
+	if e.toState != nil {
+		return e.toState(runtimeID)
+	}
+	return RuntimeIDToState(runtimeID)
+}
+
+// runtimeID returns the runtime ID of the block state with the name and properties passed.
+func (e diskEncoding) runtimeID(name string, properties map[string]interface{}) (uint32, bool) {
+	if e.toRuntimeID != nil {
+		return e.toRuntimeID(name, properties)
+	}
+	return StateToRuntimeID(name, properties)
+}
 
 func (diskEncoding) network() byte          { return 0 }
 func (diskEncoding) encoding() nbt.Encoding { return nbt.LittleEndian }
 func (diskEncoding) data2D(c *Chunk) []byte { return append(emptyHeightMap, c.biomes[:]...) }
-func (diskEncoding) encodePalette(buf *bytes.Buffer, p *Palette) {
+func (e diskEncoding) encodePalette(buf *bytes.Buffer, p *Palette) {
 	_ = binary.Write(buf, binary.LittleEndian, uint32(p.Len()))
 	blocks := make([]blockEntry, p.Len())
 	for index, runtimeID := range p.blockRuntimeIDs {
 		// Get the block state registered with the runtime IDs we have in the palette of the block storage
 		// as we need the name and data value to store.
-		name, props, _ := RuntimeIDToState(runtimeID)
+		name, props, _ := e.state(runtimeID)
 		blocks[index] = blockEntry{Name: name, State: props, Version: CurrentBlockVersion}
 	}
 	// Marshal the slice of block states into NBT and add it to the byte slice.
@@ -46,7 +66,7 @@ func (diskEncoding) encodePalette(buf *bytes.Buffer, p *Palette) {
 		_ = enc.Encode(b)
 	}
 }
-func (diskEncoding) decodePalette(buf *bytes.Buffer, blockSize paletteSize) (*Palette, error) {
+func (e diskEncoding) decodePalette(buf *bytes.Buffer, blockSize paletteSize) (*Palette, error) {
 	// The next 4 bytes are an LE int32, but we simply read it and decode the int32 ourselves, as it's much
 	// faster here.
 	data := buf.Next(4)
@@ -57,16 +77,16 @@ func (diskEncoding) decodePalette(buf *bytes.Buffer, blockSize paletteSize) (*Pa
 		paletteCount = binary.LittleEndian.Uint32(data)
 		palette      = newPalette(blockSize, make([]uint32, paletteCount))
 		dec          = nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian)
-		e            blockEntry
+		entry        blockEntry
 		ok           bool
 	)
 	for i := uint32(0); i < paletteCount; i++ {
-		if err := dec.Decode(&e); err != nil {
+		if err := dec.Decode(&entry); err != nil {
 			return nil, fmt.Errorf("error decoding block: %w", err)
 		}
-		palette.blockRuntimeIDs[i], ok = StateToRuntimeID(e.Name, e.State)
+		palette.blockRuntimeIDs[i], ok = e.runtimeID(entry.Name, entry.State)
 		if !ok {
-			return nil, fmt.Errorf("cannot get runtime ID of block state %v{%+v}", e.Name, e.State)
+			return nil, fmt.Errorf("cannot get runtime ID of block state %v{%+v}", entry.Name, entry.State)
 		}
 	}
 	return palette, nil
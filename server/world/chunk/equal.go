@@ -0,0 +1,109 @@
+package chunk
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"reflect"
+	"sort"
+)
+
+// Equal checks if the Chunk passed holds the same blocks, biomes and block NBT as the chunk. The way the blocks
+// are stored is not taken into account: Two chunks with different palettes, or where one chunk has an empty sub
+// chunk or layer that the other does not have at all, are equal if all their blocks are equal. Light is not
+// compared.
+func (chunk *Chunk) Equal(c *Chunk) bool {
+	return Diff(chunk, c) == ""
+}
+
+// Diff returns a description of the first difference found between the chunks a and b, or an empty string if
+// the chunks are equal as defined by Chunk.Equal. Sub chunks are compared from the bottom up, followed by the
+// biomes and the block NBT, so that the sub chunk returned is the lowest one that differs.
+func Diff(a, b *Chunk) string {
+	if a.air != b.air {
+		return fmt.Sprintf("air runtime ID %v != %v", a.air, b.air)
+	}
+	for i := range a.sub {
+		if d := diffSubChunk(a.sub[i], b.sub[i], a.air); d != "" {
+			return fmt.Sprintf("sub chunk %v (y %v-%v): %v", i, subY(int16(i)), subY(int16(i))+15, d)
+		}
+	}
+	if a.biomes != b.biomes {
+		for i := range a.biomes {
+			if a.biomes[i] != b.biomes[i] {
+				return fmt.Sprintf("biome at column (%v, %v): %v != %v", i&15, i>>4, a.biomes[i], b.biomes[i])
+			}
+		}
+	}
+	return diffBlockNBT(a.blockEntities, b.blockEntities)
+}
+
+// diffSubChunk returns a description of the first block that differs between the sub chunks a and b. Either
+// sub chunk may be nil, in which case it is treated as a sub chunk filled with air.
+func diffSubChunk(a, b *SubChunk, air uint32) string {
+	layers := 0
+	if a != nil {
+		layers = len(a.storages)
+	}
+	if b != nil && len(b.storages) > layers {
+		layers = len(b.storages)
+	}
+	for layer := 0; layer < layers; layer++ {
+		for y := byte(0); y < 16; y++ {
+			for x := byte(0); x < 16; x++ {
+				for z := byte(0); z < 16; z++ {
+					ra, rb := subRuntimeID(a, x, y, z, uint8(layer), air), subRuntimeID(b, x, y, z, uint8(layer), air)
+					if ra != rb {
+						return fmt.Sprintf("layer %v: block at (%v, %v, %v): runtime ID %v != %v", layer, x, y, z, ra, rb)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// subRuntimeID returns the runtime ID at the position and layer passed in a SubChunk, or air if the SubChunk is
+// nil.
+func subRuntimeID(sub *SubChunk, x, y, z byte, layer uint8, air uint32) uint32 {
+	if sub == nil {
+		return air
+	}
+	return sub.RuntimeID(x, y, z, layer)
+}
+
+// diffBlockNBT returns a description of the first position, sorted by Y, X and Z, at which the block NBT of a
+// and b differs.
+func diffBlockNBT(a, b map[cube.Pos]map[string]interface{}) string {
+	positions := make([]cube.Pos, 0, len(a)+len(b))
+	for pos := range a {
+		positions = append(positions, pos)
+	}
+	for pos := range b {
+		if _, ok := a[pos]; !ok {
+			positions = append(positions, pos)
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		pi, pj := positions[i], positions[j]
+		if pi[1] != pj[1] {
+			return pi[1] < pj[1]
+		}
+		if pi[0] != pj[0] {
+			return pi[0] < pj[0]
+		}
+		return pi[2] < pj[2]
+	})
+	for _, pos := range positions {
+		da, oka := a[pos]
+		db, okb := b[pos]
+		switch {
+		case !oka:
+			return fmt.Sprintf("block NBT at %v: missing in first chunk", pos)
+		case !okb:
+			return fmt.Sprintf("block NBT at %v: missing in second chunk", pos)
+		case !reflect.DeepEqual(da, db):
+			return fmt.Sprintf("block NBT at %v: %v != %v", pos, da, db)
+		}
+	}
+	return ""
+}
@@ -21,6 +21,13 @@ func newPalette(size paletteSize, runtimeIDs []uint32) *Palette {
 	return &Palette{size: size, blockRuntimeIDs: runtimeIDs, last: math.MaxUint32}
 }
 
+// clone returns a deep copy of the palette.
+func (palette *Palette) clone() *Palette {
+	c := *palette
+	c.blockRuntimeIDs = append([]uint32(nil), palette.blockRuntimeIDs...)
+	return &c
+}
+
 // Len returns the amount of unique block runtime IDs in the palette.
 func (palette *Palette) Len() int {
 	return len(palette.blockRuntimeIDs)
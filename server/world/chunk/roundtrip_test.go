@@ -0,0 +1,202 @@
+package chunk
+
+import (
+	"bytes"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"math/rand"
+	"testing"
+)
+
+// testAir is the runtime ID of air used in the round trip tests.
+const testAir = 0
+
+// testEncoding is the disk encoding used in the round trip tests. Block states are registered by the world
+// package, so it uses states that simply hold the runtime ID, which survive the disk encoding for any runtime
+// ID, without changing RuntimeIDToState and StateToRuntimeID.
+var testEncoding = diskEncoding{
+	toState: func(runtimeID uint32) (string, map[string]interface{}, bool) {
+		if runtimeID == testAir {
+			return "minecraft:air", map[string]interface{}{}, true
+		}
+		return "dragonfly:test", map[string]interface{}{"id": int32(runtimeID)}, true
+	},
+	toRuntimeID: func(name string, properties map[string]interface{}) (uint32, bool) {
+		if name == "minecraft:air" {
+			return testAir, true
+		}
+		id, ok := properties["id"].(int32)
+		return uint32(id), ok
+	},
+}
+
+// FuzzRoundTrip generates a random chunk for every seed, encodes it using both the disk and the network
+// encoding and checks if decoding the data results in the same chunk. The seeds below are checked as part of
+// go test. A longer run that tries random seeds may be started using go test -fuzz=FuzzRoundTrip, after which
+// any seed that fails is saved to testdata/fuzz to be checked in every run that follows.
+func FuzzRoundTrip(f *testing.F) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		c := randomChunk(rand.New(rand.NewSource(seed)))
+
+		compacted := c.Clone()
+		compacted.Compact()
+		if d := Diff(c, compacted); d != "" {
+			t.Fatalf("seed %v: compacted chunk differs: %v", seed, d)
+		}
+
+		dc, err := diskRoundTrip(c)
+		if err != nil {
+			t.Fatalf("seed %v: disk round trip: %v", seed, err)
+		}
+		if d := Diff(c, dc); d != "" {
+			t.Fatalf("seed %v: disk round trip: %v", seed, d)
+		}
+
+		nc, err := networkRoundTrip(c)
+		if err != nil {
+			t.Fatalf("seed %v: network round trip: %v", seed, err)
+		}
+		if d := Diff(c, nc); d != "" {
+			t.Fatalf("seed %v: network round trip: %v", seed, d)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	a, b := New(testAir), New(testAir)
+	// An empty layer is equal to a layer that does not exist.
+	b.Sub()[3] = NewSubChunk(testAir)
+	b.Sub()[3].Layer(1)
+	if d := Diff(a, b); d != "" {
+		t.Errorf("Diff() of chunks with only air = %q, want empty", d)
+	}
+
+	b.SetRuntimeID(4, 50, 6, 1, 5)
+	if d, want := Diff(a, b), "sub chunk 3 (y 48-63): layer 1: block at (4, 2, 6): runtime ID 0 != 5"; d != want {
+		t.Errorf("Diff() of chunks with different blocks = %q, want %q", d, want)
+	}
+	a.SetRuntimeID(4, 50, 6, 1, 5)
+	if !a.Equal(b) {
+		t.Errorf("chunks with the same blocks are not equal")
+	}
+
+	a.SetBiomeID(1, 2, 7)
+	if d, want := Diff(a, b), "biome at column (1, 2): 7 != 0"; d != want {
+		t.Errorf("Diff() of chunks with different biomes = %q, want %q", d, want)
+	}
+	b.SetBiomeID(1, 2, 7)
+
+	b.SetBlockNBT(cube.Pos{1, 2, 3}, map[string]interface{}{"id": "Chest"})
+	if d, want := Diff(a, b), "block NBT at [1 2 3]: missing in first chunk"; d != want {
+		t.Errorf("Diff() of chunks with different block NBT = %q, want %q", d, want)
+	}
+}
+
+// randomChunk generates a chunk with random blocks, biomes and block NBT using the rand.Rand passed. Palettes
+// of various sizes are used, so that every size of block storage is encoded.
+func randomChunk(r *rand.Rand) *Chunk {
+	c := New(testAir)
+	for i := range c.sub {
+		if r.Intn(3) == 0 {
+			// Leave some sub chunks out, including ones in between other sub chunks.
+			continue
+		}
+		layers := 1 + r.Intn(3)
+		for layer := 0; layer < layers; layer++ {
+			palette := make([]uint32, 1+r.Intn([]int{1, 2, 4, 8, 16, 32, 64, 256, 4096}[r.Intn(9)]))
+			for j := range palette {
+				if r.Intn(8) == 0 {
+					palette[j] = testAir
+					continue
+				}
+				palette[j] = uint32(1 + r.Intn(1<<20))
+			}
+			for y := int16(0); y < 16; y++ {
+				for x := uint8(0); x < 16; x++ {
+					for z := uint8(0); z < 16; z++ {
+						c.SetRuntimeID(x, subY(int16(i))+y, z, uint8(layer), palette[r.Intn(len(palette))])
+					}
+				}
+			}
+		}
+	}
+	for i := range c.biomes {
+		c.biomes[i] = uint8(r.Intn(256))
+	}
+	for i := r.Intn(16); i > 0; i-- {
+		pos := cube.Pos{r.Intn(16), cube.MinY + r.Intn(cube.MaxY-cube.MinY+1), r.Intn(16)}
+		c.SetBlockNBT(pos, map[string]interface{}{
+			"id":    []string{"Chest", "Sign", "Furnace", "Beacon"}[r.Intn(4)],
+			"x":     int32(pos[0]),
+			"y":     int32(pos[1]),
+			"z":     int32(pos[2]),
+			"byte":  uint8(r.Intn(256)),
+			"short": int16(r.Intn(1 << 16)),
+			"long":  r.Int63(),
+			"float": r.Float32(),
+			"text":  string(rune('a' + r.Intn(26))),
+			"nested": map[string]interface{}{
+				"double": r.Float64(),
+			},
+		})
+	}
+	return c
+}
+
+// diskRoundTrip encodes the chunk passed using the disk encoding and decodes it again. Block NBT is encoded
+// separately, like providers do.
+func diskRoundTrip(c *Chunk) (*Chunk, error) {
+	decoded, err := diskDecode(Encode(c, testEncoding), testEncoding)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	enc := nbt.NewEncoderWithEncoding(buf, nbt.LittleEndian)
+	for _, data := range c.blockEntities {
+		if err := enc.Encode(data); err != nil {
+			return nil, err
+		}
+	}
+	dec := nbt.NewDecoderWithEncoding(buf, nbt.LittleEndian)
+	for buf.Len() != 0 {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err != nil {
+			return nil, err
+		}
+		decoded.SetBlockNBT(cube.Pos{int(m["x"].(int32)), int(m["y"].(int32)), int(m["z"].(int32))}, m)
+	}
+	return decoded, nil
+}
+
+// networkRoundTrip encodes the chunk passed into the payload of a LevelChunk packet, in the same way sessions
+// do, and decodes it again.
+func networkRoundTrip(c *Chunk) (*Chunk, error) {
+	data := Encode(c, NetworkEncoding)
+
+	count := 0
+	for y := range data.SubChunks {
+		if data.SubChunks[y] != nil {
+			count = y + 1
+		}
+	}
+	buf := bytes.NewBuffer(nil)
+	for y := 0; y < count; y++ {
+		if data.SubChunks[y] == nil {
+			_, _ = buf.Write([]byte{SubChunkVersion, 0})
+			continue
+		}
+		_, _ = buf.Write(data.SubChunks[y])
+	}
+	_, _ = buf.Write(data.Data2D)
+
+	enc := nbt.NewEncoderWithEncoding(buf, nbt.NetworkLittleEndian)
+	for _, be := range c.blockEntities {
+		if err := enc.Encode(be); err != nil {
+			return nil, err
+		}
+	}
+	return NetworkDecode(testAir, buf.Bytes(), count)
+}
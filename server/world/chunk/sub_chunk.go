@@ -103,17 +103,36 @@ func (sub *SubChunk) SkyLightAt(x, y, z byte) uint8 {
 	return (sub.skyLight[index>>1] >> ((index & 1) << 2)) & 0xf
 }
 
+// clone returns a deep copy of the sub chunk.
+func (sub *SubChunk) clone() *SubChunk {
+	c := *sub
+	c.storages = make([]*BlockStorage, len(sub.storages))
+	for i, storage := range sub.storages {
+		c.storages[i] = storage.clone()
+	}
+	return &c
+}
+
+// size returns an estimate of the amount of bytes of memory used by the sub chunk.
+func (sub *SubChunk) size() int {
+	n := len(sub.blockLight) + len(sub.skyLight)
+	for _, storage := range sub.storages {
+		n += (len(storage.blocks) + storage.palette.Len()) * uint32ByteSize
+	}
+	return n
+}
+
 // Compact cleans the garbage from all block storages that sub chunk contains, so that they may be
-// cleanly written to a database.
+// cleanly written to a database. Layers that hold only air at the end of the sub chunk are removed. Empty
+// layers before a layer that holds blocks are kept, as removing them would move the blocks to another layer.
 func (sub *SubChunk) compact() {
-	newStorages := make([]*BlockStorage, 0, len(sub.storages))
-	for _, storage := range sub.storages {
+	n := 0
+	for i, storage := range sub.storages {
 		storage.compact()
-		if len(storage.palette.blockRuntimeIDs) == 1 && storage.palette.blockRuntimeIDs[0] == sub.air {
-			// If the palette has only air in it, it means the storage is empty, so we can ignore it.
-			continue
+		if len(storage.palette.blockRuntimeIDs) != 1 || storage.palette.blockRuntimeIDs[0] != sub.air {
+			// A palette with only air in it means the storage is empty, so only storages with other blocks count.
+			n = i + 1
 		}
-		newStorages = append(newStorages, storage)
 	}
-	sub.storages = newStorages
+	sub.storages = sub.storages[:n]
 }
@@ -0,0 +1,38 @@
+package world
+
+// SetEntityPush sets the strength with which living entities that collide with each other are pushed apart
+// every tick, in blocks per tick. The default strength is 0.05. If the strength is 0 or lower, entities are
+// not pushed apart and may pass through each other, which some games, such as parkour games, may prefer.
+func (w *World) SetEntityPush(strength float64) {
+	if w == nil {
+		return
+	}
+	w.entityPush.Store(strength)
+}
+
+// EntityPush returns the strength with which living entities that collide with each other are pushed apart
+// every tick, as set using SetEntityPush.
+func (w *World) EntityPush() float64 {
+	if w == nil {
+		return 0
+	}
+	return w.entityPush.Load()
+}
+
+// SetPushOutOfBlocks specifies if entities that are stuck in solid blocks, for example after being teleported
+// into them, are pushed out of the blocks. Entities are pushed out of blocks by default.
+func (w *World) SetPushOutOfBlocks(push bool) {
+	if w == nil {
+		return
+	}
+	w.pushOutOfBlocks.Store(push)
+}
+
+// PushOutOfBlocks checks if entities that are stuck in solid blocks are pushed out of them, as set using
+// SetPushOutOfBlocks.
+func (w *World) PushOutOfBlocks() bool {
+	if w == nil {
+		return false
+	}
+	return w.pushOutOfBlocks.Load()
+}
@@ -0,0 +1,116 @@
+package world
+
+import (
+	"fmt"
+	"go.uber.org/atomic"
+	"sort"
+	"strings"
+)
+
+// CustomBlock is a Block that is not part of vanilla Minecraft, but is added to the game by a resource pack.
+// Its identifier, as returned by EncodeBlock, must have a namespace other than 'minecraft', such as
+// 'example:ruby_block'. Like vanilla blocks, a CustomBlock may return math.MaxUint64 from its Hash method, in
+// which case it is looked up using its identifier and properties.
+type CustomBlock interface {
+	Block
+	// States returns all states of the CustomBlock, including the CustomBlock itself. Every state must
+	// encode to the same identifier, but with different properties. The properties of states may only hold
+	// values of the types uint8, int32 and string, as used by vanilla block states.
+	States() []Block
+	// Components returns the client-side component data of the CustomBlock, such as its geometry and
+	// material instances, as defined in the resource pack that adds the block. It is sent to clients as the
+	// 'components' of the block.
+	Components() map[string]interface{}
+}
+
+var (
+	// customBlocks holds all CustomBlocks registered, in the order they were registered in.
+	customBlocks []CustomBlock
+	// worldCreated is set to true once the first World is created, after which no more CustomBlocks may be
+	// registered.
+	worldCreated atomic.Bool
+)
+
+// RegisterCustomBlock registers a CustomBlock and all of its states. The client sorts all block states by
+// their name, so the runtime IDs of all states, including vanilla ones, are reassigned in that order after the
+// states are registered. Runtime IDs obtained before registering a CustomBlock should therefore not be reused.
+// In worlds, custom blocks are saved using their identifier and properties, like vanilla blocks.
+// RegisterCustomBlock must be called before any World is created, and so before a server is created. It panics
+// if it is called afterwards, if the identifier of the block is not namespaced, is in the 'minecraft'
+// namespace or collides with a block already registered, or if one of its states does not encode to the same
+// identifier.
+func RegisterCustomBlock(b CustomBlock) {
+	if worldCreated.Load() {
+		panic("custom blocks must be registered before a world is created")
+	}
+	name, _ := b.EncodeBlock()
+	namespace, _, ok := strings.Cut(name, ":")
+	if !ok || namespace == "" {
+		panic(fmt.Sprintf("custom block identifier %v must have a namespace", name))
+	} else if namespace == "minecraft" {
+		panic(fmt.Sprintf("custom block identifier %v collides with vanilla blocks: it may not be in the minecraft namespace", name))
+	}
+	for _, s := range blocks {
+		if n, _ := s.EncodeBlock(); n == name {
+			panic(fmt.Sprintf("custom block identifier %v is already registered", name))
+		}
+	}
+	states := b.States()
+	for _, s := range states {
+		if n, _ := s.EncodeBlock(); n != name {
+			panic(fmt.Sprintf("state %#v of custom block %v has a different identifier %v", s, name, n))
+		}
+	}
+	for _, s := range states {
+		_, properties := s.EncodeBlock()
+		registerBlockState(blockState{Name: name, Properties: properties, Version: blockVersion})
+		RegisterBlock(s)
+	}
+	sortBlockStates()
+	customBlocks = append(customBlocks, b)
+}
+
+// CustomBlocks returns all CustomBlocks registered using RegisterCustomBlock, in the order they were
+// registered in.
+func CustomBlocks() []CustomBlock {
+	return append([]CustomBlock(nil), customBlocks...)
+}
+
+// CustomBlockProperties returns the properties of the CustomBlock passed as they are sent to clients: Its
+// component data and, for every property of its states, the name of the property with all values it may
+// have.
+func CustomBlockProperties(b CustomBlock) map[string]interface{} {
+	values := map[string][]interface{}{}
+	for _, s := range b.States() {
+		_, properties := s.EncodeBlock()
+		for k, v := range properties {
+			if !containsValue(values[k], v) {
+				values[k] = append(values[k], v)
+			}
+		}
+	}
+	names := make([]string, 0, len(values))
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	properties := make([]interface{}, 0, len(names))
+	for _, k := range names {
+		properties = append(properties, map[string]interface{}{"name": k, "enum": values[k]})
+	}
+	return map[string]interface{}{
+		"components": b.Components(),
+		"properties": properties,
+	}
+}
+
+// containsValue checks if the slice of property values passed contains v.
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, val := range values {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
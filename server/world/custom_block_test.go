@@ -0,0 +1,49 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"strings"
+	"testing"
+)
+
+func init() {
+	// Custom blocks must be registered before any world is created.
+	world.RegisterCustomBlock(amberBlock{})
+}
+
+func TestCustomBlockRuntimeIDs(t *testing.T) {
+	// The client sorts the block palette by name, so the custom block is placed before all vanilla blocks.
+	if rid, _ := world.BlockRuntimeID(amberBlock{}); rid != 0 {
+		t.Errorf("expected custom block to have runtime ID 0, got %v", rid)
+	}
+	var prev string
+	for rid := uint32(0); ; rid++ {
+		name, _, ok := chunk.RuntimeIDToState(rid)
+		if !ok {
+			break
+		}
+		if name = strings.ToLower(name); name < prev {
+			t.Fatalf("block state %v with runtime ID %v is not sorted after %v", name, rid, prev)
+		}
+		prev = name
+	}
+	for _, b := range []world.Block{block.Stone{}, block.Air{}, amberBlock{}} {
+		rid, _ := world.BlockRuntimeID(b)
+		if got, ok := world.BlockByRuntimeID(rid); !ok || got != b {
+			t.Errorf("expected runtime ID %v to map back to %#v, got %#v", rid, b, got)
+		}
+	}
+}
+
+// amberBlock is a custom block used to test custom block registration.
+type amberBlock struct{}
+
+func (amberBlock) EncodeBlock() (string, map[string]interface{}) { return "a:amber_block", nil }
+func (amberBlock) Hash() uint64                                  { return math.MaxUint64 }
+func (amberBlock) Model() world.BlockModel                       { return model.Solid{} }
+func (amberBlock) States() []world.Block                         { return []world.Block{amberBlock{}} }
+func (amberBlock) Components() map[string]interface{}            { return map[string]interface{}{} }
@@ -1,5 +1,7 @@
 package world
 
+import "strings"
+
 // Difficulty represents the difficulty of a Minecraft world. The difficulty of a world influences all kinds
 // of aspects of the world, such as the damage enemies deal to players, the way hunger depletes, whether
 // hostile monsters spawn or not and more.
@@ -88,3 +90,19 @@ func (DifficultyHard) StarvationHealthLimit() float64 {
 func (DifficultyHard) FireSpreadIncrease() int {
 	return 21
 }
+
+// DifficultyByName attempts to return a Difficulty by its name. The name is case-insensitive and may be one of
+// 'peaceful', 'easy', 'normal' or 'hard'. If no Difficulty with the name exists, false is returned.
+func DifficultyByName(name string) (Difficulty, bool) {
+	switch strings.ToLower(name) {
+	case "peaceful":
+		return DifficultyPeaceful{}, true
+	case "easy":
+		return DifficultyEasy{}, true
+	case "normal":
+		return DifficultyNormal{}, true
+	case "hard":
+		return DifficultyHard{}, true
+	}
+	return nil, false
+}
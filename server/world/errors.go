@@ -0,0 +1,34 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrChunkNotFound is returned by a Provider if no chunk was stored at the position requested. The World
+	// generates a new chunk at the position when this error is returned.
+	ErrChunkNotFound = errors.New("chunk not found")
+	// ErrProviderClosed is returned by a Provider if one of its methods is called after it was closed.
+	ErrProviderClosed = errors.New("provider closed")
+)
+
+// ErrWorldCorrupt is returned by a Provider if the data stored for a chunk, such as its blocks, entities or
+// block NBT, exists but could not be decoded. It may be checked for using errors.As, after which the data of
+// the chunk may, for example, be regenerated or restored from a backup.
+type ErrWorldCorrupt struct {
+	// Pos is the position of the chunk of which the data is corrupt.
+	Pos ChunkPos
+	// Cause is the error that was encountered decoding the data.
+	Cause error
+}
+
+// Error ...
+func (err ErrWorldCorrupt) Error() string {
+	return fmt.Sprintf("corrupt data in chunk %v: %v", err.Pos, err.Cause)
+}
+
+// Unwrap returns the Cause of the error.
+func (err ErrWorldCorrupt) Unwrap() error {
+	return err.Cause
+}
@@ -0,0 +1,181 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand"
+)
+
+// Feature is a decoration placed in newly generated chunks after the Generator of a World generated their
+// terrain, such as an ore vein, a tree or a lake. Features are added to a World using World.AddFeature.
+type Feature interface {
+	// Place places the feature in the Region passed. The Region spans the chunk that the feature is placed for
+	// and the eight chunks surrounding it, so that features may cross the border of the chunk. The rand.Rand
+	// passed is seeded using the seed of the World, the position of the chunk and the index of the feature, so
+	// that a feature is placed in the same way every time the chunk is generated.
+	// Place must not use any other source of randomness and must not use the World itself.
+	Place(r *Region, rand *rand.Rand)
+}
+
+// Region is the area of a World that a Feature is placed in. It spans the chunk that the Feature is placed for
+// and the eight chunks surrounding it.
+// Blocks read from a Region are always those generated by the Generator of the World, without any features
+// placed: The chunks surrounding a chunk might not be generated yet when it is decorated. Placing features in
+// this way means that a feature crossing the border of a chunk is placed completely, regardless of the order
+// in which the chunks it crosses are generated, as long as the Generator itself always generates the same
+// terrain.
+type Region struct {
+	pos ChunkPos
+	d   *decoration
+}
+
+// Chunk returns the position of the chunk that the Feature is placed for. It is the chunk at the centre of the
+// Region.
+func (r *Region) Chunk() ChunkPos {
+	return r.pos
+}
+
+// Bounds returns the lowest and highest block positions within the Region.
+func (r *Region) Bounds() (min, max cube.Pos) {
+	min = cube.Pos{int(r.pos[0]-1) << 4, cube.MinY, int(r.pos[1]-1) << 4}
+	max = cube.Pos{int(r.pos[0]+2)<<4 - 1, cube.MaxY, int(r.pos[1]+2)<<4 - 1}
+	return min, max
+}
+
+// Contains checks if the block position passed is within the Region.
+func (r *Region) Contains(pos cube.Pos) bool {
+	min, max := r.Bounds()
+	return pos[0] >= min[0] && pos[0] <= max[0] && pos[1] >= min[1] && pos[1] <= max[1] && pos[2] >= min[2] && pos[2] <= max[2]
+}
+
+// Block returns the block at the position passed, as generated by the Generator of the World. Air is returned
+// if the position is not within the Region.
+func (r *Region) Block(pos cube.Pos) Block {
+	if !r.Contains(pos) {
+		return air()
+	}
+	c := r.d.terrain(ChunkPosFromBlockPos(pos))
+	b, _ := BlockByRuntimeID(c.RuntimeID(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0))
+	return b
+}
+
+// HighestBlock returns the Y value of the highest non-air block at the x and z passed, as generated by the
+// Generator of the World. cube.MinY is returned if the column is empty or not within the Region.
+func (r *Region) HighestBlock(x, z int) int {
+	if !r.Contains(cube.Pos{x, cube.MinY, z}) {
+		return cube.MinY
+	}
+	c := r.d.terrain(ChunkPosFromBlockPos(cube.Pos{x, 0, z}))
+	return int(c.HighestBlock(uint8(x), uint8(z)))
+}
+
+// SetBlock sets the block at the position passed. Positions outside the Region are ignored.
+func (r *Region) SetBlock(pos cube.Pos, b Block) {
+	if !r.Contains(pos) || ChunkPosFromBlockPos(pos) != r.d.pos {
+		// Blocks in other chunks than the one decorated are placed when those chunks are decorated.
+		return
+	}
+	r.d.writes = append(r.d.writes, featureWrite{pos: pos, b: b})
+}
+
+// MarkStructure records that a structure with the name passed, such as 'village', was placed at the position
+// passed, so that it may be found using World.LocateStructure without generating chunks. Like SetBlock, only
+// positions in the chunk decorated are recorded: Features should mark structures in the chunk returned by
+// Chunk, which ensures every structure is recorded exactly once.
+func (r *Region) MarkStructure(name string, pos cube.Pos) {
+	if !r.Contains(pos) || ChunkPosFromBlockPos(pos) != r.d.pos {
+		return
+	}
+	r.d.structures = append(r.d.structures, StructureLocation{Name: name, Pos: pos})
+}
+
+// AddFeature adds a Feature to the World. Features are placed in the order that they are added, in every chunk
+// generated after they are added. Chunks that were generated before are not changed.
+// AddFeature should be called before the World is used, because a Feature added later on might be placed
+// in only a part of the chunks that it crosses.
+func (w *World) AddFeature(f Feature) {
+	if w == nil {
+		return
+	}
+	w.genMu.Lock()
+	defer w.genMu.Unlock()
+	w.features = append(w.features, f)
+}
+
+// decoration holds the state of the decoration of a single newly generated chunk.
+type decoration struct {
+	pos ChunkPos
+	gen Generator
+	// chunks holds the terrain of the chunks read by features, generated when they are first read.
+	chunks map[ChunkPos]*chunk.Chunk
+	// writes holds the blocks set by features in the chunk decorated. They are only set in the chunk once all
+	// features are placed, so that features read only the terrain of the chunk.
+	writes []featureWrite
+	// structures holds the structures marked by features in the chunk decorated.
+	structures []StructureLocation
+}
+
+// featureWrite is a block set in a Region by a Feature.
+type featureWrite struct {
+	pos cube.Pos
+	b   Block
+}
+
+// decorate places the features of the World in the chunk passed, which was just generated. Features are placed
+// for the chunk itself and for the chunks surrounding it, as features placed for those chunks may cross into
+// the chunk.
+func (w *World) decorate(pos ChunkPos, c *chunkData) {
+	w.genMu.RLock()
+	gen, features := w.gen, w.features
+	w.genMu.RUnlock()
+	if len(features) == 0 {
+		return
+	}
+	seed := w.Seed()
+
+	d := &decoration{pos: pos, gen: gen, chunks: map[ChunkPos]*chunk.Chunk{pos: c.Chunk}}
+	for i, f := range features {
+		for x := int32(-1); x <= 1; x++ {
+			for z := int32(-1); z <= 1; z++ {
+				centre := ChunkPos{pos[0] + x, pos[1] + z}
+				f.Place(&Region{pos: centre, d: d}, rand.New(rand.NewSource(featureSeed(seed, centre, i))))
+			}
+		}
+	}
+	if len(d.structures) > 0 {
+		w.mu.Lock()
+		w.set.Structures = append(w.set.Structures, d.structures...)
+		w.mu.Unlock()
+	}
+	for _, write := range d.writes {
+		rid, ok := BlockRuntimeID(write.b)
+		if !ok {
+			w.log.Errorf("runtime ID of block %+v placed by feature not found", write.b)
+			continue
+		}
+		c.SetRuntimeID(uint8(write.pos[0]), int16(write.pos[1]), uint8(write.pos[2]), 0, rid)
+		if nbtBlocks[rid] {
+			c.e[write.pos] = write.b
+		} else {
+			delete(c.e, write.pos)
+		}
+	}
+}
+
+// terrain returns the terrain of the chunk at the position passed, generating it if it was not yet generated
+// during the decoration.
+func (d *decoration) terrain(pos ChunkPos) *chunk.Chunk {
+	c, ok := d.chunks[pos]
+	if !ok {
+		c = chunk.New(airRID)
+		d.gen.GenerateChunk(pos, c)
+		d.chunks[pos] = c
+	}
+	return c
+}
+
+// featureSeed returns the seed of the rand.Rand passed to the Feature with the index passed when it is placed
+// for the chunk at the position passed.
+func featureSeed(seed int64, pos ChunkPos, index int) int64 {
+	return seed ^ int64(pos[0])*341873128712 ^ int64(pos[1])*132897987541 ^ int64(index+1)*6364136223846793005
+}
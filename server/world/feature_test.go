@@ -0,0 +1,80 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"testing"
+)
+
+// wallFeature is a world.Feature that places a wall of stone crossing the border of chunk {0, 0}, but only on
+// top of grass.
+type wallFeature struct{}
+
+func (wallFeature) Place(r *world.Region, _ *rand.Rand) {
+	if r.Chunk() != (world.ChunkPos{}) {
+		return
+	}
+	for x := 8; x < 24; x++ {
+		pos := cube.Pos{x, 3, 0}
+		if _, ok := r.Block(pos).(block.Grass); ok {
+			r.SetBlock(pos.Side(cube.FaceUp), block.Stone{})
+		}
+	}
+}
+
+func TestFeatureCrossesChunkBorder(t *testing.T) {
+	for _, order := range [][]int{{8, 20}, {20, 8}} {
+		w := world.New(logrus.New(), 0)
+		w.Generator(generator.Flat{})
+		w.AddFeature(wallFeature{})
+
+		for _, x := range order {
+			_ = w.Block(cube.Pos{x, 4, 0})
+		}
+		for x := 8; x < 24; x++ {
+			if _, ok := w.Block(cube.Pos{x, 4, 0}).(block.Stone); !ok {
+				t.Errorf("order %v: expected stone at x=%v, got %T", order, x, w.Block(cube.Pos{x, 4, 0}))
+			}
+		}
+		_ = w.Close()
+	}
+}
+
+func TestFeatureDeterministic(t *testing.T) {
+	newWorld := func() *world.World {
+		w := world.New(logrus.New(), 0)
+		w.Generator(generator.Flat{})
+		w.AddFeature(generator.OakTree{Count: 3})
+		return w
+	}
+	a, b := newWorld(), newWorld()
+	defer a.Close()
+	defer b.Close()
+
+	// Load the chunks of the worlds in opposite orders: The trees placed should be the same regardless.
+	for x := -16; x < 32; x += 16 {
+		_ = a.Block(cube.Pos{x, 0, 0})
+		_ = b.Block(cube.Pos{16 - x, 0, 0})
+	}
+	logs := 0
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := 4; y < 12; y++ {
+				pos := cube.Pos{x, y, z}
+				if a.Block(pos) != b.Block(pos) {
+					t.Fatalf("block at %v differs: %#v and %#v", pos, a.Block(pos), b.Block(pos))
+				}
+				if _, ok := a.Block(pos).(block.Log); ok {
+					logs++
+				}
+			}
+		}
+	}
+	if logs == 0 {
+		t.Errorf("expected trees to be grown in chunk {0, 0}")
+	}
+}
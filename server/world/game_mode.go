@@ -1,5 +1,7 @@
 package world
 
+import "strings"
+
 // GameMode represents a game mode that may be assigned to a player. Upon joining the world, players will be
 // given the default game mode that the world holds.
 // Game modes specify the way that a player interacts with and plays in the world.
@@ -178,3 +180,20 @@ func (GameModeSpectator) AllowsInteraction() bool {
 func (GameModeSpectator) Visible() bool {
 	return false
 }
+
+// GameModeByName attempts to return a GameMode by its name. The name is case-insensitive and may be one of
+// 'survival', 'creative', 'adventure' or 'spectator'. If no GameMode with the name exists, false is
+// returned.
+func GameModeByName(name string) (GameMode, bool) {
+	switch strings.ToLower(name) {
+	case "survival":
+		return GameModeSurvival{}, true
+	case "creative":
+		return GameModeCreative{}, true
+	case "adventure":
+		return GameModeAdventure{}, true
+	case "spectator":
+		return GameModeSpectator{}, true
+	}
+	return nil, false
+}
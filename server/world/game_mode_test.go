@@ -0,0 +1,24 @@
+package world
+
+import "testing"
+
+func TestGameModeByName(t *testing.T) {
+	tests := []struct {
+		name string
+		mode GameMode
+		ok   bool
+	}{
+		{name: "survival", mode: GameModeSurvival{}, ok: true},
+		{name: "Creative", mode: GameModeCreative{}, ok: true},
+		{name: "ADVENTURE", mode: GameModeAdventure{}, ok: true},
+		{name: "spectator", mode: GameModeSpectator{}, ok: true},
+		{name: "hardcore"},
+		{name: ""},
+	}
+	for _, test := range tests {
+		mode, ok := GameModeByName(test.name)
+		if ok != test.ok || mode != test.mode {
+			t.Errorf("GameModeByName(%q) = (%T, %v), want (%T, %v)", test.name, mode, ok, test.mode, test.ok)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package world
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Names of the game rules most commonly changed. All game rules supported are listed in GameRuleNames.
+const (
+	// GameRuleShowCoordinates specifies if players see their coordinates in the top left of their screen.
+	GameRuleShowCoordinates = "showcoordinates"
+	// GameRuleDaylightCycle specifies if the time of the world advances. It is the same as the time cycle
+	// changed using World.StartTime and World.StopTime.
+	GameRuleDaylightCycle = "dodaylightcycle"
+	// GameRuleWeatherCycle specifies if the weather of the world changes naturally. It is the same as the
+	// weather cycle changed using World.SetWeatherCycle.
+	GameRuleWeatherCycle = "doweathercycle"
+	// GameRuleKeepInventory specifies if players keep their inventory when they die. It is the same as the
+	// setting changed using World.SetKeepInventory.
+	GameRuleKeepInventory = "keepinventory"
+	// GameRuleImmediateRespawn specifies if players respawn immediately when they die, without seeing the
+	// death screen. Clients that receive the game rule request to respawn as soon as they die.
+	GameRuleImmediateRespawn = "doimmediaterespawn"
+	// GameRuleShowDeathMessages specifies if a message is shown in the chat when a player dies.
+	GameRuleShowDeathMessages = "showdeathmessages"
+	// GameRulePVP specifies if players may damage each other by attacking.
+	GameRulePVP = "pvp"
+	// GameRuleFallDamage specifies if players take damage from falling.
+	GameRuleFallDamage = "falldamage"
+	// GameRuleDrowningDamage specifies if players take damage from running out of air under water.
+	GameRuleDrowningDamage = "drowningdamage"
+	// GameRuleFireDamage specifies if players take damage from fire and lava and from being on fire.
+	GameRuleFireDamage = "firedamage"
+)
+
+// gameRuleDefaults holds the default values of all game rules supported, indexed by their name. Game rules
+// are either a bool or an int.
+var gameRuleDefaults = map[string]interface{}{
+	"commandblockoutput":    true,
+	"commandblocksenabled":  true,
+	"dodaylightcycle":       true,
+	"doentitydrops":         true,
+	"dofiretick":            true,
+	"doimmediaterespawn":    false,
+	"doinsomnia":            true,
+	"domobloot":             true,
+	"domobspawning":         true,
+	"dotiledrops":           true,
+	"doweathercycle":        true,
+	"drowningdamage":        true,
+	"falldamage":            true,
+	"firedamage":            true,
+	"freezedamage":          true,
+	"functioncommandlimit":  10000,
+	"keepinventory":         false,
+	"maxcommandchainlength": 65536,
+	"mobgriefing":           true,
+	"pvp":                   true,
+	"respawnblocksexplode":  true,
+	"sendcommandfeedback":   true,
+	"showcoordinates":       true,
+	"showdeathmessages":     true,
+	"showtags":              true,
+	"spawnradius":           5,
+	"tntexplodes":           true,
+}
+
+// GameRuleNames returns the names of all game rules that may be changed using World.SetGameRule, sorted
+// alphabetically.
+func GameRuleNames() []string {
+	names := make([]string, 0, len(gameRuleDefaults))
+	for name := range gameRuleDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetGameRule sets the game rule with the name passed to a value, which must be a bool or an int depending on
+// the game rule. Names are not case-sensitive. The new value is sent to all viewers of the World and saved
+// with the World. An error is returned if no game rule with the name exists or if the value has the wrong
+// type.
+func (w *World) SetGameRule(name string, value interface{}) error {
+	if w == nil {
+		return nil
+	}
+	name = strings.ToLower(name)
+	def, ok := gameRuleDefaults[name]
+	if !ok {
+		return fmt.Errorf("set game rule: unknown game rule %v", name)
+	}
+	v, ok := gameRuleValue(def, value)
+	if !ok {
+		return fmt.Errorf("set game rule: invalid value %v (%T) for game rule %v of type %T", value, value, name, def)
+	}
+
+	w.mu.Lock()
+	switch name {
+	case GameRuleDaylightCycle:
+		w.set.TimeCycle = v.(bool)
+	case GameRuleWeatherCycle:
+		w.set.WeatherCycle = v.(bool)
+	case GameRuleKeepInventory:
+		w.set.KeepInventory = v.(bool)
+	default:
+		if w.set.GameRules == nil {
+			w.set.GameRules = map[string]interface{}{}
+		}
+		w.set.GameRules[name] = v
+	}
+	w.mu.Unlock()
+	w.viewGameRules(map[string]interface{}{name: v})
+	return nil
+}
+
+// GameRule returns the value of the game rule with the name passed, which is either a bool or an int. Names
+// are not case-sensitive. An error is returned if no game rule with the name exists.
+func (w *World) GameRule(name string) (interface{}, error) {
+	name = strings.ToLower(name)
+	if _, ok := gameRuleDefaults[name]; !ok {
+		return nil, fmt.Errorf("game rule: unknown game rule %v", name)
+	}
+	return w.GameRules()[name], nil
+}
+
+// GameRules returns the values of all game rules of the World, indexed by their name.
+func (w *World) GameRules() map[string]interface{} {
+	rules := make(map[string]interface{}, len(gameRuleDefaults))
+	for name, def := range gameRuleDefaults {
+		rules[name] = def
+	}
+	if w == nil {
+		return rules
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for name, v := range w.set.GameRules {
+		// Game rules loaded from a provider may be stored in a different type, such as a uint8 for bools, so
+		// they are converted back to the type of the default value.
+		if def, ok := gameRuleDefaults[name]; ok {
+			if v, ok := gameRuleValue(def, v); ok {
+				rules[name] = v
+			}
+		}
+	}
+	rules[GameRuleDaylightCycle] = w.set.TimeCycle
+	rules[GameRuleWeatherCycle] = w.set.WeatherCycle
+	rules[GameRuleKeepInventory] = w.set.KeepInventory
+	return rules
+}
+
+// ShowCoordinates checks if players in the World see their coordinates.
+func (w *World) ShowCoordinates() bool {
+	v, _ := w.GameRule(GameRuleShowCoordinates)
+	return v.(bool)
+}
+
+// SetShowCoordinates changes if players in the World see their coordinates. Coordinates are shown by
+// default.
+func (w *World) SetShowCoordinates(show bool) {
+	_ = w.SetGameRule(GameRuleShowCoordinates, show)
+}
+
+// viewGameRules shows the game rules passed to all viewers of the World.
+func (w *World) viewGameRules(rules map[string]interface{}) {
+	for _, viewer := range w.allViewers() {
+		viewer.ViewGameRules(rules)
+	}
+}
+
+// gameRuleValue converts the value passed to the type of the default value of a game rule passed. False is
+// returned if the value cannot be converted.
+func gameRuleValue(def, v interface{}) (interface{}, bool) {
+	switch def.(type) {
+	case bool:
+		switch v := v.(type) {
+		case bool:
+			return v, true
+		case uint8:
+			return v != 0, true
+		}
+	case int:
+		switch v := v.(type) {
+		case int:
+			return v, true
+		case int32:
+			return int(v), true
+		case int64:
+			return int(v), true
+		case uint32:
+			return int(v), true
+		}
+	}
+	return nil, false
+}
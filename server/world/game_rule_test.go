@@ -0,0 +1,41 @@
+package world
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestGameRules(t *testing.T) {
+	w := New(logrus.New(), 8)
+	defer w.Close()
+
+	if !w.ShowCoordinates() {
+		t.Errorf("expected coordinates to be shown by default")
+	}
+	if err := w.SetGameRule("doesnotexist", true); err == nil {
+		t.Errorf("expected error setting unknown game rule")
+	}
+	if err := w.SetGameRule(GameRuleShowCoordinates, 1); err == nil {
+		t.Errorf("expected error setting bool game rule to int")
+	}
+	if err := w.SetGameRule("SpawnRadius", 10); err != nil {
+		t.Fatalf("error setting game rule: %v", err)
+	}
+	if v, _ := w.GameRule("spawnradius"); v != 10 {
+		t.Errorf("expected spawn radius 10, got %v", v)
+	}
+	if err := w.SetGameRule(GameRuleDaylightCycle, false); err != nil {
+		t.Fatalf("error setting game rule: %v", err)
+	}
+	if w.set.TimeCycle {
+		t.Errorf("expected time cycle to be disabled with daylight cycle game rule")
+	}
+
+	// Game rules loaded from a provider may be stored in a different type.
+	w.mu.Lock()
+	w.set.GameRules[GameRuleShowDeathMessages] = uint8(0)
+	w.mu.Unlock()
+	if v, _ := w.GameRule(GameRuleShowDeathMessages); v != false {
+		t.Errorf("expected loaded game rule to be converted to false, got %#v", v)
+	}
+}
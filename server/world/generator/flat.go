@@ -8,22 +8,30 @@ import (
 
 // Flat is the flat generator of World. It generates flat worlds (like those in vanilla) with no other
 // decoration.
-type Flat struct{}
+type Flat struct {
+	// Layers holds the blocks that the layers of the world consist of, from the bottom up. If empty, a layer
+	// of bedrock, two layers of dirt and a layer of grass are generated.
+	Layers []world.Block
+}
 
-var (
-	grass, _   = world.BlockRuntimeID(block.Grass{})
-	dirt, _    = world.BlockRuntimeID(block.Dirt{})
-	bedrock, _ = world.BlockRuntimeID(block.Bedrock{})
-)
+// defaultLayers are the layers generated by a Flat generator without Layers set.
+var defaultLayers = []world.Block{block.Bedrock{}, block.Dirt{}, block.Dirt{}, block.Grass{}}
 
 // GenerateChunk ...
-func (Flat) GenerateChunk(_ world.ChunkPos, chunk *chunk.Chunk) {
+func (f Flat) GenerateChunk(_ world.ChunkPos, chunk *chunk.Chunk) {
+	layers := f.Layers
+	if len(layers) == 0 {
+		layers = defaultLayers
+	}
+	rids := make([]uint32, len(layers))
+	for i, b := range layers {
+		rids[i], _ = world.BlockRuntimeID(b)
+	}
 	for x := uint8(0); x < 16; x++ {
 		for z := uint8(0); z < 16; z++ {
-			chunk.SetRuntimeID(x, 0, z, 0, bedrock)
-			chunk.SetRuntimeID(x, 1, z, 0, dirt)
-			chunk.SetRuntimeID(x, 2, z, 0, dirt)
-			chunk.SetRuntimeID(x, 3, z, 0, grass)
+			for y, rid := range rids {
+				chunk.SetRuntimeID(x, int16(y), z, 0, rid)
+			}
 		}
 	}
 }
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// OakTree is a world.Feature that grows oak trees on grass. Trees near the border of a chunk have their leaves
+// placed in the neighbouring chunks too.
+type OakTree struct {
+	// Count is the amount of trees attempted to grow per chunk. A tree only grows if the highest block at the
+	// position picked is grass.
+	Count int
+}
+
+// Place ...
+func (o OakTree) Place(r *world.Region, rand *rand.Rand) {
+	base := r.Chunk()
+	for i := 0; i < o.Count; i++ {
+		x, z := int(base[0])<<4+rand.Intn(16), int(base[1])<<4+rand.Intn(16)
+		ground := cube.Pos{x, r.HighestBlock(x, z), z}
+		height := 4 + rand.Intn(3)
+		if _, ok := r.Block(ground).(block.Grass); !ok || ground[1]+height+1 > cube.MaxY {
+			continue
+		}
+		o.grow(r, ground, height, rand)
+	}
+}
+
+// grow grows a tree with the height passed on top of the ground position passed.
+func (o OakTree) grow(r *world.Region, ground cube.Pos, height int, rand *rand.Rand) {
+	leaves := block.Leaves{Wood: block.OakWood()}
+	top := ground[1] + height
+	for y := top - 3; y <= top+1; y++ {
+		radius := 2
+		if y >= top {
+			radius = 1
+		}
+		for x := -radius; x <= radius; x++ {
+			for z := -radius; z <= radius; z++ {
+				corner := abs(x) == radius && abs(z) == radius
+				if corner && (y == top+1 || rand.Intn(2) == 0) {
+					continue
+				}
+				pos := cube.Pos{ground[0] + x, y, ground[2] + z}
+				if _, ok := r.Block(pos).(block.Air); ok {
+					r.SetBlock(pos, leaves)
+				}
+			}
+		}
+	}
+	// The trunk is placed after the leaves so that it replaces the leaves placed in its place.
+	for y := ground[1] + 1; y <= top; y++ {
+		r.SetBlock(cube.Pos{ground[0], y, ground[2]}, block.Log{Wood: block.OakWood(), Axis: cube.Y})
+	}
+	r.SetBlock(ground, block.Dirt{})
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// OreVein is a world.Feature that places veins of ore underground. Veins are placed by walking randomly
+// from a position in the chunk, replacing every block on the way that may be replaced by the ore.
+type OreVein struct {
+	// Ore is the block placed in the veins, such as block.CoalOre{}.
+	Ore world.Block
+	// Replaces returns true if the ore may replace the block passed. If nil, the ore only replaces stone.
+	Replaces func(b world.Block) bool
+	// Size is the maximum amount of blocks in a single vein.
+	Size int
+	// Count is the amount of veins placed per chunk.
+	Count int
+	// MinY and MaxY are the lowest and highest Y values that veins start at.
+	MinY, MaxY int
+}
+
+// Place ...
+func (o OreVein) Place(r *world.Region, rand *rand.Rand) {
+	base := r.Chunk()
+	for i := 0; i < o.Count; i++ {
+		pos := cube.Pos{int(base[0])<<4 + rand.Intn(16), o.MinY + rand.Intn(o.MaxY-o.MinY+1), int(base[1])<<4 + rand.Intn(16)}
+		for j := 0; j < o.Size; j++ {
+			if o.replaces(r.Block(pos)) {
+				r.SetBlock(pos, o.Ore)
+			}
+			pos = pos.Add(cube.Pos{rand.Intn(3) - 1, rand.Intn(3) - 1, rand.Intn(3) - 1})
+		}
+	}
+}
+
+// replaces checks if the ore of the vein may replace the block passed.
+func (o OreVein) replaces(b world.Block) bool {
+	if o.Replaces != nil {
+		return o.Replaces(b)
+	}
+	_, ok := b.(block.Stone)
+	return ok
+}
@@ -3,6 +3,8 @@ package world
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/event"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
 )
 
 // Handler handles events that are called by a world. Implementations of Handler may be used to listen to
@@ -15,6 +17,23 @@ type Handler interface {
 	// liquidHardened, and the liquid that caused it to harden, otherLiquid, are passed. The block created
 	// as a result is also passed.
 	HandleLiquidHarden(ctx *event.Context, hardenedPos cube.Pos, liquidHardened, otherLiquid, newBlock Block)
+	// HandleCropTrample handles an entity trampling the farmland at the position passed by landing on it,
+	// turning it into dirt. Cancelling the event protects the farmland.
+	HandleCropTrample(ctx *event.Context, pos cube.Pos, e Entity)
+	// HandleExplosion handles an explosion at the position passed. The entities that will be hurt and knocked
+	// back by the explosion and the positions of the blocks that will be destroyed are passed and may be
+	// changed, for example to protect blocks in a specific area. The chance of destroyed blocks dropping as an
+	// item and whether fire is spawned may also be changed. Cancelling the event prevents the explosion
+	// altogether.
+	HandleExplosion(ctx *event.Context, position mgl64.Vec3, entities *[]Entity, blocks *[]cube.Pos, itemDropChance *float64, spawnFire *bool)
+	// HandleSaveStart handles the start of a save of the world, either an automatic save or one started using
+	// World.Save. chunks is the amount of chunks loaded in the world and players the amount of players
+	// viewing the world.
+	HandleSaveStart(chunks, players int)
+	// HandleSaveComplete handles the completion of a save of the world. chunks is the amount of chunks that
+	// were changed and thus written to the provider, players the amount of players viewing the world when
+	// the save started and dur the time that the save took.
+	HandleSaveComplete(chunks, players int, dur time.Duration)
 }
 
 // NopHandler implements the Handler interface but does not execute any code when an event is called. The
@@ -30,3 +49,66 @@ func (NopHandler) HandleLiquidFlow(*event.Context, cube.Pos, cube.Pos, Block, Bl
 
 // HandleLiquidHarden ...
 func (NopHandler) HandleLiquidHarden(*event.Context, cube.Pos, Block, Block, Block) {}
+
+// HandleCropTrample ...
+func (NopHandler) HandleCropTrample(*event.Context, cube.Pos, Entity) {}
+
+// HandleExplosion ...
+func (NopHandler) HandleExplosion(*event.Context, mgl64.Vec3, *[]Entity, *[]cube.Pos, *float64, *bool) {
+}
+
+// HandleSaveStart ...
+func (NopHandler) HandleSaveStart(int, int) {}
+
+// HandleSaveComplete ...
+func (NopHandler) HandleSaveComplete(int, int, time.Duration) {}
+
+// handlerList is a list of handlers ordered by priority. It implements Handler by calling the respective
+// method of every handler in the list with the same event.Context, so that a cancellation by one handler is
+// visible to the handlers called after it.
+type handlerList []Handler
+
+// Compile time check to make sure handlerList implements Handler.
+var _ Handler = handlerList(nil)
+
+// HandleLiquidFlow ...
+func (l handlerList) HandleLiquidFlow(ctx *event.Context, from, into cube.Pos, liquid, replaced Block) {
+	for _, h := range l {
+		h.HandleLiquidFlow(ctx, from, into, liquid, replaced)
+	}
+}
+
+// HandleLiquidHarden ...
+func (l handlerList) HandleLiquidHarden(ctx *event.Context, hardenedPos cube.Pos, liquidHardened, otherLiquid, newBlock Block) {
+	for _, h := range l {
+		h.HandleLiquidHarden(ctx, hardenedPos, liquidHardened, otherLiquid, newBlock)
+	}
+}
+
+// HandleCropTrample ...
+func (l handlerList) HandleCropTrample(ctx *event.Context, pos cube.Pos, e Entity) {
+	for _, h := range l {
+		h.HandleCropTrample(ctx, pos, e)
+	}
+}
+
+// HandleExplosion ...
+func (l handlerList) HandleExplosion(ctx *event.Context, position mgl64.Vec3, entities *[]Entity, blocks *[]cube.Pos, itemDropChance *float64, spawnFire *bool) {
+	for _, h := range l {
+		h.HandleExplosion(ctx, position, entities, blocks, itemDropChance, spawnFire)
+	}
+}
+
+// HandleSaveStart ...
+func (l handlerList) HandleSaveStart(chunks, players int) {
+	for _, h := range l {
+		h.HandleSaveStart(chunks, players)
+	}
+}
+
+// HandleSaveComplete ...
+func (l handlerList) HandleSaveComplete(chunks, players int, dur time.Duration) {
+	for _, h := range l {
+		h.HandleSaveComplete(chunks, players, dur)
+	}
+}
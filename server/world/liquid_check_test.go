@@ -0,0 +1,37 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestCachedLiquidCheck(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+
+	// The area around the position spans the corners of four chunks.
+	pos, calls := cube.Pos{15, 0, 15}, 0
+	check := func() bool {
+		calls++
+		_, ok := w.Liquid(cube.Pos{18, 0, 18})
+		return ok
+	}
+	if w.CachedLiquidCheck(pos, 4, check) || w.CachedLiquidCheck(pos, 4, check) {
+		t.Fatalf("expected no liquid to be found")
+	}
+	if calls != 1 {
+		t.Fatalf("expected check to be called once while no liquid changed, got %v calls", calls)
+	}
+
+	// Placing a liquid in another chunk that the area spans invalidates the result.
+	w.SetLiquid(cube.Pos{18, 0, 18}, block.Water{Still: true, Depth: 8})
+	if !w.CachedLiquidCheck(pos, 4, check) {
+		t.Errorf("expected liquid to be found after placing it")
+	}
+	if calls != 2 {
+		t.Errorf("expected check to be called again after a liquid changed, got %v calls", calls)
+	}
+}
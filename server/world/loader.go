@@ -3,6 +3,8 @@ package world
 import (
 	"github.com/go-gl/mathgl/mgl64"
 	"math"
+	"runtime"
+	"sort"
 	"sync"
 )
 
@@ -16,8 +18,12 @@ type Loader struct {
 
 	mu        sync.RWMutex
 	pos       ChunkPos
+	yaw       float64
 	loadQueue []ChunkPos
 	loaded    map[ChunkPos]struct{}
+	// border is the Border of the World at the time the load queue was last populated. Chunks entirely outside
+	// it are not loaded, unless the border deals damage.
+	border Border
 
 	closed bool
 }
@@ -49,9 +55,14 @@ func (l *Loader) ChangeWorld(new *World) {
 	l.mu.Unlock()
 }
 
-// ChangeRadius changes the maximum chunk radius of the Loader.
+// ChangeRadius changes the maximum chunk radius of the Loader. Loaded chunks outside the new radius are
+// unloaded, and chunks that are now within the radius are queued to be loaded.
 func (l *Loader) ChangeRadius(new int) {
 	l.mu.Lock()
+	if l.closed || l.r == new {
+		l.mu.Unlock()
+		return
+	}
 	l.r = new
 
 	l.evictUnused()
@@ -63,8 +74,7 @@ func (l *Loader) ChangeRadius(new int) {
 func (l *Loader) Move(pos mgl64.Vec3) {
 	l.mu.Lock()
 
-	floorX, floorZ := math.Floor(pos[0]), math.Floor(pos[2])
-	chunkPos := ChunkPos{int32(floorX) >> 4, int32(floorZ) >> 4}
+	chunkPos := ChunkPosFromVec3(pos)
 
 	if chunkPos == l.pos {
 		l.mu.Unlock()
@@ -77,9 +87,20 @@ func (l *Loader) Move(pos mgl64.Vec3) {
 	l.mu.Unlock()
 }
 
-// Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
-// every chunk loaded, the function f is called.
-// The function f must not hold the chunk beyond the function scope.
+// Face changes the yaw that the Loader is facing. Of the chunks at the same distance from the Loader, those in
+// front of it are loaded first. The yaw is taken into account the next time the Loader moves to another chunk
+// or changes its radius.
+func (l *Loader) Face(yaw float64) {
+	l.mu.Lock()
+	l.yaw = yaw
+	l.mu.Unlock()
+}
+
+// Load loads n chunks around the centre of the chunk, starting with the middle and working outwards, and
+// shows them to the Viewer of the Loader in that order.
+// The chunks are read from the provider or generated concurrently, without holding the Loader, so that it
+// may be moved while chunks are loaded. Chunks that are no longer in range once they are loaded are not
+// shown.
 // An error is returned if one of the chunks could not be loaded.
 func (l *Loader) Load(n int) error {
 	if n == 0 {
@@ -90,29 +111,79 @@ func (l *Loader) Load(n int) error {
 		l.mu.Unlock()
 		return nil
 	}
-	for i := 0; i < n; i++ {
-		if len(l.loadQueue) == 0 {
-			break
+	if l.w.Border() != l.border {
+		// The border of the World changed, so chunks that are now inside it must be loaded.
+		l.populateLoadQueue()
+	}
+	if n > len(l.loadQueue) {
+		n = len(l.loadQueue)
+	}
+	w, queue := l.w, append([]ChunkPos(nil), l.loadQueue[:n]...)
+	l.mu.Unlock()
+
+	// The chunks are loaded by a fixed amount of workers, so that loading many chunks at once does not start
+	// a goroutine for every one of them.
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(queue) {
+		workers = len(queue)
+	}
+	errs := make([]error, len(queue))
+	indices := make(chan int, len(queue))
+	for i := range queue {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for j := 0; j < workers; j++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				_, errs[i] = w.loadChunk(queue[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || l.w != w {
+		// The world of the Loader was changed while loading, so the chunks loaded are no longer needed.
+		return nil
+	}
+	for i, pos := range queue {
+		if errs[i] != nil {
+			return errs[i]
 		}
-		pos := l.loadQueue[0]
-		c, err := l.w.chunk(pos)
+		if !l.dequeue(pos) {
+			// The Loader moved or its radius changed while loading, so that the chunk is no longer in range.
+			continue
+		}
+		c, err := w.chunk(pos)
 		if err != nil {
-			l.mu.Unlock()
 			return err
 		}
 		l.viewer.ViewChunk(pos, c.Chunk, c.e)
-		l.w.addViewer(c, l.viewer)
+		w.addViewer(c, l.viewer)
 
 		l.loaded[pos] = struct{}{}
-
-		// Shift the first element from the load queue off so that we can take a new one during the next
-		// iteration.
-		l.loadQueue = l.loadQueue[1:]
 	}
-	l.mu.Unlock()
 	return nil
 }
 
+// dequeue removes the position passed from the load queue. False is returned if the position was not in the
+// load queue.
+func (l *Loader) dequeue(pos ChunkPos) bool {
+	for i, queued := range l.loadQueue {
+		if queued == pos {
+			l.loadQueue = append(l.loadQueue[:i:i], l.loadQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Close closes the loader. It unloads all chunks currently loaded for the viewer, and hides all entities that
 // are currently shown to it.
 func (l *Loader) Close() error {
@@ -145,9 +216,7 @@ func (l *Loader) world(new *World) {
 // and should therefore be removed.
 func (l *Loader) evictUnused() {
 	for pos := range l.loaded {
-		diffX, diffZ := pos[0]-l.pos[0], pos[1]-l.pos[1]
-		dist := math.Sqrt(float64(diffX*diffX) + float64(diffZ*diffZ))
-		if int(dist) > l.r {
+		if chunkDistance(pos[0]-l.pos[0], pos[1]-l.pos[1]) > int32(l.r) {
 			delete(l.loaded, pos)
 			l.w.removeViewer(pos, l.viewer)
 		}
@@ -156,9 +225,11 @@ func (l *Loader) evictUnused() {
 
 // populateLoadQueue populates the load queue of the loader. This method is called once to create the order in
 // which chunks around the position the loader is now in should be loaded. Chunks are ordered to be loaded
-// from the middle outwards.
+// from the middle outwards. Chunks entirely outside the border of the world are never loaded, unless the border
+// deals damage.
 func (l *Loader) populateLoadQueue() {
 	l.loadQueue = nil
+	l.border = l.w.Border()
 	// We'll first load the chunk positions to load in a map indexed by the distance to the center (basically,
 	// what precedence it should have), and put them in the loadQueue in that order.
 	toLoad := map[int32][]ChunkPos{}
@@ -168,9 +239,8 @@ func (l *Loader) populateLoadQueue() {
 
 	for x := -r; x <= r; x++ {
 		for z := -r; z <= r; z++ {
-			distance := math.Sqrt(float64(x*x) + float64(z*z))
-			chunkDistance := int32(math.Round(distance))
-			if chunkDistance > r {
+			dist := chunkDistance(x, z)
+			if dist > r {
 				// The chunk was outside of the chunk radius.
 				continue
 			}
@@ -179,14 +249,36 @@ func (l *Loader) populateLoadQueue() {
 				// The chunk was already loaded, so we don't need to do anything.
 				continue
 			}
-			if m, ok := toLoad[chunkDistance]; ok {
-				toLoad[chunkDistance] = append(m, pos)
+			if l.border.Damage <= 0 && !l.border.containsChunk(pos) {
+				// The chunk is outside the border, so players cannot enter it and it need not be sent. Players
+				// may cross a border that deals damage, so chunks outside such a border are still sent.
+				continue
+			}
+			if m, ok := toLoad[dist]; ok {
+				toLoad[dist] = append(m, pos)
 				continue
 			}
-			toLoad[chunkDistance] = []ChunkPos{pos}
+			toLoad[dist] = []ChunkPos{pos}
 		}
 	}
-	for i := int32(0); i < r; i++ {
-		l.loadQueue = append(l.loadQueue, toLoad[i]...)
+	// Of the chunks at the same distance, the ones in front of the loader are loaded first.
+	yawRad := l.yaw * math.Pi / 180
+	dirX, dirZ := -math.Sin(yawRad), math.Cos(yawRad)
+	facing := func(pos ChunkPos) float64 {
+		x, z := float64(pos[0]-chunkX), float64(pos[1]-chunkZ)
+		return (x*dirX + z*dirZ) / math.Sqrt(x*x+z*z)
+	}
+	for i := int32(0); i <= r; i++ {
+		ring := toLoad[i]
+		sort.SliceStable(ring, func(a, b int) bool {
+			return facing(ring[a]) > facing(ring[b])
+		})
+		l.loadQueue = append(l.loadQueue, ring...)
 	}
 }
+
+// chunkDistance returns the distance in chunks of a chunk at an offset of x and z chunks, rounded to the nearest
+// chunk. It is used both to load and to unload chunks, so that no chunk is loaded and unloaded repeatedly.
+func chunkDistance(x, z int32) int32 {
+	return int32(math.Round(math.Sqrt(float64(x*x) + float64(z*z))))
+}
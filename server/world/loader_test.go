@@ -0,0 +1,115 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowGenerator is a world.Generator that takes a long time to generate every chunk.
+type slowGenerator struct{}
+
+func (slowGenerator) GenerateChunk(world.ChunkPos, *chunk.Chunk) { time.Sleep(time.Millisecond * 50) }
+
+// chunkViewer is a world.Viewer that counts how often every chunk is viewed.
+type chunkViewer struct {
+	world.Viewer
+	viewed map[world.ChunkPos]int
+
+	mu  sync.Mutex
+	pos mgl64.Vec3
+}
+
+func (v *chunkViewer) Position() mgl64.Vec3 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.pos
+}
+
+func (v *chunkViewer) move(pos mgl64.Vec3) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pos = pos
+}
+
+func (v *chunkViewer) ViewChunk(pos world.ChunkPos, _ *chunk.Chunk, _ map[cube.Pos]world.Block) {
+	v.viewed[pos]++
+}
+func (v *chunkViewer) ViewTime(int)                         {}
+func (v *chunkViewer) ViewWeather(bool, bool)               {}
+func (v *chunkViewer) ViewGameRules(map[string]interface{}) {}
+func (v *chunkViewer) ViewWorldSpawn(cube.Pos)              {}
+
+func TestLoaderChangeRadius(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+
+	v := &chunkViewer{viewed: map[world.ChunkPos]int{}}
+	l := world.NewLoader(2, w, v)
+	defer l.Close()
+	if err := l.Load(1000); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if len(v.viewed) != 21 {
+		t.Errorf("viewed %v chunks with a radius of 2, want 21", len(v.viewed))
+	}
+	if _, ok := v.viewed[world.ChunkPos{2, 0}]; !ok {
+		t.Errorf("chunk at the edge of the radius was not viewed")
+	}
+
+	// Shrinking the radius unloads the chunks outside it, so growing it again sends those chunks again, but
+	// not the chunks that remained loaded.
+	v.viewed = map[world.ChunkPos]int{}
+	l.ChangeRadius(1)
+	l.ChangeRadius(4)
+	l.ChangeRadius(4)
+	if err := l.Load(1000); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	for pos, n := range v.viewed {
+		if n != 1 {
+			t.Errorf("chunk %v viewed %v times, want once", pos, n)
+		}
+	}
+	for _, pos := range []world.ChunkPos{{0, 0}, {1, 0}, {0, -1}} {
+		if _, ok := v.viewed[pos]; ok {
+			t.Errorf("chunk %v that remained loaded was viewed again", pos)
+		}
+	}
+	for _, pos := range []world.ChunkPos{{2, 0}, {4, 0}, {3, 3}} {
+		if _, ok := v.viewed[pos]; !ok {
+			t.Errorf("chunk %v within the new radius was not viewed", pos)
+		}
+	}
+	if _, ok := v.viewed[world.ChunkPos{4, 3}]; ok {
+		t.Errorf("chunk outside of the radius was viewed")
+	}
+}
+
+func TestLoaderTicksDuringLoad(t *testing.T) {
+	w := world.New(logrus.New(), 16)
+	defer w.Close()
+	w.Generator(slowGenerator{})
+
+	v := &chunkViewer{viewed: map[world.ChunkPos]int{}}
+	l := world.NewLoader(16, w, v)
+	defer l.Close()
+
+	// Generating chunks must not block the world from ticking, as if a player with a large chunk radius joined
+	// a world of which no chunks were generated yet. The time of the world advances every tick, so it must
+	// have advanced before all chunks are loaded.
+	start := w.Time()
+	for len(v.viewed) < 64 {
+		if err := l.Load(4); err != nil {
+			t.Fatalf("load chunks: %v", err)
+		}
+	}
+	if w.Time() == start {
+		t.Errorf("world did not tick while generating chunks")
+	}
+}
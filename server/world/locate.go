@@ -0,0 +1,209 @@
+package world
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"go.uber.org/atomic"
+	"math"
+)
+
+// StructureLocation is the location of a structure placed by a Feature, as marked using Region.MarkStructure.
+type StructureLocation struct {
+	// Name is the name of the structure, such as 'village'.
+	Name string
+	// Pos is the position that the structure was marked at.
+	Pos cube.Pos
+}
+
+// LocateQuery is a query started using World.LocateBiome or World.LocateStructure. Queries may need to load
+// or generate many chunks, so they run in the background. A LocateQuery may be cancelled, for example when the
+// player that started it disconnects.
+type LocateQuery struct {
+	done      chan struct{}
+	cancelled atomic.Bool
+
+	pos   cube.Pos
+	found bool
+}
+
+// newLocateQuery returns a new LocateQuery that is not yet done.
+func newLocateQuery() *LocateQuery {
+	return &LocateQuery{done: make(chan struct{})}
+}
+
+// Done returns a channel that is closed once the LocateQuery is done, either because it finished or because it
+// was cancelled.
+func (q *LocateQuery) Done() <-chan struct{} {
+	return q.done
+}
+
+// Result waits for the LocateQuery to be done and returns the position found. False is returned if nothing was
+// found within the radius of the query or if the query was cancelled.
+func (q *LocateQuery) Result() (cube.Pos, bool) {
+	<-q.done
+	return q.pos, q.found
+}
+
+// Cancel cancels the LocateQuery. The query stops as soon as possible, after which Result returns false.
+func (q *LocateQuery) Cancel() {
+	q.cancelled.Store(true)
+}
+
+// Cancelled checks if the LocateQuery was cancelled, either using Cancel or because the World was closed.
+func (q *LocateQuery) Cancelled() bool {
+	return q.cancelled.Load()
+}
+
+// finish sets the result of the LocateQuery and marks it as done.
+func (q *LocateQuery) finish(pos cube.Pos, found bool) {
+	if q.Cancelled() {
+		pos, found = cube.Pos{}, false
+	}
+	q.pos, q.found = pos, found
+	close(q.done)
+}
+
+// LocateBiome starts looking for the column with the biome ID passed that is closest to the position passed,
+// horizontally, within maxRadius blocks. The Y of the position found is that of the position passed.
+// Chunks that are not loaded are read from the Provider of the World, or generated without being added to the
+// World if they do not exist yet. The query runs in the background and may be waited for using
+// LocateQuery.Result.
+func (w *World) LocateBiome(from cube.Pos, biome uint8, maxRadius int) *LocateQuery {
+	q := newLocateQuery()
+	if w == nil {
+		q.Cancel()
+		q.finish(cube.Pos{}, false)
+		return q
+	}
+	go func() {
+		centre := ChunkPosFromBlockPos(from)
+		best, bestDist := cube.Pos{}, math.MaxInt
+		maxDist := maxRadius * maxRadius
+
+		for r := int32(0); r <= int32(maxRadius>>4)+1; r++ {
+			if minDist := int(r-1) * 16; r > 1 && minDist*minDist > bestDist {
+				// No column in this ring or the rings after can be closer than the one found.
+				break
+			}
+			for _, pos := range chunkRing(centre, r) {
+				if w.locateStopped(q) {
+					q.finish(cube.Pos{}, false)
+					return
+				}
+				biomes, ok := w.chunkBiomes(pos)
+				if !ok {
+					continue
+				}
+				for i, id := range biomes {
+					if id != biome {
+						continue
+					}
+					x, z := int(pos[0])<<4+(i&15), int(pos[1])<<4+(i>>4)
+					dx, dz := x-from[0], z-from[2]
+					if dist := dx*dx + dz*dz; dist <= maxDist && dist < bestDist {
+						best, bestDist = cube.Pos{x, from[1], z}, dist
+					}
+				}
+			}
+		}
+		q.finish(best, bestDist != math.MaxInt)
+	}()
+	return q
+}
+
+// LocateStructure starts looking for the structure with the name passed that is closest to the position passed
+// within maxRadius blocks. Only structures marked using Region.MarkStructure in chunks that were generated are
+// found: Chunks are not generated to look for structures. The query runs in the background and may be waited
+// for using LocateQuery.Result.
+func (w *World) LocateStructure(from cube.Pos, name string, maxRadius int) *LocateQuery {
+	q := newLocateQuery()
+	if w == nil {
+		q.Cancel()
+		q.finish(cube.Pos{}, false)
+		return q
+	}
+	w.mu.Lock()
+	structures := append([]StructureLocation(nil), w.set.Structures...)
+	w.mu.Unlock()
+
+	go func() {
+		best, bestDist := cube.Pos{}, math.MaxFloat64
+		for _, s := range structures {
+			if s.Name != name {
+				continue
+			}
+			if dist := s.Pos.Vec3().Sub(from.Vec3()).Len(); dist <= float64(maxRadius) && dist < bestDist {
+				best, bestDist = s.Pos, dist
+			}
+		}
+		q.finish(best, !w.locateStopped(q) && bestDist != math.MaxFloat64)
+	}()
+	return q
+}
+
+// Structures returns the locations of all structures marked using Region.MarkStructure in the chunks of the
+// World generated so far.
+func (w *World) Structures() []StructureLocation {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]StructureLocation(nil), w.set.Structures...)
+}
+
+// locateStopped checks if the LocateQuery passed should stop, because it was cancelled or because the World is
+// closing. The query is cancelled if the World is closing.
+func (w *World) locateStopped(q *LocateQuery) bool {
+	select {
+	case <-w.closing:
+		q.Cancel()
+	default:
+	}
+	return q.Cancelled()
+}
+
+// chunkBiomes returns the biome IDs of all columns in the chunk at the position passed, indexed by x | z<<4.
+// The chunk is read from the cache if loaded, from the Provider if saved, and generated otherwise. False is
+// returned if the chunk could not be read.
+func (w *World) chunkBiomes(pos ChunkPos) (biomes [256]uint8, ok bool) {
+	read := func(c *chunk.Chunk) {
+		for i := range biomes {
+			biomes[i] = c.BiomeID(uint8(i&15), uint8(i>>4))
+		}
+	}
+	if c, ok := w.chunkFromCache(pos); ok {
+		c.Lock()
+		read(c.Chunk)
+		c.Unlock()
+		return biomes, true
+	}
+	c, err := w.provider().LoadChunk(pos)
+	if errors.Is(err, ErrChunkNotFound) {
+		c, err = chunk.New(airRID), nil
+		w.generator().GenerateChunk(pos, c)
+	}
+	if err != nil {
+		w.log.Debugf("locate biome: error loading chunk %v: %v", pos, err)
+		return biomes, false
+	}
+	read(c)
+	return biomes, true
+}
+
+// chunkRing returns the positions of all chunks that are exactly r chunks away from the centre passed, either
+// on the X or on the Z axis.
+func chunkRing(centre ChunkPos, r int32) []ChunkPos {
+	if r == 0 {
+		return []ChunkPos{centre}
+	}
+	ring := make([]ChunkPos, 0, 8*r)
+	for i := -r; i <= r; i++ {
+		ring = append(ring, ChunkPos{centre[0] + i, centre[1] - r}, ChunkPos{centre[0] + i, centre[1] + r})
+	}
+	for i := -r + 1; i < r; i++ {
+		ring = append(ring, ChunkPos{centre[0] - r, centre[1] + i}, ChunkPos{centre[0] + r, centre[1] + i})
+	}
+	return ring
+}
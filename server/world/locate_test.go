@@ -0,0 +1,71 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"testing"
+)
+
+// biomeGenerator is a world.Generator that sets the biome of all columns in chunk {3, 0} to 2 (desert).
+type biomeGenerator struct{}
+
+func (biomeGenerator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	if pos != (world.ChunkPos{3, 0}) {
+		return
+	}
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			c.SetBiomeID(x, z, 2)
+		}
+	}
+}
+
+// markerFeature is a world.Feature that marks a structure named 'marker' in the corner of every chunk with an
+// even X.
+type markerFeature struct{}
+
+func (markerFeature) Place(r *world.Region, _ *rand.Rand) {
+	if pos := r.Chunk(); pos[0]%2 == 0 {
+		r.MarkStructure("marker", cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4})
+	}
+}
+
+func TestLocateBiome(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	w.Generator(biomeGenerator{})
+
+	if pos, ok := w.LocateBiome(cube.Pos{5, 10, 5}, 2, 100).Result(); !ok || pos != (cube.Pos{48, 10, 5}) {
+		t.Errorf("expected biome at {48, 10, 5}, got %v (%v)", pos, ok)
+	}
+	if _, ok := w.LocateBiome(cube.Pos{5, 10, 5}, 2, 20).Result(); ok {
+		t.Errorf("expected no biome within 20 blocks")
+	}
+	q := w.LocateBiome(cube.Pos{5, 10, 5}, 3, 1000)
+	q.Cancel()
+	if _, ok := q.Result(); ok || !q.Cancelled() {
+		t.Errorf("expected cancelled query not to find anything")
+	}
+}
+
+func TestLocateStructure(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	w.AddFeature(markerFeature{})
+	for x := 0; x < 64; x += 16 {
+		_ = w.Block(cube.Pos{x, 0, 0})
+	}
+
+	if n := len(w.Structures()); n != 2 {
+		t.Fatalf("expected 2 structures to be marked, got %v", n)
+	}
+	if pos, ok := w.LocateStructure(cube.Pos{40, 0, 0}, "marker", 100).Result(); !ok || pos != (cube.Pos{32, 0, 0}) {
+		t.Errorf("expected structure at {32, 0, 0}, got %v (%v)", pos, ok)
+	}
+	if _, ok := w.LocateStructure(cube.Pos{40, 0, 0}, "village", 100).Result(); ok {
+		t.Errorf("expected no village to be found")
+	}
+}
@@ -0,0 +1,202 @@
+package mcdb
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Snapshot is a consistent, read-only view of the data of a Provider at the moment Provider.Snapshot was
+// called. It implements world.Snapshot.
+type Snapshot struct {
+	snap *leveldb.Snapshot
+	d    data
+}
+
+// Snapshot takes a Snapshot of the chunks and settings currently stored by the Provider. Data saved to the
+// Provider after the call is not part of the Snapshot, so the Provider may be used as usual while the Snapshot
+// is being written. world.ErrProviderClosed is returned if the Provider was closed.
+func (p *Provider) Snapshot() (world.Snapshot, error) {
+	if p.closed.Load() {
+		return nil, world.ErrProviderClosed
+	}
+	snap, err := p.db.GetSnapshot()
+	if err == leveldb.ErrClosed {
+		return nil, world.ErrProviderClosed
+	} else if err != nil {
+		return nil, fmt.Errorf("error taking leveldb snapshot: %w", err)
+	}
+	return &Snapshot{snap: snap, d: p.d}, nil
+}
+
+// Backup writes the Snapshot to dst. If dst ends with .mcworld, the world is written to a .mcworld file,
+// which is a zip file that may be imported by the client. Otherwise, a world folder that may be opened using
+// New is written. Backup never overwrites an existing file or folder at dst. If dst is not writable or if the
+// disk runs out of space, the error returned says so, and errors.Is may be used to check for fs.ErrPermission
+// or syscall.ENOSPC respectively.
+func (s *Snapshot) Backup(dst string) (world.BackupStats, error) {
+	if _, err := os.Stat(dst); err == nil {
+		return world.BackupStats{}, fmt.Errorf("backup world: destination %v already exists", dst)
+	}
+	if strings.EqualFold(filepath.Ext(dst), ".mcworld") {
+		return s.backupMCWorld(dst)
+	}
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	stats, err := s.write(dst)
+	if err != nil {
+		_ = os.RemoveAll(dst)
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	return stats, nil
+}
+
+// Release releases the Snapshot.
+func (s *Snapshot) Release() {
+	s.snap.Release()
+}
+
+// backupMCWorld writes the Snapshot to a temporary folder next to dst and archives it to a .mcworld file at
+// dst.
+func (s *Snapshot) backupMCWorld(dst string) (world.BackupStats, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), ".backup-")
+	if err != nil {
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	stats, err := s.write(tmp)
+	if err != nil {
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	if stats.Bytes, err = archive(tmp, dst); err != nil {
+		_ = os.Remove(dst)
+		return world.BackupStats{}, backupErr(dst, err)
+	}
+	return stats, nil
+}
+
+// write writes the database and level.dat of the Snapshot to the folder dir. The amount of chunks and bytes
+// written are returned.
+func (s *Snapshot) write(dir string) (world.BackupStats, error) {
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{
+		Compression: opt.FlateCompression,
+		BlockSize:   16 * opt.KiB,
+	})
+	if err != nil {
+		return world.BackupStats{}, err
+	}
+	stats := world.BackupStats{}
+
+	it := s.snap.NewIterator(nil, nil)
+	batch := new(leveldb.Batch)
+	for it.Next() {
+		k := it.Key()
+		if (len(k) == 9 || len(k) == 13) && (k[len(k)-1] == keyVersion || k[len(k)-1] == keyVersionOld) {
+			stats.Chunks++
+		}
+		batch.Put(k, it.Value())
+		if batch.Len() >= 1024 {
+			if err = db.Write(batch, nil); err != nil {
+				break
+			}
+			batch.Reset()
+		}
+	}
+	it.Release()
+	if err == nil {
+		err = it.Error()
+	}
+	if err == nil {
+		err = db.Write(batch, nil)
+	}
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return world.BackupStats{}, err
+	}
+	if err := writeLevelDat(dir, s.d); err != nil {
+		return world.BackupStats{}, err
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		stats.Bytes += info.Size()
+		return nil
+	})
+	return stats, err
+}
+
+// archive writes all files in the folder dir to a new zip file at dst and returns the size of the zip file.
+func archive(dir, dst string) (int64, error) {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), f.Close()
+}
+
+// backupErr wraps an error that was encountered while writing a backup to dst, explaining the cause of the
+// error if dst is not writable or if the disk is full.
+func backupErr(dst string, err error) error {
+	switch {
+	case errors.Is(err, syscall.ENOSPC):
+		return fmt.Errorf("backup world: not enough disk space to write %v: %w", dst, err)
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("backup world: destination %v is not writable: %w", dst, err)
+	}
+	return fmt.Errorf("backup world: %w", err)
+}
@@ -0,0 +1,106 @@
+package mcdb
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConvertAnvil converts the Java Edition world in the Anvil format in the folder src to a world in the folder
+// dst, which may be opened using New. The block states of the Java world are converted to their Bedrock
+// equivalents, and blocks that could not be converted are replaced with stone and logged once the conversion
+// finishes. Chunks are converted and written one by one, so that the Java world is never held in memory as a
+// whole. If dst is the same folder as src, the level.dat of the Java world is renamed to level.dat.java, so
+// that it is not overwritten.
+func ConvertAnvil(src, dst string, log internal.Logger) error {
+	start := time.Now()
+	levelPath := filepath.Join(src, "level.dat")
+	level, err := anvil.ReadLevel(levelPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("convert anvil world: %w", err)
+	}
+	if filepath.Clean(src) == filepath.Clean(dst) && err == nil {
+		if err := os.Rename(levelPath, levelPath+".java"); err != nil {
+			return fmt.Errorf("convert anvil world: %w", err)
+		}
+	}
+
+	regions, err := filepath.Glob(filepath.Join(src, "region", "*.mca"))
+	if err != nil {
+		return fmt.Errorf("convert anvil world: %w", err)
+	}
+	p, err := New(dst)
+	if err != nil {
+		return fmt.Errorf("convert anvil world: %w", err)
+	}
+
+	conv, n := anvil.NewConverter(), 0
+	for i, path := range regions {
+		log.Debugf("Converting region %v (%v/%v)...", filepath.Base(path), i+1, len(regions))
+		c, err := convertRegion(p, conv, path, log)
+		if err != nil {
+			log.Errorf("error converting region %v: %v", filepath.Base(path), err)
+		}
+		n += c
+	}
+
+	if unmapped := conv.Unmapped(); len(unmapped) > 0 {
+		names := make([]string, 0, len(unmapped))
+		for name, count := range unmapped {
+			names = append(names, fmt.Sprintf("%v (%v)", name, count))
+		}
+		sort.Strings(names)
+		log.Infof("Replaced %v unknown Java blocks with stone: %v", len(unmapped), strings.Join(names, ", "))
+	}
+
+	if level.Name != "" {
+		s := p.Settings()
+		s.Name, s.Spawn, s.Time, s.Seed = level.Name, level.Spawn, level.Time, level.Seed
+		p.SaveSettings(s)
+	}
+	if err := p.Close(); err != nil {
+		return fmt.Errorf("convert anvil world: %w", err)
+	}
+	log.Infof("Converted %v chunks from %v regions in %v.", n, len(regions), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// convertRegion converts all chunks in the region file at the path passed and saves them to the Provider. The
+// amount of chunks converted is returned. Chunks that could not be read or converted are logged and skipped.
+func convertRegion(p *Provider, conv *anvil.Converter, path string, log internal.Logger) (int, error) {
+	r, err := anvil.OpenRegion(path)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	n := 0
+	for z := 0; z < 32; z++ {
+		for x := 0; x < 32; x++ {
+			data, ok, err := r.Chunk(x, z)
+			if err != nil {
+				log.Debugf("error reading chunk in region %v: %v", filepath.Base(path), err)
+				continue
+			} else if !ok {
+				continue
+			}
+			pos, c, err := conv.Chunk(data)
+			if err != nil {
+				log.Debugf("skipping chunk: %v", err)
+				continue
+			}
+			c.Compact()
+			if err := p.SaveChunk(pos, c); err != nil {
+				return n, fmt.Errorf("save chunk %v: %w", pos, err)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
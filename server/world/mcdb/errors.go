@@ -0,0 +1,9 @@
+package mcdb
+
+import "errors"
+
+// ErrExtendedHeight is returned by Provider.LoadChunk if a chunk was saved by a newer version of the game using the
+// extended world height of -64 to 320. Chunks in this format cannot be loaded, as worlds only support a height of 0 to
+// 255. The error is returned rather than world.ErrChunkNotFound, so that the chunk is not regenerated and
+// overwritten.
+var ErrExtendedHeight = errors.New("chunk uses extended world height, which is not supported")
@@ -117,4 +117,24 @@ type data struct {
 	Experiments                    map[string]interface{} `nbt:"experiments"`
 	FreezeDamage                   uint8                  `nbt:"freezedamage"`
 	WorldPolicies                  map[string]interface{} `nbt:"world_policies"`
+	TickingAreas                   []tickingArea          `nbt:"dragonflyTickingAreas,omitempty"`
+	GameRules                      map[string]interface{} `nbt:"dragonflyGameRules,omitempty"`
+	Structures                     []structureLocation    `nbt:"dragonflyStructures,omitempty"`
+	BorderCenterX                  float32                `nbt:"dragonflyBorderCenterX,omitempty"`
+	BorderCenterZ                  float32                `nbt:"dragonflyBorderCenterZ,omitempty"`
+	BorderRadius                   float64                `nbt:"dragonflyBorderRadius,omitempty"`
+	BorderDamage                   float64                `nbt:"dragonflyBorderDamage,omitempty"`
+}
+
+// structureLocation holds the data of a world.StructureLocation as saved in the level.dat.
+type structureLocation struct {
+	Name    string
+	X, Y, Z int32
+}
+
+// tickingArea holds the data of a world.TickingArea as saved in the level.dat.
+type tickingArea struct {
+	Name       string
+	MinX, MinZ int32
+	MaxX, MaxZ int32
 }
@@ -8,11 +8,15 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/errors"
 	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/go-gl/mathgl/mgl32"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"go.uber.org/atomic"
 	"io/ioutil"
 	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"time"
@@ -23,6 +27,11 @@ type Provider struct {
 	db  *leveldb.DB
 	dir string
 	d   data
+	// rdonly specifies if the Provider was opened using NewReadOnly. If true, nothing is written to the files
+	// of the world.
+	rdonly bool
+	// closed is set to true once the Provider is closed.
+	closed atomic.Bool
 }
 
 // chunkVersion is the current version of chunks.
@@ -33,8 +42,20 @@ const chunkVersion = 19
 // error is returned.
 func New(dir string) (*Provider, error) {
 	_ = os.MkdirAll(filepath.Join(dir, "db"), 0777)
+	return open(dir, false)
+}
+
+// NewReadOnly creates a new provider reading files under the path passed, like New. Unlike New, the provider
+// never writes to these files: Chunks, entities, block NBT and settings saved to the provider are discarded,
+// so that the files of the world stay unchanged, regardless of what happens to the world in memory. An error
+// is returned if no world is present at the path passed.
+func NewReadOnly(dir string) (*Provider, error) {
+	return open(dir, true)
+}
 
-	p := &Provider{dir: dir}
+// open opens the world at the path passed, either read-only or not.
+func open(dir string, readOnly bool) (*Provider, error) {
+	p := &Provider{dir: dir, rdonly: readOnly}
 	if _, err := os.Stat(filepath.Join(dir, "level.dat")); os.IsNotExist(err) {
 		// A level.dat was not currently present for the world.
 		p.initDefaultLevelDat()
@@ -56,6 +77,7 @@ func New(dir string) (*Provider, error) {
 	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), &opt.Options{
 		Compression: opt.FlateCompression,
 		BlockSize:   16 * opt.KiB,
+		ReadOnly:    readOnly,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error opening leveldb database: %w", err)
@@ -67,6 +89,9 @@ func New(dir string) (*Provider, error) {
 // initDefaultLevelDat initialises a default level.dat file.
 func (p *Provider) initDefaultLevelDat() {
 	p.d.DoDayLightCycle = true
+	p.d.DoWeatherCycle = true
+	p.d.RainTime = int32(rand.Intn(168000) + 12000)
+	p.d.LightningTime = int32(rand.Intn(168000) + 12000)
 	p.d.BaseGameVersion = protocol.CurrentVersion
 	p.d.LevelName = "World"
 	p.d.GameType = 1
@@ -84,6 +109,7 @@ func (p *Provider) initDefaultLevelDat() {
 	p.d.MultiPlayerGame = true
 	p.d.SpawnY = math.MaxInt32
 	p.d.Difficulty = 2
+	p.d.RandomSeed = rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
 }
 
 // Settings returns the world.Settings of the world loaded by the Provider.
@@ -96,6 +122,21 @@ func (p *Provider) Settings() world.Settings {
 		CurrentTick:     p.d.CurrentTick,
 		DefaultGameMode: p.LoadDefaultGameMode(),
 		Difficulty:      p.LoadDifficulty(),
+		KeepInventory:   p.d.KeepInventory,
+		Seed:            p.d.RandomSeed,
+		WeatherCycle:    p.d.DoWeatherCycle,
+		Raining:         p.d.RainLevel > 0,
+		RainTime:        int64(p.d.RainTime),
+		Thundering:      p.d.LightningLevel > 0,
+		ThunderTime:     int64(p.d.LightningTime),
+		GameRules:       p.loadGameRules(),
+		TickingAreas:    p.loadTickingAreas(),
+		Structures:      p.loadStructures(),
+		Border: world.Border{
+			Center: mgl32.Vec2{p.d.BorderCenterX, p.d.BorderCenterZ},
+			Radius: p.d.BorderRadius,
+			Damage: p.d.BorderDamage,
+		},
 	}
 }
 
@@ -108,37 +149,118 @@ func (p *Provider) SaveSettings(s world.Settings) {
 	p.d.CurrentTick = s.CurrentTick
 	p.SaveDefaultGameMode(s.DefaultGameMode)
 	p.SaveDifficulty(s.Difficulty)
+	p.d.KeepInventory = s.KeepInventory
+	p.d.RandomSeed = s.Seed
+	p.d.DoWeatherCycle = s.WeatherCycle
+	p.d.RainLevel, p.d.RainTime = weatherLevel(s.Raining), int32(s.RainTime)
+	p.d.LightningLevel, p.d.LightningTime = weatherLevel(s.Thundering), int32(s.ThunderTime)
+	p.saveTickingAreas(s.TickingAreas)
+	p.saveStructures(s.Structures)
+	p.saveGameRules(s.GameRules)
+	p.d.BorderCenterX, p.d.BorderCenterZ = s.Border.Center[0], s.Border.Center[1]
+	p.d.BorderRadius, p.d.BorderDamage = s.Border.Radius, s.Border.Damage
+}
+
+// loadGameRules loads the game rules changed from their default value from the level.dat.
+func (p *Provider) loadGameRules() map[string]interface{} {
+	rules := make(map[string]interface{}, len(p.d.GameRules))
+	for name, v := range p.d.GameRules {
+		rules[name] = v
+	}
+	return rules
+}
+
+// saveGameRules saves the game rules passed to the level.dat. Values of the type int, which cannot be
+// encoded using NBT, are saved as an int32.
+func (p *Provider) saveGameRules(rules map[string]interface{}) {
+	p.d.GameRules = make(map[string]interface{}, len(rules))
+	for name, v := range rules {
+		if i, ok := v.(int); ok {
+			v = int32(i)
+		}
+		p.d.GameRules[name] = v
+	}
+}
+
+// weatherLevel returns the rain or lightning level saved in the level.dat for weather that is active if
+// active is true.
+func weatherLevel(active bool) float32 {
+	if active {
+		return 1
+	}
+	return 0
+}
+
+// loadTickingAreas loads the ticking areas saved in the level.dat.
+func (p *Provider) loadTickingAreas() []world.TickingArea {
+	areas := make([]world.TickingArea, 0, len(p.d.TickingAreas))
+	for _, a := range p.d.TickingAreas {
+		areas = append(areas, world.TickingArea{Name: a.Name, Min: world.ChunkPos{a.MinX, a.MinZ}, Max: world.ChunkPos{a.MaxX, a.MaxZ}})
+	}
+	return areas
+}
+
+// saveTickingAreas saves the ticking areas passed to the level.dat.
+func (p *Provider) saveTickingAreas(areas []world.TickingArea) {
+	p.d.TickingAreas = make([]tickingArea, 0, len(areas))
+	for _, a := range areas {
+		p.d.TickingAreas = append(p.d.TickingAreas, tickingArea{Name: a.Name, MinX: a.Min[0], MinZ: a.Min[1], MaxX: a.Max[0], MaxZ: a.Max[1]})
+	}
+}
+
+// loadStructures loads the structure locations saved in the level.dat.
+func (p *Provider) loadStructures() []world.StructureLocation {
+	structures := make([]world.StructureLocation, 0, len(p.d.Structures))
+	for _, s := range p.d.Structures {
+		structures = append(structures, world.StructureLocation{Name: s.Name, Pos: cube.Pos{int(s.X), int(s.Y), int(s.Z)}})
+	}
+	return structures
 }
 
-// LoadChunk loads a chunk at the position passed from the leveldb database. If it doesn't exist, exists is
-// false. If an error is returned, exists is always assumed to be true.
-func (p *Provider) LoadChunk(position world.ChunkPos) (c *chunk.Chunk, exists bool, err error) {
+// saveStructures saves the structure locations passed to the level.dat.
+func (p *Provider) saveStructures(structures []world.StructureLocation) {
+	p.d.Structures = make([]structureLocation, 0, len(structures))
+	for _, s := range structures {
+		p.d.Structures = append(p.d.Structures, structureLocation{Name: s.Name, X: int32(s.Pos[0]), Y: int32(s.Pos[1]), Z: int32(s.Pos[2])})
+	}
+}
+
+// LoadChunk loads a chunk at the position passed from the leveldb database. If it doesn't exist,
+// world.ErrChunkNotFound is returned. If the data of the chunk could not be decoded, a world.ErrWorldCorrupt
+// is returned.
+func (p *Provider) LoadChunk(position world.ChunkPos) (*chunk.Chunk, error) {
 	data := chunk.SerialisedData{}
 	key := index(position)
 
 	// This key is where the version of a chunk resides. The chunk version has changed many times, without any
 	// actual substantial changes, so we don't check this.
-	_, err = p.db.Get(append(key, keyVersion), nil)
+	_, err := p.db.Get(append(key, keyVersion), nil)
 	if err == leveldb.ErrNotFound {
 		// The new key was not found, so we try the old key.
-		if _, err = p.db.Get(append(key, keyVersionOld), nil); err != nil {
-			return nil, false, nil
-		}
+		_, err = p.db.Get(append(key, keyVersionOld), nil)
+	}
+	if err == leveldb.ErrNotFound {
+		return nil, world.ErrChunkNotFound
 	} else if err != nil {
-		return nil, true, fmt.Errorf("error reading version: %w", err)
+		return nil, fmt.Errorf("error reading version: %w", dbErr(position, err))
 	}
 
 	data.Data2D, err = p.db.Get(append(key, key2DData), nil)
 	if err == leveldb.ErrNotFound {
-		return nil, false, nil
+		if ok, _ := p.db.Has(append(key, key3DData), nil); ok {
+			// The chunk was saved by a newer version of the game, which stores 3D biomes instead. Returning
+			// ErrChunkNotFound here would have the chunk regenerated and overwritten when the world is saved.
+			return nil, fmt.Errorf("chunk %v: %w", position, ErrExtendedHeight)
+		}
+		return nil, world.ErrChunkNotFound
 	} else if err != nil {
-		return nil, true, fmt.Errorf("error reading 2D data: %w", err)
+		return nil, fmt.Errorf("error reading 2D data: %w", dbErr(position, err))
 	}
 
 	data.BlockNBT, err = p.db.Get(append(key, keyBlockEntities), nil)
 	// Block entities aren't present when there aren't any, so it's okay if we can't find the key.
 	if err != nil && err != leveldb.ErrNotFound {
-		return nil, true, fmt.Errorf("error reading block entities: %w", err)
+		return nil, fmt.Errorf("error reading block entities: %w", dbErr(position, err))
 	}
 
 	for y := byte(0); y < 16; y++ {
@@ -148,35 +270,42 @@ func (p *Provider) LoadChunk(position world.ChunkPos) (c *chunk.Chunk, exists bo
 			// be present.
 			continue
 		} else if err != nil {
-			return nil, true, fmt.Errorf("error reading 2D sub chunk %v: %w", y, err)
+			return nil, fmt.Errorf("error reading 2D sub chunk %v: %w", y, dbErr(position, err))
 		}
 	}
-	c, err = chunk.DiskDecode(data)
-	return c, true, err
+	c, err := chunk.DiskDecode(data)
+	if err != nil {
+		return nil, world.ErrWorldCorrupt{Pos: position, Cause: err}
+	}
+	return c, nil
 }
 
 // SaveChunk saves a chunk at the position passed to the leveldb database. Its version is written as the
 // version in the chunkVersion constant.
 func (p *Provider) SaveChunk(position world.ChunkPos, c *chunk.Chunk) error {
+	if p.rdonly {
+		return nil
+	}
 	data := chunk.Encode(c, chunk.DiskEncoding)
 
 	key := index(position)
-	_ = p.db.Put(append(key, keyVersion), []byte{chunkVersion}, nil)
-	_ = p.db.Put(append(key, key2DData), data.Data2D, nil)
+	batch := new(leveldb.Batch)
+	batch.Put(append(key, keyVersion), []byte{chunkVersion})
+	batch.Put(append(key, key2DData), data.Data2D)
 
 	finalisation := make([]byte, 4)
 	binary.LittleEndian.PutUint32(finalisation, 2)
-	_ = p.db.Put(append(key, keyFinalisation), finalisation, nil)
+	batch.Put(append(key, keyFinalisation), finalisation)
 
 	for y, sub := range data.SubChunks {
 		if len(sub) == 0 {
 			// No sub chunk here: Delete it from the database and continue.
-			_ = p.db.Delete(append(key, keySubChunkData, byte(y)), nil)
+			batch.Delete(append(key, keySubChunkData, byte(y)))
 			continue
 		}
-		_ = p.db.Put(append(key, keySubChunkData, byte(y)), sub, nil)
+		batch.Put(append(key, keySubChunkData, byte(y)), sub)
 	}
-	return nil
+	return dbErr(position, p.db.Write(batch, nil))
 }
 
 // LoadDefaultGameMode returns the default game mode stored in the level.dat.
@@ -241,7 +370,7 @@ func (p *Provider) SaveDifficulty(d world.Difficulty) {
 func (p *Provider) LoadEntities(pos world.ChunkPos) ([]world.SaveableEntity, error) {
 	data, err := p.db.Get(append(index(pos), keyEntities), nil)
 	if err != leveldb.ErrNotFound && err != nil {
-		return nil, err
+		return nil, dbErr(pos, err)
 	}
 	var a []world.SaveableEntity
 
@@ -251,11 +380,11 @@ func (p *Provider) LoadEntities(pos world.ChunkPos) ([]world.SaveableEntity, err
 	for buf.Len() != 0 {
 		var m map[string]interface{}
 		if err := dec.Decode(&m); err != nil {
-			return nil, fmt.Errorf("error decoding block NBT: %w", err)
+			return nil, world.ErrWorldCorrupt{Pos: pos, Cause: fmt.Errorf("error decoding entity NBT: %w", err)}
 		}
 		id, ok := m["identifier"]
 		if !ok {
-			return nil, fmt.Errorf("entity has no ID but data (%v)", m)
+			return nil, world.ErrWorldCorrupt{Pos: pos, Cause: fmt.Errorf("entity has no ID but data (%v)", m)}
 		}
 		name, _ := id.(string)
 		e, ok := world.EntityByName(name)
@@ -273,8 +402,11 @@ func (p *Provider) LoadEntities(pos world.ChunkPos) ([]world.SaveableEntity, err
 
 // SaveEntities saves all entities to the chunk position passed.
 func (p *Provider) SaveEntities(pos world.ChunkPos, entities []world.SaveableEntity) error {
+	if p.rdonly {
+		return nil
+	}
 	if len(entities) == 0 {
-		return p.db.Delete(append(index(pos), keyEntities), nil)
+		return dbErr(pos, p.db.Delete(append(index(pos), keyEntities), nil))
 	}
 
 	buf := bytes.NewBuffer(nil)
@@ -286,14 +418,14 @@ func (p *Provider) SaveEntities(pos world.ChunkPos, entities []world.SaveableEnt
 			return fmt.Errorf("save entities: error encoding NBT: %w", err)
 		}
 	}
-	return p.db.Put(append(index(pos), keyEntities), buf.Bytes(), nil)
+	return dbErr(pos, p.db.Put(append(index(pos), keyEntities), buf.Bytes(), nil))
 }
 
 // LoadBlockNBT loads all block entities from the chunk position passed.
 func (p *Provider) LoadBlockNBT(position world.ChunkPos) ([]map[string]interface{}, error) {
 	data, err := p.db.Get(append(index(position), keyBlockEntities), nil)
 	if err != leveldb.ErrNotFound && err != nil {
-		return nil, err
+		return nil, dbErr(position, err)
 	}
 	var a []map[string]interface{}
 
@@ -303,7 +435,7 @@ func (p *Provider) LoadBlockNBT(position world.ChunkPos) ([]map[string]interface
 	for buf.Len() != 0 {
 		var m map[string]interface{}
 		if err := dec.Decode(&m); err != nil {
-			return nil, fmt.Errorf("error decoding block NBT: %w", err)
+			return nil, world.ErrWorldCorrupt{Pos: position, Cause: fmt.Errorf("error decoding block NBT: %w", err)}
 		}
 		a = append(a, m)
 	}
@@ -312,8 +444,11 @@ func (p *Provider) LoadBlockNBT(position world.ChunkPos) ([]map[string]interface
 
 // SaveBlockNBT saves all block NBT data to the chunk position passed.
 func (p *Provider) SaveBlockNBT(position world.ChunkPos, data []map[string]interface{}) error {
+	if p.rdonly {
+		return nil
+	}
 	if len(data) == 0 {
-		return p.db.Delete(append(index(position), keyBlockEntities), nil)
+		return dbErr(position, p.db.Delete(append(index(position), keyBlockEntities), nil))
 	}
 	buf := bytes.NewBuffer(nil)
 	enc := nbt.NewEncoderWithEncoding(buf, nbt.LittleEndian)
@@ -322,37 +457,70 @@ func (p *Provider) SaveBlockNBT(position world.ChunkPos, data []map[string]inter
 			return fmt.Errorf("error encoding block NBT: %w", err)
 		}
 	}
-	return p.db.Put(append(index(position), keyBlockEntities), buf.Bytes(), nil)
+	return dbErr(position, p.db.Put(append(index(position), keyBlockEntities), buf.Bytes(), nil))
 }
 
-// Close closes the provider, saving any file that might need to be saved, such as the level.dat.
+// Close closes the provider, saving any file that might need to be saved, such as the level.dat. If the
+// provider was opened using NewReadOnly, no files are saved. If the provider was already closed,
+// world.ErrProviderClosed is returned.
 func (p *Provider) Close() error {
+	if !p.closed.CAS(false, true) {
+		return world.ErrProviderClosed
+	}
+	if p.rdonly {
+		return p.db.Close()
+	}
 	p.d.LastPlayed = time.Now().Unix()
+	if err := writeLevelDat(p.dir, p.d); err != nil {
+		return err
+	}
+	return p.db.Close()
+}
 
-	f, err := os.OpenFile(filepath.Join(p.dir, "level.dat"), os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+// writeLevelDat writes the level.dat and levelname.txt files holding the data passed to the folder dir.
+func writeLevelDat(dir string, d data) error {
+	f, err := os.OpenFile(filepath.Join(dir, "level.dat"), os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("error opening level.dat file: %w", err)
 	}
 
 	buf := bytes.NewBuffer(nil)
 	_ = binary.Write(buf, binary.LittleEndian, int32(3))
-	nbtData, err := nbt.MarshalEncoding(p.d, nbt.LittleEndian)
+	nbtData, err := nbt.MarshalEncoding(d, nbt.LittleEndian)
 	if err != nil {
+		_ = f.Close()
 		return fmt.Errorf("error encoding level.dat to NBT: %w", err)
 	}
 	_ = binary.Write(buf, binary.LittleEndian, int32(len(nbtData)))
 	_, _ = buf.Write(nbtData)
 
-	_, _ = f.Write(buf.Bytes())
-
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("error writing level.dat: %w", err)
+	}
 	if err := f.Close(); err != nil {
 		return fmt.Errorf("error closing level.dat: %w", err)
 	}
 	//noinspection SpellCheckingInspection
-	if err := ioutil.WriteFile(filepath.Join(p.dir, "levelname.txt"), []byte(p.d.LevelName), 0644); err != nil {
+	if err := ioutil.WriteFile(filepath.Join(dir, "levelname.txt"), []byte(d.LevelName), 0644); err != nil {
 		return fmt.Errorf("error writing levelname.txt: %w", err)
 	}
-	return p.db.Close()
+	return nil
+}
+
+// dbErr converts an error returned by the leveldb database while accessing the data of the chunk at the
+// position passed. If the database was closed, world.ErrProviderClosed is returned. If the database is
+// corrupted, a world.ErrWorldCorrupt is returned.
+func dbErr(pos world.ChunkPos, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == leveldb.ErrClosed:
+		return world.ErrProviderClosed
+	case errors.IsCorrupted(err):
+		return world.ErrWorldCorrupt{Pos: pos, Cause: err}
+	}
+	return err
 }
 
 // index returns a byte buffer holding the written index of the chunk position passed.
@@ -0,0 +1,310 @@
+package mcdb_test
+
+import (
+	"archive/zip"
+	"errors"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/sirupsen/logrus"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	p, err := mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	if err := p.SaveChunk(world.ChunkPos{}, chunk.New(0)); err != nil {
+		t.Fatalf("save chunk: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("close world: %v", err)
+	}
+	before := readFiles(t, dir)
+
+	p, err = mcdb.NewReadOnly(dir)
+	if err != nil {
+		t.Fatalf("open world read-only: %v", err)
+	}
+	if _, err := p.LoadChunk(world.ChunkPos{}); err != nil {
+		t.Fatalf("load chunk from read-only world: %v", err)
+	}
+	_ = p.SaveChunk(world.ChunkPos{1, 1}, chunk.New(0))
+	s := p.Settings()
+	s.Name = "Changed"
+	p.SaveSettings(s)
+	if err := p.Close(); err != nil {
+		t.Fatalf("close read-only world: %v", err)
+	}
+
+	if after := readFiles(t, dir); !reflect.DeepEqual(before, after) {
+		t.Fatalf("files of read-only world changed")
+	}
+}
+
+func TestProviderErrors(t *testing.T) {
+	dir := t.TempDir()
+	p, err := mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	if _, err := p.LoadChunk(world.ChunkPos{3, 3}); !errors.Is(err, world.ErrChunkNotFound) {
+		t.Fatalf("load missing chunk: expected ErrChunkNotFound, got %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("close world: %v", err)
+	}
+	if _, err := p.LoadChunk(world.ChunkPos{}); !errors.Is(err, world.ErrProviderClosed) {
+		t.Fatalf("load chunk after close: expected ErrProviderClosed, got %v", err)
+	}
+	if err := p.Close(); !errors.Is(err, world.ErrProviderClosed) {
+		t.Fatalf("close world twice: expected ErrProviderClosed, got %v", err)
+	}
+
+	// Write a chunk with invalid data directly to the database. The key of a chunk at 0, 0 in the overworld
+	// is 8 zero bytes, followed by the key of the data.
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), nil)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	_ = db.Put(append(make([]byte, 8), ','), []byte{40}, nil)
+	_ = db.Put(append(make([]byte, 8), '-'), []byte{1, 2, 3}, nil)
+	_ = db.Put(append(make([]byte, 8), '/', 0), []byte{0xff, 0xff}, nil)
+	if err := db.Close(); err != nil {
+		t.Fatalf("close database: %v", err)
+	}
+
+	p, err = mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	defer p.Close()
+	var corrupt world.ErrWorldCorrupt
+	if _, err := p.LoadChunk(world.ChunkPos{}); !errors.As(err, &corrupt) {
+		t.Fatalf("load corrupt chunk: expected ErrWorldCorrupt, got %v", err)
+	} else if corrupt.Pos != (world.ChunkPos{}) {
+		t.Fatalf("load corrupt chunk: expected position %v, got %v", world.ChunkPos{}, corrupt.Pos)
+	}
+}
+
+func TestExtendedHeightChunk(t *testing.T) {
+	dir := t.TempDir()
+	p, err := mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("close world: %v", err)
+	}
+
+	// Chunks saved by newer versions have 3D biomes rather than 2D data.
+	db, err := leveldb.OpenFile(filepath.Join(dir, "db"), nil)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	_ = db.Put(append(make([]byte, 8), ','), []byte{40}, nil)
+	_ = db.Put(append(make([]byte, 8), '+'), make([]byte, 512), nil)
+	if err := db.Close(); err != nil {
+		t.Fatalf("close database: %v", err)
+	}
+
+	p, err = mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	defer p.Close()
+	if _, err := p.LoadChunk(world.ChunkPos{}); !errors.Is(err, mcdb.ErrExtendedHeight) {
+		t.Fatalf("load extended height chunk: expected ErrExtendedHeight, got %v", err)
+	}
+}
+
+func TestBorderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	p, err := mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	border := world.Border{Center: mgl32.Vec2{-100.5, 250}, Radius: 64, Damage: 2}
+	s := p.Settings()
+	s.Border = border
+	p.SaveSettings(s)
+	if err := p.Close(); err != nil {
+		t.Fatalf("close world: %v", err)
+	}
+
+	p, err = mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	defer p.Close()
+	if got := p.Settings().Border; got != border {
+		t.Fatalf("expected border %+v after reopening the world, got %+v", border, got)
+	}
+}
+
+func TestSnapshotBackup(t *testing.T) {
+	dir := t.TempDir()
+	p, err := mcdb.New(filepath.Join(dir, "world"))
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	defer p.Close()
+	if err := p.SaveChunk(world.ChunkPos{}, chunk.New(0)); err != nil {
+		t.Fatalf("save chunk: %v", err)
+	}
+	s, err := p.Snapshot()
+	if err != nil {
+		t.Fatalf("take snapshot: %v", err)
+	}
+	defer s.Release()
+	// Chunks saved after taking the snapshot must not end up in the backup.
+	if err := p.SaveChunk(world.ChunkPos{1, 1}, chunk.New(0)); err != nil {
+		t.Fatalf("save chunk: %v", err)
+	}
+
+	stats, err := s.Backup(filepath.Join(dir, "backup"))
+	if err != nil {
+		t.Fatalf("backup to folder: %v", err)
+	}
+	if stats.Chunks != 1 || stats.Bytes == 0 {
+		t.Fatalf("backup to folder: expected 1 chunk and a non-zero size, got %+v", stats)
+	}
+	if _, err := s.Backup(filepath.Join(dir, "backup")); err == nil {
+		t.Fatalf("backup to existing folder: expected error")
+	}
+	backup, err := mcdb.New(filepath.Join(dir, "backup"))
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer backup.Close()
+	if _, err := backup.LoadChunk(world.ChunkPos{}); err != nil {
+		t.Fatalf("load chunk from backup: %v", err)
+	}
+	if _, err := backup.LoadChunk(world.ChunkPos{1, 1}); !errors.Is(err, world.ErrChunkNotFound) {
+		t.Fatalf("load chunk saved after snapshot: expected ErrChunkNotFound, got %v", err)
+	}
+
+	stats, err = s.Backup(filepath.Join(dir, "backup.mcworld"))
+	if err != nil {
+		t.Fatalf("backup to .mcworld: %v", err)
+	} else if stats.Chunks != 1 || stats.Bytes == 0 {
+		t.Fatalf("backup to .mcworld: expected 1 chunk and a non-zero size, got %+v", stats)
+	}
+	r, err := zip.OpenReader(filepath.Join(dir, "backup.mcworld"))
+	if err != nil {
+		t.Fatalf("open .mcworld: %v", err)
+	}
+	defer r.Close()
+	files := map[string]bool{}
+	for _, f := range r.File {
+		files[f.Name] = true
+	}
+	if !files["level.dat"] || !files["db/CURRENT"] {
+		t.Fatalf("backup to .mcworld: missing files, got %v", files)
+	}
+}
+
+func TestBlockNBTRoundTrip(t *testing.T) {
+	p, err := mcdb.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	defer p.Close()
+
+	sign := block.Sign{Text: "Hello\nWorld", Glowing: true}
+	chest := block.NewChest()
+	_ = chest.Inventory().SetItem(3, item.NewStack(item.Apple{}, 12))
+	if err := p.SaveBlockNBT(world.ChunkPos{}, []map[string]interface{}{sign.EncodeNBT(), chest.EncodeNBT()}); err != nil {
+		t.Fatalf("save block NBT: %v", err)
+	}
+	m, err := p.LoadBlockNBT(world.ChunkPos{})
+	if err != nil {
+		t.Fatalf("load block NBT: %v", err)
+	} else if len(m) != 2 {
+		t.Fatalf("load block NBT: expected 2 block entities, got %v", len(m))
+	}
+	if s := (block.Sign{}).DecodeNBT(m[0]).(block.Sign); s.Text != sign.Text || !s.Glowing {
+		t.Fatalf("sign did not round-trip: expected %+v, got %+v", sign, s)
+	}
+	c := (block.Chest{}).DecodeNBT(m[1]).(block.Chest)
+	if it, _ := c.Inventory().Item(3); it.Count() != 12 {
+		t.Fatalf("chest inventory did not round-trip: expected 12 apples in slot 3, got %v", it)
+	}
+}
+
+func init() {
+	// Custom blocks must be registered before any world is created.
+	block.RegisterCustom(rubyBlock{})
+}
+
+func TestCustomBlockRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	pos := cube.Pos{1, 10, 1}
+
+	p, err := mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("open world: %v", err)
+	}
+	w := world.New(logrus.New(), 8)
+	w.Provider(p)
+	w.SetBlock(pos, rubyBlock{Lit: true})
+	_ = w.Close()
+
+	p, err = mcdb.New(dir)
+	if err != nil {
+		t.Fatalf("reopen world: %v", err)
+	}
+	w = world.New(logrus.New(), 8)
+	defer w.Close()
+	w.Provider(p)
+	if b := w.Block(pos); b != (rubyBlock{Lit: true}) {
+		t.Fatalf("expected lit ruby block after reloading world, got %#v", b)
+	}
+}
+
+// rubyBlock is a custom block used to test custom block registration.
+type rubyBlock struct {
+	Lit bool
+}
+
+func (r rubyBlock) EncodeBlock() (string, map[string]interface{}) {
+	lit := uint8(0)
+	if r.Lit {
+		lit = 1
+	}
+	return "test:ruby_block", map[string]interface{}{"lit_bit": lit}
+}
+func (rubyBlock) Hash() uint64                       { return math.MaxUint64 }
+func (rubyBlock) Model() world.BlockModel            { return model.Solid{} }
+func (rubyBlock) States() []world.Block              { return []world.Block{rubyBlock{}, rubyBlock{Lit: true}} }
+func (rubyBlock) Components() map[string]interface{} { return map[string]interface{}{} }
+
+// readFiles reads the contents of all files under the directory passed, indexed by their path.
+func readFiles(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		files[path] = string(b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("read files: %v", err)
+	}
+	return files
+}
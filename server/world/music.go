@@ -0,0 +1,118 @@
+package world
+
+import (
+	"sync"
+	"time"
+)
+
+// MusicPlayer plays background music, such as records or custom music added using resource packs, and loops
+// it if needed. Clients have no channel for music played by a server, so tracks are played as a sound at the
+// position of the head of the player when the track starts. The sound does not follow the player: A player
+// that moves far away from that position hears the track more quietly, or not at all, until it is played
+// again. The volume setting that applies is that of the category of the sound in the sound definitions, such
+// as 'record' for records or 'music' for music tracks. Only one track is played at a time: playing a new track
+// stops the previous one.
+// A MusicPlayer is used by World.PlayMusic and player.Player.PlayMusic. Methods on MusicPlayer may be called
+// from multiple goroutines.
+type MusicPlayer struct {
+	play func(name string, volume, pitch float64)
+	stop func(name string)
+
+	mu sync.Mutex
+	// name is the name of the track currently playing, or an empty string if no track is playing.
+	name  string
+	timer *time.Timer
+	// generation is incremented every time a track is played or stopped, so that a loop of a previous track
+	// that was already due does not replay it.
+	generation uint64
+}
+
+// NewMusicPlayer returns a MusicPlayer that plays music using the play function passed and stops it using the
+// stop function passed. Typically, these functions call Viewer.ViewMusic and Viewer.ViewSoundStop.
+func NewMusicPlayer(play func(name string, volume, pitch float64), stop func(name string)) *MusicPlayer {
+	return &MusicPlayer{play: play, stop: stop}
+}
+
+// Play plays the music track with the name passed, such as 'record.cat', at the volume and pitch passed. The
+// length of the track must be passed if it is looped: Clients do not loop tracks by themselves, so the
+// MusicPlayer plays the track again every time its length passes. If loop is false, the length may be 0. Any
+// track that was playing before is stopped first.
+func (m *MusicPlayer) Play(name string, volume, pitch float64, length time.Duration, loop bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopCurrent()
+
+	m.name = name
+	m.play(name, volume, pitch)
+	if !loop || length <= 0 {
+		return
+	}
+	generation := m.generation
+	var replay func()
+	replay = func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.generation != generation {
+			// The track was stopped or replaced by another one after the timer expired.
+			return
+		}
+		m.stop(name)
+		m.play(name, volume, pitch)
+		m.timer = time.AfterFunc(length, replay)
+	}
+	m.timer = time.AfterFunc(length, replay)
+}
+
+// Stop stops the music track currently playing. Nothing happens if no track is playing.
+func (m *MusicPlayer) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopCurrent()
+}
+
+// Playing returns the name of the music track currently playing. False is returned if no track is playing.
+// Tracks that are not looped are considered playing until Stop is called or another track is played.
+func (m *MusicPlayer) Playing() (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.name, m.name != ""
+}
+
+// stopCurrent stops the track currently playing and cancels its loop. It must be called while holding m.mu.
+func (m *MusicPlayer) stopCurrent() {
+	m.generation++
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	if m.name != "" {
+		m.stop(m.name)
+		m.name = ""
+	}
+}
+
+// PlayMusic plays the music track with the name passed to all viewers of the World, stopping any music that
+// was previously played using PlayMusic. The music is played as a sound at the head position of every viewer,
+// which does not follow them as they move. If loop is true, the track is played again every time the length
+// passed passes. See MusicPlayer for more information.
+func (w *World) PlayMusic(name string, volume, pitch float64, length time.Duration, loop bool) {
+	if w == nil {
+		return
+	}
+	w.music.Play(name, volume, pitch, length, loop)
+}
+
+// StopMusic stops the music played to all viewers of the World using PlayMusic.
+func (w *World) StopMusic() {
+	if w == nil {
+		return
+	}
+	w.music.Stop()
+}
+
+// playMusic plays a music track to all viewers of the World.
+func (w *World) playMusic(name string, volume, pitch float64) {
+	for _, viewer := range w.allViewers() {
+		viewer.ViewMusic(name, volume, pitch)
+	}
+}
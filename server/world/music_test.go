@@ -0,0 +1,46 @@
+package world
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMusicPlayer(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	m := NewMusicPlayer(func(name string, volume, pitch float64) {
+		record("play " + name)
+	}, func(name string) {
+		record("stop " + name)
+	})
+
+	m.Play("record.cat", 1, 1, time.Millisecond*20, true)
+	time.Sleep(time.Millisecond * 50)
+	m.Play("record.blocks", 1, 1, 0, false)
+	if name, ok := m.Playing(); !ok || name != "record.blocks" {
+		t.Fatalf("expected record.blocks to be playing, got %v (%v)", name, ok)
+	}
+	m.Stop()
+	time.Sleep(time.Millisecond * 50)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The looped track must have been played at least twice, and must be stopped before the next track.
+	if len(events) < 6 || events[0] != "play record.cat" || events[1] != "stop record.cat" || events[2] != "play record.cat" {
+		t.Fatalf("expected record.cat to be looped, got %v", events)
+	}
+	if want := []string{"stop record.cat", "play record.blocks", "stop record.blocks"}; events[len(events)-3] != want[0] || events[len(events)-2] != want[1] || events[len(events)-1] != want[2] {
+		t.Fatalf("expected events to end with %v, got %v", want, events)
+	}
+	if _, ok := m.Playing(); ok {
+		t.Fatalf("expected no music to be playing after stopping")
+	}
+}
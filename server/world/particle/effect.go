@@ -0,0 +1,10 @@
+package particle
+
+// Effect is a particle effect identified by its name, such as 'minecraft:villager_happy'. It may be used to
+// show any particle effect known to the client, including custom particles added by resource packs.
+type Effect struct {
+	particle
+
+	// Name is the name of the particle effect to show.
+	Name string
+}
@@ -0,0 +1,61 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"testing"
+)
+
+func TestCatchUpBlockUpdates(t *testing.T) {
+	w := &World{blockUpdates: map[cube.Pos]int64{{0, 0, 0}: 30, {1, 0, 0}: 100}}
+	w.pausedTicks = 50
+	w.resume()
+	if w.catchUpTicks != 0 || w.pausedTicks != 0 {
+		t.Fatalf("resuming without catch-up added %v catch-up ticks, want 0", w.catchUpTicks)
+	}
+
+	w.catchUp.Store(true)
+	w.pausedTicks = 50
+	w.resume()
+	if w.catchUpTicks != 50 {
+		t.Fatalf("resuming after 50 ticks added %v catch-up ticks, want 50", w.catchUpTicks)
+	}
+	// Updates scheduled while catching up are performed after their normal delay.
+	w.blockUpdates[cube.Pos{2, 0, 0}] = 60
+	for _, want := range []int64{20, 20, 10, 0} {
+		before := w.blockUpdates[cube.Pos{0, 0, 0}]
+		w.catchUpBlockUpdates()
+		if moved := before - w.blockUpdates[cube.Pos{0, 0, 0}]; moved != want {
+			t.Fatalf("block updates moved %v ticks forward, want %v", moved, want)
+		}
+	}
+	if w.blockUpdates[cube.Pos{1, 0, 0}] != 50 {
+		t.Fatalf("block update at tick %v after catching up, want 50", w.blockUpdates[cube.Pos{1, 0, 0}])
+	}
+	if w.blockUpdates[cube.Pos{2, 0, 0}] != 60 {
+		t.Fatalf("block update scheduled while catching up at tick %v, want 60", w.blockUpdates[cube.Pos{2, 0, 0}])
+	}
+}
+
+func TestDueBlockUpdates(t *testing.T) {
+	w := &World{blockUpdates: map[cube.Pos]int64{}, positionCache: []ChunkPos{{0, 0}}}
+	w.simDist.Store(4)
+	for i := 0; i < maxBlockUpdatesPerTick+10; i++ {
+		w.blockUpdates[cube.Pos{i % 16, i / 256, (i / 16) % 16}] = int64(maxBlockUpdatesPerTick + 10 - i)
+	}
+	// Updates that are not yet due or outside the simulation distance must stay scheduled.
+	w.blockUpdates[cube.Pos{0, 200, 0}] = 1 << 40
+	w.blockUpdates[cube.Pos{1000, 0, 0}] = 0
+
+	w.dueBlockUpdates(1 << 20)
+	if len(w.updatePositions) != maxBlockUpdatesPerTick {
+		t.Fatalf("performed %v block updates in one tick, want %v", len(w.updatePositions), maxBlockUpdatesPerTick)
+	}
+	for i := 1; i < len(w.updatePositions); i++ {
+		if w.updatePositions[i].tick < w.updatePositions[i-1].tick {
+			t.Fatalf("block update scheduled for tick %v performed after update for tick %v", w.updatePositions[i].tick, w.updatePositions[i-1].tick)
+		}
+	}
+	if len(w.blockUpdates) != 12 {
+		t.Fatalf("%v block updates still scheduled, want 12", len(w.blockUpdates))
+	}
+}
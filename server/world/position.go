@@ -38,17 +38,18 @@ func (p ChunkPos) Z() int32 {
 	return p[1]
 }
 
-// chunkPosFromVec3 returns a chunk position from the Vec3 passed. The coordinates of the chunk position are
+// ChunkPosFromVec3 returns a chunk position from the Vec3 passed. The coordinates of the chunk position are
 // those of the Vec3 divided by 16, then rounded down.
-func chunkPosFromVec3(vec3 mgl64.Vec3) ChunkPos {
+func ChunkPosFromVec3(vec3 mgl64.Vec3) ChunkPos {
 	return ChunkPos{
 		int32(math.Floor(vec3[0])) >> 4,
 		int32(math.Floor(vec3[2])) >> 4,
 	}
 }
 
-// chunkPosFromBlockPos returns a chunk position of the chunk that a block at this position would be in.
-func chunkPosFromBlockPos(p cube.Pos) ChunkPos {
+// ChunkPosFromBlockPos returns a chunk position of the chunk that a block at this position would be in.
+// Negative coordinates are correctly rounded down, so that block position -1 is in chunk position -1.
+func ChunkPosFromBlockPos(p cube.Pos) ChunkPos {
 	return ChunkPos{int32(p[0] >> 4), int32(p[2] >> 4)}
 }
 
@@ -7,6 +7,8 @@ import (
 
 // Provider represents a value that may provide world data to a World value. It usually does the reading and
 // writing of the world data so that the World may use it.
+// All methods returning an error return ErrProviderClosed if they are called after the Provider was closed,
+// including Close itself.
 type Provider interface {
 	io.Closer
 	// Settings returns the settings for a World.
@@ -14,23 +16,23 @@ type Provider interface {
 	// SaveSettings saves the settings of a World.
 	SaveSettings(Settings)
 
-	// LoadChunk attempts to load a chunk from the chunk position passed. If successful, a non-nil chunk is
-	// returned and exists is true and err nil. If no chunk was saved at the chunk position passed, the chunk
-	// returned is nil, and so is the error. If the chunk did exist, but if the data was invalid, nil is
-	// returned for the chunk and true, with a non-nil error.
-	// If exists ends up false, the chunk at the position is instead newly generated by the world.
-	LoadChunk(position ChunkPos) (c *chunk.Chunk, exists bool, err error)
+	// LoadChunk attempts to load a chunk from the chunk position passed. If no chunk was saved at the chunk
+	// position passed, ErrChunkNotFound is returned and the chunk at the position is instead newly generated
+	// by the world. If the chunk did exist, but its data was invalid, an ErrWorldCorrupt is returned.
+	LoadChunk(position ChunkPos) (*chunk.Chunk, error)
 	// SaveChunk saves a chunk at a specific position in the provider. If writing was not successful, an error
 	// is returned.
 	SaveChunk(position ChunkPos, c *chunk.Chunk) error
-	// LoadEntities loads all entities stored at a particular chunk position. If the entities cannot be read,
-	// LoadEntities returns a non-nil error.
+	// LoadEntities loads all entities stored at a particular chunk position. If no entities are stored at the
+	// position, no entities and no error are returned. If the entities cannot be decoded, an ErrWorldCorrupt
+	// is returned.
 	LoadEntities(position ChunkPos) ([]SaveableEntity, error)
 	// SaveEntities saves a list of entities in a chunk position. If writing is not successful, an error is
 	// returned.
 	SaveEntities(position ChunkPos, entities []SaveableEntity) error
-	// LoadBlockNBT loads the block NBT, also known as block entities, at a specific chunk position. If the
-	// NBT cannot be read, LoadBlockNBT returns a non-nil error.
+	// LoadBlockNBT loads the block NBT, also known as block entities, at a specific chunk position. If no
+	// block NBT is stored at the position, no NBT and no error are returned. If the NBT cannot be decoded, an
+	// ErrWorldCorrupt is returned.
 	LoadBlockNBT(position ChunkPos) ([]map[string]interface{}, error)
 	// SaveBlockNBT saves block NBT, or block entities, to a specific chunk position. If the NBT cannot be
 	// stored, SaveBlockNBT returns a non-nil error.
@@ -72,9 +74,9 @@ func (NoIOProvider) SaveChunk(ChunkPos, *chunk.Chunk) error {
 	return nil
 }
 
-// LoadChunk ...
-func (NoIOProvider) LoadChunk(ChunkPos) (*chunk.Chunk, bool, error) {
-	return nil, false, nil
+// LoadChunk always returns ErrChunkNotFound.
+func (NoIOProvider) LoadChunk(ChunkPos) (*chunk.Chunk, error) {
+	return nil, ErrChunkNotFound
 }
 
 // Close ...
@@ -0,0 +1,139 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"image"
+	"image/color"
+)
+
+// defaultMapColour is the colour on maps of blocks that do not implement MapColourer.
+var defaultMapColour = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+
+// maxWaterDepth is the depth of water from which the blocks below it are no longer visible on maps rendered
+// using World.RenderTopDown.
+const maxWaterDepth = 10
+
+// RenderTopDown renders a top-down map of the chunks from min to max, inclusive, in which every block column
+// is one pixel. The top left of the image is the north-west corner of min. Columns are coloured using the
+// MapColour of the highest block in them and shaded depending on the height of the column north of them.
+// Shallow water shows the blocks below it.
+// Chunks are read from the provider, unless they are loaded in the World, in which case a copy of the loaded
+// chunk is used. Rendering therefore neither loads nor generates chunks, and chunks that do not exist are
+// left transparent.
+func (w *World) RenderTopDown(min, max ChunkPos) (image.Image, error) {
+	if min[0] > max[0] {
+		min[0], max[0] = max[0], min[0]
+	}
+	if min[1] > max[1] {
+		min[1], max[1] = max[1], min[1]
+	}
+	width, height := int(max[0]-min[0]+1)<<4, int(max[1]-min[1]+1)<<4
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	heights := make([]int16, width*height)
+
+	for cx := min[0]; cx <= max[0]; cx++ {
+		for cz := min[1]; cz <= max[1]; cz++ {
+			c, err := w.renderChunk(ChunkPos{cx, cz})
+			if errors.Is(err, ErrChunkNotFound) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("render top down: %w", err)
+			}
+			baseX, baseZ := int(cx-min[0])<<4, int(cz-min[1])<<4
+			for x := uint8(0); x < 16; x++ {
+				for z := uint8(0); z < 16; z++ {
+					px, pz := baseX+int(x), baseZ+int(z)
+					col, y := columnColour(c, x, z)
+					img.SetRGBA(px, pz, col)
+					heights[pz*width+px] = y
+				}
+			}
+		}
+	}
+
+	// Columns higher than the column north of them are drawn lighter, and columns lower than it darker, so
+	// that the terrain is visible on the map.
+	for pz := 1; pz < height; pz++ {
+		for px := 0; px < width; px++ {
+			col := img.RGBAAt(px, pz)
+			if col.A == 0 || img.RGBAAt(px, pz-1).A == 0 {
+				continue
+			}
+			y, north := heights[pz*width+px], heights[(pz-1)*width+px]
+			switch {
+			case y > north:
+				img.SetRGBA(px, pz, shade(col, 1.15))
+			case y < north:
+				img.SetRGBA(px, pz, shade(col, 0.85))
+			}
+		}
+	}
+	return img, nil
+}
+
+// renderChunk returns the chunk at the position passed for rendering. If the chunk is loaded, a copy of it is
+// returned. Otherwise, it is loaded from the provider, without adding it to the World.
+func (w *World) renderChunk(pos ChunkPos) (*chunk.Chunk, error) {
+	w.chunkMu.Lock()
+	c, ok := w.chunks[pos]
+	w.chunkMu.Unlock()
+	if ok {
+		c.Lock()
+		defer c.Unlock()
+		return c.Chunk.Clone(), nil
+	}
+	return w.provider().LoadChunk(pos)
+}
+
+// columnColour returns the colour of the column at the x and z passed in a chunk and the Y value of the
+// highest visible block in it. Blocks with a transparent colour are skipped, and the colour of blocks below
+// water is mixed with that of the water depending on its depth.
+func columnColour(c *chunk.Chunk, x, z uint8) (color.RGBA, int16) {
+	var water color.RGBA
+	depth, surface := 0, int16(cube.MinY)
+	for y := c.HighestBlock(x, z); y >= cube.MinY; y-- {
+		rid := c.RuntimeID(x, y, z, 0)
+		if rid >= uint32(len(mapColours)) || mapColours[rid].A == 0 {
+			continue
+		}
+		col := mapColours[rid]
+		if l, ok := blocks[rid].(Liquid); ok && l.LiquidType() == "water" {
+			if depth == 0 {
+				water, surface = col, y
+			}
+			if depth++; depth < maxWaterDepth {
+				continue
+			}
+			return water, surface
+		}
+		if depth == 0 {
+			return col, y
+		}
+		return mix(water, col, 0.4+0.6*float64(depth)/maxWaterDepth), surface
+	}
+	return water, surface
+}
+
+// mix mixes the colours a and b, using a fraction f of colour a and the rest of colour b.
+func mix(a, b color.RGBA, f float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(a.R)*f + float64(b.R)*(1-f)),
+		G: uint8(float64(a.G)*f + float64(b.G)*(1-f)),
+		B: uint8(float64(a.B)*f + float64(b.B)*(1-f)),
+		A: 0xff,
+	}
+}
+
+// shade multiplies the red, green and blue channels of a colour with the factor passed, up to their maximum.
+func shade(col color.RGBA, f float64) color.RGBA {
+	channel := func(v uint8) uint8 {
+		if s := float64(v) * f; s < 0xff {
+			return uint8(s)
+		}
+		return 0xff
+	}
+	return color.RGBA{R: channel(col.R), G: channel(col.G), B: channel(col.B), A: col.A}
+}
@@ -0,0 +1,35 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestRenderTopDown(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+
+	w.SetBlock(cube.Pos{17, 5, 2}, block.Grass{})
+	w.SetBlock(cube.Pos{18, 5, 2}, block.Stone{})
+	w.SetBlock(cube.Pos{18, 6, 2}, block.Water{Depth: 8, Still: true})
+
+	img, err := w.RenderTopDown(world.ChunkPos{1, 0}, world.ChunkPos{0, 1})
+	if err != nil {
+		t.Fatalf("error rendering world: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 32 || b.Dy() != 32 {
+		t.Fatalf("expected 32x32 image, got %vx%v", b.Dx(), b.Dy())
+	}
+	if _, _, _, a := img.At(17, 2).RGBA(); a == 0 {
+		t.Errorf("expected grass to be visible on the map")
+	}
+	if r, g, b, _ := img.At(18, 2).RGBA(); b <= r || b <= g {
+		t.Errorf("expected shallow water over stone to be blue, got %v, %v, %v", r, g, b)
+	}
+	if _, _, _, a := img.At(3, 3).RGBA(); a != 0 {
+		t.Errorf("expected column without blocks to be transparent")
+	}
+}
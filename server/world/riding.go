@@ -0,0 +1,244 @@
+package world
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EntityLink is a link between an entity and the entity that it is riding. Any entity may ride any other
+// entity, and entities riding one another form a stack, with the entity at the bottom carrying all entities
+// above it.
+type EntityLink struct {
+	// Rider is the entity riding the Mount.
+	Rider Entity
+	// Mount is the entity that is being ridden by the Rider.
+	Mount Entity
+	// Seat is the offset of the Rider relative to the position of the Mount.
+	Seat mgl64.Vec3
+}
+
+// Ride makes the rider passed ride the mount passed, seated at the offset passed relative to the position of
+// the mount. Both entities must be in the world. If the rider is already riding another entity, it first
+// dismounts it. An error is returned if the mount is, directly or indirectly, riding the rider itself.
+// Viewers will see the rider move along with the mount until the rider is dismounted using Dismount or
+// either of the entities is removed from the world.
+func (w *World) Ride(rider, mount Entity, seat mgl64.Vec3) error {
+	if w == nil {
+		return fmt.Errorf("ride: world is nil")
+	}
+	if rider == mount {
+		return fmt.Errorf("ride: entity cannot ride itself")
+	}
+	w.entityMu.RLock()
+	_, riderFound := w.entities[rider]
+	_, mountFound := w.entities[mount]
+	w.entityMu.RUnlock()
+	if !riderFound || !mountFound {
+		return fmt.Errorf("ride: rider and mount must both be in the world")
+	}
+
+	w.ridingMu.Lock()
+	for e := mount; ; {
+		l, ok := w.riding[e]
+		if !ok {
+			break
+		}
+		if l.Mount == rider {
+			w.ridingMu.Unlock()
+			return fmt.Errorf("ride: mount is riding the rider")
+		}
+		e = l.Mount
+	}
+	old, wasRiding := w.riding[rider]
+	if wasRiding {
+		w.unlink(old)
+	}
+	l := EntityLink{Rider: rider, Mount: mount, Seat: seat}
+	w.riding[rider] = l
+	w.passengers[mount] = append(w.passengers[mount], rider)
+	w.ridingMu.Unlock()
+
+	if wasRiding {
+		for _, viewer := range w.linkViewers(old) {
+			viewer.ViewEntityUnlink(old)
+		}
+	}
+	for _, viewer := range w.linkViewers(l) {
+		viewer.ViewEntityLink(l)
+	}
+	return nil
+}
+
+// Dismount makes the rider passed stop riding the entity that it is currently riding. Entities riding the
+// rider itself keep riding it. Dismount does nothing if the rider is not riding any entity.
+func (w *World) Dismount(rider Entity) {
+	if w == nil {
+		return
+	}
+	w.ridingMu.Lock()
+	l, ok := w.riding[rider]
+	if ok {
+		w.unlink(l)
+	}
+	w.ridingMu.Unlock()
+
+	if ok {
+		for _, viewer := range w.linkViewers(l) {
+			viewer.ViewEntityUnlink(l)
+		}
+	}
+}
+
+// Riding returns the EntityLink of the rider passed to the entity that it is riding. If the rider is not
+// riding any entity, false is returned.
+func (w *World) Riding(rider Entity) (EntityLink, bool) {
+	if w == nil {
+		return EntityLink{}, false
+	}
+	w.ridingMu.Lock()
+	defer w.ridingMu.Unlock()
+	l, ok := w.riding[rider]
+	return l, ok
+}
+
+// Passengers returns the entities directly riding the mount passed, in the order that they started riding
+// it. Entities riding these passengers are not included.
+func (w *World) Passengers(mount Entity) []Entity {
+	if w == nil {
+		return nil
+	}
+	w.ridingMu.Lock()
+	defer w.ridingMu.Unlock()
+	return append([]Entity(nil), w.passengers[mount]...)
+}
+
+// dismountAll removes all links of the entity passed, both to the entity it is riding and to the entities
+// riding it. It is called when the entity is removed from the world, so that the entities riding it are
+// dismounted. The stack above these passengers is left intact.
+func (w *World) dismountAll(e Entity) {
+	w.ridingMu.Lock()
+	links := w.links(e)
+	for _, l := range links {
+		w.unlink(l)
+	}
+	w.ridingMu.Unlock()
+
+	for _, l := range links {
+		for _, viewer := range w.linkViewers(l) {
+			viewer.ViewEntityUnlink(l)
+		}
+	}
+}
+
+// links returns all links that the entity passed is part of, either as a rider or as a mount. links must
+// only be called while holding w.ridingMu.
+func (w *World) links(e Entity) []EntityLink {
+	var links []EntityLink
+	if l, ok := w.riding[e]; ok {
+		links = append(links, l)
+	}
+	for _, passenger := range w.passengers[e] {
+		links = append(links, w.riding[passenger])
+	}
+	return links
+}
+
+// unlink removes the EntityLink passed from the world. unlink must only be called while holding w.ridingMu.
+func (w *World) unlink(l EntityLink) {
+	delete(w.riding, l.Rider)
+	passengers := w.passengers[l.Mount]
+	n := make([]Entity, 0, len(passengers))
+	for _, passenger := range passengers {
+		if passenger != l.Rider {
+			n = append(n, passenger)
+		}
+	}
+	if len(n) == 0 {
+		delete(w.passengers, l.Mount)
+		return
+	}
+	w.passengers[l.Mount] = n
+}
+
+// bottom returns the entity at the bottom of the stack that the entity passed is part of. If the entity is
+// not riding any entity, the entity itself is returned.
+func (w *World) bottom(e Entity) Entity {
+	w.ridingMu.Lock()
+	defer w.ridingMu.Unlock()
+	for {
+		l, ok := w.riding[e]
+		if !ok {
+			return e
+		}
+		e = l.Mount
+	}
+}
+
+// showLinks shows the links of the entity passed to the viewer, if the viewer is able to see the other entity
+// of the link. It is called after the entity was shown to the viewer, so that a link is always shown after
+// both of its entities, regardless of which of the two entities the viewer saw first. shown holds all
+// entities that were shown to the viewer together with the entity passed: Links between two of these
+// entities are only shown once, when showLinks is called for the rider.
+func (w *World) showLinks(e Entity, viewer Viewer, shown []Entity) {
+	w.ridingMu.Lock()
+	links := w.links(e)
+	w.ridingMu.Unlock()
+
+	for _, l := range links {
+		other := l.Mount
+		if other == e {
+			other = l.Rider
+			if containsEntity(shown, other) {
+				continue
+			}
+		}
+		if w.hasViewer(viewer, w.entityViewers(other)) {
+			viewer.ViewEntityLink(l)
+		}
+	}
+}
+
+// linkViewers returns all viewers that are viewing both entities of the EntityLink passed.
+func (w *World) linkViewers(l EntityLink) []Viewer {
+	mountViewers := w.entityViewers(l.Mount)
+	riderViewers := w.entityViewers(l.Rider)
+
+	viewers := make([]Viewer, 0, len(riderViewers))
+	for _, viewer := range riderViewers {
+		if w.hasViewer(viewer, mountViewers) {
+			viewers = append(viewers, viewer)
+		}
+	}
+	return viewers
+}
+
+// entityViewers returns all viewers of the chunk that the entity passed is currently stored in.
+func (w *World) entityViewers(e Entity) (viewers []Viewer) {
+	w.entityMu.RLock()
+	pos, ok := w.entities[e]
+	w.entityMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	c, ok := w.chunkFromCache(pos)
+	if !ok {
+		return nil
+	}
+	c.Lock()
+	if len(c.v) > 0 {
+		viewers = make([]Viewer, len(c.v))
+		copy(viewers, c.v)
+	}
+	c.Unlock()
+	return
+}
+
+// containsEntity checks if the entities passed contain the entity e.
+func containsEntity(entities []Entity, e Entity) bool {
+	for _, entity := range entities {
+		if entity == e {
+			return true
+		}
+	}
+	return false
+}
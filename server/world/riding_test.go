@@ -0,0 +1,139 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"testing"
+)
+
+// linkViewer is a world.Viewer that keeps track of the entities and links it is viewing. It reports an error
+// if a link is viewed before both of its entities were spawned, or if a link is viewed twice.
+type linkViewer struct {
+	world.Viewer
+	t   *testing.T
+	pos mgl64.Vec3
+
+	mu      sync.Mutex
+	visible map[world.Entity]bool
+	linked  map[world.EntityLink]bool
+}
+
+func newLinkViewer(t *testing.T, pos mgl64.Vec3) *linkViewer {
+	return &linkViewer{t: t, pos: pos, visible: map[world.Entity]bool{}, linked: map[world.EntityLink]bool{}}
+}
+
+func (v *linkViewer) Position() mgl64.Vec3 { return v.pos }
+
+func (v *linkViewer) ViewEntity(e world.Entity) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.visible[e] = true
+}
+
+func (v *linkViewer) HideEntity(e world.Entity) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.visible, e)
+	for l := range v.linked {
+		// The link is lost once either of its entities is no longer visible.
+		if l.Rider == e || l.Mount == e {
+			delete(v.linked, l)
+		}
+	}
+}
+
+func (v *linkViewer) ViewEntityLink(l world.EntityLink) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.visible[l.Rider] || !v.visible[l.Mount] {
+		v.t.Errorf("link viewed before both entities were spawned")
+	}
+	if v.linked[l] {
+		v.t.Errorf("link viewed twice")
+	}
+	v.linked[l] = true
+}
+
+func (v *linkViewer) ViewEntityUnlink(l world.EntityLink) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.linked[l] {
+		v.t.Errorf("unlink viewed for a link that was not viewed")
+	}
+	delete(v.linked, l)
+}
+
+func (v *linkViewer) isLinked(l world.EntityLink) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.linked[l]
+}
+
+func (v *linkViewer) ViewEntityState(world.Entity)                                        {}
+func (v *linkViewer) ViewEntityItems(world.Entity)                                        {}
+func (v *linkViewer) ViewEntityArmour(world.Entity)                                       {}
+func (v *linkViewer) ViewEntityMovement(world.Entity, mgl64.Vec3, float64, float64, bool) {}
+func (v *linkViewer) ViewEntityVelocity(world.Entity, mgl64.Vec3)                         {}
+func (v *linkViewer) ViewEntityTeleport(world.Entity, mgl64.Vec3)                         {}
+func (v *linkViewer) ViewChunk(world.ChunkPos, *chunk.Chunk, map[cube.Pos]world.Block)    {}
+func (v *linkViewer) ViewTime(int)                                                        {}
+func (v *linkViewer) ViewWeather(bool, bool)                                              {}
+func (v *linkViewer) ViewGameRules(map[string]interface{})                                {}
+func (v *linkViewer) ViewWorldSpawn(cube.Pos)                                             {}
+
+func TestRideLateViewer(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+
+	// The mount and its rider are in different chunks, so that the late viewer loads them at different times.
+	mount := entity.NewText("mount", mgl64.Vec3{24, 0, 8})
+	rider := entity.NewText("rider", mgl64.Vec3{8, 0, 8})
+	top := entity.NewText("top", mgl64.Vec3{8, 0, 8})
+	w.AddEntity(mount)
+	w.AddEntity(rider)
+	w.AddEntity(top)
+
+	if err := w.Ride(rider, mount, mgl64.Vec3{0, 1, 0}); err != nil {
+		t.Fatalf("ride: %v", err)
+	}
+	if err := w.Ride(top, rider, mgl64.Vec3{0, 1, 0}); err != nil {
+		t.Fatalf("ride: %v", err)
+	}
+	if err := w.Ride(mount, top, mgl64.Vec3{}); err == nil {
+		t.Fatalf("expected an error riding an entity that is riding the mount")
+	}
+
+	riderLink, _ := w.Riding(rider)
+	topLink, _ := w.Riding(top)
+
+	v := newLinkViewer(t, mgl64.Vec3{8, 0, 8})
+	l := world.NewLoader(2, w, v)
+	defer l.Close()
+	l.Move(v.Position())
+	if err := l.Load(25); err != nil {
+		t.Fatalf("load chunks: %v", err)
+	}
+	if !v.isLinked(riderLink) || !v.isLinked(topLink) {
+		t.Fatalf("late viewer did not view all links of the stack")
+	}
+
+	// Removing the bottom of the stack dismounts the entity riding it, but leaves the rest of the stack intact.
+	w.RemoveEntity(mount)
+	if _, ok := w.Riding(rider); ok {
+		t.Fatalf("rider still riding after its mount was removed")
+	}
+	if v.isLinked(riderLink) {
+		t.Fatalf("viewer still viewing link of removed mount")
+	}
+	if l, ok := w.Riding(top); !ok || l.Mount != rider {
+		t.Fatalf("top of the stack no longer riding the rider after the mount was removed")
+	}
+	if len(w.Passengers(mount)) != 0 {
+		t.Fatalf("removed mount still has passengers")
+	}
+}
@@ -0,0 +1,80 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
+	"testing"
+	"time"
+)
+
+// countingProvider is a world.Provider that counts the chunks saved to it.
+type countingProvider struct {
+	world.NoIOProvider
+	saved atomic.Int32
+}
+
+func (p *countingProvider) SaveChunk(world.ChunkPos, *chunk.Chunk) error {
+	p.saved.Inc()
+	return nil
+}
+
+func TestSaveChangedChunks(t *testing.T) {
+	p := &countingProvider{}
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	w.Provider(p)
+
+	// Loading the chunk generates it, so it must be saved once.
+	_ = w.Block(cube.Pos{0, 0, 0})
+	w.Save()
+	if n := p.saved.Swap(0); n != 1 {
+		t.Fatalf("saved %v chunks after generating a chunk, want 1", n)
+	}
+	w.Save()
+	if n := p.saved.Swap(0); n != 0 {
+		t.Fatalf("saved %v chunks without changes, want 0", n)
+	}
+	w.SetBlock(cube.Pos{1, 1, 1}, block.Stone{})
+	w.Save()
+	if n := p.saved.Swap(0); n != 1 {
+		t.Fatalf("saved %v chunks after setting a block, want 1", n)
+	}
+}
+
+// saveHandler is a world.Handler that records the save events called.
+type saveHandler struct {
+	world.NopHandler
+	started, completed, chunks int
+}
+
+func (h *saveHandler) HandleSaveStart(int, int) {
+	h.started++
+}
+
+func (h *saveHandler) HandleSaveComplete(chunks, _ int, _ time.Duration) {
+	h.completed++
+	h.chunks = chunks
+}
+
+func TestSaveEvents(t *testing.T) {
+	p := &countingProvider{}
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	w.Provider(p)
+	w.SetPauseTickingOnSave(true)
+	h := &saveHandler{}
+	w.Handle(h)
+
+	w.SetBlock(cube.Pos{1, 1, 1}, block.Stone{})
+	w.Save()
+	if h.started != 1 || h.completed != 1 {
+		t.Fatalf("expected one save start and completion, got %v and %v", h.started, h.completed)
+	}
+	if h.chunks != 1 || p.saved.Load() != 1 {
+		t.Fatalf("expected 1 chunk written by the time Save returned, got %v (%v saved)", h.chunks, p.saved.Load())
+	}
+}
@@ -0,0 +1,130 @@
+package world
+
+import (
+	"fmt"
+	"go.uber.org/atomic"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Task is a function scheduled to run on the World using World.RunLater or World.RunRepeating. Tasks run on
+// the goroutine that ticks the World, so that they may use the World and the entities in it without
+// additional synchronisation.
+type Task struct {
+	f func()
+	// next is the time at which the task runs next, and interval the interval at which it is repeated. next
+	// is only used by the ticking goroutine after the task was scheduled.
+	next     time.Time
+	interval time.Duration
+	repeat   bool
+	// site is the file and line at which the task was scheduled, which is logged if the task panics.
+	site      string
+	cancelled atomic.Bool
+}
+
+// Cancel cancels the Task, so that it no longer runs. Cancel may be called at any time, including from within
+// the Task itself.
+func (t *Task) Cancel() {
+	t.cancelled.Store(true)
+}
+
+// Cancelled checks if the Task was cancelled, either using Cancel or because the World was closed.
+func (t *Task) Cancelled() bool {
+	return t.cancelled.Load()
+}
+
+// RunLater runs the function passed once after the delay passed. The function is run on the goroutine that
+// ticks the World, even while the World is paused, on the first tick after the delay passed. A Task is
+// returned that may be cancelled to prevent it from running. Tasks are cancelled when the World is closed.
+func (w *World) RunLater(delay time.Duration, f func()) *Task {
+	return w.schedule(f, delay, false)
+}
+
+// RunRepeating runs the function passed every time the interval passed passes, starting after the first
+// interval. The function is run on the goroutine that ticks the World, even while the World is paused. If the
+// interval is shorter than a tick, the function is run every tick. The Task returned must be cancelled to
+// stop running the function. Tasks are cancelled when the World is closed.
+func (w *World) RunRepeating(interval time.Duration, f func()) *Task {
+	return w.schedule(f, interval, true)
+}
+
+// schedule schedules a function to run after the duration passed, repeating it if repeat is true.
+func (w *World) schedule(f func(), d time.Duration, repeat bool) *Task {
+	t := &Task{f: f, next: time.Now().Add(d), interval: d, repeat: repeat, site: "unknown"}
+	if _, file, line, ok := runtime.Caller(2); ok {
+		t.site = fmt.Sprintf("%v:%v", file, line)
+	}
+	if w == nil {
+		t.Cancel()
+		return t
+	}
+	w.taskMu.Lock()
+	defer w.taskMu.Unlock()
+	select {
+	case <-w.closing:
+		t.Cancel()
+	default:
+		w.tasks = append(w.tasks, t)
+	}
+	return t
+}
+
+// runTasks runs all tasks that are due at the time passed, in the order that they were scheduled. Tasks
+// scheduled by the tasks run are not run until the next call.
+func (w *World) runTasks(now time.Time) {
+	w.taskMu.Lock()
+	due := w.dueTasks[:0]
+	tasks := w.tasks[:0]
+	for _, t := range w.tasks {
+		if t.Cancelled() {
+			continue
+		}
+		if now.Before(t.next) {
+			tasks = append(tasks, t)
+			continue
+		}
+		due = append(due, t)
+		if t.repeat {
+			t.next = now.Add(t.interval)
+			tasks = append(tasks, t)
+		}
+	}
+	// Clear the tasks removed from the end of the slice, so that they may be garbage collected.
+	for i := len(tasks); i < len(w.tasks); i++ {
+		w.tasks[i] = nil
+	}
+	w.tasks = tasks
+	w.taskMu.Unlock()
+
+	for _, t := range due {
+		// A task run before this one may have cancelled it.
+		if !t.Cancelled() {
+			w.runTask(t)
+		}
+	}
+	for i := range due {
+		due[i] = nil
+	}
+	w.dueTasks = due[:0]
+}
+
+// runTask runs a single Task, recovering and logging any panic that occurs.
+func (w *World) runTask(t *Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Errorf("panic in task scheduled at %v: %v\n%s", t.site, r, debug.Stack())
+		}
+	}()
+	t.f()
+}
+
+// cancelTasks cancels all tasks scheduled in the World. It is called when the World is closed.
+func (w *World) cancelTasks() {
+	w.taskMu.Lock()
+	defer w.taskMu.Unlock()
+	for _, t := range w.tasks {
+		t.Cancel()
+	}
+	w.tasks = nil
+}
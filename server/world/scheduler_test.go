@@ -0,0 +1,38 @@
+package world
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	// The world is not started, so that tasks are only run when runTasks is called.
+	w := &World{log: logrus.New(), closing: make(chan struct{})}
+
+	var order []int
+	later := w.RunLater(time.Second, func() { order = append(order, 1) })
+	repeating := w.RunRepeating(time.Second, func() { order = append(order, 2) })
+	cancelled := w.RunLater(time.Second, func() { order = append(order, 3) })
+	cancelled.Cancel()
+	w.RunLater(0, func() { panic("task panic") })
+
+	now := time.Now()
+	w.runTasks(now)
+	if len(order) != 0 {
+		t.Fatalf("expected no tasks to run before their delay, got %v", order)
+	}
+	w.runTasks(now.Add(time.Second * 2))
+	w.runTasks(now.Add(time.Second * 4))
+	if want := []int{1, 2, 2}; len(order) != len(want) || order[0] != 1 || order[1] != 2 || order[2] != 2 {
+		t.Fatalf("expected tasks to run in order %v, got %v", want, order)
+	}
+	if later.Cancelled() || repeating.Cancelled() {
+		t.Errorf("expected tasks not to be cancelled")
+	}
+
+	w.cancelTasks()
+	if !repeating.Cancelled() {
+		t.Errorf("expected repeating task to be cancelled when the world closes")
+	}
+}
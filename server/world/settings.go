@@ -2,6 +2,7 @@ package world
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"math/rand"
 )
 
 // Settings holds the settings of a World. These are typically saved to a level.dat file.
@@ -22,9 +23,46 @@ type Settings struct {
 	// Difficulty is the difficulty of the World. Behaviour of hunger, regeneration and monsters differs based on the
 	// difficulty of the world.
 	Difficulty Difficulty
+	// KeepInventory specifies if players keep their inventory when they die. If false, the inventory of a
+	// player is dropped at the position where it died.
+	KeepInventory bool
+	// Seed is the seed of the World. It is used to place the features of the World in a way that is the same
+	// every time a chunk is generated.
+	Seed int64
+	// WeatherCycle specifies if the weather changes naturally over time.
+	WeatherCycle bool
+	// Raining specifies if it is raining. RainTime is the amount of ticks until it stops raining, or until it
+	// starts raining if it is not raining and the weather cycle is enabled.
+	Raining  bool
+	RainTime int64
+	// Thundering specifies if there is a thunderstorm. Thunderstorms only occur while it is also raining.
+	// ThunderTime is the amount of ticks until the thunderstorm stops, or until it starts if there is no
+	// thunderstorm and the weather cycle is enabled.
+	Thundering  bool
+	ThunderTime int64
+	// GameRules holds the values of game rules changed using World.SetGameRule, indexed by their name. Game
+	// rules not present use their default value. The game rules 'dodaylightcycle', 'doweathercycle' and
+	// 'keepinventory' are stored in TimeCycle, WeatherCycle and KeepInventory instead.
+	GameRules map[string]interface{}
+	// TickingAreas holds the ticking areas of the World. Chunks in these areas are kept loaded and ticked,
+	// even if no viewers are near.
+	TickingAreas []TickingArea
+	// Structures holds the locations of the structures marked by the features of the World in the chunks
+	// generated so far, so that they may be found using World.LocateStructure.
+	Structures []StructureLocation
+	// Border is the border of the World, as set using World.SetBorder. It is disabled if its radius is 0.
+	Border Border
 }
 
 // defaultSettings returns the default Settings for a new World.
 func defaultSettings() Settings {
-	return Settings{Name: "World", DefaultGameMode: GameModeSurvival{}, Difficulty: DifficultyNormal{}, TimeCycle: true}
+	return Settings{
+		Name:            "World",
+		DefaultGameMode: GameModeSurvival{},
+		Difficulty:      DifficultyNormal{},
+		TimeCycle:       true,
+		WeatherCycle:    true,
+		RainTime:        clearDuration(rand.Intn),
+		ThunderTime:     clearDuration(rand.Intn),
+	}
 }
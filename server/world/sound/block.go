@@ -40,6 +40,9 @@ type BarrelOpen struct{ sound }
 // BarrelClose is played when a barrel is closed.
 type BarrelClose struct{ sound }
 
+// LecternBookPlace is a sound played when a book is placed on a lectern.
+type LecternBookPlace struct{ sound }
+
 // Deny is a sound played when a block is placed or broken above a 'Deny' block from Education edition.
 type Deny struct{ sound }
 
@@ -58,6 +61,9 @@ type Ignite struct{ sound }
 // FireExtinguish is a sound played when a fire is extinguished.
 type FireExtinguish struct{ sound }
 
+// SweetBerryBushPick is a sound played when sweet berries are picked from a sweet berry bush.
+type SweetBerryBushPick struct{ sound }
+
 // Note is a sound played by note blocks.
 type Note struct {
 	sound
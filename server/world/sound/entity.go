@@ -12,6 +12,15 @@ type Attack struct {
 // Burp is a sound played when a player finishes eating an item.
 type Burp struct{ sound }
 
+// Eat is a sound played while a player is eating an item, such as bread or a steak.
+type Eat struct{ sound }
+
+// Drink is a sound played while a player is drinking an item, such as a potion.
+type Drink struct{ sound }
+
+// LevelUp is a sound played when the experience level of a player increases.
+type LevelUp struct{ sound }
+
 // Pop is a sound played when a chicken lays an egg.
 type Pop struct{ sound }
 
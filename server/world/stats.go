@@ -0,0 +1,88 @@
+package world
+
+import (
+	"sort"
+	"time"
+)
+
+// tickSamples is the amount of recent ticks of which the duration is kept to calculate TickDurations.
+const tickSamples = 100
+
+// Stats holds statistics of a World, as returned by World.Stats.
+type Stats struct {
+	// TPS is the average amount of ticks per second of the world. It is at most 20.
+	TPS float64
+	// TickDuration holds the durations of recent ticks of the world.
+	TickDuration TickDurations
+	// Chunks is the amount of chunks currently loaded in the world.
+	Chunks int
+	// Entities is the amount of entities currently in the world, including players.
+	Entities int
+	// ChunkMemory is an estimate of the amount of bytes of memory used by the chunks loaded in the world.
+	ChunkMemory int
+}
+
+// TickDurations holds percentiles of the time taken by the last 100 ticks of a World.
+type TickDurations struct {
+	// P50, P95 and P99 are the 50th, 95th and 99th percentiles of the duration of the ticks.
+	P50, P95, P99 time.Duration
+	// Max is the duration of the longest tick.
+	Max time.Duration
+}
+
+// Stats returns a snapshot of the statistics of the World. The statistics are only aggregated when Stats is
+// called, so recording them costs next to nothing while they are not used.
+func (w *World) Stats() Stats {
+	if w == nil {
+		return Stats{}
+	}
+	s := Stats{TPS: w.TPS(), TickDuration: w.tickDurations()}
+
+	w.chunkMu.Lock()
+	s.Chunks = len(w.chunks)
+	chunks := make([]*chunkData, 0, len(w.chunks))
+	for _, c := range w.chunks {
+		chunks = append(chunks, c)
+	}
+	w.chunkMu.Unlock()
+
+	for _, c := range chunks {
+		c.Lock()
+		s.ChunkMemory += c.Size()
+		c.Unlock()
+	}
+
+	w.entityMu.RLock()
+	s.Entities = len(w.entities)
+	w.entityMu.RUnlock()
+	return s
+}
+
+// recordTick records the duration of a single tick of the World.
+func (w *World) recordTick(d time.Duration) {
+	w.tickDuration[w.tickCount.Inc()%tickSamples].Store(int64(d))
+}
+
+// tickDurations calculates the TickDurations of the ticks recorded using recordTick.
+func (w *World) tickDurations() TickDurations {
+	n := w.tickCount.Load()
+	if n > tickSamples {
+		n = tickSamples
+	}
+	if n == 0 {
+		return TickDurations{}
+	}
+	durations := make([]time.Duration, tickSamples)
+	for i := range durations {
+		durations[i] = time.Duration(w.tickDuration[i].Load())
+	}
+	// Slots that were never written are all at the start of the ring after sorting, so only the last n
+	// durations are recorded ones.
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	durations = durations[tickSamples-n:]
+
+	percentile := func(p float64) time.Duration {
+		return durations[int(p*float64(len(durations)-1)+0.5)]
+	}
+	return TickDurations{P50: percentile(0.5), P95: percentile(0.95), P99: percentile(0.99), Max: durations[len(durations)-1]}
+}
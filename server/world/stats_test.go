@@ -0,0 +1,28 @@
+package world
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickDurations(t *testing.T) {
+	w := &World{}
+	if d := w.tickDurations(); d != (TickDurations{}) {
+		t.Errorf("tickDurations() without ticks = %v, want zero", d)
+	}
+	for i := 1; i <= 10; i++ {
+		w.recordTick(time.Duration(i) * time.Millisecond)
+	}
+	want := TickDurations{P50: 6 * time.Millisecond, P95: 10 * time.Millisecond, P99: 10 * time.Millisecond, Max: 10 * time.Millisecond}
+	if d := w.tickDurations(); d != want {
+		t.Errorf("tickDurations() after 10 ticks = %+v, want %+v", d, want)
+	}
+
+	// Only the last ticks recorded should be taken into account.
+	for i := 0; i < tickSamples; i++ {
+		w.recordTick(time.Millisecond)
+	}
+	if d := w.tickDurations(); d.Max != time.Millisecond {
+		t.Errorf("tickDurations().Max after overwriting all samples = %v, want 1ms", d.Max)
+	}
+}
@@ -0,0 +1,141 @@
+package structure
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// mcstructure is the NBT format of .mcstructure files, as exported by structure blocks in vanilla.
+type mcstructure struct {
+	FormatVersion int32   `nbt:"format_version"`
+	Size          []int32 `nbt:"size"`
+	Structure     struct {
+		// BlockIndices holds two layers of indices into the block palette, one for every position in the
+		// structure. An index of -1 means no block is placed at the position.
+		BlockIndices [][]int32 `nbt:"block_indices"`
+		Palette      struct {
+			Default struct {
+				BlockPalette []paletteEntry `nbt:"block_palette"`
+				// BlockPositionData holds additional data of blocks, such as their block entity data, indexed
+				// by the index of the position in the structure.
+				BlockPositionData map[string]interface{} `nbt:"block_position_data"`
+			} `nbt:"default"`
+		} `nbt:"palette"`
+	} `nbt:"structure"`
+}
+
+// paletteEntry is a block state in the palette of a .mcstructure file.
+type paletteEntry struct {
+	Name    string                 `nbt:"name"`
+	States  map[string]interface{} `nbt:"states"`
+	Version int32                  `nbt:"version"`
+}
+
+// ReadFile reads a Structure from the .mcstructure file at the path passed, as exported from vanilla using a
+// structure block. See Read for more information.
+func ReadFile(path string) (*Structure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read structure: %w", err)
+	}
+	return Read(bytes.NewReader(data))
+}
+
+// Read reads a Structure in the .mcstructure format from the io.Reader passed. Positions marked as structure
+// void are left empty, so that placing the Structure does not change the blocks at those positions. Liquids
+// in the second layer of the structure are read as liquids placed alongside the blocks. Entities in the
+// structure are not read.
+// An error is returned if the data is not a valid structure or if it holds blocks that don't exist.
+func Read(r io.Reader) (*Structure, error) {
+	var m mcstructure
+	if err := nbt.NewDecoderWithEncoding(r, nbt.LittleEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read structure: decode nbt: %w", err)
+	}
+	if len(m.Size) != 3 {
+		return nil, fmt.Errorf("read structure: expected 3 dimensions, got %v", len(m.Size))
+	}
+	for _, n := range m.Size {
+		if n < 0 {
+			return nil, fmt.Errorf("read structure: negative dimensions %v", m.Size)
+		}
+	}
+	s := New([3]int{int(m.Size[0]), int(m.Size[1]), int(m.Size[2])})
+
+	blocks, err := m.blocks()
+	if err != nil {
+		return nil, fmt.Errorf("read structure: %w", err)
+	}
+	indices := m.Structure.BlockIndices
+	if len(indices) == 0 {
+		return s, nil
+	}
+	for layer, l := range indices {
+		if len(l) != len(s.blocks) {
+			return nil, fmt.Errorf("read structure: layer %v has %v blocks, expected %v", layer, len(l), len(s.blocks))
+		}
+	}
+	for i, index := range indices[0] {
+		if index < 0 {
+			continue
+		}
+		b, err := blockAt(blocks, index)
+		if err != nil {
+			return nil, fmt.Errorf("read structure: %w", err)
+		}
+		if data, ok := m.blockEntityData(i); ok {
+			if nbter, ok := b.(world.NBTer); ok {
+				b = nbter.DecodeNBT(data).(world.Block)
+			}
+		}
+		s.blocks[i] = s.paletteIndex(b)
+
+		if len(indices) < 2 || indices[1][i] < 0 {
+			continue
+		}
+		extra, err := blockAt(blocks, indices[1][i])
+		if err != nil {
+			return nil, fmt.Errorf("read structure: %w", err)
+		}
+		if liq, ok := extra.(world.Liquid); ok {
+			s.liquids[i] = liq
+		}
+	}
+	return s, nil
+}
+
+// blocks returns the blocks in the palette of the mcstructure.
+func (m mcstructure) blocks() ([]world.Block, error) {
+	palette := m.Structure.Palette.Default.BlockPalette
+	blocks := make([]world.Block, len(palette))
+	for i, entry := range palette {
+		b, ok := world.BlockByName(entry.Name, entry.States)
+		if !ok {
+			return nil, fmt.Errorf("unknown block %v{%+v}", entry.Name, entry.States)
+		}
+		blocks[i] = b
+	}
+	return blocks, nil
+}
+
+// blockEntityData returns the block entity data of the block at the index passed, if it has any.
+func (m mcstructure) blockEntityData(i int) (map[string]interface{}, bool) {
+	posData, ok := m.Structure.Palette.Default.BlockPositionData[strconv.Itoa(i)].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	data, ok := posData["block_entity_data"].(map[string]interface{})
+	return data, ok
+}
+
+// blockAt returns the block at the index passed in the blocks of a palette.
+func blockAt(blocks []world.Block, index int32) (world.Block, error) {
+	if int(index) >= len(blocks) {
+		return nil, fmt.Errorf("block palette index %v out of range: palette has %v blocks", index, len(blocks))
+	}
+	return blocks[index], nil
+}
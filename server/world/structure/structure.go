@@ -0,0 +1,105 @@
+package structure
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Structure is a world.Structure that holds all of its blocks in memory. It may be filled using Set, or read
+// from a .mcstructure file using Read or ReadFile, and placed in a world using world.World.BuildStructure.
+// A Structure is not safe for use by multiple goroutines at the same time.
+type Structure struct {
+	dim [3]int
+	// palette holds the unique blocks of the Structure. Blocks without block entity data are only added to
+	// it once, indexed by their runtime ID in paletteIndices.
+	palette        []world.Block
+	paletteIndices map[uint32]int32
+	// blocks holds an index into palette for every position in the Structure, or -1 for positions without a
+	// block. liquids holds the liquids of the positions that have one.
+	blocks  []int32
+	liquids map[int]world.Liquid
+}
+
+// New returns a new Structure with the dimensions passed: The width, height and length respectively. No
+// blocks are set in the Structure, so placing it does not change the blocks in the world until blocks are set
+// using Set.
+func New(dimensions [3]int) *Structure {
+	blocks := make([]int32, dimensions[0]*dimensions[1]*dimensions[2])
+	for i := range blocks {
+		blocks[i] = -1
+	}
+	return &Structure{
+		dim:            dimensions,
+		paletteIndices: map[uint32]int32{},
+		blocks:         blocks,
+		liquids:        map[int]world.Liquid{},
+	}
+}
+
+// Dimensions returns the width, height and length of the Structure.
+func (s *Structure) Dimensions() [3]int {
+	return s.dim
+}
+
+// At returns the block and liquid at the position passed, relative to the origin of the Structure. The block
+// returned is nil if no block was set at the position, in which case the block in the world is not changed
+// when the Structure is placed.
+func (s *Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	i := s.index(x, y, z)
+	if i < 0 || s.blocks[i] < 0 {
+		return nil, nil
+	}
+	return s.palette[s.blocks[i]], s.liquids[i]
+}
+
+// Set sets the block and liquid at the position passed, relative to the origin of the Structure. The liquid
+// is placed in the same position as the block and may be nil. Passing a nil block removes the block and
+// liquid at the position, so that the block in the world is not changed when the Structure is placed.
+// Positions outside the dimensions of the Structure are ignored.
+func (s *Structure) Set(x, y, z int, b world.Block, liq world.Liquid) {
+	i := s.index(x, y, z)
+	if i < 0 {
+		return
+	}
+	delete(s.liquids, i)
+	if b == nil {
+		s.blocks[i] = -1
+		return
+	}
+	s.blocks[i] = s.paletteIndex(b)
+	if liq != nil {
+		s.liquids[i] = liq
+	}
+}
+
+// paletteIndex returns the index of the block passed in the palette of the Structure, adding it to the palette
+// if it isn't yet present.
+func (s *Structure) paletteIndex(b world.Block) int32 {
+	if _, ok := b.(world.NBTer); ok {
+		// Blocks with block entity data may differ even if their runtime IDs are equal, so they are always
+		// added to the palette.
+		s.palette = append(s.palette, b)
+		return int32(len(s.palette) - 1)
+	}
+	rid, ok := world.BlockRuntimeID(b)
+	if !ok {
+		s.palette = append(s.palette, b)
+		return int32(len(s.palette) - 1)
+	}
+	if index, ok := s.paletteIndices[rid]; ok {
+		return index
+	}
+	s.palette = append(s.palette, b)
+	index := int32(len(s.palette) - 1)
+	s.paletteIndices[rid] = index
+	return index
+}
+
+// index returns the index of the position passed in the blocks of the Structure, or -1 if the position is
+// outside the dimensions of the Structure. Positions are ordered with Z increasing first, then Y and then X,
+// like in .mcstructure files.
+func (s *Structure) index(x, y, z int) int {
+	if x < 0 || y < 0 || z < 0 || x >= s.dim[0] || y >= s.dim[1] || z >= s.dim[2] {
+		return -1
+	}
+	return (x*s.dim[1]+y)*s.dim[2] + z
+}
@@ -0,0 +1,61 @@
+package structure_test
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/structure"
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestBuildStructure(t *testing.T) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+
+	s := stoneStructure(200, 100, 200)
+	// Positions without a block leave the block in the world untouched.
+	s.Set(5, 5, 5, nil, nil)
+	w.SetBlock(cube.Pos{-95, 15, -95}, block.Dirt{})
+
+	w.BuildStructure(cube.Pos{-100, 10, -100}, s)
+
+	for _, pos := range []cube.Pos{{-100, 10, -100}, {99, 109, 99}, {0, 50, 0}, {-1, 10, 37}} {
+		if b := w.Block(pos); b != (block.Stone{}) {
+			t.Errorf("block at %v is %#v, want stone", pos, b)
+		}
+	}
+	for _, pos := range []cube.Pos{{-101, 10, -100}, {100, 109, 99}, {0, 110, 0}, {0, 9, 0}} {
+		if b := w.Block(pos); b != (block.Air{}) {
+			t.Errorf("block outside the structure at %v is %#v, want air", pos, b)
+		}
+	}
+	if b := w.Block(cube.Pos{-95, 15, -95}); b != (block.Dirt{}) {
+		t.Errorf("block at an empty position of the structure is %#v, want dirt", b)
+	}
+}
+
+// BenchmarkBuildStructure measures the time it takes to build a 200x100x200 structure of stone in a world.
+func BenchmarkBuildStructure(b *testing.B) {
+	w := world.New(logrus.New(), 8)
+	defer w.Close()
+	s := stoneStructure(200, 100, 200)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.BuildStructure(cube.Pos{-100, 10, -100}, s)
+	}
+}
+
+// stoneStructure returns a structure of the dimensions passed that is entirely filled with stone.
+func stoneStructure(width, height, length int) *structure.Structure {
+	s := structure.New([3]int{width, height, length})
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			for z := 0; z < length; z++ {
+				s.Set(x, y, z, block.Stone{}, nil)
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package world
+
+import "fmt"
+
+// TickingArea is an area of chunks in a World that is kept loaded and ticked, even if no viewers are near.
+// Ticking areas may be added to a World using World.AddTickingArea. They are saved with the settings of
+// the World, so that they persist after a restart.
+type TickingArea struct {
+	// Name is the unique name of the TickingArea within its World.
+	Name string
+	// Min and Max are the corners of the TickingArea. Both corners are included in the area.
+	Min, Max ChunkPos
+}
+
+// Contains checks if the chunk at the position passed is within the TickingArea.
+func (a TickingArea) Contains(pos ChunkPos) bool {
+	return pos[0] >= a.Min[0] && pos[0] <= a.Max[0] && pos[1] >= a.Min[1] && pos[1] <= a.Max[1]
+}
+
+// Chunks returns the amount of chunks within the TickingArea.
+func (a TickingArea) Chunks() int {
+	return int(a.Max[0]-a.Min[0]+1) * int(a.Max[1]-a.Min[1]+1)
+}
+
+// Positions returns the positions of all chunks within the TickingArea.
+func (a TickingArea) Positions() []ChunkPos {
+	positions := make([]ChunkPos, 0, a.Chunks())
+	for x := a.Min[0]; x <= a.Max[0]; x++ {
+		for z := a.Min[1]; z <= a.Max[1]; z++ {
+			positions = append(positions, ChunkPos{x, z})
+		}
+	}
+	return positions
+}
+
+// TickingAreas returns all ticking areas currently present in the World.
+func (w *World) TickingAreas() []TickingArea {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]TickingArea(nil), w.set.TickingAreas...)
+}
+
+// AddTickingArea adds a ticking area with the name passed to the World. Chunks between min and max,
+// inclusive, are kept loaded and are ticked regardless of the simulation distance, as if a viewer was
+// present in each of them. An error is returned if an area with the same name already exists or if the
+// area would exceed the limits set using SetTickingAreaLimits.
+func (w *World) AddTickingArea(name string, min, max ChunkPos) error {
+	if w == nil {
+		return fmt.Errorf("add ticking area: world is nil")
+	}
+	a := TickingArea{
+		Name: name,
+		Min:  ChunkPos{minInt32(min[0], max[0]), minInt32(min[1], max[1])},
+		Max:  ChunkPos{maxInt32(min[0], max[0]), maxInt32(min[1], max[1])},
+	}
+	if n := int(w.maxTickingAreaChunks.Load()); a.Chunks() > n {
+		return fmt.Errorf("add ticking area %v: area of %v chunks exceeds the maximum of %v chunks", name, a.Chunks(), n)
+	}
+
+	w.mu.Lock()
+	if n := int(w.maxTickingAreas.Load()); len(w.set.TickingAreas) >= n {
+		w.mu.Unlock()
+		return fmt.Errorf("add ticking area %v: maximum of %v ticking areas reached", name, n)
+	}
+	for _, existing := range w.set.TickingAreas {
+		if existing.Name == name {
+			w.mu.Unlock()
+			return fmt.Errorf("add ticking area %v: ticking area with this name already exists", name)
+		}
+	}
+	w.set.TickingAreas = append(w.set.TickingAreas, a)
+	w.mu.Unlock()
+
+	// Load the chunks of the area right away, so that they are ticked starting from the next tick.
+	w.loadTickingArea(a)
+	return nil
+}
+
+// RemoveTickingArea removes the ticking area with the name passed from the World. The chunks in the area
+// are unloaded once they no longer have any viewers. RemoveTickingArea returns false if no ticking area
+// with the name existed.
+func (w *World) RemoveTickingArea(name string) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, a := range w.set.TickingAreas {
+		if a.Name == name {
+			w.set.TickingAreas = append(w.set.TickingAreas[:i:i], w.set.TickingAreas[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetTickingAreaLimits changes the maximum amount of ticking areas that may be present in the World and the
+// maximum amount of chunks that a single ticking area may contain. By default, these are 10 and 100,
+// respectively. Ticking areas already present in the World are not affected.
+func (w *World) SetTickingAreaLimits(maxAreas, maxChunks int) {
+	if w == nil {
+		return
+	}
+	w.maxTickingAreas.Store(int32(maxAreas))
+	w.maxTickingAreaChunks.Store(int32(maxChunks))
+}
+
+// loadTickingArea loads all chunks within the TickingArea passed that are not yet loaded.
+func (w *World) loadTickingArea(a TickingArea) {
+	for _, pos := range a.Positions() {
+		c, err := w.chunk(pos)
+		if err != nil {
+			w.log.Errorf("error loading chunk %v of ticking area %v: %v", pos, a.Name, err)
+			continue
+		}
+		c.Unlock()
+	}
+}
+
+// inTickingArea checks if the chunk at the position passed is within one of the ticking areas passed.
+func inTickingArea(areas []TickingArea, pos ChunkPos) bool {
+	for _, a := range areas {
+		if a.Contains(pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// minInt32 returns the smallest of two int32s.
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt32 returns the largest of two int32s.
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,29 @@
+package world
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+)
+
+func TestTickingAreaLimits(t *testing.T) {
+	w := New(logrus.New(), 8)
+	defer w.Close()
+	w.SetTickingAreaLimits(1, 4)
+
+	if err := w.AddTickingArea("big", ChunkPos{0, 0}, ChunkPos{2, 2}); err == nil {
+		t.Errorf("AddTickingArea with 9 chunks succeeded, want error")
+	}
+	if err := w.AddTickingArea("a", ChunkPos{1, 1}, ChunkPos{0, 0}); err != nil {
+		t.Fatalf("AddTickingArea: %v", err)
+	}
+	if err := w.AddTickingArea("b", ChunkPos{5, 5}, ChunkPos{5, 5}); err == nil {
+		t.Errorf("AddTickingArea beyond the maximum count succeeded, want error")
+	}
+	areas := w.TickingAreas()
+	if len(areas) != 1 || areas[0].Min != (ChunkPos{0, 0}) || areas[0].Max != (ChunkPos{1, 1}) {
+		t.Errorf("TickingAreas() = %v, want one area from {0 0} to {1 1}", areas)
+	}
+	if !w.RemoveTickingArea("a") || w.RemoveTickingArea("a") {
+		t.Errorf("RemoveTickingArea should only succeed for existing areas")
+	}
+}
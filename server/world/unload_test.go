@@ -0,0 +1,56 @@
+package world_test
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+func TestChunkUnloading(t *testing.T) {
+	w := world.New(logrus.New(), 0)
+	defer w.Close()
+	w.SetChunkCacheLimits(time.Hour, 50)
+
+	v := &chunkViewer{viewed: map[world.ChunkPos]int{}}
+	l := world.NewLoader(2, w, v)
+	defer l.Close()
+
+	// Fly in a straight line for 200 chunks. The chunks left behind are not unloaded because of the unload
+	// delay, so only the chunk limit keeps the amount of chunks from growing.
+	peak := 0
+	for x := 0; x < 200; x++ {
+		pos := mgl64.Vec3{float64(x*16 + 8), 0, 8}
+		v.move(pos)
+		l.Move(pos)
+		if err := l.Load(100); err != nil {
+			t.Fatalf("load chunks: %v", err)
+		}
+		if n := w.LoadedChunks(); n > peak {
+			peak = n
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if peak > 200 {
+		t.Errorf("%v chunks loaded at most while flying with a limit of 50 chunks", peak)
+	}
+	waitForLoadedChunks(t, w, 50)
+
+	// Without a limit, the chunks that are not viewed are unloaded once the unload delay passed. Only the 21
+	// chunks within a radius of 2 chunks remain.
+	w.SetChunkCacheLimits(0, 0)
+	waitForLoadedChunks(t, w, 21)
+}
+
+// waitForLoadedChunks waits until at most max chunks are loaded in the world passed, or fails the test if this
+// takes too long.
+func waitForLoadedChunks(t *testing.T, w *world.World, max int) {
+	deadline := time.Now().Add(time.Second * 5)
+	for w.LoadedChunks() > max {
+		if time.Now().After(deadline) {
+			t.Fatalf("%v chunks loaded, want at most %v", w.LoadedChunks(), max)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
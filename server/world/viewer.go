@@ -35,6 +35,12 @@ type Viewer interface {
 	// ViewTime views the time of the world. It is called every time the time is changed or otherwise every
 	// second.
 	ViewTime(time int)
+	// ViewWeather views the weather of the world. It is called every time it starts or stops raining or
+	// thundering, and when the viewer starts viewing the world.
+	ViewWeather(raining, thundering bool)
+	// ViewGameRules views game rules of the world, indexed by their name. It is called with all game rules
+	// when the viewer starts viewing the world, and with the game rules changed every time one is changed.
+	ViewGameRules(rules map[string]interface{})
 	// ViewEntityItems views the items currently held by an entity that is able to equip items.
 	ViewEntityItems(e Entity)
 	// ViewEntityArmour views the items currently equipped as armour by the entity.
@@ -42,6 +48,13 @@ type Viewer interface {
 	// ViewEntityAction views an action performed by an entity. Available actions may be found in the `action`
 	// package, and include things such as swinging an arm.
 	ViewEntityAction(e Entity, a action.Action)
+	// ViewEntityLink views an entity riding another entity. It is called when an entity starts riding another
+	// entity and when the viewer starts viewing both entities of a link. It is always called after both
+	// entities of the link were shown to the viewer.
+	ViewEntityLink(l EntityLink)
+	// ViewEntityUnlink views an entity no longer riding another entity, either because it was dismounted or
+	// because one of the entities was removed from the world.
+	ViewEntityUnlink(l EntityLink)
 	// ViewEntityState views the current state of an entity. It is called whenever an entity changes its
 	// physical appearance, for example when sprinting.
 	ViewEntityState(e Entity)
@@ -50,6 +63,13 @@ type Viewer interface {
 	ViewParticle(pos mgl64.Vec3, p Particle)
 	// ViewSound is called when a sound is played in the world.
 	ViewSound(pos mgl64.Vec3, s Sound)
+	// ViewSoundStop stops the sounds with the name passed, such as sounds played using sound.PlaySound, that
+	// are playing for the viewer. If the name is empty, all sounds are stopped.
+	ViewSoundStop(name string)
+	// ViewMusic plays the music track with the name passed to the viewer, such as when World.PlayMusic is
+	// called. Clients have no music channel for it, so the track is played as a sound at the head position of
+	// the viewer.
+	ViewMusic(name string, volume, pitch float64)
 	// ViewBlockUpdate views the updating of a block. It is called when a block is set at the position passed
 	// to the method.
 	ViewBlockUpdate(pos cube.Pos, b Block, layer int)
@@ -62,4 +82,7 @@ type Viewer interface {
 	ViewSkin(e Entity)
 	// ViewWorldSpawn views the current spawn location of the world.
 	ViewWorldSpawn(pos cube.Pos)
+	// ViewVisuals views the client-side visual settings of the world. It is called every time the Visuals of
+	// the world are changed.
+	ViewVisuals(v Visuals)
 }
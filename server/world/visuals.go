@@ -0,0 +1,15 @@
+package world
+
+// Visuals holds client-side visual settings of a World. They are applied to every viewer of the World when
+// it joins the World and are reset when it moves to a World with different Visuals.
+type Visuals struct {
+	// Fog is a list of fog identifiers, such as "minecraft:fog_hell", rendered by viewers of the World. If
+	// empty, viewers render the default fog of the biome they are in.
+	Fog []string
+	// MaxRenderDistance is the maximum chunk radius that viewers of the World may render. If 0, viewers may
+	// render up to the maximum chunk radius of the server.
+	MaxRenderDistance int
+	// CounteractBrightness specifies if a dark fog stack should be rendered on top of Fog to counteract
+	// video settings of viewers that increase brightness, such as gamma.
+	CounteractBrightness bool
+}
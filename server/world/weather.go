@@ -0,0 +1,204 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// StartRaining makes it rain in the World for the duration passed. If the duration is 0 or lower, it rains
+// for a random duration, as it does when the weather changes naturally. Once the duration passes, the rain
+// stops, regardless of whether the weather cycle is enabled.
+func (w *World) StartRaining(dur time.Duration) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Raining, w.set.RainTime = true, weatherTicks(dur, rainDuration)
+	w.mu.Unlock()
+	w.viewWeather()
+}
+
+// StopRaining stops the rain in the World, along with any thunderstorm.
+func (w *World) StopRaining() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Raining, w.set.RainTime = false, clearDuration(rand.Intn)
+	w.set.Thundering, w.set.ThunderTime = false, clearDuration(rand.Intn)
+	w.mu.Unlock()
+	w.viewWeather()
+}
+
+// Raining checks if it is currently raining in the World.
+func (w *World) Raining() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.set.Raining
+}
+
+// StartThundering starts a thunderstorm in the World for the duration passed, during which lightning strikes
+// near viewers every now and then. If the duration is 0 or lower, the thunderstorm lasts for a random
+// duration. A thunderstorm can only occur while it is raining, so StartThundering also makes it rain for at
+// least the same duration.
+func (w *World) StartThundering(dur time.Duration) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Thundering, w.set.ThunderTime = true, weatherTicks(dur, thunderDuration)
+	if !w.set.Raining || w.set.RainTime < w.set.ThunderTime {
+		w.set.Raining, w.set.RainTime = true, w.set.ThunderTime
+	}
+	w.mu.Unlock()
+	w.viewWeather()
+}
+
+// StopThundering stops the thunderstorm in the World. It keeps on raining if it was raining.
+func (w *World) StopThundering() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.Thundering, w.set.ThunderTime = false, clearDuration(rand.Intn)
+	w.mu.Unlock()
+	w.viewWeather()
+}
+
+// Thundering checks if there is currently a thunderstorm in the World. This is only the case if it is also
+// raining.
+func (w *World) Thundering() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.set.Raining && w.set.Thundering
+}
+
+// SetWeatherCycle specifies if the weather of the World changes naturally over time. If disabled, the weather
+// only changes when it is changed using the methods of World, and rain and thunderstorms started using
+// StartRaining and StartThundering stop once their duration passes. The weather cycle is enabled by default.
+func (w *World) SetWeatherCycle(cycle bool) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.WeatherCycle = cycle
+	w.mu.Unlock()
+	w.viewGameRules(map[string]interface{}{GameRuleWeatherCycle: cycle})
+}
+
+// tickWeather advances the weather of the World by one tick. True is returned if the weather visibly changed.
+// It must be called while holding w.mu.
+func (w *World) tickWeather() bool {
+	raining, thundering := w.set.Raining, w.set.Raining && w.set.Thundering
+
+	w.set.RainTime--
+	if w.set.RainTime <= 0 {
+		if w.set.WeatherCycle {
+			w.set.Raining = !w.set.Raining
+		} else {
+			w.set.Raining = false
+		}
+		if w.set.Raining {
+			w.set.RainTime = rainDuration(w.r.Intn)
+		} else {
+			w.set.RainTime = clearDuration(w.r.Intn)
+		}
+	}
+	w.set.ThunderTime--
+	if w.set.ThunderTime <= 0 {
+		if w.set.WeatherCycle {
+			w.set.Thundering = !w.set.Thundering
+		} else {
+			w.set.Thundering = false
+		}
+		if w.set.Thundering {
+			w.set.ThunderTime = thunderDuration(w.r.Intn)
+		} else {
+			w.set.ThunderTime = clearDuration(w.r.Intn)
+		}
+	}
+	return raining != w.set.Raining || thundering != (w.set.Raining && w.set.Thundering)
+}
+
+// viewWeather shows the current weather of the World to all of its viewers.
+func (w *World) viewWeather() {
+	raining, thundering := w.Raining(), w.Thundering()
+	for _, viewer := range w.allViewers() {
+		viewer.ViewWeather(raining, thundering)
+	}
+}
+
+// lightningChance is the chance per tick for lightning to strike in a chunk within the simulation distance of
+// a viewer during a thunderstorm. On average, lightning strikes once every 100000 ticks in every chunk.
+const lightningChance = 100000
+
+// tickLightning makes lightning strike at random positions in the chunks that are within the simulation
+// distance of viewers. It is only called during thunderstorms.
+func (w *World) tickLightning() {
+	if lightning == nil {
+		return
+	}
+	var strikes []mgl64.Vec3
+	w.chunkMu.Lock()
+	for pos, c := range w.chunks {
+		if w.r.Intn(lightningChance) != 0 || !w.simulating(pos) {
+			continue
+		}
+		// Every chunk is equally likely to be struck and every column in it is equally likely to be hit.
+		x, z := uint8(w.r.Intn(16)), uint8(w.r.Intn(16))
+		c.Lock()
+		y := c.HighestBlock(x, z) + 1
+		c.Unlock()
+		strikes = append(strikes, cube.Pos{int(pos[0])<<4 + int(x), int(y), int(pos[1])<<4 + int(z)}.Vec3Middle())
+	}
+	w.chunkMu.Unlock()
+
+	for _, pos := range strikes {
+		w.AddEntity(lightning(pos))
+	}
+}
+
+// lightning creates the entity of a lightning bolt striking at a position. It is registered by the entity
+// package using RegisterLightning.
+var lightning func(pos mgl64.Vec3) Entity
+
+// RegisterLightning registers the function used to create lightning bolts that strike during thunderstorms.
+// It is called by the entity package, which holds the lightning entity, and does not need to be called by
+// users.
+func RegisterLightning(f func(pos mgl64.Vec3) Entity) {
+	lightning = f
+}
+
+// weatherTicks converts the duration passed to ticks. If the duration is 0 or lower, a random duration is
+// returned using the function passed.
+func weatherTicks(dur time.Duration, random func(intn func(n int) int) int64) int64 {
+	if dur <= 0 {
+		return random(rand.Intn)
+	}
+	return dur.Nanoseconds() / int64(time.Second/20)
+}
+
+// clearDuration returns a random amount of ticks that the weather stays clear for, from half a day up to a
+// week of in-game days.
+func clearDuration(intn func(n int) int) int64 {
+	return int64(intn(168000) + 12000)
+}
+
+// rainDuration returns a random amount of ticks that it rains for, between half a day and a full in-game day.
+func rainDuration(intn func(n int) int) int64 {
+	return int64(intn(12000) + 12000)
+}
+
+// thunderDuration returns a random amount of ticks that a thunderstorm lasts for, between three minutes and
+// half an in-game day.
+func thunderDuration(intn func(n int) int) int64 {
+	return int64(intn(12000) + 3600)
+}
@@ -0,0 +1,42 @@
+package world
+
+import (
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+func TestWeather(t *testing.T) {
+	w := New(logrus.New(), 8)
+	defer w.Close()
+	w.SetWeatherCycle(false)
+
+	w.StartThundering(time.Second)
+	if !w.Raining() || !w.Thundering() {
+		t.Fatalf("expected rain and thunder after starting a thunderstorm")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := 0; i < 19; i++ {
+		if w.tickWeather() {
+			t.Fatalf("weather changed after %v ticks, want 20", i+1)
+		}
+	}
+	if !w.tickWeather() || w.set.Raining || w.set.Thundering {
+		t.Fatalf("expected thunderstorm to stop after 20 ticks")
+	}
+	for i := 0; i < 200000; i++ {
+		if w.tickWeather() {
+			t.Fatalf("weather changed with weather cycle disabled")
+		}
+	}
+
+	w.set.WeatherCycle = true
+	w.set.RainTime = 1
+	if !w.tickWeather() || !w.set.Raining {
+		t.Fatalf("expected rain to start naturally with weather cycle enabled")
+	}
+	if w.set.RainTime < 12000 || w.set.RainTime >= 24000 {
+		t.Errorf("natural rain lasts %v ticks, want between 12000 and 24000", w.set.RainTime)
+	}
+}
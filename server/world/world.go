@@ -1,14 +1,18 @@
 package world
 
 import (
+	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/physics"
+	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/internal"
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 	"go.uber.org/atomic"
+	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -21,79 +25,173 @@ import (
 type World struct {
 	log internal.Logger
 
-	mu   sync.Mutex
-	set  Settings
-	prov Provider
+	mu      sync.Mutex
+	set     Settings
+	visuals Visuals
+	prov    Provider
 
 	rdonly atomic.Bool
 
+	// immunity is the duration that entities in the world are immune to damage after being hurt.
+	immunity atomic.Duration
+	// tickInterval is the moving average of the time between the start of two ticks, in seconds.
+	tickInterval atomic.Float64
+	// tickDuration holds the durations of the last ticks of the world in a ring buffer, indexed by tickCount.
+	tickDuration [tickSamples]atomic.Int64
+	tickCount    atomic.Uint64
+	// tickRate is the amount of times per second that the world is ticked.
+	tickRate atomic.Int32
+	// pauseWhenEmpty specifies if the world stops ticking while it has no viewers, even if it has ticking
+	// areas. catchUp specifies if scheduled block updates catch up on the ticks that passed while paused.
+	pauseWhenEmpty, catchUp atomic.Bool
+	// entityPush is the strength with which colliding living entities push each other apart every tick.
+	// pushOutOfBlocks specifies if entities stuck in blocks are pushed out of them.
+	entityPush      atomic.Float64
+	pushOutOfBlocks atomic.Bool
+	// pausedTicks is the amount of ticks that were skipped since the world was paused. It is only used by the
+	// ticking goroutine.
+	pausedTicks int64
+
 	lastPos   ChunkPos
 	lastChunk *chunkData
 
 	closing chan struct{}
 	running sync.WaitGroup
 
-	handlerMu sync.RWMutex
-	handler   Handler
-
-	genMu sync.RWMutex
-	gen   Generator
+	// saveMu is held while the world is being saved. saveRequests is the amount of times Save was called, and
+	// savedRequests the value of saveRequests when the last save started, so that calls to Save waiting for
+	// an ongoing save may return once a save that started after them completes. savedRequests is guarded by
+	// saveMu.
+	saveMu        sync.Mutex
+	saveRequests  atomic.Uint64
+	savedRequests uint64
+	// tickMu is held while the world is ticked. If pauseOnSave is true, it is also held while the chunks of
+	// the world are copied to be saved, so that no ticks happen in the meantime.
+	tickMu      sync.Mutex
+	pauseOnSave atomic.Bool
+	// saveInterval is the interval at which the world is saved automatically. If 0, the world is only saved
+	// when it is closed or when Save is called.
+	saveInterval atomic.Duration
+	// backupMu is held for reading while a chunk is written to the provider, and for writing while a snapshot
+	// of the provider is taken by Backup.
+	backupMu sync.RWMutex
+
+	handlers event.Handlers[Handler]
+
+	genMu    sync.RWMutex
+	gen      Generator
+	features []Feature
 
 	chunkMu sync.Mutex
 	// chunks holds a cache of chunks currently loaded. These chunks are cleared from this map after some time
 	// of not being used.
 	chunks map[ChunkPos]*chunkData
+	// loading holds the chunks that are currently being loaded or generated. Chunks are loaded without holding
+	// chunkMu, and a chunk requested while it is being loaded is waited for rather than loaded again.
+	loading map[ChunkPos]*chunkLoad
 
 	entityMu sync.RWMutex
 	// entities holds a map of entities currently loaded and the last ChunkPos that the Entity was in.
 	// These are tracked so that a call to RemoveEntity can find the correct entity.
 	entities map[Entity]ChunkPos
 
-	r         *rand.Rand
-	simDistSq int32
+	ridingMu sync.Mutex
+	// riding holds the EntityLinks of all entities riding another entity, indexed by the rider. passengers
+	// holds the entities riding an entity, indexed by that entity, in the order that they started riding it.
+	riding     map[Entity]EntityLink
+	passengers map[Entity][]Entity
+
+	r *rand.Rand
+	// simDist is the simulation distance of the world in chunks. Blocks and entities in chunks further than
+	// the simulation distance away from all viewers are not ticked.
+	simDist atomic.Int32
 
 	randomTickSpeed atomic.Uint32
 
+	// maxTickingAreas and maxTickingAreaChunks limit the amount of ticking areas in the world and the amount
+	// of chunks in a single ticking area.
+	maxTickingAreas, maxTickingAreaChunks atomic.Int32
+
+	// unloadDelay is the time after which chunks without viewers are unloaded. maxChunks is the maximum amount
+	// of chunks loaded at the same time, or 0 if unlimited. Exceeding it sends to evictChunks, so that the
+	// chunk cache janitor unloads chunks right away.
+	unloadDelay atomic.Duration
+	maxChunks   atomic.Int32
+	evictChunks chan struct{}
+
 	updateMu sync.Mutex
 	// blockUpdates is a map of tick time values indexed by the block position at which an update is
 	// scheduled. If the current tick exceeds the tick value passed, the block update will be performed
 	// and the entry will be removed from the map.
 	blockUpdates             map[cube.Pos]int64
-	updatePositions          []cube.Pos
+	updatePositions          []scheduledUpdate
 	neighbourUpdatePositions []neighbourUpdate
 	neighbourUpdatesSync     []neighbourUpdate
+	// catchUpTicks is the amount of ticks that scheduled block updates are still behind after the world
+	// resumed ticking. They catch up at most maxCatchUpTicks every tick, so that not all backlogged updates
+	// are performed at once. catchUpUpdates holds the positions of the block updates that were scheduled when
+	// the world resumed, which are the only updates that catch up: Updates scheduled while catching up are
+	// performed after their normal delay.
+	catchUpTicks   int64
+	catchUpUpdates map[cube.Pos]struct{}
 
 	toTick              []toTick
 	blockEntitiesToTick []blockEntityToTick
 	positionCache       []ChunkPos
+	tickingAreaCache    []TickingArea
 	entitiesToTick      []TickerEntity
 
 	viewersMu sync.Mutex
 	viewers   map[Viewer]struct{}
+
+	taskMu sync.Mutex
+	// tasks holds the tasks scheduled using RunLater and RunRepeating in the order that they were scheduled.
+	// dueTasks is reused by the ticking goroutine to hold the tasks that are due.
+	tasks    []*Task
+	dueTasks []*Task
+
+	// music is the MusicPlayer used to play music to all viewers of the world using PlayMusic.
+	music *MusicPlayer
 }
 
 // New creates a new initialised world. The world may be used right away, but it will not be saved or loaded
 // from files until it has been given a different provider than the default. (NoIOProvider)
 // By default, the name of the world will be 'World'.
 func New(log internal.Logger, simulationDistance int) *World {
+	worldCreated.Store(true)
 	w := &World{
-		r:               rand.New(rand.NewSource(time.Now().Unix())),
-		blockUpdates:    map[cube.Pos]int64{},
-		entities:        map[Entity]ChunkPos{},
-		viewers:         map[Viewer]struct{}{},
-		prov:            NoIOProvider{},
-		gen:             NopGenerator{},
-		handler:         NopHandler{},
-		simDistSq:       int32(simulationDistance * simulationDistance),
-		randomTickSpeed: *atomic.NewUint32(3),
-		log:             log,
-		set:             defaultSettings(),
-		closing:         make(chan struct{}),
-	}
-
+		r:                    rand.New(rand.NewSource(time.Now().Unix())),
+		blockUpdates:         map[cube.Pos]int64{},
+		entities:             map[Entity]ChunkPos{},
+		riding:               map[Entity]EntityLink{},
+		passengers:           map[Entity][]Entity{},
+		viewers:              map[Viewer]struct{}{},
+		prov:                 NoIOProvider{},
+		gen:                  NopGenerator{},
+		simDist:              *atomic.NewInt32(int32(simulationDistance)),
+		randomTickSpeed:      *atomic.NewUint32(3),
+		maxTickingAreas:      *atomic.NewInt32(10),
+		maxTickingAreaChunks: *atomic.NewInt32(100),
+		unloadDelay:          *atomic.NewDuration(time.Minute),
+		evictChunks:          make(chan struct{}, 1),
+		log:                  log,
+		set:                  defaultSettings(),
+		immunity:             *atomic.NewDuration(time.Second / 2),
+		tickInterval:         *atomic.NewFloat64(0.05),
+		tickRate:             *atomic.NewInt32(20),
+		entityPush:           *atomic.NewFloat64(0.05),
+		pushOutOfBlocks:      *atomic.NewBool(true),
+		closing:              make(chan struct{}),
+	}
+
+	w.music = NewMusicPlayer(w.playMusic, w.StopSound)
 	w.initChunkCache()
+	// The goroutines are added to the WaitGroup before starting them, so that a call to Close directly after
+	// New always waits for them to stop.
+	w.running.Add(3)
 	go w.startTicking()
 	go w.chunkCacheJanitor()
+	go w.autoSave()
 	return w
 }
 
@@ -114,7 +212,7 @@ func (w *World) Block(pos cube.Pos) Block {
 		// Fast way out.
 		return air()
 	}
-	chunkPos := ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}
+	chunkPos := ChunkPosFromBlockPos(pos)
 	c, err := w.chunk(chunkPos)
 	if err != nil {
 		w.log.Errorf("error getting block: %v", err)
@@ -156,14 +254,15 @@ func (w *World) blockInChunk(c *chunkData, pos cube.Pos) (Block, error) {
 }
 
 // runtimeID gets the block runtime ID at a specific position in the world.
+// noinspection GoUnusedFunction
+//
 //lint:ignore U1000 Function is used using compiler directives.
-//noinspection GoUnusedFunction
 func runtimeID(w *World, pos cube.Pos) uint32 {
 	if w == nil || pos.OutOfBounds() {
 		// Fast way out.
 		return airRID
 	}
-	c, err := w.chunk(ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)})
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
 		return airRID
 	}
@@ -179,7 +278,7 @@ func (w *World) HighestLightBlocker(x, z int) int16 {
 	if w == nil {
 		return 0
 	}
-	c, err := w.chunk(ChunkPos{int32(x >> 4), int32(z >> 4)})
+	c, err := w.chunk(ChunkPosFromBlockPos(cube.Pos{x, 0, z}))
 	if err != nil {
 		return 0
 	}
@@ -194,7 +293,7 @@ func (w *World) HighestBlock(x, z int) int {
 	if w == nil {
 		return 0
 	}
-	c, err := w.chunk(ChunkPos{int32(x >> 4), int32(z >> 4)})
+	c, err := w.chunk(ChunkPosFromBlockPos(cube.Pos{x, 0, z}))
 	if err != nil {
 		return 0
 	}
@@ -215,8 +314,7 @@ func (w *World) SetBlock(pos cube.Pos, b Block) {
 		return
 	}
 
-	x, z := int32(pos[0]>>4), int32(pos[2]>>4)
-	c, err := w.chunk(ChunkPos{x, z})
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
 		return
 	}
@@ -227,7 +325,14 @@ func (w *World) SetBlock(pos cube.Pos, b Block) {
 		w.log.Errorf("runtime ID of block %+v not found", b)
 		return
 	}
+	before := c.RuntimeID(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
 	c.SetRuntimeID(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, rid)
+	c.dirty = true
+	if _, ok := b.(Liquid); ok {
+		c.liquidChanged()
+	} else if _, ok := blocks[before].(Liquid); ok {
+		c.liquidChanged()
+	}
 
 	if nbtBlocks[rid] {
 		c.e[pos] = b
@@ -282,6 +387,54 @@ func (w *World) BreakBlockWithoutParticles(pos cube.Pos) {
 	}
 }
 
+// BreakBlocks breaks the blocks at all positions passed without showing particles, like
+// BreakBlockWithoutParticles. Instead of sending an update for every block broken, every chunk that had blocks
+// broken is sent to its viewers again once all of its blocks are broken, which makes BreakBlocks much cheaper
+// than separate BreakBlockWithoutParticles calls when breaking a lot of blocks at once, such as in an
+// explosion.
+func (w *World) BreakBlocks(positions []cube.Pos) {
+	if w == nil {
+		return
+	}
+	byChunk := make(map[ChunkPos][]cube.Pos)
+	for _, pos := range positions {
+		if pos.OutOfBounds() {
+			continue
+		}
+		chunkPos := ChunkPosFromBlockPos(pos)
+		byChunk[chunkPos] = append(byChunk[chunkPos], pos)
+	}
+	for chunkPos, chunkPositions := range byChunk {
+		c, err := w.chunk(chunkPos)
+		if err != nil {
+			w.log.Errorf("error loading chunk for breaking blocks: %v", err)
+			continue
+		}
+		for _, pos := range chunkPositions {
+			x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+			rid := airRID
+			if liqRID := c.RuntimeID(x, y, z, 1); liqRID != airRID {
+				if _, ok := blocks[liqRID].(Liquid); ok {
+					// Move the liquid down a layer.
+					rid = liqRID
+					c.SetRuntimeID(x, y, z, 1, airRID)
+				}
+			}
+			c.SetRuntimeID(x, y, z, 0, rid)
+			delete(c.e, pos)
+		}
+		c.dirty = true
+		c.liquidChanged()
+		for _, viewer := range c.v {
+			viewer.ViewChunk(chunkPos, c.Chunk, c.e)
+		}
+		c.Unlock()
+	}
+	for _, pos := range positions {
+		w.doBlockUpdatesAround(pos)
+	}
+}
+
 // PlaceBlock places a block at the position passed. Unlike when using SetBlock, PlaceBlock also schedules
 // block updates around the position.
 // If the block can displace liquids at the position placed, it will do so, and liquid source blocks will be
@@ -306,21 +459,28 @@ func (w *World) PlaceBlock(pos cube.Pos, b Block) {
 // BuildStructure is specifically tinkered to be able to process a large batch of chunks simultaneously and
 // will do so within much less time than separate SetBlock calls would.
 // The method operates on a per-chunk basis, setting all blocks within a single chunk part of the structure
-// before moving on to the next chunk.
+// before moving on to the next chunk. Chunks that are not yet loaded are loaded or generated first. Instead of
+// sending an update for every block changed, every chunk is sent to its viewers again once all its blocks
+// are set.
 func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 	if w == nil {
 		return
 	}
 	dim := s.Dimensions()
-	width, height, length := dim[0], dim[1], dim[2]
-	maxX, maxY, maxZ := pos[0]+width, pos[1]+height, pos[2]+length
+	maxX, maxY, maxZ := pos[0]+dim[0], pos[1]+dim[1], pos[2]+dim[2]
+	minY := pos[1]
+	if minY < cube.MinY {
+		minY = cube.MinY
+	}
+	if maxY > cube.MaxY+1 {
+		maxY = cube.MaxY + 1
+	}
 
-	for chunkX := pos[0] >> 4; chunkX < (maxX>>4)+1; chunkX++ {
-		for chunkZ := pos[2] >> 4; chunkZ < (maxZ>>4)+1; chunkZ++ {
+	for chunkX := pos[0] >> 4; chunkX <= (maxX-1)>>4; chunkX++ {
+		for chunkZ := pos[2] >> 4; chunkZ <= (maxZ-1)>>4; chunkZ++ {
 			// We approach this on a per-chunk basis, so that we can keep only one chunk in memory at a time
 			// while not needing to acquire a new chunk lock for every block. This also allows us not to send
 			// block updates, but instead send a single chunk update once.
-
 			chunkPos := ChunkPos{int32(chunkX), int32(chunkZ)}
 			c, err := w.chunk(chunkPos)
 			if err != nil {
@@ -328,84 +488,64 @@ func (w *World) BuildStructure(pos cube.Pos, s Structure) {
 				continue
 			}
 			f := func(x, y, z int) Block {
-				actualX, actualZ := chunkX+x, chunkZ+z
-				if actualX>>4 == chunkX && actualZ>>4 == chunkZ {
-					b, _ := w.blockInChunk(c, cube.Pos{actualX, y, actualZ})
+				actual := cube.Pos{pos[0] + x, pos[1] + y, pos[2] + z}
+				if actual[0]>>4 == chunkX && actual[2]>>4 == chunkZ {
+					b, _ := w.blockInChunk(c, actual)
 					return b
 				}
-				return w.Block(cube.Pos{actualX, y, actualZ})
+				return w.Block(actual)
 			}
-			baseX, baseZ := chunkX<<4, chunkZ<<4
-			subs := c.Sub()
-			for i, sub := range subs {
-				baseY := i << 4
-				if sub == nil {
-					c.SetRuntimeID(0, int16(baseY), 0, 0, airRID)
-					sub = subs[i]
-				}
-
-				if i < pos[1]>>4 {
-					continue
-				} else if baseY >= maxY {
-					break
-				}
-
-				for localY := 0; localY < 16; localY++ {
-					yOffset := baseY + localY
-					if yOffset > cube.MaxY || yOffset >= maxY {
-						// We've hit the height limit for blocks.
-						break
-					} else if yOffset < cube.MinY || yOffset < pos[1] {
-						// We've got a block below the minimum, but other blocks might still reach above
-						// it, so don't break but continue.
-						continue
-					}
-					for localX := 0; localX < 16; localX++ {
-						xOffset := baseX + localX
-						if xOffset < pos[0] || xOffset >= maxX {
+			// Only the part of the structure that lies within this chunk is placed.
+			startX, endX := chunkX<<4, chunkX<<4+16
+			if startX < pos[0] {
+				startX = pos[0]
+			}
+			if endX > maxX {
+				endX = maxX
+			}
+			startZ, endZ := chunkZ<<4, chunkZ<<4+16
+			if startZ < pos[2] {
+				startZ = pos[2]
+			}
+			if endZ > maxZ {
+				endZ = maxZ
+			}
+			for y := minY; y < maxY; y++ {
+				for x := startX; x < endX; x++ {
+					for z := startZ; z < endZ; z++ {
+						b, liq := s.At(x-pos[0], y-pos[1], z-pos[2], f)
+						if b == nil {
+							// Nil blocks leave the block at the position untouched.
 							continue
 						}
-						for localZ := 0; localZ < 16; localZ++ {
-							zOffset := baseZ + localZ
-							if zOffset < pos[2] || zOffset >= maxZ {
+						rid, ok := BlockRuntimeID(b)
+						if !ok {
+							w.log.Errorf("error setting block of structure: runtime ID of block state %+v not found", b)
+							continue
+						}
+						blockPos := cube.Pos{x, y, z}
+						c.SetRuntimeID(uint8(x), int16(y), uint8(z), 0, rid)
+						if nbtBlocks[rid] {
+							c.e[blockPos] = b
+						} else {
+							delete(c.e, blockPos)
+						}
+
+						liqRID := airRID
+						if liq != nil {
+							if liqRID, ok = BlockRuntimeID(liq); !ok {
+								w.log.Errorf("error setting liquid of structure: runtime ID of block state %+v not found", liq)
 								continue
 							}
-							b, liq := s.At(xOffset-pos[0], yOffset-pos[1], zOffset-pos[2], f)
-							if b != nil {
-								rid, ok := BlockRuntimeID(b)
-								if !ok {
-									w.log.Errorf("error setting block of structure: runtime ID of block state %+v not found", b)
-									continue
-								}
-								sub.SetRuntimeID(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0, rid)
-
-								if nbtBlocks[rid] {
-									c.e[pos] = b
-								} else {
-									delete(c.e, pos)
-								}
-							} else {
-								sub.SetRuntimeID(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0, airRID)
-							}
-							if liq != nil {
-								rid, ok := BlockRuntimeID(liq)
-								if !ok {
-									w.log.Errorf("runtime ID of block state %+v not found", liq)
-									continue
-								}
-								sub.SetRuntimeID(uint8(xOffset), uint8(yOffset), uint8(zOffset), 1, rid)
-							} else {
-								if len(sub.Layers()) < 2 {
-									continue
-								}
-								sub.SetRuntimeID(uint8(xOffset), uint8(yOffset), uint8(zOffset), 1, airRID)
-							}
 						}
+						c.SetRuntimeID(uint8(x), int16(y), uint8(z), 1, liqRID)
 					}
 				}
 			}
 			// After setting all blocks of the structure within a single chunk, we show the new chunk to all
 			// viewers once, and unlock it.
+			c.dirty = true
+			c.liquidChanged()
 			for _, viewer := range c.v {
 				viewer.ViewChunk(chunkPos, c.Chunk, c.e)
 			}
@@ -422,9 +562,9 @@ func (w *World) Liquid(pos cube.Pos) (Liquid, bool) {
 		// Fast way out.
 		return nil, false
 	}
-	c, err := w.chunk(chunkPosFromBlockPos(pos))
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
-		w.log.Errorf("failed getting liquid: error getting chunk at position %v: %v", chunkPosFromBlockPos(pos), err)
+		w.log.Errorf("failed getting liquid: error getting chunk at position %v: %v", ChunkPosFromBlockPos(pos), err)
 		return nil, false
 	}
 	x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
@@ -463,14 +603,16 @@ func (w *World) SetLiquid(pos cube.Pos, b Liquid) {
 		// Fast way out.
 		return
 	}
-	chunkPos := chunkPosFromBlockPos(pos)
+	chunkPos := ChunkPosFromBlockPos(pos)
 	c, err := w.chunk(chunkPos)
 	if err != nil {
-		w.log.Errorf("failed setting liquid: error getting chunk at position %v: %v", chunkPosFromBlockPos(pos), err)
+		w.log.Errorf("failed setting liquid: error getting chunk at position %v: %v", ChunkPosFromBlockPos(pos), err)
 		return
 	}
+	c.liquidChanged()
 	if b == nil {
 		w.removeLiquids(c, pos)
+		c.dirty = true
 		c.Unlock()
 		w.doBlockUpdatesAround(pos)
 		return
@@ -480,7 +622,7 @@ func (w *World) SetLiquid(pos cube.Pos, b Liquid) {
 		current, err := w.blockInChunk(c, pos)
 		if err != nil {
 			c.Unlock()
-			w.log.Errorf("failed setting liquid: error getting block at position %v: %v", chunkPosFromBlockPos(pos), err)
+			w.log.Errorf("failed setting liquid: error getting block at position %v: %v", ChunkPosFromBlockPos(pos), err)
 			return
 		}
 		if displacer, ok := current.(LiquidDisplacer); !ok || !displacer.CanDisplace(b) {
@@ -494,6 +636,7 @@ func (w *World) SetLiquid(pos cube.Pos, b Liquid) {
 		w.log.Errorf("failed setting liquid: runtime ID of block state %+v not found", b)
 		return
 	}
+	c.dirty = true
 	if w.removeLiquids(c, pos) {
 		c.SetRuntimeID(x, y, z, 0, runtimeID)
 		for _, v := range c.v {
@@ -557,9 +700,9 @@ func (w *World) additionalLiquid(pos cube.Pos) (Liquid, bool) {
 		// Fast way out.
 		return nil, false
 	}
-	c, err := w.chunk(chunkPosFromBlockPos(pos))
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
-		w.log.Errorf("failed getting liquid: error getting chunk at position %v: %v", chunkPosFromBlockPos(pos), err)
+		w.log.Errorf("failed getting liquid: error getting chunk at position %v: %v", ChunkPosFromBlockPos(pos), err)
 		return nil, false
 	}
 	id := c.RuntimeID(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 1)
@@ -585,7 +728,7 @@ func (w *World) Light(pos cube.Pos) uint8 {
 		// Above the rest of the world, so full sky light.
 		return 15
 	}
-	c, err := w.chunk(chunkPosFromBlockPos(pos))
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
 		return 0
 	}
@@ -607,7 +750,7 @@ func (w *World) SkyLight(pos cube.Pos) uint8 {
 		// Above the rest of the world, so full sky light.
 		return 15
 	}
-	c, err := w.chunk(chunkPosFromBlockPos(pos))
+	c, err := w.chunk(ChunkPosFromBlockPos(pos))
 	if err != nil {
 		return 0
 	}
@@ -617,6 +760,17 @@ func (w *World) SkyLight(pos cube.Pos) uint8 {
 	return l
 }
 
+// Times of notable moments of the day that may be passed to World.SetTime. A full day lasts 24000 ticks, and
+// the time of the world keeps increasing past it, so that the time of the day is Time() % 24000.
+const (
+	TimeSunrise  = 23000
+	TimeDay      = 1000
+	TimeNoon     = 6000
+	TimeSunset   = 12000
+	TimeNight    = 13000
+	TimeMidnight = 18000
+)
+
 // Time returns the current time of the world. The time is incremented every 1/20th of a second, unless
 // World.StopTime() is called.
 func (w *World) Time() int {
@@ -662,8 +816,9 @@ func (w *World) enableTimeCycle(v bool) {
 		return
 	}
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	w.set.TimeCycle = v
+	w.mu.Unlock()
+	w.viewGameRules(map[string]interface{}{GameRuleDaylightCycle: v})
 }
 
 // AddParticle spawns a particle at a given position in the world. Viewers that are viewing the chunk will be
@@ -686,6 +841,14 @@ func (w *World) PlaySound(pos mgl64.Vec3, s Sound) {
 	}
 }
 
+// StopSound stops the sounds with the name passed, such as sounds played using sound.PlaySound, for all
+// viewers of the world. If the name is empty, all sounds are stopped.
+func (w *World) StopSound(name string) {
+	for _, viewer := range w.allViewers() {
+		viewer.ViewSoundStop(name)
+	}
+}
+
 var (
 	worldsMu sync.RWMutex
 	// entityWorlds holds a list of all entities added to a world. It may be used to lookup the world that an
@@ -708,7 +871,7 @@ func (w *World) AddEntity(e Entity) {
 	entityWorlds[e] = w
 	worldsMu.Unlock()
 
-	chunkPos := chunkPosFromVec3(e.Position())
+	chunkPos := ChunkPosFromVec3(e.Position())
 	w.entityMu.Lock()
 	w.entities[e] = chunkPos
 	w.entityMu.Unlock()
@@ -719,6 +882,7 @@ func (w *World) AddEntity(e Entity) {
 		return
 	}
 	c.entities = append(c.entities, e)
+	c.dirty = true
 
 	var viewers []Viewer
 	if len(c.v) > 0 {
@@ -752,13 +916,20 @@ func (w *World) RemoveEntity(e Entity) {
 	}
 	w.entityMu.Unlock()
 
+	// Entities riding the entity are dismounted before it is hidden from viewers.
+	w.dismountAll(e)
+
 	worldsMu.Lock()
 	delete(entityWorlds, e)
 	worldsMu.Unlock()
 
 	c, ok := w.chunkFromCache(chunkPos)
 	if !ok {
-		// The chunk wasn't loaded, so we can't remove any entity from the chunk.
+		// The chunk wasn't loaded, for example because it is being unloaded, so we can't remove any entity from
+		// the chunk.
+		w.entityMu.Lock()
+		delete(w.entities, e)
+		w.entityMu.Unlock()
 		return
 	}
 	c.Lock()
@@ -770,6 +941,7 @@ func (w *World) RemoveEntity(e Entity) {
 		}
 	}
 	c.entities = n
+	c.dirty = true
 
 	var viewers []Viewer
 	if len(c.v) > 0 {
@@ -798,7 +970,7 @@ func (w *World) CollidingEntities(aabb physics.AABB, ignoredEntities ...Entity)
 
 	// We expand it by 3 blocks in all horizontal directions to account for entities that may be in
 	// neighbouring chunks while having a bounding box that extends into the current one.
-	minPos, maxPos := chunkPosFromVec3(aabb.Min().Sub(mgl64.Vec3{3.0, 0, 3.0})), chunkPosFromVec3(aabb.Max().Add(mgl64.Vec3{3.0, 0, 3.0}))
+	minPos, maxPos := ChunkPosFromVec3(aabb.Min().Sub(mgl64.Vec3{3.0, 0, 3.0})), ChunkPosFromVec3(aabb.Max().Add(mgl64.Vec3{3.0, 0, 3.0}))
 
 	for x := minPos[0]; x <= maxPos[0]; x++ {
 		for z := minPos[1]; z <= maxPos[1]; z++ {
@@ -838,7 +1010,7 @@ func (w *World) EntitiesWithin(aabb physics.AABB) []Entity {
 	// Make an estimate of 16 entities on average.
 	m := make([]Entity, 0, 16)
 
-	minPos, maxPos := chunkPosFromVec3(aabb.Min()), chunkPosFromVec3(aabb.Max())
+	minPos, maxPos := ChunkPosFromVec3(aabb.Min()), ChunkPosFromVec3(aabb.Max())
 
 	for x := minPos[0]; x <= maxPos[0]; x++ {
 		for z := minPos[1]; z <= maxPos[1]; z++ {
@@ -898,6 +1070,30 @@ func (w *World) Spawn() cube.Pos {
 	return s
 }
 
+// Visuals returns the client-side visual settings of the world.
+func (w *World) Visuals() Visuals {
+	if w == nil {
+		return Visuals{}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.visuals
+}
+
+// SetVisuals changes the client-side visual settings of the world. The new Visuals are applied to all
+// viewers of the world immediately.
+func (w *World) SetVisuals(v Visuals) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.visuals = v
+	w.mu.Unlock()
+	for _, viewer := range w.allViewers() {
+		viewer.ViewVisuals(v)
+	}
+}
+
 // SetSpawn sets the spawn of the world to a different position. The player will be spawned in the center of
 // this position when newly joining.
 func (w *World) SetSpawn(pos cube.Pos) {
@@ -935,6 +1131,16 @@ func (w *World) SetDefaultGameMode(mode GameMode) {
 	w.set.DefaultGameMode = mode
 }
 
+// Seed returns the seed of the world, which is used to place the features added using AddFeature.
+func (w *World) Seed() int64 {
+	if w == nil {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.set.Seed
+}
+
 // Difficulty returns the difficulty of the world. Properties of mobs in the world and the player's hunger
 // will depend on this difficulty.
 func (w *World) Difficulty() Difficulty {
@@ -956,6 +1162,125 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// liquidVersion returns a number that changes every time a liquid in the chunk at the position passed is
+// placed or removed. liquidVersion returns 0 if the chunk could not be loaded.
+func (w *World) liquidVersion(pos ChunkPos) uint64 {
+	c, err := w.chunk(pos)
+	if err != nil {
+		return 0
+	}
+	v := c.liquidVersion
+	c.Unlock()
+	return v
+}
+
+// CachedLiquidCheck returns the result of a check on the liquids within r blocks horizontally of the position
+// passed, such as whether farmland is hydrated by water nearby. The result of the check function is cached in
+// the chunk of the position, and check is only called again once a liquid is placed or removed in one of the
+// chunks that the area spans. The cache is dropped when the chunk is unloaded.
+// Only one check may be cached for every position, and r must be at most 8.
+func (w *World) CachedLiquidCheck(pos cube.Pos, r int, check func() bool) bool {
+	if w == nil {
+		return check()
+	}
+	var versions [4]uint64
+	for i, offset := range [4]cube.Pos{{-r, 0, -r}, {-r, 0, r}, {r, 0, -r}, {r, 0, r}} {
+		versions[i] = w.liquidVersion(ChunkPosFromBlockPos(pos.Add(offset)))
+	}
+	chunkPos := ChunkPosFromBlockPos(pos)
+	c, err := w.chunk(chunkPos)
+	if err != nil {
+		return check()
+	}
+	e, ok := c.liquidChecks[pos]
+	c.Unlock()
+	if ok && e.versions == versions {
+		return e.result
+	}
+	// The chunk is not locked while checking, as check reads the liquids of the chunk.
+	result := check()
+	if c, err = w.chunk(chunkPos); err == nil {
+		if c.liquidChecks == nil {
+			c.liquidChecks = map[cube.Pos]liquidCheck{}
+		}
+		c.liquidChecks[pos] = liquidCheck{versions: versions, result: result}
+		c.Unlock()
+	}
+	return result
+}
+
+// KeepInventory checks if players in the world keep their inventory when they die.
+func (w *World) KeepInventory() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.set.KeepInventory
+}
+
+// SetKeepInventory changes if players in the world keep their inventory when they die. If set to false,
+// the inventory of a player is dropped at the position where it died.
+func (w *World) SetKeepInventory(keep bool) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.set.KeepInventory = keep
+	w.mu.Unlock()
+	w.viewGameRules(map[string]interface{}{GameRuleKeepInventory: keep})
+}
+
+// ImmunityDuration returns the duration that entities in the world are immune to damage after being hurt.
+// Damage dealt to an entity during this duration is only applied if it is higher than the damage that made
+// it immune. By default, this duration is half a second, or 10 ticks.
+func (w *World) ImmunityDuration() time.Duration {
+	if w == nil {
+		return time.Second / 2
+	}
+	return w.immunity.Load()
+}
+
+// SetImmunityDuration changes the duration that entities in the world are immune to damage after being hurt.
+// Servers focused on PvP may lower this duration to allow faster combos. The duration is not saved with the
+// settings of the world.
+func (w *World) SetImmunityDuration(d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.immunity.Store(d)
+}
+
+// SimulationDistance returns the simulation distance of the world in chunks, as passed to New or set using
+// SetSimulationDistance.
+func (w *World) SimulationDistance() int {
+	if w == nil {
+		return 0
+	}
+	return int(w.simDist.Load())
+}
+
+// SetSimulationDistance changes the simulation distance of the world in chunks. The simulation distance is
+// independent of the view distance of viewers: Chunks further away than the simulation distance from all
+// viewers are still shown, but their entities are not ticked and random ticks are skipped. Scheduled block
+// updates in those chunks are delayed until a viewer comes within the simulation distance. A simulation
+// distance of 0 disables random ticks and only simulates the chunks that viewers are in.
+func (w *World) SetSimulationDistance(d int) {
+	if w == nil {
+		return
+	}
+	w.simDist.Store(int32(d))
+}
+
+// TPS returns the average amount of ticks per second that the world is currently ticking at. This is at most
+// 20, but it may be lower if ticking the world takes longer than 50ms.
+func (w *World) TPS() float64 {
+	if w == nil {
+		return 0
+	}
+	return math.Min(20, 1/w.tickInterval.Load())
+}
+
 // SetRandomTickSpeed sets the random tick speed of blocks. By default, each sub chunk has 3 blocks randomly
 // ticked per sub chunk, so the default value is 3. Setting this value to 0 will stop random ticking
 // altogether, while setting it higher results in faster ticking.
@@ -966,6 +1291,42 @@ func (w *World) SetRandomTickSpeed(v int) {
 	w.randomTickSpeed.Store(uint32(v))
 }
 
+// SetTickRate sets the amount of times per second that the world is ticked. It is clamped between 1 and 20,
+// which is the default. Lowering the tick rate reduces the resources used by worlds that don't need to be
+// updated often, but slows down everything that happens in the world, such as the time and block updates.
+func (w *World) SetTickRate(tps int) {
+	if w == nil {
+		return
+	}
+	if tps < 1 {
+		tps = 1
+	} else if tps > 20 {
+		tps = 20
+	}
+	w.tickRate.Store(int32(tps))
+}
+
+// SetPauseWhenEmpty specifies if the world should stop ticking entirely while it has no viewers. A world
+// without viewers and ticking areas is always paused, but if pause is true, ticking areas no longer keep the
+// world ticking either. While paused, the time, entities and blocks of the world are frozen.
+func (w *World) SetPauseWhenEmpty(pause bool) {
+	if w == nil {
+		return
+	}
+	w.pauseWhenEmpty.Store(pause)
+}
+
+// SetCatchUpBlockUpdates specifies if scheduled block updates should catch up on the ticks that passed while
+// the world was paused. If false, which is the default, scheduled block updates are frozen along with the
+// rest of the world. If true, they are performed as if the world was never paused, spread over the ticks
+// after the world resumes so that not all backlogged updates are performed at once.
+func (w *World) SetCatchUpBlockUpdates(catchUp bool) {
+	if w == nil {
+		return
+	}
+	w.catchUp.Store(catchUp)
+}
+
 // ScheduleBlockUpdate schedules a block update at the position passed after a specific delay. If the block at
 // that position does not handle block updates, nothing will happen.
 func (w *World) ScheduleBlockUpdate(pos cube.Pos, delay time.Duration) {
@@ -1031,7 +1392,10 @@ func (w *World) Provider(p Provider) {
 }
 
 // ReadOnly makes the world read only. Chunks will no longer be saved to disk, just like entities and data
-// in the level.dat.
+// in the level.dat. Changes to the world, such as blocks being set, are still made in memory, but are lost
+// once the chunks they were made in are unloaded or the world is closed. Note that the provider itself may
+// still write files when it is closed: Providers such as one created using mcdb.NewReadOnly guarantee that
+// the files of the world remain unchanged.
 func (w *World) ReadOnly() {
 	if w == nil {
 		return
@@ -1056,18 +1420,37 @@ func (w *World) Generator(g Generator) {
 
 // Handle changes the current Handler of the world. As a result, events called by the world will call
 // handlers of the Handler passed.
-// Handle sets the world's Handler to NopHandler if nil is passed.
+// Handle detaches all handlers previously attached to the world, including those attached using Attach. If
+// nil is passed, all handlers are detached.
 func (w *World) Handle(h Handler) {
 	if w == nil {
 		return
 	}
-	w.handlerMu.Lock()
-	defer w.handlerMu.Unlock()
+	w.handlers.Clear()
+	if h != nil {
+		w.handlers.Attach(h, 0)
+	}
+}
 
-	if h == nil {
-		h = NopHandler{}
+// Attach attaches a Handler to the world with a specific priority, without removing any handlers already
+// attached. Handlers with a lower priority are called first, and handlers with the same priority are called
+// in the order they were attached. All handlers are called for an event, even if an earlier handler
+// cancelled it.
+// Attach returns an event.Token that may be passed to Detach to detach the Handler again.
+func (w *World) Attach(h Handler, priority int) event.Token {
+	if w == nil {
+		return 0
 	}
-	w.handler = h
+	return w.handlers.Attach(h, priority)
+}
+
+// Detach detaches the Handler attached using Attach with the event.Token passed. Detach returns false if
+// no Handler was attached with the token.
+func (w *World) Detach(t event.Token) bool {
+	if w == nil {
+		return false
+	}
+	return w.handlers.Detach(t)
 }
 
 // Viewers returns a list of all viewers viewing the position passed. A viewer will be assumed to be watching
@@ -1076,7 +1459,7 @@ func (w *World) Viewers(pos mgl64.Vec3) (viewers []Viewer) {
 	if w == nil {
 		return nil
 	}
-	c, ok := w.chunkFromCache(chunkPosFromVec3(pos))
+	c, ok := w.chunkFromCache(ChunkPosFromVec3(pos))
 	if !ok {
 		return nil
 	}
@@ -1096,6 +1479,13 @@ func (w *World) Close() error {
 	}
 	close(w.closing)
 	w.running.Wait()
+	w.cancelTasks()
+	w.music.Stop()
+
+	// Wait for any save that is still in progress, so that it does not write to the provider after it is
+	// closed.
+	w.saveMu.Lock()
+	defer w.saveMu.Unlock()
 
 	w.log.Debugf("Saving chunks in memory to disk...")
 
@@ -1129,14 +1519,29 @@ func (w *World) Close() error {
 // startTicking starts ticking the world, updating all entities, blocks and other features such as the time of
 // the world, as required.
 func (w *World) startTicking() {
-	ticker := time.NewTicker(time.Second / 20)
+	rate := w.tickRate.Load()
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
 	defer ticker.Stop()
 
-	w.running.Add(1)
+	last := time.Now()
 	for {
 		select {
-		case <-ticker.C:
+		case now := <-ticker.C:
+			if r := w.tickRate.Load(); r != rate {
+				rate = r
+				ticker.Reset(time.Second / time.Duration(rate))
+			}
+			// Ticks are dropped by the ticker if a tick takes longer than 50ms, so the TPS follows from the
+			// time between two ticks.
+			w.tickInterval.Store(w.tickInterval.Load()*0.95 + now.Sub(last).Seconds()*0.05)
+			last = now
+
+			start := time.Now()
+			w.tickMu.Lock()
+			w.runTasks(start)
 			w.tick()
+			w.tickMu.Unlock()
+			w.recordTick(time.Since(start))
 		case <-w.closing:
 			// World is being closed: Stop ticking and get rid of a task.
 			w.running.Done()
@@ -1148,11 +1553,15 @@ func (w *World) startTicking() {
 // tick ticks the world and updates the time, blocks and entities that require updates.
 func (w *World) tick() {
 	viewers := w.allViewers()
-	if len(viewers) == 0 {
-		return
-	}
 
 	w.mu.Lock()
+	if len(viewers) == 0 && (len(w.set.TickingAreas) == 0 || w.pauseWhenEmpty.Load()) {
+		// The world is paused: Time, entities and blocks are frozen until a viewer enters the world.
+		w.mu.Unlock()
+		w.pausedTicks++
+		return
+	}
+	w.tickingAreaCache = append(w.tickingAreaCache, w.set.TickingAreas...)
 	tick := w.set.CurrentTick
 	w.set.CurrentTick++
 
@@ -1160,34 +1569,90 @@ func (w *World) tick() {
 		w.set.Time++
 	}
 	t := int(w.set.Time)
+	weatherChanged := w.tickWeather()
+	thundering := w.set.Raining && w.set.Thundering
 	w.mu.Unlock()
 
+	if w.pausedTicks > 0 {
+		w.resume()
+	}
 	if tick%20 == 0 {
 		for _, viewer := range viewers {
 			viewer.ViewTime(t)
 		}
 	}
+	if weatherChanged {
+		w.viewWeather()
+	}
+
+	if tick%20 == 0 {
+		// Chunks in ticking areas are kept loaded as if they had a viewer, so load any chunks that were
+		// not loaded yet, for example after a restart.
+		for _, a := range w.tickingAreaCache {
+			w.loadTickingArea(a)
+		}
+	}
 
+	for _, viewer := range viewers {
+		w.positionCache = append(w.positionCache, ChunkPosFromVec3(viewer.Position()))
+	}
 	w.tickEntities(tick)
-	w.tickRandomBlocks(viewers, tick)
+	if thundering {
+		w.tickLightning()
+	}
+	w.tickRandomBlocks(tick)
 	w.tickScheduledBlocks(tick)
+	w.positionCache = w.positionCache[:0]
+	w.tickingAreaCache = w.tickingAreaCache[:0]
+}
+
+// resume is called on the first tick after the world was paused. If block updates catch up on the time that
+// the world was paused, the ticks that were skipped are added to the catch-up ticks and the block updates
+// currently scheduled are marked to catch up.
+func (w *World) resume() {
+	if w.catchUp.Load() {
+		w.updateMu.Lock()
+		w.catchUpTicks += w.pausedTicks
+		if w.catchUpUpdates == nil {
+			w.catchUpUpdates = make(map[cube.Pos]struct{}, len(w.blockUpdates))
+		}
+		for pos := range w.blockUpdates {
+			w.catchUpUpdates[pos] = struct{}{}
+		}
+		w.updateMu.Unlock()
+	}
+	w.pausedTicks = 0
+}
+
+// simulating checks if the chunk at the position passed is within the simulation distance of at least one
+// of the viewers of the world or within a ticking area. It may only be called while ticking the world.
+func (w *World) simulating(pos ChunkPos) bool {
+	if inTickingArea(w.tickingAreaCache, pos) {
+		return true
+	}
+	simDist := w.simDist.Load()
+	for _, chunkPos := range w.positionCache {
+		xDiff, zDiff := chunkPos[0]-pos[0], chunkPos[1]-pos[1]
+		if (xDiff*xDiff)+(zDiff*zDiff) <= simDist*simDist {
+			return true
+		}
+	}
+	return false
 }
 
 // tickScheduledBlocks executes scheduled block ticks in chunks that are still loaded at the time of
-// execution.
+// execution. Block ticks in chunks outside the simulation distance remain scheduled until a viewer comes
+// within the simulation distance, so that they are executed late rather than dropped.
 func (w *World) tickScheduledBlocks(tick int64) {
 	w.updateMu.Lock()
-	for pos, scheduledTick := range w.blockUpdates {
-		if scheduledTick <= tick {
-			w.updatePositions = append(w.updatePositions, pos)
-			delete(w.blockUpdates, pos)
-		}
-	}
+	w.catchUpBlockUpdates()
+	w.dueBlockUpdates(tick)
 	w.neighbourUpdatesSync = append(w.neighbourUpdatesSync, w.neighbourUpdatePositions...)
 	w.neighbourUpdatePositions = w.neighbourUpdatePositions[:0]
 	w.updateMu.Unlock()
 
-	for _, pos := range w.updatePositions {
+	for _, update := range w.updatePositions {
+		pos := update.pos
 		if ticker, ok := w.Block(pos).(ScheduledTicker); ok {
 			ticker.ScheduledTick(pos, w, w.r)
 		}
@@ -1213,6 +1678,61 @@ func (w *World) tickScheduledBlocks(tick int64) {
 	w.neighbourUpdatesSync = w.neighbourUpdatesSync[:0]
 }
 
+// dueBlockUpdates moves the scheduled block updates in simulated chunks that are due at the tick passed to
+// w.updatePositions, in the order they were scheduled for. Updates beyond maxBlockUpdatesPerTick stay
+// scheduled and are performed in the next ticks instead, so that a large amount of flowing liquid cannot stall
+// the world. It must be called while holding w.updateMu.
+func (w *World) dueBlockUpdates(tick int64) {
+	for pos, scheduledTick := range w.blockUpdates {
+		if scheduledTick <= tick && w.simulating(ChunkPosFromBlockPos(pos)) {
+			w.updatePositions = append(w.updatePositions, scheduledUpdate{pos: pos, tick: scheduledTick})
+		}
+	}
+	sort.Slice(w.updatePositions, func(i, j int) bool {
+		return w.updatePositions[i].tick < w.updatePositions[j].tick
+	})
+	if len(w.updatePositions) > maxBlockUpdatesPerTick {
+		w.updatePositions = w.updatePositions[:maxBlockUpdatesPerTick]
+	}
+	for _, update := range w.updatePositions {
+		delete(w.blockUpdates, update.pos)
+		delete(w.catchUpUpdates, update.pos)
+	}
+}
+
+// scheduledUpdate is a block update scheduled at a position for a specific tick.
+type scheduledUpdate struct {
+	pos  cube.Pos
+	tick int64
+}
+
+// maxBlockUpdatesPerTick is the maximum amount of scheduled block updates performed in a single tick.
+const maxBlockUpdatesPerTick = 8192
+
+// maxCatchUpTicks is the maximum amount of ticks that scheduled block updates catch up on every tick after the
+// world resumed ticking.
+const maxCatchUpTicks = 20
+
+// catchUpBlockUpdates moves the block updates that were scheduled when the world resumed forward by at most
+// maxCatchUpTicks if they are behind after the world was paused. Backlogged block updates are thereby spread
+// over several ticks, rather than all being performed in the first tick. It must be called while holding
+// w.updateMu.
+func (w *World) catchUpBlockUpdates() {
+	if w.catchUpTicks <= 0 {
+		return
+	}
+	n := w.catchUpTicks
+	if n > maxCatchUpTicks {
+		n = maxCatchUpTicks
+	}
+	for pos := range w.catchUpUpdates {
+		w.blockUpdates[pos] -= n
+	}
+	if w.catchUpTicks -= n; w.catchUpTicks == 0 {
+		w.catchUpUpdates = nil
+	}
+}
+
 // toTick is a struct used to keep track of blocks that need to be ticked upon a random tick.
 type toTick struct {
 	b   RandomTicker
@@ -1226,40 +1746,20 @@ type blockEntityToTick struct {
 	pos cube.Pos
 }
 
-// tickRandomBlocks executes random block ticks in each sub chunk in the world that has at least one viewer
-// registered from the viewers passed.
-func (w *World) tickRandomBlocks(viewers []Viewer, tick int64) {
-	if w.simDistSq == 0 {
-		// NOP if the simulation distance is 0.
+// tickRandomBlocks executes random block ticks in each sub chunk in the world that is within the simulation
+// distance of at least one viewer of the world.
+func (w *World) tickRandomBlocks(tick int64) {
+	if w.simDist.Load() == 0 && len(w.tickingAreaCache) == 0 {
+		// NOP if the simulation distance is 0 and there are no ticking areas.
 		return
 	}
 	tickSpeed := w.randomTickSpeed.Load()
 
-	for _, viewer := range viewers {
-		pos := viewer.Position()
-		w.positionCache = append(w.positionCache, ChunkPos{
-			// Technically we could obtain the wrong chunk position here due to truncating, but this
-			// inaccuracy doesn't matter and it allows us to cut a corner.
-			int32(pos[0]) >> 4,
-			int32(pos[2]) >> 4,
-		})
-	}
-
 	var g randUint4
 
 	w.chunkMu.Lock()
 	for pos, c := range w.chunks {
-		withinSimDist := false
-		for _, chunkPos := range w.positionCache {
-			xDiff, zDiff := chunkPos[0]-pos[0], chunkPos[1]-pos[1]
-			if (xDiff*xDiff)+(zDiff*zDiff) <= w.simDistSq {
-				// The chunk was within the simulation distance of at least one viewer, so we can proceed to
-				// ticking the block.
-				withinSimDist = true
-				break
-			}
-		}
-		if !withinSimDist {
+		if !w.simulating(pos) {
 			// No viewers in this chunk that are within the simulation distance, so proceed to the next.
 			continue
 		}
@@ -1331,7 +1831,6 @@ func (w *World) tickRandomBlocks(viewers []Viewer, tick int64) {
 	}
 	w.toTick = w.toTick[:0]
 	w.blockEntitiesToTick = w.blockEntitiesToTick[:0]
-	w.positionCache = w.positionCache[:0]
 }
 
 // randUint4 is a structure used to generate random uint4s.
@@ -1366,7 +1865,9 @@ func (w *World) tickEntities(tick int64) {
 	w.entityMu.Lock()
 	w.chunkMu.Lock()
 	for e, lastPos := range w.entities {
-		chunkPos := chunkPosFromVec3(e.Position())
+		// Entities riding another entity are stored in the chunk of the entity at the bottom of their stack,
+		// so that viewers always see the whole stack at once.
+		chunkPos := ChunkPosFromVec3(w.bottom(e).Position())
 
 		c, ok := w.chunks[chunkPos]
 		if !ok {
@@ -1377,7 +1878,9 @@ func (w *World) tickEntities(tick int64) {
 		v := len(c.v)
 		c.Unlock()
 
-		if v > 0 {
+		// Entities outside the simulation distance of all viewers are not ticked, but they are still moved
+		// to the correct chunk below.
+		if (v > 0 || inTickingArea(w.tickingAreaCache, chunkPos)) && w.simulating(chunkPos) {
 			if ticker, ok := e.(TickerEntity); ok {
 				w.entitiesToTick = append(w.entitiesToTick, ticker)
 			}
@@ -1388,23 +1891,26 @@ func (w *World) tickEntities(tick int64) {
 			// for viewers to view it.
 			w.entities[e] = chunkPos
 
-			old := w.chunks[lastPos]
-			old.Lock()
-			chunkEntities := make([]Entity, 0, len(old.entities)-1)
-			for _, entity := range old.entities {
-				if entity == e {
-					continue
+			var viewers []Viewer
+			if old, ok := w.chunks[lastPos]; ok {
+				// The old chunk may have been unloaded if the entity was moved while it was not ticked.
+				old.Lock()
+				chunkEntities := make([]Entity, 0, len(old.entities)-1)
+				for _, entity := range old.entities {
+					if entity == e {
+						continue
+					}
+					chunkEntities = append(chunkEntities, entity)
 				}
-				chunkEntities = append(chunkEntities, entity)
-			}
-			old.entities = chunkEntities
+				old.entities = chunkEntities
+				old.dirty = true
 
-			var viewers []Viewer
-			if len(old.v) > 0 {
-				viewers = make([]Viewer, len(old.v))
-				copy(viewers, old.v)
+				if len(old.v) > 0 {
+					viewers = make([]Viewer, len(old.v))
+					copy(viewers, old.v)
+				}
+				old.Unlock()
 			}
-			old.Unlock()
 
 			entitiesToMove = append(entitiesToMove, entityToMove{e: e, viewersBefore: viewers, after: c})
 		}
@@ -1412,9 +1918,11 @@ func (w *World) tickEntities(tick int64) {
 	w.chunkMu.Unlock()
 	w.entityMu.Unlock()
 
+	shown := make(map[Viewer][]Entity)
 	for _, move := range entitiesToMove {
 		move.after.Lock()
 		move.after.entities = append(move.after.entities, move.e)
+		move.after.dirty = true
 		viewersAfter := move.after.v
 		move.after.Unlock()
 
@@ -1430,9 +1938,17 @@ func (w *World) tickEntities(tick int64) {
 				// Then we show the entity to all viewers that are now viewing the entity in the new
 				// chunk.
 				showEntity(move.e, viewer)
+				shown[viewer] = append(shown[viewer], move.e)
 			}
 		}
 	}
+	// Links are only shown once all entities that moved are visible to their new viewers, so that entities
+	// riding one another that moved together are linked after both were spawned.
+	for viewer, entities := range shown {
+		for _, e := range entities {
+			w.showLinks(e, viewer, entities)
+		}
+	}
 	for _, ticker := range w.entitiesToTick {
 		if _, ok := OfEntity(ticker.(Entity)); !ok {
 			continue
@@ -1464,6 +1980,8 @@ func (w *World) addWorldViewer(viewer Viewer) {
 	w.viewersMu.Unlock()
 	viewer.ViewTime(w.Time())
 	viewer.ViewWorldSpawn(w.Spawn())
+	viewer.ViewWeather(w.Raining(), w.Thundering())
+	viewer.ViewGameRules(w.GameRules())
 }
 
 // removeWorldViewer removes a viewer from the world. Should only be used while the viewer isn't viewing any chunks.
@@ -1491,6 +2009,11 @@ func (w *World) addViewer(c *chunkData, viewer Viewer) {
 	for _, entity := range entities {
 		showEntity(entity, viewer)
 	}
+	// Links are only shown once all entities in the chunk are visible to the viewer, so that entities riding
+	// one another in the same chunk are linked after both were spawned.
+	for _, entity := range entities {
+		w.showLinks(entity, viewer, entities)
+	}
 }
 
 // removeViewer removes a viewer from the world at a given position. All entities will be hidden from the
@@ -1512,6 +2035,9 @@ func (w *World) removeViewer(pos ChunkPos, viewer Viewer) {
 		}
 	}
 	c.v = n
+	if len(n) == 0 {
+		c.lastViewed = time.Now()
+	}
 
 	var entities []Entity
 	if len(c.entities) > 0 {
@@ -1547,16 +2073,13 @@ func (w *World) provider() Provider {
 	return w.prov
 }
 
-// Handler returns the Handler of the world. It should always be used, rather than direct field access, in
+// Handler returns the Handler of the world, which calls all handlers attached to the world in order. It should always be used, rather than direct field access, in
 // order to provide synchronisation safety.
 func (w *World) Handler() Handler {
 	if w == nil {
 		return NopHandler{}
 	}
-	w.handlerMu.RLock()
-	handler := w.handler
-	w.handlerMu.RUnlock()
-	return handler
+	return handlerList(w.handlers.List())
 }
 
 // generator returns the generator of the world. It should always be used, rather than direct field access, in
@@ -1588,7 +2111,7 @@ func showEntity(e Entity, viewer Viewer) {
 
 // chunk reads a chunk from the position passed. If a chunk at that position is not yet loaded, the chunk is
 // loaded from the provider, or generated if it did not yet exist. Both of these actions are done
-// synchronously.
+// synchronously, but without blocking the world from ticking or other chunks from being read.
 // An error is returned if the chunk could not be loaded successfully.
 // chunk locks the chunk returned, meaning that any call to chunk made at the same time has to wait until the
 // user calls Chunk.Unlock() on the chunk returned.
@@ -1602,15 +2125,12 @@ func (w *World) chunk(pos ChunkPos) (*chunkData, error) {
 	}
 	c, ok := w.chunks[pos]
 	if !ok {
+		w.chunkMu.Unlock()
 		var err error
-		c, err = w.loadChunk(pos)
-		if err != nil {
+		if c, err = w.loadChunk(pos); err != nil {
 			return nil, err
 		}
-
-		c.Unlock()
 		w.chunkMu.Lock()
-		w.calculateLight(c.Chunk, pos)
 	}
 	w.lastChunk, w.lastPos = c, pos
 	w.chunkMu.Unlock()
@@ -1621,6 +2141,7 @@ func (w *World) chunk(pos ChunkPos) (*chunkData, error) {
 
 // setChunk sets the chunk.Chunk passed at a specific ChunkPos without replacing any entities at that
 // position.
+//
 //lint:ignore U1000 This method is explicitly present to be used using compiler directives.
 func (w *World) setChunk(pos ChunkPos, c *chunk.Chunk) {
 	if w == nil {
@@ -1634,8 +2155,10 @@ func (w *World) setChunk(pos ChunkPos, c *chunk.Chunk) {
 		data.Chunk = c
 	} else {
 		data = newChunkData(c)
+		data.lastViewed = time.Now()
 		w.chunks[pos] = data
 	}
+	data.dirty = true
 	blockNBT := make([]map[string]interface{}, 0, len(c.BlockNBT()))
 	for pos, e := range c.BlockNBT() {
 		e["x"], e["y"], e["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
@@ -1644,22 +2167,104 @@ func (w *World) setChunk(pos ChunkPos, c *chunk.Chunk) {
 	w.loadIntoBlocks(data, blockNBT)
 }
 
-// loadChunk attempts to load a chunk from the provider, or generates a chunk if one doesn't currently exist.
+// chunkLoad is a chunk that is being loaded by loadChunk. done is closed once the chunk is loaded, after which
+// c and err hold the result. A chunkLoad of which both c and err are nil is a chunk that was being unloaded.
+type chunkLoad struct {
+	done chan struct{}
+	c    *chunkData
+	err  error
+}
+
+// loadChunk loads the chunk at the position passed using readChunk and adds it to the chunk cache. If the chunk
+// is already being loaded by another goroutine, loadChunk waits for it to be loaded and returns the result, so
+// that every chunk is only loaded once.
 func (w *World) loadChunk(pos ChunkPos) (*chunkData, error) {
-	c, found, err := w.provider().LoadChunk(pos)
+	w.chunkMu.Lock()
+	if c, ok := w.chunks[pos]; ok {
+		// The chunk was loaded between the caller checking the cache and now.
+		w.chunkMu.Unlock()
+		return c, nil
+	}
+	if l, ok := w.loading[pos]; ok {
+		w.chunkMu.Unlock()
+		<-l.done
+		if l.c == nil && l.err == nil {
+			// The chunk was being unloaded. Now that it was saved, it can be loaded again.
+			return w.loadChunk(pos)
+		}
+		return l.c, l.err
+	}
+	l := &chunkLoad{done: make(chan struct{})}
+	w.loading[pos] = l
+	w.chunkMu.Unlock()
+	defer close(l.done)
+
+	c, err := w.readChunk(pos)
+
+	w.chunkMu.Lock()
+	delete(w.loading, pos)
 	if err != nil {
+		w.chunkMu.Unlock()
+		l.err = err
+		return nil, err
+	}
+	if existing, ok := w.chunks[pos]; ok {
+		// The chunk was set using setChunk while it was being loaded, so the chunk loaded is outdated.
+		w.chunkMu.Unlock()
+		l.c = existing
+		return existing, nil
+	}
+	c.lastViewed = time.Now()
+	// The entities are copied before the chunk is added to the cache: Once it is, the chunk may be unloaded
+	// and its entities cleared by another goroutine.
+	ent := append([]Entity(nil), c.entities...)
+	w.chunks[pos] = c
+	w.calculateLight(c.Chunk, pos)
+	if max := int(w.maxChunks.Load()); max > 0 && len(w.chunks) > max {
+		select {
+		case w.evictChunks <- struct{}{}:
+		default:
+			// The chunk cache janitor was already notified.
+		}
+	}
+	w.chunkMu.Unlock()
+
+	// Iterate through the entities twice and make sure they're added to all relevant maps. Note that this iteration
+	// happens twice to avoid having to lock both worldsMu and entityMu. This is intentional, to avoid deadlocks.
+	worldsMu.Lock()
+	for _, e := range ent {
+		entityWorlds[e] = w
+	}
+	worldsMu.Unlock()
+
+	w.entityMu.Lock()
+	for _, e := range ent {
+		w.entities[e] = pos
+	}
+	w.entityMu.Unlock()
+
+	l.c = c
+	return c, nil
+}
+
+// readChunk reads the chunk at the position passed from the provider, including its entities and block
+// entities, or generates a chunk if one doesn't currently exist. The chunk returned is not yet added to the
+// chunk cache and its entities are not yet added to the world.
+func (w *World) readChunk(pos ChunkPos) (*chunkData, error) {
+	c, err := w.provider().LoadChunk(pos)
+	if err != nil && !errors.Is(err, ErrChunkNotFound) {
 		return nil, fmt.Errorf("error loading chunk %v: %w", pos, err)
 	}
 
-	if !found {
+	if err != nil {
 		// The provider doesn't have a chunk saved at this position, so we generate a new one.
 		c = chunk.New(airRID)
 		data := newChunkData(c)
-		w.chunks[pos] = data
-		data.Lock()
-		w.chunkMu.Unlock()
 
 		w.generator().GenerateChunk(pos, c)
+		w.decorate(pos, data)
+		// The chunk did not exist in the provider yet, so it must be written to it when saving.
+		data.dirty = true
 		for _, sub := range c.Sub() {
 			if sub == nil {
 				continue
@@ -1671,30 +2276,15 @@ func (w *World) loadChunk(pos ChunkPos) (*chunkData, error) {
 		return data, nil
 	}
 	data := newChunkData(c)
-	w.chunks[pos] = data
-	data.Lock()
-	w.chunkMu.Unlock()
 
 	ent, err := w.provider().LoadEntities(pos)
 	if err != nil {
 		return nil, fmt.Errorf("error loading entities of chunk %v: %w", pos, err)
 	}
 	data.entities = make([]Entity, 0, len(ent))
-
-	// Iterate through the entities twice and make sure they're added to all relevant maps. Note that this iteration
-	// happens twice to avoid having to lock both worldsMu and entityMu. This is intentional, to avoid deadlocks.
-	worldsMu.Lock()
 	for _, e := range ent {
 		data.entities = append(data.entities, e)
-		entityWorlds[e] = w
 	}
-	worldsMu.Unlock()
-
-	w.entityMu.Lock()
-	for _, e := range ent {
-		w.entities[e] = pos
-	}
-	w.entityMu.Unlock()
 
 	blockEntities, err := w.provider().LoadBlockNBT(pos)
 	if err != nil {
@@ -1778,37 +2368,183 @@ func (w *World) loadIntoBlocks(c *chunkData, blockEntityData []map[string]interf
 	}
 }
 
-// saveChunk is called when a chunk is removed from the cache. We first compact the chunk, then we write it to
-// the provider.
-func (w *World) saveChunk(pos ChunkPos, c *chunkData) {
-	c.Lock()
-	// We allocate a new map for all block entities.
-	m := make([]map[string]interface{}, 0, len(c.e))
-	for pos, b := range c.e {
-		if n, ok := b.(NBTer); ok {
-			// Encode the block entities and add the 'x', 'y' and 'z' tags to it.
-			data := n.EncodeNBT()
-			data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
-			m = append(m, data)
-		}
+// SetChunkCacheLimits sets the limits of the chunks kept loaded by the world. Chunks that are no longer viewed
+// are saved and unloaded after unloadDelay, unless they are viewed again in the meantime. If maxChunks is
+// above 0 and more chunks than maxChunks are loaded, the chunks that were viewed least recently are unloaded
+// right away. Chunks that are viewed, that are in a ticking area or that are within the simulation distance of
+// a viewer are never unloaded, so more than maxChunks chunks may remain loaded if all of them are in use.
+// By default, chunks are unloaded one minute after they were last viewed and the amount of chunks is not
+// limited.
+func (w *World) SetChunkCacheLimits(unloadDelay time.Duration, maxChunks int) {
+	if w == nil {
+		return
 	}
-	if !w.rdonly.Load() {
-		c.Compact()
-		if err := w.provider().SaveChunk(pos, c.Chunk); err != nil {
-			w.log.Errorf("error saving chunk %v to provider: %v", pos, err)
+	w.unloadDelay.Store(unloadDelay)
+	w.maxChunks.Store(int32(maxChunks))
+	select {
+	case w.evictChunks <- struct{}{}:
+	default:
+	}
+}
+
+// LoadedChunks returns the amount of chunks currently loaded in the world.
+func (w *World) LoadedChunks() int {
+	if w == nil {
+		return 0
+	}
+	w.chunkMu.Lock()
+	defer w.chunkMu.Unlock()
+	return len(w.chunks)
+}
+
+// SaveInterval changes the interval at which the world is saved automatically. If d is 0, the world is only
+// saved when it is closed or when Save is called.
+func (w *World) SaveInterval(d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.saveInterval.Store(d)
+}
+
+// SetPauseTickingOnSave specifies if the world stops ticking while its chunks are copied to be saved. If
+// true, the state saved is consistent across all chunks, as no blocks or entities change while the chunks are
+// copied, but large worlds may briefly stutter for players when saved. If false, which is the default, the
+// world keeps ticking during saves.
+// If true, Save must not be called from within the ticking of the world, such as from a Handler.
+func (w *World) SetPauseTickingOnSave(pause bool) {
+	if w == nil {
+		return
+	}
+	w.pauseOnSave.Store(pause)
+}
+
+// Save writes all chunks that were changed since they were last saved, and the settings of the world, to the
+// provider. Chunks remain loaded after saving. The chunks are copied while locked and written afterwards, so
+// that saving does not hold up the world.
+// Save returns once all changes made before the call have been written to the provider. If Save is called
+// while the world is already being saved, it waits for that save to finish and saves again, unless a save
+// that started after the call finished in the meantime.
+func (w *World) Save() {
+	if w == nil || w.rdonly.Load() {
+		return
+	}
+	req := w.saveRequests.Inc()
+
+	w.saveMu.Lock()
+	defer w.saveMu.Unlock()
+	select {
+	case <-w.closing:
+		// The world is closing and saves all chunks by itself.
+		return
+	default:
+	}
+	if w.savedRequests >= req {
+		// Another call to Save was made after this one and already saved the world.
+		return
+	}
+	w.save()
+}
+
+// save writes all changed chunks and the settings of the world to the provider. It must be called while
+// saveMu is held.
+func (w *World) save() {
+	start := time.Now()
+	w.savedRequests = w.saveRequests.Load()
+	players := len(w.allViewers())
+
+	w.chunkMu.Lock()
+	chunks := make(map[ChunkPos]*chunkData, len(w.chunks))
+	for pos, c := range w.chunks {
+		chunks[pos] = c
+	}
+	w.chunkMu.Unlock()
+
+	w.Handler().HandleSaveStart(len(chunks), players)
+
+	type changedChunk struct {
+		pos      ChunkPos
+		c        *chunk.Chunk
+		blockNBT []map[string]interface{}
+		entities []SaveableEntity
+	}
+	pause := w.pauseOnSave.Load()
+	if pause {
+		w.tickMu.Lock()
+	}
+	changed := make([]changedChunk, 0, len(chunks))
+	for pos, c := range chunks {
+		c.Lock()
+		if c.changed() {
+			changed = append(changed, changedChunk{pos: pos, c: c.Chunk.Clone(), blockNBT: c.encodeBlockNBT(), entities: c.saveableEntities()})
+			c.dirty = false
 		}
-		s := make([]SaveableEntity, 0, len(c.entities))
-		for _, e := range c.entities {
-			if saveable, ok := e.(SaveableEntity); ok {
-				s = append(s, saveable)
+		c.Unlock()
+	}
+	w.mu.Lock()
+	set := w.set
+	w.mu.Unlock()
+	if pause {
+		w.tickMu.Unlock()
+	}
+
+	for _, c := range changed {
+		w.writeChunk(c.pos, c.c, c.blockNBT, c.entities)
+	}
+	w.provider().SaveSettings(set)
+
+	dur := time.Since(start)
+	w.log.Debugf("Saved %v chunks in %v.", len(changed), dur)
+	w.Handler().HandleSaveComplete(len(changed), players, dur)
+}
+
+// autoSave runs until the world is closed, saving the world every time the interval set using SaveInterval
+// passes.
+func (w *World) autoSave() {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case now := <-t.C:
+			interval := w.saveInterval.Load()
+			if interval <= 0 {
+				last = now
+				continue
 			}
+			if now.Sub(last) >= interval {
+				w.Save()
+				last = time.Now()
+			}
+		case <-w.closing:
+			w.running.Done()
+			return
 		}
-		if err := w.provider().SaveEntities(pos, s); err != nil {
-			w.log.Errorf("error saving entities in chunk %v to provider: %v", pos, err)
-		}
-		if err := w.provider().SaveBlockNBT(pos, m); err != nil {
-			w.log.Errorf("error saving block NBT in chunk %v to provider: %v", pos, err)
-		}
+	}
+}
+
+// writeChunk compacts a chunk and writes it, its block NBT and its entities to the provider.
+func (w *World) writeChunk(pos ChunkPos, c *chunk.Chunk, blockNBT []map[string]interface{}, entities []SaveableEntity) {
+	c.Compact()
+	w.backupMu.RLock()
+	defer w.backupMu.RUnlock()
+	if err := w.provider().SaveChunk(pos, c); err != nil {
+		w.log.Errorf("error saving chunk %v to provider: %v", pos, err)
+	}
+	if err := w.provider().SaveEntities(pos, entities); err != nil {
+		w.log.Errorf("error saving entities in chunk %v to provider: %v", pos, err)
+	}
+	if err := w.provider().SaveBlockNBT(pos, blockNBT); err != nil {
+		w.log.Errorf("error saving block NBT in chunk %v to provider: %v", pos, err)
+	}
+}
+
+// saveChunk is called when a chunk is removed from the cache. If the chunk was changed since it was loaded or
+// last saved, it is written to the provider.
+func (w *World) saveChunk(pos ChunkPos, c *chunkData) {
+	c.Lock()
+	if !w.rdonly.Load() && c.changed() {
+		w.writeChunk(pos, c.Chunk, c.encodeBlockNBT(), c.saveableEntities())
 	}
 	ent := c.entities
 	c.entities = nil
@@ -1823,35 +2559,21 @@ func (w *World) saveChunk(pos ChunkPos, c *chunkData) {
 func (w *World) initChunkCache() {
 	w.chunkMu.Lock()
 	w.chunks = make(map[ChunkPos]*chunkData)
+	w.loading = make(map[ChunkPos]*chunkLoad)
 	w.chunkMu.Unlock()
 }
 
-// chunkCacheJanitor runs until the world is running, cleaning chunks that are no longer in use from the cache.
+// chunkCacheJanitor runs until the world is running, unloading chunks that are no longer in use from the cache.
 func (w *World) chunkCacheJanitor() {
-	t := time.NewTicker(time.Minute * 5)
+	t := time.NewTicker(time.Second)
 	defer t.Stop()
 
-	w.running.Add(1)
-	chunksToRemove := map[ChunkPos]*chunkData{}
 	for {
 		select {
 		case <-t.C:
-			w.chunkMu.Lock()
-			for pos, c := range w.chunks {
-				if len(c.v) == 0 {
-					chunksToRemove[pos] = c
-					delete(w.chunks, pos)
-					if w.lastPos == pos {
-						w.lastChunk = nil
-					}
-				}
-			}
-			w.chunkMu.Unlock()
-
-			for pos, c := range chunksToRemove {
-				w.saveChunk(pos, c)
-				delete(chunksToRemove, pos)
-			}
+			w.unloadChunks(time.Now())
+		case <-w.evictChunks:
+			w.unloadChunks(time.Now())
 		case <-w.closing:
 			w.running.Done()
 			return
@@ -1859,6 +2581,86 @@ func (w *World) chunkCacheJanitor() {
 	}
 }
 
+// unloadChunks saves and removes chunks from the cache that have had no viewers for at least the unload delay
+// of the World. If more chunks than the maximum are loaded, the chunks that were viewed least recently are
+// unloaded right away until the maximum is no longer exceeded. Chunks with viewers, chunks in ticking areas
+// and chunks within the simulation distance of a viewer are never unloaded.
+func (w *World) unloadChunks(now time.Time) {
+	delay, max := w.unloadDelay.Load(), int(w.maxChunks.Load())
+	areas := w.TickingAreas()
+	viewers := w.allViewers()
+	positions := make([]ChunkPos, 0, len(viewers))
+	for _, viewer := range viewers {
+		positions = append(positions, ChunkPosFromVec3(viewer.Position()))
+	}
+	simDist := w.simDist.Load()
+
+	type unusedChunk struct {
+		pos        ChunkPos
+		c          *chunkData
+		lastViewed time.Time
+	}
+	var unused []unusedChunk
+
+	// The world is not ticked while chunks are removed from the cache, so that no chunk is removed in the
+	// middle of a tick.
+	w.tickMu.Lock()
+	w.chunkMu.Lock()
+	for pos, c := range w.chunks {
+		c.Lock()
+		viewed, lastViewed := len(c.v) > 0, c.lastViewed
+		c.Unlock()
+		if viewed || inTickingArea(areas, pos) || nearChunk(pos, positions, simDist) {
+			continue
+		}
+		unused = append(unused, unusedChunk{pos: pos, c: c, lastViewed: lastViewed})
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].lastViewed.Before(unused[j].lastViewed)
+	})
+	over := 0
+	if max > 0 {
+		over = len(w.chunks) - max
+	}
+	for i, u := range unused {
+		if i >= over && now.Sub(u.lastViewed) < delay {
+			// This chunk and the ones after it were viewed too recently to be unloaded.
+			unused = unused[:i]
+			break
+		}
+		delete(w.chunks, u.pos)
+		if w.lastPos == u.pos {
+			w.lastChunk = nil
+		}
+		// Loading the chunk again is delayed until it is saved, so that it is not read from the provider
+		// before its changes are written.
+		w.loading[u.pos] = &chunkLoad{done: make(chan struct{})}
+	}
+	w.chunkMu.Unlock()
+	w.tickMu.Unlock()
+
+	for _, u := range unused {
+		w.saveChunk(u.pos, u.c)
+
+		w.chunkMu.Lock()
+		l := w.loading[u.pos]
+		delete(w.loading, u.pos)
+		w.chunkMu.Unlock()
+		close(l.done)
+	}
+}
+
+// nearChunk checks if the chunk at the position passed is within dist chunks of any of the positions passed.
+func nearChunk(pos ChunkPos, positions []ChunkPos, dist int32) bool {
+	for _, p := range positions {
+		xDiff, zDiff := p[0]-pos[0], p[1]-pos[1]
+		if (xDiff*xDiff)+(zDiff*zDiff) <= dist*dist {
+			return true
+		}
+	}
+	return false
+}
+
 // chunkData represents the data of a chunk including the block entities and viewers. This data is protected
 // by the mutex present in the chunk.Chunk held.
 type chunkData struct {
@@ -1866,9 +2668,69 @@ type chunkData struct {
 	e        map[cube.Pos]Block
 	v        []Viewer
 	entities []Entity
+
+	// liquidVersion is changed every time a liquid in the chunk is placed or removed.
+	liquidVersion uint64
+	// liquidChecks holds the results of the checks cached using World.CachedLiquidCheck for positions in the
+	// chunk.
+	liquidChecks map[cube.Pos]liquidCheck
+	// dirty is true if the blocks or entities of the chunk were changed since it was loaded or last saved.
+	dirty bool
+	// lastViewed is the time at which the last viewer of the chunk stopped viewing it, or the time the chunk
+	// was loaded if it was never viewed. Chunks without viewers are unloaded some time after lastViewed.
+	lastViewed time.Time
 }
 
+// changed checks if the chunk must be written when saving the world. Besides chunks that are dirty, this is
+// the case for chunks with entities or block entities, as these may change without the chunk being notified.
+// changed must be called while the chunk is locked.
+func (c *chunkData) changed() bool {
+	return c.dirty || len(c.entities) != 0 || len(c.e) != 0
+}
+
+// encodeBlockNBT encodes the block entities of the chunk, adding the 'x', 'y' and 'z' tags to each of them.
+// encodeBlockNBT must be called while the chunk is locked.
+func (c *chunkData) encodeBlockNBT() []map[string]interface{} {
+	m := make([]map[string]interface{}, 0, len(c.e))
+	for pos, b := range c.e {
+		if n, ok := b.(NBTer); ok {
+			data := n.EncodeNBT()
+			data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+			m = append(m, data)
+		}
+	}
+	return m
+}
+
+// saveableEntities returns all entities in the chunk that implement SaveableEntity. saveableEntities must be
+// called while the chunk is locked.
+func (c *chunkData) saveableEntities() []SaveableEntity {
+	s := make([]SaveableEntity, 0, len(c.entities))
+	for _, e := range c.entities {
+		if saveable, ok := e.(SaveableEntity); ok {
+			s = append(s, saveable)
+		}
+	}
+	return s
+}
+
+// liquidCheck is the result of a check cached using World.CachedLiquidCheck. It holds the liquid versions of
+// the chunks that the area checked spans at the time of the check.
+type liquidCheck struct {
+	versions [4]uint64
+	result   bool
+}
+
+// liquidVersions is incremented to produce a new liquid version every time a liquid in any chunk changes. A
+// global counter is used so that chunks that are unloaded and loaded again never reuse a liquid version.
+var liquidVersions atomic.Uint64
+
 // newChunkData returns a new chunkData wrapper around the chunk.Chunk passed.
 func newChunkData(c *chunk.Chunk) *chunkData {
-	return &chunkData{Chunk: c, e: map[cube.Pos]Block{}}
+	return &chunkData{Chunk: c, e: map[cube.Pos]Block{}, liquidVersion: liquidVersions.Inc()}
+}
+
+// liquidChanged changes the liquid version of the chunk. It must be called while the chunk is locked.
+func (c *chunkData) liquidChanged() {
+	c.liquidVersion = liquidVersions.Inc()
 }